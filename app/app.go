@@ -3,9 +3,30 @@ package app
 import (
 	"github.com/natemago/card-games-api/config"
 	"github.com/natemago/card-games-api/repositories"
+	baccarat_repo "github.com/natemago/card-games-api/repositories/baccarat"
+	blackjack_repo "github.com/natemago/card-games-api/repositories/blackjack"
 	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+	holdem_repo "github.com/natemago/card-games-api/repositories/holdem"
+	klondike_repo "github.com/natemago/card-games-api/repositories/klondike"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
 	"github.com/natemago/card-games-api/rest"
+	baccarat_svcs "github.com/natemago/card-games-api/rest/baccarat"
+	blackjack_svcs "github.com/natemago/card-games-api/rest/blackjack"
+	bridge_svcs "github.com/natemago/card-games-api/rest/bridge"
+	cribbage_svcs "github.com/natemago/card-games-api/rest/cribbage"
 	deck_svcs "github.com/natemago/card-games-api/rest/deck"
+	games_svcs "github.com/natemago/card-games-api/rest/games"
+	graphql_svcs "github.com/natemago/card-games-api/rest/graphql"
+	health_svcs "github.com/natemago/card-games-api/rest/health"
+	holdem_svcs "github.com/natemago/card-games-api/rest/holdem"
+	klondike_svcs "github.com/natemago/card-games-api/rest/klondike"
+	poker_svcs "github.com/natemago/card-games-api/rest/poker"
+	rummy_svcs "github.com/natemago/card-games-api/rest/rummy"
+	tricks_svcs "github.com/natemago/card-games-api/rest/tricks"
+	"github.com/natemago/card-games-api/rpc"
+	deck_rpc "github.com/natemago/card-games-api/rpc/deck"
+	"github.com/natemago/card-games-api/webhooks"
 )
 
 // RunApp sets up and runs the API application.
@@ -21,12 +42,80 @@ func RunApp(conf *config.Config) error {
 		return err
 	}
 
+	// Keep checking the database connection
+	healthCheck := repositories.NewDBHealthCheck(db, conf.DBConfig.HealthCheckInterval)
+	healthCheck.Start()
+	defer healthCheck.Stop()
+
 	// Build the repositories
-	deckRepository := deck_repo.NewDBDeckRepository(db)
+	deckRepository, err := deck_repo.NewDeckRepository(db, conf.DBConfig.StorageLayout)
+	if err != nil {
+		return err
+	}
+
+	webhookRepository := deck_repo.NewDBWebhookRepository(db)
+
+	// Deliver the events of the decks to the webhooks
+	webhookWorker := webhooks.NewWorker(webhookRepository, &conf.WebhooksConfig)
+	webhookWorker.Start()
+	defer webhookWorker.Stop()
 
 	// Build the services
-	deckService := deck_svcs.NewDeckService(deckRepository)
+	healthService := health_svcs.NewHealthService(healthCheck)
+
+	if conf.CacheConfig.Size > 0 {
+		cachingRepository := deck_repo.NewCachingDeckRepository(deckRepository, conf.CacheConfig.Size, conf.CacheConfig.TTL)
+		healthService.Cache = cachingRepository
+		deckRepository = cachingRepository
+	}
+
+	deckService := deck_svcs.NewDeckService(deckRepository, deck_repo.NewDBPlayerRepository(db))
+	deckService.EventLog = deck_repo.NewDBEventRepository(db)
+	deckService.AdminToken = conf.APIConfig.AdminToken
+	deckService.Heartbeat = conf.APIConfig.EventsHeartbeat
+	deckService.MaxStreams = conf.APIConfig.MaxEventStreams
+	deckService.Webhooks = webhookRepository
+	deckService.AllowPrivateWebhooks = conf.WebhooksConfig.AllowPrivateAddresses
+	pokerService := poker_svcs.NewPokerService(deckService)
+	tableService := holdem_svcs.NewTableService(holdem_repo.NewDBTableRepository(db), deckService)
+	blackjackService := blackjack_svcs.NewTableService(blackjack_repo.NewDBTableRepository(db), deckService)
+	klondikeService := klondike_svcs.NewGameService(klondike_repo.NewDBGameRepository(db), deckService)
+	tricksService := tricks_svcs.NewGameService(tricks_repo.NewDBGameRepository(db), deckRepository)
+	bridgeService := bridge_svcs.NewBridgeService()
+	cribbageService := cribbage_svcs.NewCribbageService()
+	rummyService := rummy_svcs.NewRummyService()
+	baccaratService := baccarat_svcs.NewShoeService(baccarat_repo.NewDBShoeRepository(db), deckRepository)
+	gamesService := games_svcs.NewSessionService(games_repo.NewDBSessionRepository(db), deckRepository)
+	graphQLService, err := graphql_svcs.NewGraphQLService(deckService, conf.APIConfig.GraphQLMaxDepth, conf.APIConfig.GraphQLMaxComplexity)
+	if err != nil {
+		return err
+	}
+
+	// Serve the gRPC API next to the REST API, sharing the deck events
+	grpcServer, err := rpc.ServeGRPC(&conf.APIConfig, &rpc.Services{
+		DeckServer: deck_rpc.NewServer(deckService),
+	})
+	if err != nil {
+		return err
+	}
+	if grpcServer != nil {
+		defer grpcServer.Stop()
+	}
 
 	// Finally run the API
-	return rest.RunAPI(&conf.APIConfig, *deckService)
+	return rest.RunAPI(&conf.APIConfig, &rest.Services{
+		DeckService:      deckService,
+		HealthService:    healthService,
+		PokerService:     pokerService,
+		TableService:     tableService,
+		BlackjackService: blackjackService,
+		KlondikeService:  klondikeService,
+		TricksService:    tricksService,
+		BridgeService:    bridgeService,
+		CribbageService:  cribbageService,
+		RummyService:     rummyService,
+		BaccaratService:  baccaratService,
+		GamesService:     gamesService,
+		GraphQLService:   graphQLService,
+	})
 }
@@ -0,0 +1,238 @@
+package bridge
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+// seatNames maps the names of the seats in constraints to the index of the seat in Seats.
+var seatNames = map[string]int{
+	"n": 0, "north": 0,
+	"e": 1, "east": 1,
+	"s": 2, "south": 2,
+	"w": 3, "west": 3,
+}
+
+// suitNames maps the names of the suits in constraints to their codes.
+var suitNames = map[string]string{
+	"spades": "S", "spade": "S",
+	"hearts": "H", "heart": "H",
+	"diamonds": "D", "diamond": "D",
+	"clubs": "C", "club": "C",
+}
+
+// Shapes of the balanced and semi-balanced hands.
+var (
+	balancedPatterns     = []string{"4333", "4432", "5332"}
+	semiBalancedPatterns = []string{"4333", "4432", "5332", "5422", "6322"}
+)
+
+// Constraint is a condition on the hand of a seat, parsed from the constraint language (see ParseConstraint).
+type Constraint struct {
+	// Seat is the index of the seat in Seats.
+	Seat int
+
+	// Text is the constraint as it was parsed.
+	Text string
+
+	conditions []func(hand Hand) bool
+}
+
+// Matches returns true if the hand of the seat of the constraint, from the hands of the deal in the order of Seats,
+// meets all the conditions.
+func (c *Constraint) Matches(hands []Hand) bool {
+	for _, condition := range c.conditions {
+		if !condition(hands[c.Seat]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseConstraint parses a constraint on the hand of a seat. A constraint is the seat followed by a colon and
+// a comma-separated list of conditions, all of which must be met, like "N: hcp 15-17, balanced".
+//
+// The seat is N, E, S or W (or North, East, South or West). The conditions are:
+//   - "hcp" and a range of high card points, like "hcp 15-17".
+//   - a suit (spades, hearts, diamonds or clubs) and a range of its length, like "spades 5+".
+//   - "balanced" (4333, 4432 or 5332) or "semibalanced" (also 5422 and 6322).
+//   - "shape" and a list of shapes separated by "|". A shape of four digits, like 4432, matches the suit lengths in
+//     any order. A shape of four lengths separated by "=", like 5=4=3=1, matches the lengths of spades, hearts,
+//     diamonds and clubs in this order.
+//
+// A range is a number ("5"), two numbers ("15-17"), at least a number ("5+") or at most a number ("3-").
+// The names are case insensitive.
+// Returns a ValidationError if the constraint is not valid.
+func ParseConstraint(text string) (*Constraint, error) {
+	parts := strings.SplitN(text, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.ValidationError(fmt.Sprintf("invalid constraint %q: expected a seat and conditions, like \"N: hcp 15-17\"", text), nil)
+	}
+
+	seat, ok := seatNames[strings.ToLower(strings.TrimSpace(parts[0]))]
+	if !ok {
+		return nil, errors.ValidationError(fmt.Sprintf("invalid constraint %q: unknown seat %q", text, strings.TrimSpace(parts[0])), nil)
+	}
+
+	constraint := &Constraint{
+		Seat: seat,
+		Text: text,
+	}
+	for _, term := range strings.Split(parts[1], ",") {
+		condition, err := parseCondition(strings.Fields(strings.ToLower(term)))
+		if err != nil {
+			return nil, errors.ValidationError(fmt.Sprintf("invalid constraint %q: %s", text, err.Error()), err)
+		}
+		constraint.conditions = append(constraint.conditions, condition)
+	}
+
+	return constraint, nil
+}
+
+// ParseConstraints parses a list of constraints, see ParseConstraint.
+func ParseConstraints(texts []string) ([]*Constraint, error) {
+	constraints := make([]*Constraint, 0, len(texts))
+	for _, text := range texts {
+		constraint, err := ParseConstraint(text)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+func parseCondition(words []string) (func(hand Hand) bool, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty condition")
+	}
+
+	switch words[0] {
+	case "balanced", "semibalanced":
+		if len(words) != 1 {
+			return nil, fmt.Errorf("unexpected %q after %s", strings.Join(words[1:], " "), words[0])
+		}
+		patterns := balancedPatterns
+		if words[0] == "semibalanced" {
+			patterns = semiBalancedPatterns
+		}
+		return func(hand Hand) bool {
+			return containsString(patterns, hand.Pattern())
+		}, nil
+	case "shape":
+		if len(words) != 2 {
+			return nil, fmt.Errorf("expected shapes after shape, like \"shape 4432|5332\"")
+		}
+		return parseShapes(words[1])
+	}
+
+	if len(words) != 2 {
+		return nil, fmt.Errorf("unknown condition %q", strings.Join(words, " "))
+	}
+	min, max, err := parseRange(words[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if words[0] == "hcp" {
+		return func(hand Hand) bool {
+			hcp := hand.HCP()
+			return hcp >= min && hcp <= max
+		}, nil
+	}
+	if suit, ok := suitNames[words[0]]; ok {
+		return func(hand Hand) bool {
+			length := hand.Length(suit)
+			return length >= min && length <= max
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown condition %q", words[0])
+}
+
+// parseRange parses a range: "5", "15-17", "5+" or "3-".
+func parseRange(text string) (min, max int, err error) {
+	bounds := strings.SplitN(text, "-", 2)
+	switch {
+	case strings.HasSuffix(text, "+"):
+		min, err = strconv.Atoi(strings.TrimSuffix(text, "+"))
+		max = 40
+	case len(bounds) == 2 && bounds[1] == "":
+		max, err = strconv.Atoi(bounds[0])
+	case len(bounds) == 2:
+		min, err = strconv.Atoi(bounds[0])
+		if err == nil {
+			max, err = strconv.Atoi(bounds[1])
+		}
+	default:
+		min, err = strconv.Atoi(text)
+		max = min
+	}
+
+	if err != nil || min < 0 || max < min {
+		return 0, 0, fmt.Errorf("invalid range %q", text)
+	}
+	return min, max, nil
+}
+
+// parseShapes parses shapes separated by "|", like "4432|5=4=3=1".
+func parseShapes(text string) (func(hand Hand) bool, error) {
+	patterns := []string{}
+	exact := []string{}
+
+	for _, shape := range strings.Split(text, "|") {
+		if strings.Contains(shape, "=") {
+			lengths, err := parseLengths(strings.Split(shape, "="), shape)
+			if err != nil {
+				return nil, err
+			}
+			exact = append(exact, joinLengths(lengths, "="))
+			continue
+		}
+
+		lengths, err := parseLengths(strings.Split(shape, ""), shape)
+		if err != nil {
+			return nil, err
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+		patterns = append(patterns, joinLengths(lengths, ""))
+	}
+
+	return func(hand Hand) bool {
+		return containsString(patterns, hand.Pattern()) || containsString(exact, joinLengths(hand.Shape(), "="))
+	}, nil
+}
+
+func parseLengths(values []string, shape string) ([]int, error) {
+	if len(values) != len(Suits) {
+		return nil, fmt.Errorf("invalid shape %q: expected four suit lengths", shape)
+	}
+
+	lengths := make([]int, 0, len(values))
+	total := 0
+	for _, value := range values {
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("invalid shape %q", shape)
+		}
+		lengths = append(lengths, length)
+		total += length
+	}
+	if total != HandSize {
+		return nil, fmt.Errorf("invalid shape %q: the suit lengths must add up to %d", shape, HandSize)
+	}
+	return lengths, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// testHands holds a deal: North 4333 with 17 HCP, East 5431, South 6 spades 5422, West the rest.
+func testHands() []Hand {
+	return []Hand{
+		Hand(deck_repo.AsCards("AS,KS,2S,3S,AH,KH,2H,QD,2D,3D,JC,2C,3C")),
+		Hand(deck_repo.AsCards("4S,5S,6S,7S,8S,3H,4H,5H,4D,5D,6D,7D,4C")),
+		Hand(deck_repo.AsCards("9S,10S,JS,QS,6H,7H,8H,9H,AD,KD,5C,6C,7C")),
+		Hand(deck_repo.AsCards("10H,JH,QH,8D,9D,10D,JD,8C,9C,10C,QC,KC,AC")),
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	hands := testHands()
+
+	cases := []struct {
+		constraint string
+		matches    bool
+	}{
+		{"N: hcp 15-17, balanced", true},
+		{"north: HCP 18+", false},
+		{"N: shape 4333", true},
+		{"N: shape 3=4=3=3", false},
+		{"N: shape 4=3=3=3", true},
+		{"E: spades 5+, hearts 3, clubs 1-", true},
+		{"E: balanced", false},
+		{"E: semibalanced", false},
+		{"E: shape 5431|5422", true},
+		{"S: spades 4, hearts 4+, semibalanced", true},
+		{"S: diamonds 3-", true},
+		{"W: hcp 13, clubs 6", true},
+	}
+
+	for _, c := range cases {
+		constraint, err := ParseConstraint(c.constraint)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %s", c.constraint, err.Error())
+			continue
+		}
+		if matches := constraint.Matches(hands); matches != c.matches {
+			t.Errorf("Expected %q to match: %v, but got %v.", c.constraint, c.matches, matches)
+		}
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	for _, text := range []string{
+		"hcp 15-17",
+		"X: hcp 15",
+		"N: hcp",
+		"N: hcp 17-15",
+		"N: hcp -3",
+		"N: trumps 5+",
+		"N: shape 4432|5",
+		"N: shape 5=5=5=5",
+		"N: balanced please",
+		"N: hcp 12,",
+	} {
+		if _, err := ParseConstraint(text); !errors.IsValidationError(err) {
+			t.Errorf("Expected a ValidationError for %q, but got: %v", text, err)
+		}
+	}
+}
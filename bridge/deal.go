@@ -0,0 +1,139 @@
+package bridge
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// DefaultMaxAttempts is the default number of shuffles tried for a single board before giving up.
+const DefaultMaxAttempts = 1000000
+
+// vulnerabilities holds the vulnerability of the boards 1 to 16, repeating every 16 boards.
+var vulnerabilities = []string{
+	"None", "NS", "EW", "All",
+	"NS", "EW", "All", "None",
+	"EW", "All", "None", "NS",
+	"All", "None", "NS", "EW",
+}
+
+// Deal is a deal of a board: a hand of 13 cards for every seat.
+type Deal struct {
+	// Board is the number of the board, starting at 1.
+	Board int
+
+	// Dealer is the code of the seat of the dealer, given by the board number.
+	Dealer string
+
+	// Vulnerable is the vulnerability of the board: "None", "NS", "EW" or "All".
+	Vulnerable string
+
+	// Hands holds the hands of the seats, in the order of Seats.
+	Hands []Hand
+
+	// Attempts is the number of shuffles until the constraints were met.
+	Attempts int
+}
+
+// DealOptions holds the options of GenerateDeals.
+type DealOptions struct {
+	// Boards is the number of boards to deal.
+	Boards int
+
+	// FirstBoard is the number of the first board. Zero means 1.
+	FirstBoard int
+
+	// Constraints are the constraints every deal must meet.
+	Constraints []*Constraint
+
+	// Seed seeds the shuffles. The same seed and options give the same deals.
+	Seed int64
+
+	// MaxAttempts is the maximal number of shuffles tried for a single board. Zero means DefaultMaxAttempts.
+	MaxAttempts int
+}
+
+// BoardDealer returns the code of the dealer of the board: North deals board 1, East board 2, and so on.
+func BoardDealer(board int) string {
+	return Seats[(board-1)%len(Seats)]
+}
+
+// BoardVulnerability returns the vulnerability of the board, following the standard 16-board cycle.
+func BoardVulnerability(board int) string {
+	return vulnerabilities[(board-1)%len(vulnerabilities)]
+}
+
+// GenerateDeals deals the boards by shuffling a deck until the deal meets all constraints.
+// Returns a ValidationError if the options are not valid, or a BadRequestError if no deal meeting the constraints
+// was found within the maximal number of attempts.
+func GenerateDeals(options *DealOptions) ([]*Deal, error) {
+	if options.Boards < 1 {
+		return nil, errors.ValidationError("at least one board must be dealt", nil)
+	}
+	firstBoard := options.FirstBoard
+	if firstBoard == 0 {
+		firstBoard = 1
+	}
+	if firstBoard < 1 {
+		return nil, errors.ValidationError("the first board number must be positive", nil)
+	}
+	maxAttempts := options.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	cards := deck_repo.AsCards(strings.Join(deck_repo.NewFullDeck(), ","))
+	random := rand.New(rand.NewSource(options.Seed))
+	deals := make([]*Deal, 0, options.Boards)
+
+	for board := firstBoard; board < firstBoard+options.Boards; board++ {
+		hands, attempts := dealHands(cards, random, options.Constraints, maxAttempts)
+		if hands == nil {
+			return nil, errors.BadRequestError(fmt.Sprintf("no deal of board %d met the constraints in %d attempts", board, maxAttempts), nil)
+		}
+
+		deals = append(deals, &Deal{
+			Board:      board,
+			Dealer:     BoardDealer(board),
+			Vulnerable: BoardVulnerability(board),
+			Hands:      hands,
+			Attempts:   attempts,
+		})
+	}
+
+	return deals, nil
+}
+
+// dealHands shuffles the cards until the deal meets the constraints. Returns nil if no deal met the constraints
+// within the maximal number of attempts.
+func dealHands(cards []*deck_repo.Card, random *rand.Rand, constraints []*Constraint, maxAttempts int) ([]Hand, int) {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		random.Shuffle(len(cards), func(i, j int) {
+			cards[i], cards[j] = cards[j], cards[i]
+		})
+
+		hands := make([]Hand, 0, len(Seats))
+		for seat := range Seats {
+			hands = append(hands, Hand(cards[seat*HandSize:(seat+1)*HandSize]))
+		}
+		if matchesAll(constraints, hands) {
+			for seat, hand := range hands {
+				hands[seat] = hand.Sorted()
+			}
+			return hands, attempt
+		}
+	}
+	return nil, maxAttempts
+}
+
+func matchesAll(constraints []*Constraint, hands []Hand) bool {
+	for _, constraint := range constraints {
+		if !constraint.Matches(hands) {
+			return false
+		}
+	}
+	return true
+}
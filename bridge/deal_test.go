@@ -0,0 +1,95 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestBoardDealerAndVulnerability(t *testing.T) {
+	cases := []struct {
+		board      int
+		dealer     string
+		vulnerable string
+	}{
+		{1, "N", "None"},
+		{2, "E", "NS"},
+		{4, "W", "All"},
+		{5, "N", "NS"},
+		{13, "N", "All"},
+		{16, "W", "EW"},
+		{17, "N", "None"},
+	}
+
+	for _, c := range cases {
+		if dealer, vulnerable := BoardDealer(c.board), BoardVulnerability(c.board); dealer != c.dealer || vulnerable != c.vulnerable {
+			t.Errorf("Expected board %d to be dealt by %s with %s vulnerable, but got %s and %s.",
+				c.board, c.dealer, c.vulnerable, dealer, vulnerable)
+		}
+	}
+}
+
+func TestGenerateDeals(t *testing.T) {
+	constraints, err := ParseConstraints([]string{"N: hcp 15-17, balanced", "S: spades 5+"})
+	if err != nil {
+		t.Fatalf("Failed to parse constraints: %s", err.Error())
+	}
+
+	deals, err := GenerateDeals(&DealOptions{
+		Boards:      3,
+		FirstBoard:  7,
+		Constraints: constraints,
+		Seed:        42,
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate deals: %s", err.Error())
+	}
+
+	if len(deals) != 3 || deals[0].Board != 7 || deals[0].Dealer != "S" || deals[0].Vulnerable != "All" {
+		t.Fatalf("Expected boards 7 to 9, but got: %+v", deals[0])
+	}
+	for _, deal := range deals {
+		seen := map[string]bool{}
+		for _, hand := range deal.Hands {
+			if len(hand) != HandSize {
+				t.Errorf("Expected hands of 13 cards, but got %d.", len(hand))
+			}
+			for _, card := range hand {
+				seen[card.Value] = true
+			}
+		}
+		if len(seen) != 52 {
+			t.Errorf("Expected every card to be dealt once, but got %d cards.", len(seen))
+		}
+
+		north, south := deal.Hands[0], deal.Hands[2]
+		if north.HCP() < 15 || north.HCP() > 17 || south.Length("S") < 5 || deal.Attempts < 1 {
+			t.Errorf("Expected the deal of board %d to meet the constraints, but got: %+v", deal.Board, deal)
+		}
+	}
+
+	// The same seed gives the same deals.
+	again, err := GenerateDeals(&DealOptions{Boards: 3, FirstBoard: 7, Constraints: constraints, Seed: 42})
+	if err != nil {
+		t.Fatalf("Failed to generate deals: %s", err.Error())
+	}
+	if again[2].PBN() != deals[2].PBN() {
+		t.Errorf("Expected the same deals for the same seed, but got %s and %s.", again[2].PBN(), deals[2].PBN())
+	}
+}
+
+func TestGenerateDeals_Impossible(t *testing.T) {
+	constraints, err := ParseConstraints([]string{"N: hcp 30+", "S: hcp 30+"})
+	if err != nil {
+		t.Fatalf("Failed to parse constraints: %s", err.Error())
+	}
+
+	_, err = GenerateDeals(&DealOptions{Boards: 1, Constraints: constraints, MaxAttempts: 1000})
+	if !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for impossible constraints, but got: %v", err)
+	}
+
+	if _, err := GenerateDeals(&DealOptions{Boards: 0}); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for no boards, but got: %v", err)
+	}
+}
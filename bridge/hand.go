@@ -0,0 +1,108 @@
+package bridge
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Seats holds the codes of the seats at the table, in the order of play.
+var Seats = []string{"N", "E", "S", "W"}
+
+// Suits holds the codes of the suits in bridge order, from spades to clubs.
+var Suits = []string{"S", "H", "D", "C"}
+
+// HandSize is the number of cards dealt to each seat.
+const HandSize = 13
+
+// highCardPoints maps the rank codes of the honours to their high card points.
+var highCardPoints = map[string]int{
+	"A": 4,
+	"K": 3,
+	"Q": 2,
+	"J": 1,
+}
+
+// rankOrder maps the rank codes to their order within a suit, from the two (1) to the ace (13).
+var rankOrder = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		rankOrder[rank] = i
+	}
+	rankOrder[deck_repo.Ranks[0]] = len(deck_repo.Ranks)
+}
+
+// Hand is the hand of a seat.
+type Hand []*deck_repo.Card
+
+// HCP returns the high card points of the hand: 4 for an ace, 3 for a king, 2 for a queen and 1 for a jack.
+func (h Hand) HCP() int {
+	points := 0
+	for _, card := range h {
+		points += highCardPoints[rank(card)]
+	}
+	return points
+}
+
+// Length returns the number of cards of the suit in the hand.
+func (h Hand) Length(suit string) int {
+	length := 0
+	for _, card := range h {
+		if suitOf(card) == suit {
+			length++
+		}
+	}
+	return length
+}
+
+// Shape returns the lengths of the suits, from spades to clubs.
+func (h Hand) Shape() []int {
+	shape := make([]int, 0, len(Suits))
+	for _, suit := range Suits {
+		shape = append(shape, h.Length(suit))
+	}
+	return shape
+}
+
+// Pattern returns the lengths of the suits, longest first, like "4432".
+func (h Hand) Pattern() string {
+	shape := h.Shape()
+	sort.Sort(sort.Reverse(sort.IntSlice(shape)))
+	return joinLengths(shape, "")
+}
+
+// Sorted returns the cards of the hand sorted by suit, from spades to clubs, and from the ace down.
+func (h Hand) Sorted() Hand {
+	suitOrder := map[string]int{}
+	for i, suit := range Suits {
+		suitOrder[suit] = i
+	}
+
+	sorted := append(Hand{}, h...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if suitOf(sorted[i]) != suitOf(sorted[j]) {
+			return suitOrder[suitOf(sorted[i])] < suitOrder[suitOf(sorted[j])]
+		}
+		return rankOrder[rank(sorted[i])] > rankOrder[rank(sorted[j])]
+	})
+	return sorted
+}
+
+func rank(card *deck_repo.Card) string {
+	return card.Value[:len(card.Value)-1]
+}
+
+func suitOf(card *deck_repo.Card) string {
+	return card.Value[len(card.Value)-1:]
+}
+
+func joinLengths(lengths []int, separator string) string {
+	values := make([]string, 0, len(lengths))
+	for _, length := range lengths {
+		values = append(values, strconv.Itoa(length))
+	}
+	return strings.Join(values, separator)
+}
@@ -0,0 +1,26 @@
+package bridge
+
+import (
+	"testing"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func TestHand(t *testing.T) {
+	hand := Hand(deck_repo.AsCards("AS,KS,QS,2S,JH,9H,4H,10D,8D,3D,AC,5C,2C"))
+
+	if hcp := hand.HCP(); hcp != 14 {
+		t.Errorf("Expected 14 HCP, but got %d.", hcp)
+	}
+	if length := hand.Length("S"); length != 4 {
+		t.Errorf("Expected 4 spades, but got %d.", length)
+	}
+	if pattern := hand.Pattern(); pattern != "4333" {
+		t.Errorf("Expected a 4333 pattern, but got %s.", pattern)
+	}
+
+	sorted := Hand(deck_repo.AsCards("2C,10D,AS,AC,KS,JH")).Sorted()
+	if codes := sorted[0].Value + sorted[1].Value + sorted[2].Value + sorted[3].Value + sorted[4].Value; codes != "ASKSJH10DAC" {
+		t.Errorf("Expected the cards sorted by suit from spades and by rank from the ace, but got %s.", codes)
+	}
+}
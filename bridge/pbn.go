@@ -0,0 +1,65 @@
+package bridge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pbnRanks maps the rank codes to the ranks in Portable Bridge Notation, which writes the ten as T.
+var pbnRanks = map[string]string{
+	"10": "T",
+}
+
+// PBN returns the deal of the hands in Portable Bridge Notation, starting with the dealer, like
+// "N:AKQ2.J94.T83.A52 ...". The suits of a hand are separated by dots, from spades to clubs.
+func (d *Deal) PBN() string {
+	first := 0
+	for i, seat := range Seats {
+		if seat == d.Dealer {
+			first = i
+		}
+	}
+
+	hands := make([]string, 0, len(Seats))
+	for i := range Seats {
+		hands = append(hands, pbnHand(d.Hands[(first+i)%len(Seats)]))
+	}
+	return fmt.Sprintf("%s:%s", d.Dealer, strings.Join(hands, " "))
+}
+
+// FormatPBN formats the deals as a Portable Bridge Notation (PBN) file, with the board number, the dealer,
+// the vulnerability and the deal of every board.
+func FormatPBN(deals []*Deal) string {
+	builder := &strings.Builder{}
+	builder.WriteString("% PBN 2.1\n% EXPORT\n")
+
+	for _, deal := range deals {
+		builder.WriteString("\n")
+		fmt.Fprintf(builder, "[Board \"%d\"]\n", deal.Board)
+		fmt.Fprintf(builder, "[Dealer \"%s\"]\n", deal.Dealer)
+		fmt.Fprintf(builder, "[Vulnerable \"%s\"]\n", deal.Vulnerable)
+		fmt.Fprintf(builder, "[Deal \"%s\"]\n", deal.PBN())
+	}
+
+	return builder.String()
+}
+
+// pbnHand writes the hand as the cards of the suits separated by dots, from spades to clubs.
+func pbnHand(hand Hand) string {
+	suits := make([]string, 0, len(Suits))
+	for _, suit := range Suits {
+		ranks := ""
+		for _, card := range hand.Sorted() {
+			if suitOf(card) != suit {
+				continue
+			}
+			if pbnRank, ok := pbnRanks[rank(card)]; ok {
+				ranks += pbnRank
+			} else {
+				ranks += rank(card)
+			}
+		}
+		suits = append(suits, ranks)
+	}
+	return strings.Join(suits, ".")
+}
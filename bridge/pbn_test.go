@@ -0,0 +1,27 @@
+package bridge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPBN(t *testing.T) {
+	deal := &Deal{
+		Board:      2,
+		Dealer:     "E",
+		Vulnerable: "NS",
+		Hands:      testHands(),
+	}
+
+	expected := "E:87654.543.7654.4 QJT9.9876.AK.765 .QJT.JT98.AKQT98 AK32.AK2.Q32.J32"
+	if pbn := deal.PBN(); pbn != expected {
+		t.Errorf("Expected the deal %s, but got %s.", expected, pbn)
+	}
+
+	pbn := FormatPBN([]*Deal{deal})
+	for _, tag := range []string{"% PBN 2.1", `[Board "2"]`, `[Dealer "E"]`, `[Vulnerable "NS"]`, `[Deal "E:`} {
+		if !strings.Contains(pbn, tag) {
+			t.Errorf("Expected the PBN to contain %s, but got:\n%s", tag, pbn)
+		}
+	}
+}
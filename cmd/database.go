@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/gorm"
+)
+
+// openDatabase connects to the database configured with the flags and ENV variables and migrates the models.
+func openDatabase() (*gorm.DB, error) {
+	readFromEnv()
+
+	db, err := repositories.OpenDatabase(&Config.DBConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repositories.AutoMigrateModels(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// openDeckRepository connects to the configured database and builds a DeckRepository for the configured storage layout.
+func openDeckRepository() (deck_repo.DeckRepository, error) {
+	db, err := openDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	return deck_repo.NewDeckRepository(db, Config.DBConfig.StorageLayout)
+}
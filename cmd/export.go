@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/spf13/cobra"
+)
+
+var exportAll bool
+var exportOutput string
+
+// exportCmd exports decks as a versioned JSON document.
+var exportCmd = &cobra.Command{
+	Use:   "export [deck-id...]",
+	Short: "Export decks, with all of their cards, as a JSON document",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && !exportAll {
+			return fmt.Errorf("specify the ids of the decks to export or use --all")
+		}
+
+		deckRepository, err := openDeckRepository()
+		if err != nil {
+			return err
+		}
+
+		deckIDs := args
+		if exportAll {
+			if deckIDs, err = deckRepository.ListDeckIDs(); err != nil {
+				return err
+			}
+		}
+
+		decks := []*deck_repo.Deck{}
+		for _, deckID := range deckIDs {
+			deck, err := deckRepository.ExportDeck(deckID)
+			if err != nil {
+				return fmt.Errorf("failed to export deck %s: %s", deckID, err.Error())
+			}
+			decks = append(decks, deck)
+		}
+
+		var output io.Writer = os.Stdout
+		if exportOutput != "" && exportOutput != "-" {
+			file, err := os.Create(exportOutput)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			output = file
+		}
+
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(deck_repo.NewDeckExport(decks...))
+	},
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Export all decks.")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "-", "File to write the export document to. Defaults to stdout.")
+	rootCmd.AddCommand(exportCmd)
+}
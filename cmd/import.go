@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/spf13/cobra"
+)
+
+var importRemapIDs bool
+
+// importCmd imports decks from a JSON document produced by the export command.
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import decks from a JSON document produced by the export command",
+	Args:  cobra.MaximumNArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var input io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			input = file
+		}
+
+		export := &deck_repo.DeckExport{}
+		if err := json.NewDecoder(input).Decode(export); err != nil {
+			return fmt.Errorf("invalid export document: %s", err.Error())
+		}
+
+		decks, err := export.ToDecks(importRemapIDs)
+		if err != nil {
+			return err
+		}
+
+		deckRepository, err := openDeckRepository()
+		if err != nil {
+			return err
+		}
+
+		// the decks are reported once the transaction is committed, as nothing is imported if any of them fails
+		imported := []string{}
+		err = deckRepository.Transaction(func(repository deck_repo.DeckRepository) error {
+			for i, deck := range decks {
+				if _, err := repository.ImportDeck(deck); err != nil {
+					return fmt.Errorf("failed to import deck %s: %s", export.Decks[i].ID, err.Error())
+				}
+				imported = append(imported, fmt.Sprintf("Imported deck %s as %s.", export.Decks[i].ID, deck.ID))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, line := range imported {
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importRemapIDs, "remap-ids", false, "Generate new ids for the imported decks instead of preserving the original ids.")
+	rootCmd.AddCommand(importCmd)
+}
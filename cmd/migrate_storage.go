@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/spf13/cobra"
+)
+
+// migrateStorageCmd copies the decks stored in the cards layout into the compact storage layout.
+var migrateStorageCmd = &cobra.Command{
+	Use:   "migrate-storage",
+	Short: "Migrate the decks from the cards storage layout to the compact storage layout",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDatabase()
+		if err != nil {
+			return err
+		}
+
+		migrated, err := deck_repo.MigrateToCompactStorage(db)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Migrated %d decks to the compact storage layout.\n", migrated)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateStorageCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/spf13/cobra"
+)
+
+// revertPacksCmd removes the pack from the cards table, so the database can be used by a version of the API from
+// before the decks of more than one pack.
+var revertPacksCmd = &cobra.Command{
+	Use:   "revert-packs",
+	Short: "Remove the pack from the cards table, before downgrading to a version without decks of more than one pack",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDatabase()
+		if err != nil {
+			return err
+		}
+
+		if err := deck_repo.RevertCardsPrimaryKey(db); err != nil {
+			return err
+		}
+
+		fmt.Println("Removed the pack from the cards table.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(revertPacksCmd)
+}
@@ -7,6 +7,7 @@ package cmd
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/natemago/card-games-api/app"
 	"github.com/natemago/card-games-api/config"
@@ -36,10 +37,33 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().StringVar(&Config.DBConfig.URL, "db-url", "", "URL to sqlite database or PostgreSQL DSN.")
-	rootCmd.Flags().StringVar(&Config.DBConfig.Dialect, "db-type", "postgres", "Database type: postgres or sqlite.")
+	rootCmd.PersistentFlags().StringVar(&Config.DBConfig.URL, "db-url", "", "URL to sqlite database or PostgreSQL DSN.")
+	rootCmd.PersistentFlags().StringVar(&Config.DBConfig.Dialect, "db-type", "postgres", "Database type: postgres or sqlite.")
+	rootCmd.PersistentFlags().IntVar(&Config.DBConfig.MaxOpenConns, "db-max-open-conns", 0, "Maximum number of open database connections. 0 means unlimited.")
+	rootCmd.PersistentFlags().IntVar(&Config.DBConfig.MaxIdleConns, "db-max-idle-conns", 2, "Maximum number of idle database connections.")
+	rootCmd.PersistentFlags().DurationVar(&Config.DBConfig.ConnMaxLifetime, "db-conn-max-lifetime", 0, "Maximum amount of time a database connection may be reused. 0 means forever.")
+	rootCmd.PersistentFlags().DurationVar(&Config.DBConfig.StatementTimeout, "db-statement-timeout", 0, "Abort any SQL statement that takes longer than this (PostgreSQL only). 0 means no timeout.")
+	rootCmd.PersistentFlags().IntVar(&Config.DBConfig.ConnectRetries, "db-connect-retries", 5, "Number of times to retry connecting to the database on startup.")
+	rootCmd.PersistentFlags().DurationVar(&Config.DBConfig.ConnectBackoff, "db-connect-backoff", time.Second, "Wait time before the first retry to connect to the database. Doubles with every retry.")
+	rootCmd.PersistentFlags().DurationVar(&Config.DBConfig.HealthCheckInterval, "db-health-check-interval", 10*time.Second, "Interval to check the database connection. 0 disables the periodic check.")
+	rootCmd.PersistentFlags().StringVar(&Config.DBConfig.StorageLayout, "db-storage-layout", "cards", "Storage layout for the decks: cards (one row per card) or compact (one row per deck).")
+	rootCmd.PersistentFlags().StringVar(&Config.DBConfig.LogLevel, "db-log-level", "error", "SQL log level: silent, error, warn or info.")
 	rootCmd.Flags().StringVar(&Config.APIConfig.Host, "bind-host", "", "Bind to hostname.")
 	rootCmd.Flags().IntVar(&Config.APIConfig.Port, "bind-port", 8080, "Listen on port.")
+	rootCmd.Flags().IntVar(&Config.APIConfig.GRPCPort, "grpc-port", 9090, "Listen on port for the gRPC API. 0 disables the gRPC API.")
+	rootCmd.Flags().StringVar(&Config.APIConfig.AdminToken, "admin-token", "", "Secret token of the administrator, the dealer of every deck. Empty disables the administrator.")
+	rootCmd.Flags().DurationVar(&Config.APIConfig.EventsHeartbeat, "events-heartbeat", 15*time.Second, "Interval to send a heartbeat on the deck event streams. 0 disables the heartbeat.")
+	rootCmd.Flags().IntVar(&Config.APIConfig.MaxEventStreams, "max-event-streams", 1000, "Maximal number of open deck event streams. 0 means unlimited.")
+	rootCmd.Flags().IntVar(&Config.APIConfig.GraphQLMaxDepth, "graphql-max-depth", 8, "Maximal depth of a GraphQL query. 0 means unlimited.")
+	rootCmd.Flags().IntVar(&Config.APIConfig.GraphQLMaxComplexity, "graphql-max-complexity", 200, "Maximal number of fields selected by a GraphQL query. 0 means unlimited.")
+	rootCmd.Flags().IntVar(&Config.CacheConfig.Size, "cache-size", 0, "Maximal number of decks kept in the deck cache. 0 disables the cache.")
+	rootCmd.Flags().DurationVar(&Config.CacheConfig.TTL, "cache-ttl", 5*time.Second, "Maximal amount of time a deck is kept in the deck cache.")
+	rootCmd.Flags().DurationVar(&Config.WebhooksConfig.Interval, "webhooks-interval", time.Second, "Interval to check for webhook events due for delivery. 0 disables the delivery.")
+	rootCmd.Flags().DurationVar(&Config.WebhooksConfig.Timeout, "webhooks-timeout", 10*time.Second, "Maximal amount of time to wait for a webhook to respond.")
+	rootCmd.Flags().IntVar(&Config.WebhooksConfig.MaxAttempts, "webhooks-max-attempts", 8, "Number of attempts to deliver a webhook event before it is given up on.")
+	rootCmd.Flags().DurationVar(&Config.WebhooksConfig.Backoff, "webhooks-backoff", 5*time.Second, "Wait time before the first retry of a failed webhook delivery. Doubles with every retry.")
+	rootCmd.Flags().DurationVar(&Config.WebhooksConfig.MaxBackoff, "webhooks-max-backoff", time.Hour, "Maximal wait time before a retry of a failed webhook delivery. 0 means no limit.")
+	rootCmd.Flags().BoolVar(&Config.WebhooksConfig.AllowPrivateAddresses, "webhooks-allow-private", false, "Allow webhooks on loopback, private and link-local addresses.")
 }
 
 func readFromEnv() {
@@ -53,15 +77,76 @@ func readFromEnv() {
 		Config.DBConfig.Dialect = dbType
 	}
 
+	readIntFromEnv("DB_MAX_OPEN_CONNS", &Config.DBConfig.MaxOpenConns)
+	readIntFromEnv("DB_MAX_IDLE_CONNS", &Config.DBConfig.MaxIdleConns)
+	readDurationFromEnv("DB_CONN_MAX_LIFETIME", &Config.DBConfig.ConnMaxLifetime)
+	readDurationFromEnv("DB_STATEMENT_TIMEOUT", &Config.DBConfig.StatementTimeout)
+	readIntFromEnv("DB_CONNECT_RETRIES", &Config.DBConfig.ConnectRetries)
+	readDurationFromEnv("DB_CONNECT_BACKOFF", &Config.DBConfig.ConnectBackoff)
+	readDurationFromEnv("DB_HEALTH_CHECK_INTERVAL", &Config.DBConfig.HealthCheckInterval)
+
+	storageLayout := os.Getenv("DB_STORAGE_LAYOUT")
+	if storageLayout != "" {
+		Config.DBConfig.StorageLayout = storageLayout
+	}
+
+	dbLogLevel := os.Getenv("DB_LOG_LEVEL")
+	if dbLogLevel != "" {
+		Config.DBConfig.LogLevel = dbLogLevel
+	}
+
 	bindHost := os.Getenv("BIND_HOST")
 	if bindHost != "" {
 		Config.APIConfig.Host = bindHost
 	}
 
-	bindPort := os.Getenv("BIND_PORT")
-	if bindPort != "" {
-		if port, err := strconv.Atoi(bindPort); err == nil {
-			Config.APIConfig.Port = port
+	readIntFromEnv("BIND_PORT", &Config.APIConfig.Port)
+	readIntFromEnv("GRPC_PORT", &Config.APIConfig.GRPCPort)
+
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	if adminToken != "" {
+		Config.APIConfig.AdminToken = adminToken
+	}
+
+	readDurationFromEnv("EVENTS_HEARTBEAT", &Config.APIConfig.EventsHeartbeat)
+	readIntFromEnv("MAX_EVENT_STREAMS", &Config.APIConfig.MaxEventStreams)
+	readIntFromEnv("GRAPHQL_MAX_DEPTH", &Config.APIConfig.GraphQLMaxDepth)
+	readIntFromEnv("GRAPHQL_MAX_COMPLEXITY", &Config.APIConfig.GraphQLMaxComplexity)
+
+	readIntFromEnv("CACHE_SIZE", &Config.CacheConfig.Size)
+	readDurationFromEnv("CACHE_TTL", &Config.CacheConfig.TTL)
+
+	readDurationFromEnv("WEBHOOKS_INTERVAL", &Config.WebhooksConfig.Interval)
+	readDurationFromEnv("WEBHOOKS_TIMEOUT", &Config.WebhooksConfig.Timeout)
+	readIntFromEnv("WEBHOOKS_MAX_ATTEMPTS", &Config.WebhooksConfig.MaxAttempts)
+	readDurationFromEnv("WEBHOOKS_BACKOFF", &Config.WebhooksConfig.Backoff)
+	readDurationFromEnv("WEBHOOKS_MAX_BACKOFF", &Config.WebhooksConfig.MaxBackoff)
+	readBoolFromEnv("WEBHOOKS_ALLOW_PRIVATE", &Config.WebhooksConfig.AllowPrivateAddresses)
+}
+
+func readIntFromEnv(name string, value *int) {
+	envValue := os.Getenv(name)
+	if envValue != "" {
+		if intValue, err := strconv.Atoi(envValue); err == nil {
+			*value = intValue
+		}
+	}
+}
+
+func readBoolFromEnv(name string, value *bool) {
+	envValue := os.Getenv(name)
+	if envValue != "" {
+		if boolValue, err := strconv.ParseBool(envValue); err == nil {
+			*value = boolValue
+		}
+	}
+}
+
+func readDurationFromEnv(name string, value *time.Duration) {
+	envValue := os.Getenv(name)
+	if envValue != "" {
+		if duration, err := time.ParseDuration(envValue); err == nil {
+			*value = duration
 		}
 	}
 }
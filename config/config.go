@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // DBConfig holds the database configuration values, like the database dialect and connection URL or DSN.
 type DBConfig struct {
 	// Dialect is the database driver dialect (database type).
@@ -7,6 +9,37 @@ type DBConfig struct {
 
 	// URL is the connection URL or DSN.
 	URL string
+
+	// MaxOpenConns is the maximum number of open connections to the database. Zero means unlimited.
+	MaxOpenConns int
+
+	// MaxIdleConns is the maximum number of idle connections kept in the connection pool.
+	MaxIdleConns int
+
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused. Zero means connections are reused forever.
+	ConnMaxLifetime time.Duration
+
+	// StatementTimeout aborts any statement that takes more than the specified amount of time.
+	// Zero means no timeout. Only supported for PostgreSQL.
+	StatementTimeout time.Duration
+
+	// ConnectRetries is the number of times to retry connecting to the database on startup before giving up.
+	ConnectRetries int
+
+	// ConnectBackoff is the time to wait before the first retry to connect to the database.
+	// The wait time doubles with every subsequent retry.
+	ConnectBackoff time.Duration
+
+	// HealthCheckInterval is the interval at which the database connection is checked (pinged).
+	// Zero disables the periodic health check.
+	HealthCheckInterval time.Duration
+
+	// StorageLayout is the layout used to store the decks of cards: "cards" (one row per card) or "compact"
+	// (one row per deck, with the order of the cards encoded in a single column).
+	StorageLayout string
+
+	// LogLevel is the SQL log level: silent, error, warn or info. The "info" level logs every SQL statement.
+	LogLevel string
 }
 
 // APIConfig holds configuration values for the API routing and setup.
@@ -16,6 +49,58 @@ type APIConfig struct {
 
 	// Port to listen on.
 	Port int
+
+	// GRPCPort is the port the gRPC API listens on. Zero disables the gRPC API.
+	GRPCPort int
+
+	// AdminToken is the secret token of the administrator, who acts as the dealer of every deck. Empty disables the
+	// administrator.
+	AdminToken string
+
+	// EventsHeartbeat is the interval to send a heartbeat to the clients of the Server-Sent Events streams, so idle
+	// connections are kept open by the proxies. Zero disables the heartbeat.
+	EventsHeartbeat time.Duration
+
+	// MaxEventStreams is the maximal number of open Server-Sent Events streams. Zero means unlimited.
+	MaxEventStreams int
+
+	// GraphQLMaxDepth is the maximal depth of the selections of a GraphQL query. Zero means unlimited.
+	GraphQLMaxDepth int
+
+	// GraphQLMaxComplexity is the maximal number of fields selected by a GraphQL query. Zero means unlimited.
+	GraphQLMaxComplexity int
+}
+
+// CacheConfig holds the configuration values for the deck cache.
+type CacheConfig struct {
+	// Size is the maximal number of decks kept in the cache. Zero disables the cache.
+	Size int
+
+	// TTL is the maximal amount of time a deck is kept in the cache.
+	TTL time.Duration
+}
+
+// WebhooksConfig holds the configuration values for the delivery of the events to the webhooks.
+type WebhooksConfig struct {
+	// Interval is the interval to check for events due for delivery. Zero disables the delivery.
+	Interval time.Duration
+
+	// Timeout is the maximal amount of time to wait for a webhook to respond.
+	Timeout time.Duration
+
+	// MaxAttempts is the number of attempts to deliver an event, before it is given up on.
+	MaxAttempts int
+
+	// Backoff is the time to wait before the first retry of a failed delivery. The wait time doubles with every
+	// subsequent retry.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximal time to wait before a retry. Zero means no limit.
+	MaxBackoff time.Duration
+
+	// AllowPrivateAddresses allows the webhooks on loopback, private and link-local addresses, like for a local
+	// development. By default, the webhooks may reach only public addresses.
+	AllowPrivateAddresses bool
 }
 
 // Config holds the API configuration values.
@@ -25,4 +110,10 @@ type Config struct {
 
 	// API Configuration.
 	APIConfig
+
+	// Deck cache configuration.
+	CacheConfig
+
+	// Webhooks configuration.
+	WebhooksConfig
 }
@@ -0,0 +1,123 @@
+package cribbage
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// HandSize is the number of cards in a hand (or the crib) after the discard.
+const HandSize = 4
+
+// ScoreHand scores a hand of four cards together with the starter card, as counted in the show: fifteens, pairs,
+// runs, flush and nobs. The crib scores a flush only if the starter is of the same suit as well.
+// Returns a ValidationError if the hand does not have exactly four cards, or the cards are not valid or repeat.
+func ScoreHand(hand []*deck_repo.Card, starter *deck_repo.Card, crib bool) (*Score, error) {
+	if len(hand) != HandSize {
+		return nil, errors.ValidationError(fmt.Sprintf("a hand must have exactly %d cards", HandSize), nil)
+	}
+	if starter == nil {
+		return nil, errors.ValidationError("the starter card is required", nil)
+	}
+	cards := append(append([]*deck_repo.Card{}, hand...), starter)
+	if err := deck_repo.ValidateDeckCards(cards); err != nil {
+		return nil, err
+	}
+
+	score := &Score{}
+	scoreFifteens(score, cards)
+	scorePairs(score, cards)
+	scoreRuns(score, cards)
+	scoreFlush(score, hand, starter, crib)
+	scoreNobs(score, hand, starter)
+
+	return score, nil
+}
+
+// subset returns the cards selected by the bits of the mask.
+func subset(cards []*deck_repo.Card, mask int) []*deck_repo.Card {
+	var result []*deck_repo.Card
+	for i, card := range cards {
+		if mask&(1<<i) != 0 {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func scoreFifteens(score *Score, cards []*deck_repo.Card) {
+	for mask := 1; mask < 1<<len(cards); mask++ {
+		combination := subset(cards, mask)
+		total := 0
+		for _, card := range combination {
+			total += Value(card)
+		}
+		if total == 15 {
+			score.add(FifteenItem, 2, combination...)
+		}
+	}
+}
+
+func scorePairs(score *Score, cards []*deck_repo.Card) {
+	for i := 0; i < len(cards); i++ {
+		for j := i + 1; j < len(cards); j++ {
+			if Rank(cards[i]) == Rank(cards[j]) {
+				score.add(PairItem, 2, cards[i], cards[j])
+			}
+		}
+	}
+}
+
+// scoreRuns scores every longest run. A run contained in a longer one does not score, but the runs repeated with
+// another card of the same rank (double and triple runs) do.
+func scoreRuns(score *Score, cards []*deck_repo.Card) {
+	for length := len(cards); length >= 3; length-- {
+		found := false
+		for mask := 1; mask < 1<<len(cards); mask++ {
+			if bits.OnesCount(uint(mask)) != length {
+				continue
+			}
+			if combination := subset(cards, mask); isRun(combination) {
+				score.add(RunItem, length, sortByRank(combination)...)
+				found = true
+			}
+		}
+		if found {
+			return
+		}
+	}
+}
+
+func scoreFlush(score *Score, hand []*deck_repo.Card, starter *deck_repo.Card, crib bool) {
+	suit := Suit(hand[0])
+	for _, card := range hand[1:] {
+		if Suit(card) != suit {
+			return
+		}
+	}
+	if Suit(starter) == suit {
+		score.add(FlushItem, len(hand)+1, append(append([]*deck_repo.Card{}, hand...), starter)...)
+	} else if !crib {
+		score.add(FlushItem, len(hand), hand...)
+	}
+}
+
+func scoreNobs(score *Score, hand []*deck_repo.Card, starter *deck_repo.Card) {
+	for _, card := range hand {
+		if Rank(card) == rankOrders["J"] && Suit(card) == Suit(starter) {
+			score.add(NobsItem, 1, card)
+		}
+	}
+}
+
+// sortByRank returns the cards sorted by rank, from the ace to the king.
+func sortByRank(cards []*deck_repo.Card) []*deck_repo.Card {
+	sorted := append([]*deck_repo.Card{}, cards...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Rank(sorted[i]) < Rank(sorted[j])
+	})
+	return sorted
+}
@@ -0,0 +1,169 @@
+package cribbage
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func score(t *testing.T, hand, starter string, crib bool) *Score {
+	result, err := ScoreHand(deck_repo.AsCards(hand), deck_repo.AsCards(starter)[0], crib)
+	if err != nil {
+		t.Fatalf("Expected to score %s with %s, but got error: %s", hand, starter, err.Error())
+	}
+	return result
+}
+
+func points(score *Score, kind string) int {
+	total := 0
+	for _, item := range score.Items {
+		if item.Kind == kind {
+			total += item.Points
+		}
+	}
+	return total
+}
+
+func TestScoreHand_KnownHands(t *testing.T) {
+	tests := []struct {
+		hand     string
+		starter  string
+		expected int
+	}{
+		{"5H,5C,5S,JD", "5D", 29},
+		{"5H,5C,5S,5D", "JS", 28},
+		{"5H,5C,5S,JH", "5D", 28},
+		{"5H,5C,5S,5D", "10S", 28},
+		{"3C,3D,3H,3S", "9C", 24},
+		{"4C,4D,5H,6S", "6C", 24},
+		{"7C,7D,8H,8S", "9C", 24},
+		{"6C,6D,6H,9S", "9C", 20},
+		{"5C,5D,JH,QS", "KC", 17},
+		{"5C,5D,5H,10S", "10C", 22},
+		{"4H,5H,6H,7H", "8H", 14},
+		{"AC,2D,3H,4S", "5C", 7},
+		{"2C,4D,6H,8S", "KC", 0},
+	}
+
+	for _, test := range tests {
+		result := score(t, test.hand, test.starter, false)
+		if result.Total != test.expected {
+			t.Errorf("Expected %s with %s to score %d, but got %d: %+v", test.hand, test.starter, test.expected, result.Total, result.Items)
+		}
+	}
+}
+
+func TestScoreHand_Breakdown(t *testing.T) {
+	result := score(t, "5H,5C,5S,JD", "5D", false)
+
+	if fifteens := points(result, FifteenItem); fifteens != 16 {
+		t.Errorf("Expected 16 for fifteens, but got %d.", fifteens)
+	}
+	if pairs := points(result, PairItem); pairs != 12 {
+		t.Errorf("Expected 12 for pairs, but got %d.", pairs)
+	}
+	if nobs := points(result, NobsItem); nobs != 1 {
+		t.Errorf("Expected 1 for nobs, but got %d.", nobs)
+	}
+
+	result = score(t, "3C,3D,4H,5S", "5C", false)
+	runs := 0
+	for _, item := range result.Items {
+		if item.Kind == RunItem {
+			runs++
+			if len(item.Cards) != 3 || Rank(item.Cards[0]) != 3 || Rank(item.Cards[2]) != 5 {
+				t.Errorf("Expected the run sorted by rank, but got: %+v", item.Cards)
+			}
+		}
+	}
+	if runs != 4 {
+		t.Errorf("Expected a double-double run, but got %d runs.", runs)
+	}
+}
+
+func TestScoreHand_Flush(t *testing.T) {
+	if flush := points(score(t, "2H,4H,6H,8H", "KS", false), FlushItem); flush != 4 {
+		t.Errorf("Expected a four card flush in the hand, but got %d.", flush)
+	}
+	if flush := points(score(t, "2H,4H,6H,8H", "KS", true), FlushItem); flush != 0 {
+		t.Errorf("Expected no four card flush in the crib, but got %d.", flush)
+	}
+	if flush := points(score(t, "2H,4H,6H,8H", "KH", true), FlushItem); flush != 5 {
+		t.Errorf("Expected a five card flush in the crib, but got %d.", flush)
+	}
+	if flush := points(score(t, "2H,4H,6H,8S", "KH", false), FlushItem); flush != 0 {
+		t.Errorf("Expected no flush with the starter only, but got %d.", flush)
+	}
+}
+
+func TestScoreHand_Nobs(t *testing.T) {
+	if nobs := points(score(t, "2H,4H,6D,JS", "KS", false), NobsItem); nobs != 1 {
+		t.Errorf("Expected a point for nobs, but got %d.", nobs)
+	}
+	if nobs := points(score(t, "2H,4H,6D,JS", "JH", false), NobsItem); nobs != 0 {
+		t.Errorf("Expected no nobs for the jack as the starter, but got %d.", nobs)
+	}
+}
+
+// TestScoreHand_AllRanks scores a hand for every combination of ranks, and checks the scores that no hand can get.
+func TestScoreHand_AllRanks(t *testing.T) {
+	impossible := map[int]bool{19: true, 25: true, 26: true, 27: true}
+	best := 0
+	ranks := make([]int, 5)
+
+	var deal func(i, from int)
+	deal = func(i, from int) {
+		if i == len(ranks) {
+			used := map[int]int{}
+			var cards []*deck_repo.Card
+			for _, rank := range ranks {
+				if used[rank] == len(deck_repo.Suits) {
+					return
+				}
+				cards = append(cards, &deck_repo.Card{Value: deck_repo.Ranks[rank] + deck_repo.Suits[used[rank]]})
+				used[rank]++
+			}
+			result, err := ScoreHand(cards[:HandSize], cards[HandSize], false)
+			if err != nil {
+				t.Fatalf("Expected to score %+v, but got error: %s", ranks, err.Error())
+			}
+			if impossible[result.Total] {
+				t.Errorf("Expected no hand to score %d, but got it for %+v.", result.Total, ranks)
+			}
+			if result.Total > best {
+				best = result.Total
+			}
+			return
+		}
+		if i == HandSize {
+			// the starter is any rank
+			from = 0
+		}
+		for rank := from; rank < len(deck_repo.Ranks); rank++ {
+			ranks[i] = rank
+			deal(i+1, rank)
+		}
+	}
+	deal(0, 0)
+
+	// the 29 needs nobs, which the suits here never give with four fives
+	if best != 28 {
+		t.Errorf("Expected the best score without nobs to be 28, but got %d.", best)
+	}
+}
+
+func TestScoreHand_Invalid(t *testing.T) {
+	if _, err := ScoreHand(deck_repo.AsCards("5H,5C,5S"), &deck_repo.Card{Value: "5D"}, false); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for three cards, but got: %v", err)
+	}
+	if _, err := ScoreHand(deck_repo.AsCards("5H,5C,5S,5D"), &deck_repo.Card{Value: "5D"}, false); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for a repeated card, but got: %v", err)
+	}
+	if _, err := ScoreHand(deck_repo.AsCards("5H,5C,5S,1D"), &deck_repo.Card{Value: "5D"}, false); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for an invalid card, but got: %v", err)
+	}
+	if _, err := ScoreHand(deck_repo.AsCards("5H,5C,5S,5D"), nil, false); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError without a starter, but got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package cribbage
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// GoMarker is the code of the marker in the play sequence ending the current count when no one can play without
+// going over 31.
+const GoMarker = "GO"
+
+// MaxCount is the count the cards played in the pegging must not go over.
+const MaxCount = 31
+
+// Play is a card played in the pegging, with the points it scored.
+type Play struct {
+	// Card is the played card.
+	Card *deck_repo.Card
+
+	// Count is the count after the card was played.
+	Count int
+
+	// Score holds the points scored by the play, including the point for the go, if the count ended with it.
+	Score
+}
+
+// Pegging is the itemized score of the play sequence.
+type Pegging struct {
+	// Plays holds the played cards in order, with their points.
+	Plays []*Play
+
+	// Total is the sum of the points of all plays.
+	Total int
+}
+
+// ScorePegging scores the sequence of cards played in the pegging. A card scores for bringing the count to 15 or
+// 31, for pairs with the cards played right before it, and for runs made with the cards played right before it, in
+// any order. The count starts over after 31, or after a GoMarker in the sequence, which scores a point for the go
+// to the last card played.
+// Returns a ValidationError if the cards are not valid or repeat, if a card would bring the count over 31, or if a
+// go is called before any card is played to the count.
+func ScorePegging(sequence []*deck_repo.Card) (*Pegging, error) {
+	var cards []*deck_repo.Card
+	for _, card := range sequence {
+		if card.Value != GoMarker {
+			cards = append(cards, card)
+		}
+	}
+	if err := deck_repo.ValidateDeckCards(cards); err != nil {
+		return nil, err
+	}
+
+	pegging := &Pegging{
+		Plays: []*Play{},
+	}
+	var current []*deck_repo.Card
+	count := 0
+	for _, card := range sequence {
+		if card.Value == GoMarker {
+			if len(current) == 0 {
+				return nil, errors.ValidationError("a go must follow a played card", nil)
+			}
+			last := pegging.Plays[len(pegging.Plays)-1]
+			last.add(GoItem, 1, last.Card)
+			pegging.Total++
+			current, count = nil, 0
+			continue
+		}
+
+		if count+Value(card) > MaxCount {
+			return nil, errors.ValidationError(fmt.Sprintf("playing %s would bring the count over %d", card.Value, MaxCount), nil)
+		}
+		count += Value(card)
+		current = append(current, card)
+
+		play := &Play{
+			Card:  card,
+			Count: count,
+		}
+		scorePlay(&play.Score, current, count)
+		pegging.Plays = append(pegging.Plays, play)
+		pegging.Total += play.Total
+
+		if count == MaxCount {
+			current, count = nil, 0
+		}
+	}
+
+	return pegging, nil
+}
+
+// scorePlay scores the last card of the cards played to the current count.
+func scorePlay(score *Score, current []*deck_repo.Card, count int) {
+	if count == 15 {
+		score.add(FifteenItem, 2, current...)
+	}
+	if count == MaxCount {
+		score.add(ThirtyOneItem, 2, current...)
+	}
+
+	last := len(current) - 1
+	same := 1
+	for same <= last && Rank(current[last-same]) == Rank(current[last]) {
+		same++
+	}
+	switch same {
+	case 2:
+		score.add(PairItem, 2, current[last-1:]...)
+	case 3:
+		score.add(PairRoyalItem, 6, current[last-2:]...)
+	case 4:
+		score.add(DoublePairRoyalItem, 12, current[last-3:]...)
+	}
+
+	for length := len(current); length >= 3; length-- {
+		if run := current[len(current)-length:]; isRun(run) {
+			score.add(RunItem, length, run...)
+			return
+		}
+	}
+}
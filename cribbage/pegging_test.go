@@ -0,0 +1,79 @@
+package cribbage
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func TestScorePegging(t *testing.T) {
+	pegging, err := ScorePegging(deck_repo.AsCards("7H,8C,8D,8S,AC,2D,3H,GO,4C,6D,5H,7S"))
+	if err != nil {
+		t.Fatalf("Expected to score the pegging, but got error: %s", err.Error())
+	}
+
+	expected := []struct {
+		count  int
+		points int
+		kinds  []string
+	}{
+		{7, 0, nil},
+		{15, 2, []string{FifteenItem}},
+		{23, 2, []string{PairItem}},
+		{31, 8, []string{ThirtyOneItem, PairRoyalItem}},
+		{1, 0, nil},
+		{3, 0, nil},
+		{6, 4, []string{RunItem, GoItem}},
+		{4, 0, nil},
+		{10, 0, nil},
+		{15, 5, []string{FifteenItem, RunItem}},
+		{22, 4, []string{RunItem}},
+	}
+	if len(pegging.Plays) != len(expected) {
+		t.Fatalf("Expected %d plays, but got %d.", len(expected), len(pegging.Plays))
+	}
+	for i, play := range pegging.Plays {
+		if play.Count != expected[i].count || play.Total != expected[i].points || len(play.Items) != len(expected[i].kinds) {
+			t.Errorf("Expected play %d (%s) to count %d for %d points, but got %d for %d: %+v", i, play.Card.Value,
+				expected[i].count, expected[i].points, play.Count, play.Total, play.Items)
+			continue
+		}
+		for j, item := range play.Items {
+			if item.Kind != expected[i].kinds[j] {
+				t.Errorf("Expected play %d to score %s, but got %s.", i, expected[i].kinds[j], item.Kind)
+			}
+		}
+	}
+	if pegging.Total != 25 {
+		t.Errorf("Expected 25 points in total, but got %d.", pegging.Total)
+	}
+}
+
+func TestScorePegging_DoublePairRoyal(t *testing.T) {
+	pegging, err := ScorePegging(deck_repo.AsCards("3C,3D,3H,3S"))
+	if err != nil {
+		t.Fatalf("Expected to score the pegging, but got error: %s", err.Error())
+	}
+	if last := pegging.Plays[3]; last.Total != 12 || last.Items[0].Kind != DoublePairRoyalItem {
+		t.Errorf("Expected a double pair royal, but got: %+v", last.Items)
+	}
+	if pegging.Total != 20 {
+		t.Errorf("Expected 20 points in total, but got %d.", pegging.Total)
+	}
+}
+
+func TestScorePegging_Invalid(t *testing.T) {
+	tests := []string{
+		"10C,JD,QH,2S",
+		"GO,5C",
+		"5C,GO,GO",
+		"5C,5C",
+		"5X",
+	}
+	for _, test := range tests {
+		if _, err := ScorePegging(deck_repo.AsCards(test)); !errors.IsValidationError(err) {
+			t.Errorf("Expected a ValidationError for %s, but got: %v", test, err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package cribbage
+
+import (
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Kinds of the scoring items.
+const (
+	// FifteenItem is a combination of cards adding up to 15, for 2 points.
+	FifteenItem = "FIFTEEN"
+
+	// PairItem is a pair of cards of the same rank, for 2 points.
+	PairItem = "PAIR"
+
+	// PairRoyalItem is three cards of the same rank played in a row in the pegging, for 6 points.
+	PairRoyalItem = "PAIR_ROYAL"
+
+	// DoublePairRoyalItem is four cards of the same rank played in a row in the pegging, for 12 points.
+	DoublePairRoyalItem = "DOUBLE_PAIR_ROYAL"
+
+	// RunItem is a sequence of three or more cards of consecutive ranks, for a point per card.
+	RunItem = "RUN"
+
+	// FlushItem is a hand of cards of the same suit, for a point per card.
+	FlushItem = "FLUSH"
+
+	// NobsItem is the jack in the hand of the same suit as the starter, for 1 point.
+	NobsItem = "NOBS"
+
+	// ThirtyOneItem is a play bringing the count to exactly 31 in the pegging, for 2 points.
+	ThirtyOneItem = "THIRTY_ONE"
+
+	// GoItem is the last card played before no one can play without going over 31, for 1 point.
+	GoItem = "GO"
+)
+
+// Item is a single scoring combination.
+type Item struct {
+	// Kind is the kind of the combination, like FifteenItem or RunItem.
+	Kind string
+
+	// Cards are the cards making up the combination.
+	Cards []*deck_repo.Card
+
+	// Points is the number of points the combination scores.
+	Points int
+}
+
+// Score is the itemized score of a hand.
+type Score struct {
+	// Items holds the scoring combinations.
+	Items []Item
+
+	// Total is the sum of the points of all items.
+	Total int
+}
+
+func (s *Score) add(kind string, points int, cards ...*deck_repo.Card) {
+	s.Items = append(s.Items, Item{
+		Kind:   kind,
+		Cards:  cards,
+		Points: points,
+	})
+	s.Total += points
+}
+
+// rankOrders maps the rank codes to their order, from the ace (1) to the king (13).
+var rankOrders = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		rankOrders[rank] = i + 1
+	}
+}
+
+// Rank returns the order of the card rank, from the ace (1) to the king (13).
+func Rank(card *deck_repo.Card) int {
+	return rankOrders[card.Value[:len(card.Value)-1]]
+}
+
+// Value returns the counting value of the card: the ace counts 1, the face cards count 10 and the rest count their
+// number.
+func Value(card *deck_repo.Card) int {
+	if rank := Rank(card); rank < 10 {
+		return rank
+	}
+	return 10
+}
+
+// Suit returns the suit code of the card.
+func Suit(card *deck_repo.Card) string {
+	return card.Value[len(card.Value)-1:]
+}
+
+// isRun checks if the cards have distinct, consecutive ranks in any order.
+func isRun(cards []*deck_repo.Card) bool {
+	seen := map[int]bool{}
+	low, high := 0, 0
+	for _, card := range cards {
+		rank := Rank(card)
+		if seen[rank] {
+			return false
+		}
+		seen[rank] = true
+		if low == 0 || rank < low {
+			low = rank
+		}
+		if rank > high {
+			high = rank
+		}
+	}
+	return high-low == len(cards)-1
+}
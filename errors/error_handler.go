@@ -21,16 +21,30 @@ func ErrorHandler() gin.HandlerFunc {
 			return
 		}
 
-		statusCode := http.StatusInternalServerError
-
-		if IsBadRequestError(err.Err) || IsValidationError(err.Err) {
-			statusCode = http.StatusBadRequest
-		} else if IsNotFoundError(err.Err) {
-			statusCode = http.StatusNotFound
-		}
-
-		ctx.JSON(statusCode, &ErrorResponse{
+		ctx.JSON(StatusCode(err.Err), &ErrorResponse{
 			Message: err.Error(),
 		})
 	}
 }
+
+// StatusCode returns the HTTP status code for the given error: 400 Bad Request for BadRequestError and ValidationError,
+// 401 Unauthorized for UnauthorizedError, 403 Forbidden for ForbiddenError, 404 Not Found for NotFoundError,
+// 503 Service Unavailable for UnavailableError and 500 Internal Server Error for any other error.
+func StatusCode(err error) int {
+	if IsBadRequestError(err) || IsValidationError(err) {
+		return http.StatusBadRequest
+	}
+	if IsUnauthorizedError(err) {
+		return http.StatusUnauthorized
+	}
+	if IsForbiddenError(err) {
+		return http.StatusForbidden
+	}
+	if IsNotFoundError(err) {
+		return http.StatusNotFound
+	}
+	if IsUnavailableError(err) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -50,3 +50,6 @@ func ErrorType(errType string) (ErrorBuilder, ErrorTypeCheck) {
 var NotFoundError, IsNotFoundError = ErrorType("not-found")
 var ValidationError, IsValidationError = ErrorType("validation")
 var BadRequestError, IsBadRequestError = ErrorType("bad-request")
+var UnauthorizedError, IsUnauthorizedError = ErrorType("unauthorized")
+var ForbiddenError, IsForbiddenError = ErrorType("forbidden")
+var UnavailableError, IsUnavailableError = ErrorType("unavailable")
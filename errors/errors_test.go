@@ -49,3 +49,47 @@ func TestBadRequestError(t *testing.T) {
 		t.Error("Generic error should not be a 'bad-request-error'.")
 	}
 }
+
+func TestUnauthorizedError(t *testing.T) {
+	err := UnauthorizedError("invalid token", nil)
+	if !IsUnauthorizedError(err) {
+		t.Error("Expected to be an 'unauthorized-error'.")
+	}
+	if IsForbiddenError(err) {
+		t.Error("An 'unauthorized-error' should not be a 'forbidden-error'.")
+	}
+}
+
+func TestForbiddenError(t *testing.T) {
+	err := ForbiddenError("not allowed", nil)
+	if !IsForbiddenError(err) {
+		t.Error("Expected to be a 'forbidden-error'.")
+	}
+	if err.Error() != "not allowed" {
+		t.Error("Expected to get correct error message from APIError.")
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	if StatusCode(BadRequestError("bad parameter", nil)) != 400 {
+		t.Error("Expected 400 for a 'bad-request-error'.")
+	}
+	if StatusCode(ValidationError("invalid value", nil)) != 400 {
+		t.Error("Expected 400 for a 'validation-error'.")
+	}
+	if StatusCode(UnauthorizedError("invalid token", nil)) != 401 {
+		t.Error("Expected 401 for an 'unauthorized-error'.")
+	}
+	if StatusCode(ForbiddenError("not allowed", nil)) != 403 {
+		t.Error("Expected 403 for a 'forbidden-error'.")
+	}
+	if StatusCode(NotFoundError("record not found", nil)) != 404 {
+		t.Error("Expected 404 for a 'not-found-error'.")
+	}
+	if StatusCode(UnavailableError("try again later", nil)) != 503 {
+		t.Error("Expected 503 for an 'unavailable-error'.")
+	}
+	if StatusCode(fmt.Errorf("generic-error")) != 500 {
+		t.Error("Expected 500 for a generic error.")
+	}
+}
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Types of the deck events.
+const (
+	// Drawn is published when cards are drawn from a deck, also into the hand of a player.
+	Drawn = "drawn"
+
+	// Shuffled is published when the remaining cards of a deck are shuffled.
+	Shuffled = "shuffled"
+
+	// PileMoved is published when cards are moved from the hand of a player onto a public pile.
+	PileMoved = "pile_moved"
+)
+
+// DefaultBuffer is the default number of events buffered for a subscriber.
+const DefaultBuffer = 64
+
+// Event is a change of a deck of cards. Only public information is held: cards drawn into the hand of a player, or
+// from a deck with players, are counted but not shown.
+type Event struct {
+	// ID is the sequence number of the event in the event log of the deck, if the event was logged.
+	ID uint64 `json:"id,omitempty"`
+
+	// Type is the type of the event, like Drawn.
+	Type string `json:"type"`
+
+	// DeckID is the id of the changed deck.
+	DeckID string `json:"deck_id"`
+
+	// Time is the time of the change.
+	Time time.Time `json:"time"`
+
+	// Remaining is the number of remaining cards in the deck after the change.
+	Remaining int `json:"remaining"`
+
+	// Count is the number of drawn or moved cards.
+	Count int `json:"count,omitempty"`
+
+	// Cards holds the codes of the drawn or moved cards, if they are public.
+	Cards []string `json:"cards,omitempty"`
+
+	// PlayerID is the id of the player, for the cards drawn into or moved from a hand.
+	PlayerID string `json:"player_id,omitempty"`
+
+	// Pile is the name of the pile the cards were moved onto.
+	Pile string `json:"pile,omitempty"`
+}
+
+// Subscription receives the events of a single deck from a Hub.
+type Subscription struct {
+	// DeckID is the id of the deck.
+	DeckID string
+
+	// Events delivers the events of the deck, in the order they were published. The channel is closed once the
+	// subscription is closed, also when the subscriber is too slow to keep up with the events.
+	Events <-chan *Event
+
+	events chan *Event
+	hub    *Hub
+	once   sync.Once
+}
+
+// Close closes the subscription. Safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.remove(s)
+}
+
+// Hub is an in-process publish/subscribe hub of the deck events, per deck. Publishing never blocks: a subscriber
+// that does not keep up with the events is dropped, and its Events channel is closed.
+// A nil Hub discards the events: its subscriptions never receive any event until they are closed.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*Subscription]bool
+	buffer      int
+}
+
+// Subscribe subscribes to the events of the deck.
+func (h *Hub) Subscribe(deckID string) *Subscription {
+	if h == nil {
+		events := make(chan *Event)
+		return &Subscription{
+			DeckID: deckID,
+			Events: events,
+			events: events,
+		}
+	}
+
+	events := make(chan *Event, h.buffer)
+	subscription := &Subscription{
+		DeckID: deckID,
+		Events: events,
+		events: events,
+		hub:    h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[deckID] == nil {
+		h.subscribers[deckID] = map[*Subscription]bool{}
+	}
+	h.subscribers[deckID][subscription] = true
+
+	return subscription
+}
+
+// Publish delivers the event to the subscribers of its deck. If the time of the event is not set, it is set to now.
+func (h *Hub) Publish(event *Event) {
+	if h == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	slow := []*Subscription{}
+	h.mu.RLock()
+	for subscription := range h.subscribers[event.DeckID] {
+		select {
+		case subscription.events <- event:
+		default:
+			slow = append(slow, subscription)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, subscription := range slow {
+		subscription.Close()
+	}
+}
+
+// Subscribers returns the number of subscribers to the events of the deck.
+func (h *Hub) Subscribers(deckID string) int {
+	if h == nil {
+		return 0
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[deckID])
+}
+
+func (h *Hub) remove(subscription *Subscription) {
+	subscription.once.Do(func() {
+		if h != nil {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subscribers[subscription.DeckID], subscription)
+			if len(h.subscribers[subscription.DeckID]) == 0 {
+				delete(h.subscribers, subscription.DeckID)
+			}
+		}
+		close(subscription.events)
+	})
+}
+
+// NewHub creates a new Hub, buffering up to the given number of events for each subscriber. A buffer less than one
+// defaults to DefaultBuffer.
+func NewHub(buffer int) *Hub {
+	if buffer < 1 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{
+		subscribers: map[string]map[*Subscription]bool{},
+		buffer:      buffer,
+	}
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub(0)
+	first := hub.Subscribe("deck-1")
+	second := hub.Subscribe("deck-1")
+	other := hub.Subscribe("deck-2")
+
+	hub.Publish(&Event{Type: Drawn, DeckID: "deck-1", Count: 2})
+
+	for _, subscription := range []*Subscription{first, second} {
+		event := <-subscription.Events
+		if event.Type != Drawn || event.Count != 2 || event.Time.IsZero() {
+			t.Errorf("Expected the drawn event, but got: %+v", event)
+		}
+	}
+	if len(other.Events) != 0 {
+		t.Error("Expected no events for another deck.")
+	}
+
+	first.Close()
+	first.Close()
+	if _, ok := <-first.Events; ok {
+		t.Error("Expected the events of a closed subscription to be closed.")
+	}
+	if hub.Subscribers("deck-1") != 1 {
+		t.Errorf("Expected a single subscriber left, but got %d.", hub.Subscribers("deck-1"))
+	}
+
+	second.Close()
+	other.Close()
+	if hub.Subscribers("deck-1") != 0 || len(hub.subscribers) != 0 {
+		t.Errorf("Expected no subscribers left, but got: %+v", hub.subscribers)
+	}
+}
+
+func TestHub_SlowSubscriber(t *testing.T) {
+	hub := NewHub(2)
+	slow := hub.Subscribe("deck")
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(&Event{Type: Shuffled, DeckID: "deck"})
+	}
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != 2 || hub.Subscribers("deck") != 0 {
+		t.Errorf("Expected the slow subscriber to be dropped after 2 events, but got %d.", received)
+	}
+}
+
+func TestHub_Nil(t *testing.T) {
+	var hub *Hub
+	subscription := hub.Subscribe("deck")
+	hub.Publish(&Event{Type: Drawn, DeckID: "deck"})
+
+	select {
+	case event := <-subscription.Events:
+		t.Fatalf("Expected no event from a nil hub, got: %v", event)
+	default:
+	}
+	if count := hub.Subscribers("deck"); count != 0 {
+		t.Errorf("Expected no subscribers, got %d", count)
+	}
+
+	subscription.Close()
+	subscription.Close()
+	if _, ok := <-subscription.Events; ok {
+		t.Error("Expected the subscription to be closed")
+	}
+}
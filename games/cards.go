@@ -0,0 +1,77 @@
+package games
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// rankOf returns the rank code of the card, like "Q".
+func rankOf(card *deck_repo.Card) string {
+	return card.Value[:len(card.Value)-1]
+}
+
+// suitOf returns the suit code of the card, like "H".
+func suitOf(card *deck_repo.Card) string {
+	return card.Value[len(card.Value)-1:]
+}
+
+// isRank returns true if the code is a rank code, like "Q".
+func isRank(code string) bool {
+	for _, rank := range deck_repo.Ranks {
+		if rank == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuit returns true if the code is a suit code, like "H".
+func isSuit(code string) bool {
+	for _, suit := range deck_repo.Suits {
+		if suit == code {
+			return true
+		}
+	}
+	return false
+}
+
+// take removes the card with the code from the hand of the player.
+// Returns a BadRequestError if the player does not hold the card.
+func take(player *games_repo.Player, code string) (*deck_repo.Card, error) {
+	hand := player.HandCards()
+	for i, card := range hand {
+		if card.Value == code {
+			player.SetHand(append(hand[:i], hand[i+1:]...))
+			return card, nil
+		}
+	}
+	return nil, errors.BadRequestError(fmt.Sprintf("seat %d does not hold the card %s", player.Seat, code), nil)
+}
+
+// remaining returns the number of cards left in the named deck of the session.
+func remaining(session *games_repo.Session, name string, decks deck_repo.DeckRepository) (int, error) {
+	deckID := session.DeckID(name)
+	if deckID == "" {
+		return 0, nil
+	}
+	deck, err := decks.GetDeck(deckID)
+	if err != nil {
+		return 0, err
+	}
+	return deck.Remaining, nil
+}
+
+// dealHands deals the number of cards to every player from the named deck of the session.
+func dealHands(session *games_repo.Session, name string, cards int, decks deck_repo.DeckRepository) error {
+	for _, player := range session.Players {
+		hand, err := decks.DrawCards(session.DeckID(name), cards)
+		if err != nil {
+			return err
+		}
+		player.SetHand(hand)
+	}
+	return nil
+}
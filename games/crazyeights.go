@@ -0,0 +1,324 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// CrazyEights is the type of the game of Crazy Eights.
+const CrazyEights = "crazy-eights"
+
+// Actions of Crazy Eights.
+const (
+	// PlayAction plays the card given as the cards of the action to the discard pile. An eight is played with the
+	// declared suit as the "suit" parameter.
+	PlayAction = "play"
+
+	// DrawAction draws cards from the stock until a card may be played, by a player without a card to play.
+	DrawAction = "draw"
+)
+
+// Eight is the rank of the wild cards of Crazy Eights.
+const Eight = "8"
+
+// EightPoints is the penalty of an eight left in a hand at the end of Crazy Eights.
+const EightPoints = 50
+
+// CrazyEightsEngine is the rule engine of Crazy Eights, for 2 to 7 players. Each player is dealt 7 cards with
+// 2 players, or 5 cards otherwise. The rest of the shuffled deck is the stock, and its top card is turned up to start
+// the discard pile. In turn, a player plays a card matching the suit or the rank of the top card of the discard pile.
+// Eights are wild: an eight may be played on any card, and the player declares the suit to be matched next. A player
+// without a card to play draws from the stock until a card may be played, and then has to play; once the stock is
+// empty, the discard pile under its top card is shuffled into a new stock. If no card can be drawn, the turn passes.
+//
+// The first player to get rid of all of the cards wins, and scores the penalty points of the cards left in the other
+// hands: 50 for an eight, 10 for a face card or a ten, 1 for an ace and the number of the card otherwise. If every
+// player in turn passes, the game is blocked, and the player with the fewest penalty points wins.
+type CrazyEightsEngine struct{}
+
+// CrazyEightsPlay is the last turn in a game of Crazy Eights.
+type CrazyEightsPlay struct {
+	// Seat is the seat of the player.
+	Seat int `json:"seat"`
+
+	// Card is the code of the played card, if any.
+	Card string `json:"card,omitempty"`
+
+	// Suit is the suit declared with an eight.
+	Suit string `json:"suit,omitempty"`
+
+	// Drawn is the number of cards drawn from the stock.
+	Drawn int `json:"drawn"`
+
+	// Passed is set if the player could neither play nor draw a card to play.
+	Passed bool `json:"passed"`
+}
+
+// CrazyEightsState is the state of a game of Crazy Eights.
+type CrazyEightsState struct {
+	// Discard holds the codes of the cards of the discard pile, with the top card last.
+	Discard []string `json:"discard"`
+
+	// Suit is the suit to be matched: the suit of the top card of the discard pile, or the suit declared with an
+	// eight.
+	Suit string `json:"suit"`
+
+	// Passes is the number of turns passed in a row.
+	Passes int `json:"passes"`
+
+	// Winner is the seat of the winner once the game is over, or NoWinner.
+	Winner int `json:"winner"`
+
+	// Last is the last turn, if any.
+	Last *CrazyEightsPlay `json:"last,omitempty"`
+}
+
+// Type returns the type of Crazy Eights.
+func (e *CrazyEightsEngine) Type() string {
+	return CrazyEights
+}
+
+// Players returns the number of players of Crazy Eights: 2 to 7.
+func (e *CrazyEightsEngine) Players() (int, int) {
+	return 2, 7
+}
+
+// Setup deals the hands from a new shuffled deck, the stock, and turns up the top card of the stock to start the
+// discard pile. If it is an eight, its suit is to be matched. The first seat plays first.
+func (e *CrazyEightsEngine) Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error {
+	if _, err := CreateDeck(session, "stock", &deck_repo.Deck{Shuffled: true}, decks); err != nil {
+		return err
+	}
+	cards := 5
+	if len(session.Players) == 2 {
+		cards = 7
+	}
+	if err := dealHands(session, "stock", cards, decks); err != nil {
+		return err
+	}
+
+	starter, err := decks.DrawCards(session.DeckID("stock"), 1)
+	if err != nil {
+		return err
+	}
+	session.Turn = 0
+	return session.SetState(&CrazyEightsState{
+		Discard: []string{starter[0].Value},
+		Suit:    suitOf(starter[0]),
+		Winner:  NoWinner,
+	})
+}
+
+// LegalActions returns the plays of the cards that may be played by the player in turn, with every suit for the
+// eights, or the draw if the player has no card to play.
+func (e *CrazyEightsEngine) LegalActions(session *games_repo.Session, seat int) []*Action {
+	actions := []*Action{}
+	player := session.Player(seat)
+	state := &CrazyEightsState{}
+	if player == nil || seat != session.Turn || session.GetState(state) != nil {
+		return actions
+	}
+
+	for _, card := range playable(player.HandCards(), state) {
+		if rankOf(card) != Eight {
+			actions = append(actions, &Action{Seat: seat, Type: PlayAction, Cards: card.Value})
+			continue
+		}
+		for _, suit := range deck_repo.Suits {
+			actions = append(actions, &Action{Seat: seat, Type: PlayAction, Cards: card.Value, Params: map[string]string{"suit": suit}})
+		}
+	}
+	if len(actions) == 0 {
+		actions = append(actions, &Action{Seat: seat, Type: DrawAction})
+	}
+	return actions
+}
+
+// Apply plays the card, or draws cards until a card may be played.
+// Returns a BadRequestError if the card may not be played.
+func (e *CrazyEightsEngine) Apply(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error {
+	state := &CrazyEightsState{}
+	if err := session.GetState(state); err != nil {
+		return err
+	}
+
+	var err error
+	if action.Type == PlayAction {
+		err = e.play(session, state, action)
+	} else {
+		err = e.draw(session, state, action, decks)
+	}
+	if err != nil {
+		return err
+	}
+
+	if state.Winner == NoWinner && state.Passes >= len(session.Players) {
+		// blocked: the fewest penalty points win
+		state.Winner = 0
+		for seat, player := range session.Players {
+			if penalty(player.HandCards()) < penalty(session.Players[state.Winner].HandCards()) {
+				state.Winner = seat
+			}
+		}
+	}
+	return session.SetState(state)
+}
+
+// play plays the card of the action to the discard pile.
+func (e *CrazyEightsEngine) play(session *games_repo.Session, state *CrazyEightsState, action *Action) error {
+	player := session.Player(action.Seat)
+	hand := player.HandCards()
+
+	card := &deck_repo.Card{Value: action.Cards}
+	if len(playable([]*deck_repo.Card{card}, state)) == 0 {
+		return errors.BadRequestError(fmt.Sprintf("the card %s cannot be played, play a card of the suit %s or the rank of %s, or an eight",
+			action.Cards, state.Suit, state.Discard[len(state.Discard)-1]), nil)
+	}
+	suit := ""
+	if rankOf(card) == Eight {
+		suit = strings.ToUpper(action.Params["suit"])
+		if !isSuit(suit) {
+			return errors.BadRequestError(fmt.Sprintf("declare the suit of the eight as one of %v", deck_repo.Suits), nil)
+		}
+	}
+	if _, err := take(player, action.Cards); err != nil {
+		return err
+	}
+
+	state.Discard = append(state.Discard, card.Value)
+	state.Suit = suitOf(card)
+	if suit != "" {
+		state.Suit = suit
+	}
+	state.Passes = 0
+	state.Last = &CrazyEightsPlay{Seat: action.Seat, Card: card.Value, Suit: suit}
+
+	if len(hand) == 1 {
+		state.Winner = action.Seat
+		return nil
+	}
+	session.Turn = (action.Seat + 1) % len(session.Players)
+	return nil
+}
+
+// draw draws cards from the stock until a card may be played. The stock is refilled from the discard pile when empty.
+// If no card can be drawn, the turn passes.
+func (e *CrazyEightsEngine) draw(session *games_repo.Session, state *CrazyEightsState, action *Action, decks deck_repo.DeckRepository) error {
+	player := session.Player(action.Seat)
+	state.Last = &CrazyEightsPlay{Seat: action.Seat}
+
+	for len(playable(player.HandCards(), state)) == 0 {
+		stock, err := remaining(session, "stock", decks)
+		if err != nil {
+			return err
+		}
+		if stock == 0 && len(state.Discard) > 1 {
+			// shuffle the discard pile under the top card into a new stock
+			top := state.Discard[len(state.Discard)-1]
+			deck, err := CreateDeck(session, "stock", &deck_repo.Deck{
+				Cards:    deck_repo.AsCards(strings.Join(state.Discard[:len(state.Discard)-1], ",")),
+				Shuffled: true,
+			}, decks)
+			if err != nil {
+				return err
+			}
+			state.Discard = []string{top}
+			stock = deck.Remaining
+		}
+		if stock == 0 {
+			state.Last.Passed = true
+			state.Passes++
+			session.Turn = (action.Seat + 1) % len(session.Players)
+			return nil
+		}
+
+		drawn, err := decks.DrawCards(session.DeckID("stock"), 1)
+		if err != nil {
+			return err
+		}
+		player.SetHand(append(player.HandCards(), drawn...))
+		state.Last.Drawn++
+	}
+
+	state.Passes = 0
+	return nil
+}
+
+// IsFinished returns true once a player got rid of all of the cards, or the game is blocked.
+func (e *CrazyEightsEngine) IsFinished(session *games_repo.Session) bool {
+	state := &CrazyEightsState{}
+	if err := session.GetState(state); err != nil {
+		return false
+	}
+	return state.Winner != NoWinner
+}
+
+// Scores returns the penalty points of the other hands for the winner, once the game is over, and 0 otherwise.
+// In a blocked game, the winner scores the difference to the penalty points of each other hand.
+func (e *CrazyEightsEngine) Scores(session *games_repo.Session) []int {
+	state := &CrazyEightsState{}
+	scores := make([]int, len(session.Players))
+	if err := session.GetState(state); err != nil || state.Winner == NoWinner {
+		return scores
+	}
+
+	own := penalty(session.Players[state.Winner].HandCards())
+	for seat, player := range session.Players {
+		if seat != state.Winner {
+			scores[state.Winner] += penalty(player.HandCards()) - own
+		}
+	}
+	return scores
+}
+
+// View returns the discard pile, the suit to be matched and the last turn. Everything but the hands is public.
+func (e *CrazyEightsEngine) View(session *games_repo.Session, seat int) interface{} {
+	state := &CrazyEightsState{}
+	if err := session.GetState(state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// playable returns the cards that may be played on the discard pile.
+func playable(cards []*deck_repo.Card, state *CrazyEightsState) []*deck_repo.Card {
+	top := &deck_repo.Card{Value: state.Discard[len(state.Discard)-1]}
+	result := []*deck_repo.Card{}
+	for _, card := range cards {
+		if card.RankName() == "" || card.SuitName() == "" {
+			continue
+		}
+		if rankOf(card) == Eight || suitOf(card) == state.Suit || rankOf(card) == rankOf(top) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// penalty returns the penalty points of the cards left in a hand.
+func penalty(cards []*deck_repo.Card) int {
+	points := 0
+	for _, card := range cards {
+		switch rank := rankOf(card); rank {
+		case Eight:
+			points += EightPoints
+		case "10", "J", "Q", "K":
+			points += 10
+		case "A":
+			points++
+		default:
+			number, _ := strconv.Atoi(rank)
+			points += number
+		}
+	}
+	return points
+}
+
+func init() {
+	Register(&CrazyEightsEngine{})
+}
@@ -0,0 +1,165 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+func newCrazyEights(t *testing.T, discard string, players ...string) (*games_repo.Session, *CrazyEightsState) {
+	session, err := NewSession(CrazyEights, players, nil, setupTest(t))
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	state := crazyEightsState(t, session)
+	state.Discard = []string{discard}
+	state.Suit = discard[len(discard)-1:]
+	if err := session.SetState(state); err != nil {
+		t.Fatalf("Failed to set the state: %s", err.Error())
+	}
+	return session, state
+}
+
+func crazyEightsState(t *testing.T, session *games_repo.Session) *CrazyEightsState {
+	state := &CrazyEightsState{}
+	if err := session.GetState(state); err != nil {
+		t.Fatalf("Failed to read the state: %s", err.Error())
+	}
+	return state
+}
+
+func TestCrazyEights_Setup(t *testing.T) {
+	decks := setupTest(t)
+
+	for players, cards := range map[int]int{2: 7, 3: 5, 7: 5} {
+		names := []string{"a", "b", "c", "d", "e", "f", "g"}[:players]
+		session, err := NewSession(CrazyEights, names, nil, decks)
+		if err != nil {
+			t.Fatalf("Failed to create session: %s", err.Error())
+		}
+		state := crazyEightsState(t, session)
+		stock, _ := remaining(session, "stock", decks)
+		if len(session.Players[0].HandCards()) != cards || len(state.Discard) != 1 || stock != 52-players*cards-1 {
+			t.Errorf("Expected %d cards dealt to %d players and a card turned up, but got: %+v", cards, players, state)
+		}
+		if state.Suit != state.Discard[0][len(state.Discard[0])-1:] || state.Winner != NoWinner {
+			t.Errorf("Expected the suit of the turned up card, but got: %+v", state)
+		}
+	}
+}
+
+func TestCrazyEights_Play(t *testing.T) {
+	decks := setupTest(t)
+	session, _ := newCrazyEights(t, "9H", "alice", "bob")
+	rig(t, session, decks, "2C", "9S,4H,8C,KD", "5S,6S,KH")
+
+	legal := LegalActions(session, 0)
+	if len(legal) != 6 {
+		t.Errorf("Expected the nine, the four and the eight with every suit, but got: %+v", legal)
+	}
+
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "KD"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a card not matching, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "9C"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a card not in the hand, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "8C"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for an eight without a suit, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 1, Type: PlayAction, Cards: "KH"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError out of turn, but got: %v", err)
+	}
+
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "8C", Params: map[string]string{"suit": "s"}}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+	state := crazyEightsState(t, session)
+	if state.Suit != "S" || state.Discard[1] != "8C" || session.Turn != 1 || session.Players[0].Hand != "9S,4H,KD" {
+		t.Errorf("Expected the eight to declare spades, but got: %+v", state)
+	}
+
+	if err := Act(session, &Action{Seat: 1, Type: PlayAction, Cards: "5S"}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "9S"}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+	if err := Act(session, &Action{Seat: 1, Type: PlayAction, Cards: "6S"}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "6S"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a card already played, but got: %v", err)
+	}
+}
+
+func TestCrazyEights_Draw(t *testing.T) {
+	decks := setupTest(t)
+	session, _ := newCrazyEights(t, "9H", "alice", "bob")
+	rig(t, session, decks, "2C,3D,7H,5C", "KS", "5S,6S")
+
+	legal := LegalActions(session, 0)
+	if len(legal) != 1 || legal[0].Type != DrawAction {
+		t.Fatalf("Expected alice to draw, but got: %+v", legal)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "KS"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a card not matching, but got: %v", err)
+	}
+
+	if err := Act(session, &Action{Seat: 0, Type: DrawAction}, decks); err != nil {
+		t.Fatalf("Failed to draw: %s", err.Error())
+	}
+	state := crazyEightsState(t, session)
+	if session.Players[0].Hand != "KS,2C,3D,7H" || session.Turn != 0 || state.Last.Drawn != 3 {
+		t.Errorf("Expected alice to draw until the seven of hearts, but got: %+v, %+v", session.Players[0], state.Last)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: DrawAction}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for drawing with a card to play, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "7H"}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+
+	// the stock is refilled from the discard pile under the seven
+	rig(t, session, decks, "", "KS,2C,3D", "5S,6S")
+	if _, err := decks.DrawCards(session.DeckID("stock"), 52); err != nil {
+		t.Fatalf("Failed to empty the stock: %s", err.Error())
+	}
+	if err := Act(session, &Action{Seat: 1, Type: DrawAction}, decks); err != nil {
+		t.Fatalf("Failed to draw: %s", err.Error())
+	}
+	state = crazyEightsState(t, session)
+	if session.Players[1].Hand != "5S,6S,9H" || len(state.Discard) != 1 || state.Discard[0] != "7H" {
+		t.Errorf("Expected bob to draw the nine from the discard pile, but got: %+v, %+v", session.Players[1], state)
+	}
+}
+
+func TestCrazyEights_GameOver(t *testing.T) {
+	decks := setupTest(t)
+	session, _ := newCrazyEights(t, "9H", "alice", "bob", "carol")
+	rig(t, session, decks, "2C", "4H", "8S,KD,AC", "3C")
+
+	if err := Act(session, &Action{Seat: 0, Type: PlayAction, Cards: "4H"}, decks); err != nil {
+		t.Fatalf("Failed to play: %s", err.Error())
+	}
+	if !session.Finished || session.Players[0].Score != 50+10+1+3 || session.Players[1].Score != 0 {
+		t.Errorf("Expected alice to win and score the penalty points, but got: %+v", session.Players)
+	}
+
+	// blocked: nobody can play or draw
+	session, state := newCrazyEights(t, "9H", "alice", "bob")
+	rig(t, session, decks, "", "KS,2C", "3C")
+	if _, err := decks.DrawCards(session.DeckID("stock"), 52); err != nil {
+		t.Fatalf("Failed to empty the stock: %s", err.Error())
+	}
+	for seat := 0; seat < 2; seat++ {
+		if err := Act(session, &Action{Seat: seat, Type: DrawAction}, decks); err != nil {
+			t.Fatalf("Failed to draw: %s", err.Error())
+		}
+	}
+	state = crazyEightsState(t, session)
+	if !session.Finished || state.Winner != 1 || session.Players[1].Score != 12-3 {
+		t.Errorf("Expected bob to win the blocked game, but got: %+v, %+v", session.Players, state)
+	}
+}
@@ -0,0 +1,113 @@
+package games
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// Dealer is the seat of the actions that are not made by a player, like the battles of an automated game of War.
+const Dealer = -1
+
+// Action is a move in a session: the type of the action, as defined by the rule engine, made by the player at
+// the seat, with the cards and the parameters of the action. For example, playing "8H" and declaring spades in
+// Crazy Eights could be Action{Seat: 1, Type: "play", Cards: "8H", Params: {"suit": "S"}}.
+type Action struct {
+	// Seat is the seat of the acting player, or Dealer.
+	Seat int
+
+	// Type is the type of the action, like "play".
+	Type string
+
+	// Cards holds the codes of the cards of the action, comma separated.
+	Cards string
+
+	// Params holds the other parameters of the action, by name.
+	Params map[string]string
+}
+
+// RuleEngine defines the rules of a turn-based game. The framework (see NewSession and Act) takes care of the players
+// and their seats, the decks and the session, and leaves the rules of the game to the engine. An engine keeps the
+// state of the game in the session: the turn, the hands of the players, the decks, and anything else as the state
+// of the session (see games_repo.Session.SetState).
+type RuleEngine interface {
+
+	// Type returns the type of the game, the name the engine is registered by, like "war".
+	Type() string
+
+	// Players returns the minimal and the maximal number of players of the game.
+	Players() (min, max int)
+
+	// Setup sets up a new session with the players seated: creates the decks (see CreateDeck), deals the cards and
+	// sets the first turn. The options are specific to the engine.
+	// Returns a ValidationError if an option is not valid.
+	Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error
+
+	// LegalActions returns the actions the player at the seat (or the Dealer) may make now. Actions that take
+	// a parameter of any value are given once, with the parameter left out.
+	LegalActions(session *games_repo.Session, seat int) []*Action
+
+	// Apply applies the action to the session. Called only with an action of one of the types of the legal actions
+	// of the seat.
+	// Returns a BadRequestError if the action is not legal.
+	Apply(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error
+
+	// IsFinished returns true once the game is over.
+	IsFinished(session *games_repo.Session) bool
+
+	// Scores returns the scores of the players, by seat.
+	Scores(session *games_repo.Session) []int
+
+	// View returns the state of the game visible to the player at the seat, besides the hands, to be serialized as
+	// JSON. Called with Dealer for a view of the public state.
+	View(session *games_repo.Session, seat int) interface{}
+}
+
+var (
+	// engines holds the registered rule engines by the type of the game.
+	engines = map[string]RuleEngine{}
+
+	enginesMutex sync.RWMutex
+)
+
+// Register registers the rule engine by the type of its game. Meant to be called from init functions.
+// Panics if an engine of the same type is already registered.
+func Register(engine RuleEngine) {
+	enginesMutex.Lock()
+	defer enginesMutex.Unlock()
+
+	if _, ok := engines[engine.Type()]; ok {
+		panic(fmt.Sprintf("games: engine %s is already registered", engine.Type()))
+	}
+	engines[engine.Type()] = engine
+}
+
+// GetEngine returns the rule engine of the type of game.
+// Returns a NotFoundError if there is no such engine.
+func GetEngine(gameType string) (RuleEngine, error) {
+	enginesMutex.RLock()
+	defer enginesMutex.RUnlock()
+
+	engine, ok := engines[gameType]
+	if !ok {
+		return nil, errors.NotFoundError(fmt.Sprintf("unsupported game: %s", gameType), nil)
+	}
+	return engine, nil
+}
+
+// Types returns the types of the registered engines, sorted.
+func Types() []string {
+	enginesMutex.RLock()
+	defer enginesMutex.RUnlock()
+
+	types := make([]string, 0, len(engines))
+	for gameType := range engines {
+		types = append(types, gameType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,306 @@
+package games
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// GoFish is the type of the game of Go Fish.
+const GoFish = "go-fish"
+
+// AskAction asks another player for all of the cards of a rank, in Go Fish. The asked player is given with the
+// "target" parameter and the rank with the "rank" parameter.
+const AskAction = "ask"
+
+// BookSize is the number of cards of a book: all four cards of a rank.
+const BookSize = 4
+
+// GoFishEngine is the rule engine of Go Fish, for 2 to 6 players. Each player is dealt 7 cards, or 5 with 4 players
+// or more, and the rest of the shuffled deck is the stock. In turn, a player asks another player for a rank the
+// player holds. The asked player gives all cards of the rank, and the player goes again. If the asked player has none,
+// the player fishes a card from the stock, and goes again only when fishing the asked rank. The four cards of a rank
+// make a book, which is laid down. A player that runs out of cards fishes a card to go on, if the stock has any.
+// The game is over once all books are laid down, and the player with the most books wins.
+type GoFishEngine struct{}
+
+// GoFishAsk is the last ask in a game of Go Fish.
+type GoFishAsk struct {
+	// Seat is the seat of the asking player.
+	Seat int `json:"seat"`
+
+	// Target is the seat of the asked player.
+	Target int `json:"target"`
+
+	// Rank is the asked rank.
+	Rank string `json:"rank"`
+
+	// Given is the number of cards the asked player gave.
+	Given int `json:"given"`
+
+	// Fished is set if the player fished a card from the stock.
+	Fished bool `json:"fished"`
+
+	// Drawn is the code of the fished card. Only the asking player sees it, unless it was the asked rank.
+	Drawn string `json:"drawn,omitempty"`
+
+	// Books holds the ranks of the books the asking player laid down after the ask.
+	Books []string `json:"books,omitempty"`
+}
+
+// GoFishState is the state of a game of Go Fish.
+type GoFishState struct {
+	// Books holds the ranks of the books laid down by each player, by seat.
+	Books [][]string `json:"books"`
+
+	// Last is the last ask, if any.
+	Last *GoFishAsk `json:"last,omitempty"`
+
+	// Over is set once no player can go on.
+	Over bool `json:"over,omitempty"`
+}
+
+// Type returns the type of Go Fish.
+func (e *GoFishEngine) Type() string {
+	return GoFish
+}
+
+// Players returns the number of players of Go Fish: 2 to 6.
+func (e *GoFishEngine) Players() (int, int) {
+	return 2, 6
+}
+
+// Setup deals the hands from a new shuffled deck, the stock. The first seat goes first.
+func (e *GoFishEngine) Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error {
+	if _, err := CreateDeck(session, "stock", &deck_repo.Deck{Shuffled: true}, decks); err != nil {
+		return err
+	}
+	cards := 7
+	if len(session.Players) >= 4 {
+		cards = 5
+	}
+	if err := dealHands(session, "stock", cards, decks); err != nil {
+		return err
+	}
+
+	state := &GoFishState{Books: make([][]string, len(session.Players))}
+	for seat, player := range session.Players {
+		state.Books[seat] = layBooks(player)
+	}
+	session.Turn = 0
+	return session.SetState(state)
+}
+
+// LegalActions returns the asks of the player in turn: for every rank in the hand, from every other player with cards.
+func (e *GoFishEngine) LegalActions(session *games_repo.Session, seat int) []*Action {
+	actions := []*Action{}
+	player := session.Player(seat)
+	if player == nil || seat != session.Turn {
+		return actions
+	}
+
+	for _, rank := range handRanks(player) {
+		for _, target := range session.Players {
+			if target.Seat == seat || len(target.HandCards()) == 0 {
+				continue
+			}
+			actions = append(actions, &Action{
+				Seat:   seat,
+				Type:   AskAction,
+				Params: map[string]string{"target": strconv.Itoa(target.Seat), "rank": rank},
+			})
+		}
+	}
+	return actions
+}
+
+// Apply asks the target player for the rank.
+// Returns a BadRequestError if the player may not ask the target for the rank.
+func (e *GoFishEngine) Apply(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error {
+	state := &GoFishState{}
+	if err := session.GetState(state); err != nil {
+		return err
+	}
+
+	player := session.Player(action.Seat)
+	rank := strings.ToUpper(action.Params["rank"])
+	target, err := strconv.Atoi(action.Params["target"])
+	if err != nil || target == action.Seat || session.Player(target) == nil {
+		return errors.BadRequestError(fmt.Sprintf("invalid target: %s", action.Params["target"]), err)
+	}
+	if !isRank(rank) {
+		return errors.BadRequestError(fmt.Sprintf("invalid rank: %s", rank), nil)
+	}
+	if len(ofRank(player.HandCards(), rank)) == 0 {
+		return errors.BadRequestError(fmt.Sprintf("seat %d may only ask for a rank in the hand", action.Seat), nil)
+	}
+	asked := session.Player(target)
+	if len(asked.HandCards()) == 0 {
+		return errors.BadRequestError(fmt.Sprintf("seat %d has no cards", target), nil)
+	}
+
+	ask := &GoFishAsk{Seat: action.Seat, Target: target, Rank: rank}
+	again := false
+	if given := ofRank(asked.HandCards(), rank); len(given) > 0 {
+		asked.SetHand(withoutRank(asked.HandCards(), rank))
+		player.SetHand(append(player.HandCards(), given...))
+		ask.Given = len(given)
+		again = true
+	} else {
+		stock, err := remaining(session, "stock", decks)
+		if err != nil {
+			return err
+		}
+		if stock > 0 {
+			drawn, err := decks.DrawCards(session.DeckID("stock"), 1)
+			if err != nil {
+				return err
+			}
+			player.SetHand(append(player.HandCards(), drawn...))
+			ask.Fished = true
+			ask.Drawn = drawn[0].Value
+			again = rankOf(drawn[0]) == rank
+		}
+	}
+	ask.Books = layBooks(player)
+	state.Books[action.Seat] = append(state.Books[action.Seat], ask.Books...)
+	state.Last = ask
+
+	next := action.Seat
+	if !again {
+		next = (action.Seat + 1) % len(session.Players)
+	}
+	turn, err := goFishTurn(session, next, decks)
+	if err != nil {
+		return err
+	}
+	session.Turn = turn
+	state.Over = turn == games_repo.NoTurn
+	return session.SetState(state)
+}
+
+// IsFinished returns true once all books are laid down, or no player can go on.
+func (e *GoFishEngine) IsFinished(session *games_repo.Session) bool {
+	state := &GoFishState{}
+	if err := session.GetState(state); err != nil {
+		return false
+	}
+	books := 0
+	for _, ranks := range state.Books {
+		books += len(ranks)
+	}
+	return state.Over || books == len(deck_repo.Ranks)
+}
+
+// Scores returns the number of books of each player.
+func (e *GoFishEngine) Scores(session *games_repo.Session) []int {
+	state := &GoFishState{}
+	scores := make([]int, len(session.Players))
+	if err := session.GetState(state); err != nil {
+		return scores
+	}
+	for seat, ranks := range state.Books {
+		scores[seat] = len(ranks)
+	}
+	return scores
+}
+
+// View returns the books and the last ask. The fished card is hidden from the other players, unless it was the asked
+// rank and so had to be shown.
+func (e *GoFishEngine) View(session *games_repo.Session, seat int) interface{} {
+	state := &GoFishState{}
+	if err := session.GetState(state); err != nil {
+		return nil
+	}
+	if state.Last != nil && state.Last.Drawn != "" && seat != state.Last.Seat {
+		if rankOf(&deck_repo.Card{Value: state.Last.Drawn}) != state.Last.Rank {
+			state.Last.Drawn = ""
+		}
+	}
+	return state
+}
+
+// goFishTurn returns the seat of the next player to ask, starting at the given seat. A player without cards fishes
+// a card to go on; if the stock is empty, the player is skipped. Returns NoTurn if no player can go on.
+func goFishTurn(session *games_repo.Session, seat int, decks deck_repo.DeckRepository) (int, error) {
+	for i := 0; i < len(session.Players); i++ {
+		player := session.Player((seat + i) % len(session.Players))
+		if len(player.HandCards()) == 0 {
+			stock, err := remaining(session, "stock", decks)
+			if err != nil {
+				return games_repo.NoTurn, err
+			}
+			if stock == 0 {
+				continue
+			}
+			drawn, err := decks.DrawCards(session.DeckID("stock"), 1)
+			if err != nil {
+				return games_repo.NoTurn, err
+			}
+			player.SetHand(drawn)
+		}
+
+		// the player must have another player to ask
+		for _, other := range session.Players {
+			if other.Seat != player.Seat && len(other.HandCards()) > 0 {
+				return player.Seat, nil
+			}
+		}
+	}
+	return games_repo.NoTurn, nil
+}
+
+// layBooks lays down the books in the hand of the player and returns their ranks.
+func layBooks(player *games_repo.Player) []string {
+	books := []string{}
+	for _, rank := range handRanks(player) {
+		if len(ofRank(player.HandCards(), rank)) == BookSize {
+			player.SetHand(withoutRank(player.HandCards(), rank))
+			books = append(books, rank)
+		}
+	}
+	return books
+}
+
+// handRanks returns the distinct ranks in the hand of the player, in the order of deck_repo.Ranks.
+func handRanks(player *games_repo.Player) []string {
+	seen := map[string]bool{}
+	for _, card := range player.HandCards() {
+		seen[rankOf(card)] = true
+	}
+	ranks := []string{}
+	for _, rank := range deck_repo.Ranks {
+		if seen[rank] {
+			ranks = append(ranks, rank)
+		}
+	}
+	return ranks
+}
+
+func ofRank(cards []*deck_repo.Card, rank string) []*deck_repo.Card {
+	result := []*deck_repo.Card{}
+	for _, card := range cards {
+		if rankOf(card) == rank {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func withoutRank(cards []*deck_repo.Card, rank string) []*deck_repo.Card {
+	result := []*deck_repo.Card{}
+	for _, card := range cards {
+		if rankOf(card) != rank {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func init() {
+	Register(&GoFishEngine{})
+}
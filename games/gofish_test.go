@@ -0,0 +1,146 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// rig sets the hands of the players and replaces the stock with the given cards, top card first.
+func rig(t *testing.T, session *games_repo.Session, decks deck_repo.DeckRepository, stock string, hands ...string) {
+	for seat, hand := range hands {
+		session.Players[seat].Hand = hand
+	}
+	if _, err := CreateDeck(session, "stock", &deck_repo.Deck{Cards: deck_repo.AsCards(stock)}, decks); err != nil {
+		t.Fatalf("Failed to rig the stock: %s", err.Error())
+	}
+}
+
+func ask(target, rank string) map[string]string {
+	return map[string]string{"target": target, "rank": rank}
+}
+
+func goFishState(t *testing.T, session *games_repo.Session) *GoFishState {
+	state := &GoFishState{}
+	if err := session.GetState(state); err != nil {
+		t.Fatalf("Failed to read the state: %s", err.Error())
+	}
+	return state
+}
+
+func TestGoFish_Setup(t *testing.T) {
+	decks := setupTest(t)
+
+	for players, cards := range map[int]int{2: 7, 3: 7, 4: 5, 6: 5} {
+		names := []string{"a", "b", "c", "d", "e", "f"}[:players]
+		session, err := NewSession(GoFish, names, nil, decks)
+		if err != nil {
+			t.Fatalf("Failed to create session: %s", err.Error())
+		}
+		dealt := 0
+		for seat, player := range session.Players {
+			dealt += len(player.HandCards()) + BookSize*len(goFishState(t, session).Books[seat])
+		}
+		stock, _ := remaining(session, "stock", decks)
+		if dealt != players*cards || stock != 52-players*cards || session.Turn != 0 {
+			t.Errorf("Expected %d cards dealt to %d players, but got %d and %d left.", cards, players, dealt, stock)
+		}
+	}
+}
+
+func TestGoFish_Ask(t *testing.T) {
+	decks := setupTest(t)
+	session, err := NewSession(GoFish, []string{"alice", "bob", "carol"}, nil, decks)
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	rig(t, session, decks, "5C,6D,2H", "QH,QS,QC,3H", "QD,4S,5S", "7H,8H")
+
+	legal := LegalActions(session, 0)
+	if len(legal) != 4 || legal[0].Params["rank"] != "3" || len(LegalActions(session, 1)) != 0 {
+		t.Errorf("Expected alice to ask for 3s or Qs from bob or carol, but got: %+v", legal)
+	}
+
+	// bob gives the queen: a book, and alice goes again
+	if err := Act(session, &Action{Seat: 0, Type: AskAction, Params: ask("1", "q")}, decks); err != nil {
+		t.Fatalf("Failed to ask: %s", err.Error())
+	}
+	state := goFishState(t, session)
+	if session.Players[0].Hand != "3H" || session.Players[1].Hand != "4S,5S" || session.Turn != 0 || session.Players[0].Score != 1 {
+		t.Errorf("Expected alice to lay down the queens and go again, but got: %+v, %+v", session.Players, state)
+	}
+	if state.Last.Given != 1 || len(state.Last.Books) != 1 || state.Last.Books[0] != "Q" {
+		t.Errorf("Expected the last ask to give a queen, but got: %+v", state.Last)
+	}
+
+	// go fish: alice fishes the 5C and the turn passes
+	if err := Act(session, &Action{Seat: 0, Type: AskAction, Params: ask("2", "3")}, decks); err != nil {
+		t.Fatalf("Failed to ask: %s", err.Error())
+	}
+	if session.Players[0].Hand != "3H,5C" || session.Turn != 1 || !goFishState(t, session).Last.Fished {
+		t.Errorf("Expected alice to fish and the turn to pass, but got: %+v", session.Players[0])
+	}
+	if view := View(session, 1).(*GoFishState); view.Last.Drawn != "" {
+		t.Errorf("Expected the fished card hidden from bob, but got: %+v", view.Last)
+	}
+	if view := View(session, 0).(*GoFishState); view.Last.Drawn != "5C" {
+		t.Errorf("Expected alice to see the fished card, but got: %+v", view.Last)
+	}
+
+	// bob fishes the asked rank and goes again
+	rig(t, session, decks, "4D,2D")
+	if err := Act(session, &Action{Seat: 1, Type: AskAction, Params: ask("2", "4")}, decks); err != nil {
+		t.Fatalf("Failed to ask: %s", err.Error())
+	}
+	if session.Turn != 1 || View(session, 0).(*GoFishState).Last.Drawn != "4D" {
+		t.Errorf("Expected bob to go again and show the lucky card, but got: %+v", goFishState(t, session).Last)
+	}
+}
+
+func TestGoFish_Invalid(t *testing.T) {
+	decks := setupTest(t)
+	session, err := NewSession(GoFish, []string{"alice", "bob"}, nil, decks)
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	rig(t, session, decks, "5C", "QH,3H", "QD")
+
+	tests := []map[string]string{
+		ask("0", "Q"),
+		ask("5", "Q"),
+		ask("x", "Q"),
+		ask("1", "Z"),
+		ask("1", "K"),
+	}
+	for _, params := range tests {
+		if err := Act(session, &Action{Seat: 0, Type: AskAction, Params: params}, decks); !errors.IsBadRequestError(err) {
+			t.Errorf("Expected a BadRequestError for %v, but got: %v", params, err)
+		}
+	}
+	if err := Act(session, &Action{Seat: 1, Type: AskAction, Params: ask("0", "Q")}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError out of turn, but got: %v", err)
+	}
+}
+
+func TestGoFish_GameOver(t *testing.T) {
+	decks := setupTest(t)
+	session, err := NewSession(GoFish, []string{"alice", "bob"}, nil, decks)
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	rig(t, session, decks, "2C", "KH,KS,KD", "KC")
+	state := goFishState(t, session)
+	state.Books = [][]string{{"A", "2", "3", "4", "5", "6", "7"}, {"8", "9", "10", "J", "Q"}}
+	if err := session.SetState(state); err != nil {
+		t.Fatalf("Failed to set the state: %s", err.Error())
+	}
+
+	if err := Act(session, &Action{Seat: 0, Type: AskAction, Params: ask("1", "K")}, decks); err != nil {
+		t.Fatalf("Failed to ask: %s", err.Error())
+	}
+	if !session.Finished || session.Players[0].Score != 8 || session.Players[1].Score != 5 {
+		t.Errorf("Expected the game over with all of the books, but got: %+v", session.Players)
+	}
+}
@@ -0,0 +1,158 @@
+package games
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// NewSession creates a new session of the type of game for the named players, in the order of the seats, and sets it
+// up with the rule engine of the game.
+// Returns a NotFoundError if there is no engine for the game, and a ValidationError if the number of players does
+// not match the game or an option is not valid.
+func NewSession(gameType string, names []string, options map[string]string, decks deck_repo.DeckRepository) (*games_repo.Session, error) {
+	engine, err := GetEngine(gameType)
+	if err != nil {
+		return nil, err
+	}
+	min, max := engine.Players()
+	if len(names) < min || len(names) > max {
+		if min == max {
+			return nil, errors.ValidationError(fmt.Sprintf("%s is played by %d players", gameType, min), nil)
+		}
+		return nil, errors.ValidationError(fmt.Sprintf("%s is played by %d to %d players", gameType, min, max), nil)
+	}
+
+	session := &games_repo.Session{
+		Type:    gameType,
+		Turn:    games_repo.NoTurn,
+		Players: []*games_repo.Player{},
+		Decks:   []*games_repo.SessionDeck{},
+	}
+	for seat, name := range names {
+		session.Players = append(session.Players, &games_repo.Player{
+			Seat: seat,
+			Name: name,
+		})
+	}
+
+	if err := engine.Setup(session, options, decks); err != nil {
+		return nil, err
+	}
+	update(session, engine)
+	return session, nil
+}
+
+// Act applies the action to the session with the rule engine of the game, then updates the scores of the players.
+// Returns a BadRequestError if the session is finished, or the action is not legal for the seat.
+func Act(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error {
+	engine, err := GetEngine(session.Type)
+	if err != nil {
+		return err
+	}
+	if session.Finished {
+		return errors.BadRequestError("the game is over", nil)
+	}
+	if action.Seat != Dealer && session.Player(action.Seat) == nil {
+		return errors.BadRequestError(fmt.Sprintf("there is no seat %d", action.Seat), nil)
+	}
+
+	legal := engine.LegalActions(session, action.Seat)
+	if len(legal) == 0 {
+		if session.Turn != games_repo.NoTurn {
+			return errors.BadRequestError(fmt.Sprintf("it is the turn of seat %d", session.Turn), nil)
+		}
+		return errors.BadRequestError(fmt.Sprintf("seat %d has no legal actions", action.Seat), nil)
+	}
+	if !hasType(legal, action.Type) {
+		return errors.BadRequestError(fmt.Sprintf("the action %s is not legal, legal actions are %v", action.Type,
+			actionTypes(legal)), nil)
+	}
+
+	if err := engine.Apply(session, action, decks); err != nil {
+		return err
+	}
+	session.Actions++
+	update(session, engine)
+	return nil
+}
+
+// LegalActions returns the actions the player at the seat may make, or an empty list if the seat may not act.
+func LegalActions(session *games_repo.Session, seat int) []*Action {
+	engine, err := GetEngine(session.Type)
+	if err != nil || session.Finished {
+		return []*Action{}
+	}
+	if seat != Dealer && session.Player(seat) == nil {
+		return []*Action{}
+	}
+	return engine.LegalActions(session, seat)
+}
+
+// View returns the state of the game visible to the player at the seat, besides the hands, or nil if there is no
+// engine for the game.
+func View(session *games_repo.Session, seat int) interface{} {
+	engine, err := GetEngine(session.Type)
+	if err != nil {
+		return nil
+	}
+	return engine.View(session, seat)
+}
+
+// CreateDeck creates the deck with the DeckRepository and adds it to the session under the name. A deck of the same
+// name is replaced, for example when a new deck is shuffled for the next hand.
+func CreateDeck(session *games_repo.Session, name string, deck *deck_repo.Deck, decks deck_repo.DeckRepository) (*deck_repo.Deck, error) {
+	deck, err := decks.CreateDeck(deck)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sessionDeck := range session.Decks {
+		if sessionDeck.Name == name {
+			sessionDeck.DeckID = deck.ID
+			return deck, nil
+		}
+	}
+	session.Decks = append(session.Decks, &games_repo.SessionDeck{
+		SessionID: session.ID,
+		Name:      name,
+		DeckID:    deck.ID,
+	})
+	return deck, nil
+}
+
+// update updates the scores of the players and the end of the game from the rule engine.
+func update(session *games_repo.Session, engine RuleEngine) {
+	for seat, score := range engine.Scores(session) {
+		if player := session.Player(seat); player != nil {
+			player.Score = score
+		}
+	}
+	if engine.IsFinished(session) {
+		session.Finished = true
+		session.Turn = games_repo.NoTurn
+	}
+}
+
+func hasType(actions []*Action, actionType string) bool {
+	for _, action := range actions {
+		if action.Type == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+func actionTypes(actions []*Action) []string {
+	types := []string{}
+	seen := map[string]bool{}
+	for _, action := range actions {
+		if !seen[action.Type] {
+			seen[action.Type] = true
+			types = append(types, action.Type)
+		}
+	}
+	return types
+}
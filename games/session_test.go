@@ -0,0 +1,185 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// highCard is a test game: the players draw a card each in turn from the "stock", and the highest rank wins.
+type highCard struct{}
+
+type highCardState struct {
+	Drawn int `json:"drawn"`
+}
+
+func (e *highCard) Type() string {
+	return "high-card"
+}
+
+func (e *highCard) Players() (int, int) {
+	return 2, 4
+}
+
+func (e *highCard) Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error {
+	if options["deck"] == "invalid" {
+		return errors.ValidationError("invalid deck", nil)
+	}
+	if _, err := CreateDeck(session, "stock", &deck_repo.Deck{Cards: deck_repo.AsCards(options["deck"])}, decks); err != nil {
+		return err
+	}
+	session.Turn = 0
+	return session.SetState(&highCardState{})
+}
+
+func (e *highCard) LegalActions(session *games_repo.Session, seat int) []*Action {
+	if seat != session.Turn {
+		return []*Action{}
+	}
+	return []*Action{{Seat: seat, Type: "draw"}}
+}
+
+func (e *highCard) Apply(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error {
+	cards, err := decks.DrawCards(session.DeckID("stock"), 1)
+	if err != nil {
+		return err
+	}
+	session.Player(action.Seat).SetHand(cards)
+	session.Turn = (session.Turn + 1) % len(session.Players)
+
+	state := &highCardState{}
+	if err := session.GetState(state); err != nil {
+		return err
+	}
+	state.Drawn++
+	return session.SetState(state)
+}
+
+func (e *highCard) IsFinished(session *games_repo.Session) bool {
+	state := &highCardState{}
+	session.GetState(state)
+	return state.Drawn == len(session.Players)
+}
+
+func (e *highCard) Scores(session *games_repo.Session) []int {
+	scores := []int{}
+	for _, player := range session.Players {
+		score := 0
+		for _, card := range player.HandCards() {
+			for i, rank := range deck_repo.Ranks {
+				if card.Value[:len(card.Value)-1] == rank {
+					score = i + 1
+				}
+			}
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+func (e *highCard) View(session *games_repo.Session, seat int) interface{} {
+	state := &highCardState{}
+	session.GetState(state)
+	return state
+}
+
+func init() {
+	Register(&highCard{})
+}
+
+func setupTest(t *testing.T) deck_repo.DeckRepository {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+
+	return deck_repo.NewDBDeckRepository(db)
+}
+
+func TestNewSession(t *testing.T) {
+	decks := setupTest(t)
+
+	session, err := NewSession("high-card", []string{"alice", "bob"}, map[string]string{"deck": "5H,QS,2C"}, decks)
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	if session.Type != "high-card" || session.Turn != 0 || len(session.Players) != 2 || session.Players[1].Name != "bob" {
+		t.Errorf("Expected a session of high-card with 2 players, but got: %+v", session)
+	}
+	if session.DeckID("stock") == "" {
+		t.Errorf("Expected the session to own the stock, but got: %+v", session.Decks)
+	}
+
+	if _, err := NewSession("missing", []string{"alice", "bob"}, nil, decks); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for an unsupported game, but got: %v", err)
+	}
+	if _, err := NewSession("high-card", []string{"alice"}, nil, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for too few players, but got: %v", err)
+	}
+	if _, err := NewSession("high-card", []string{"alice", "bob"}, map[string]string{"deck": "invalid"}, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for an invalid option, but got: %v", err)
+	}
+}
+
+func TestAct(t *testing.T) {
+	decks := setupTest(t)
+
+	session, err := NewSession("high-card", []string{"alice", "bob"}, map[string]string{"deck": "5H,QS,2C"}, decks)
+	if err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+
+	if err := Act(session, &Action{Seat: 1, Type: "draw"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError out of turn, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 0, Type: "pass"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for an illegal action, but got: %v", err)
+	}
+	if err := Act(session, &Action{Seat: 5, Type: "draw"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a missing seat, but got: %v", err)
+	}
+
+	for seat := 0; seat < 2; seat++ {
+		if err := Act(session, &Action{Seat: seat, Type: "draw"}, decks); err != nil {
+			t.Fatalf("Failed to draw: %s", err.Error())
+		}
+	}
+	if !session.Finished || session.Turn != games_repo.NoTurn || session.Actions != 2 {
+		t.Errorf("Expected the session to be finished after two actions, but got: %+v", session)
+	}
+	if session.Players[0].Score != 5 || session.Players[1].Score != 12 {
+		t.Errorf("Expected the scores 5 and 12, but got %d and %d.", session.Players[0].Score, session.Players[1].Score)
+	}
+	if len(LegalActions(session, 0)) != 0 {
+		t.Errorf("Expected no legal actions in a finished session.")
+	}
+	if err := Act(session, &Action{Seat: 0, Type: "draw"}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a finished session, but got: %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	found := false
+	for _, gameType := range Types() {
+		if gameType == "high-card" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected high-card to be registered, but got: %v", Types())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected registering the same type twice to panic.")
+		}
+	}()
+	Register(&highCard{})
+}
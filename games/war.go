@@ -0,0 +1,357 @@
+package games
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+// War is the type of the game of War.
+const War = "war"
+
+// Actions of War.
+const (
+	// BattleAction plays a single battle, with all of its wars.
+	BattleAction = "battle"
+
+	// RunAction plays battles until the game is over, or up to the number of battles given with the "rounds" parameter.
+	RunAction = "run"
+)
+
+const (
+	// DefaultFaceDown is the default number of cards each player puts face down in a war, before the face-up card.
+	DefaultFaceDown = 3
+
+	// DefaultMaxRounds is the default round cap: the game is over after this many battles, even if both players
+	// still have cards.
+	DefaultMaxRounds = 5000
+
+	// MaxRounds is the highest round cap that may be set.
+	MaxRounds = 100000
+
+	// MaxBattles is the number of battles kept in the transcript: the state holds the last battles of the game only,
+	// so it stays small however long the game is.
+	MaxBattles = 100
+)
+
+// WarEngine is the rule engine of War. Each player gets half of a shuffled deck as a face-down pile. In a battle, both
+// players turn up the top card of their pile, and the higher rank (aces high) takes both cards to the bottom of the
+// pile. On equal ranks there is a war: both players put cards face down, then turn up another card, and the higher
+// rank takes all of the cards; wars repeat as long as the ranks are equal. A player that runs out of cards, even in
+// the middle of a war, loses the game.
+//
+// The deck is shuffled with a seed (the "seed" option), so a game with the same seed and options is played the same
+// way. The battles are automated and may be played by any seat, or the Dealer, one at a time or to the end of the
+// game. The game is over once a player has all of the cards, or after the round cap (the "max_rounds" option), with
+// the player having more cards winning. The "face_down" option sets the number of face-down cards in a war.
+type WarEngine struct{}
+
+// WarBattle is a battle of a game of War, in the transcript of the game.
+type WarBattle struct {
+	// Round is the number of the battle, starting at 1.
+	Round int `json:"round"`
+
+	// Up holds the codes of the cards each player turned up, by seat: the first card and the card of every war.
+	Up [][]string `json:"up"`
+
+	// Down holds the codes of the cards each player put face down in the wars, by seat.
+	Down [][]string `json:"down,omitempty"`
+
+	// Wars is the number of wars in the battle.
+	Wars int `json:"wars"`
+
+	// Winner is the seat of the player that took the cards, or NoWinner if both players ran out of cards.
+	Winner int `json:"winner"`
+
+	// Piles holds the number of cards in the pile of each player after the battle, by seat.
+	Piles []int `json:"piles"`
+}
+
+// NoWinner is the winner of a game or a battle that nobody won.
+const NoWinner = -1
+
+// WarState is the state of a game of War, as seen by everyone.
+type WarState struct {
+	// Seed is the seed the deck was shuffled with.
+	Seed int64 `json:"seed"`
+
+	// FaceDown is the number of cards put face down in a war.
+	FaceDown int `json:"face_down"`
+
+	// MaxRounds is the round cap.
+	MaxRounds int `json:"max_rounds"`
+
+	// Rounds is the number of battles played.
+	Rounds int `json:"rounds"`
+
+	// Winner is the seat of the winner once the game is over, or NoWinner for a draw or if the game goes on.
+	Winner int `json:"winner"`
+
+	// Battles is the transcript of the game: the last battles, at most MaxBattles, in order.
+	Battles []*WarBattle `json:"battles"`
+}
+
+// Type returns the type of War.
+func (e *WarEngine) Type() string {
+	return War
+}
+
+// Players returns the number of players of War: two.
+func (e *WarEngine) Players() (int, int) {
+	return 2, 2
+}
+
+// Setup shuffles a deck with the seed and deals it into the piles of the players, one card at a time.
+// Returns a ValidationError if an option is not valid.
+func (e *WarEngine) Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error {
+	state := &WarState{
+		Seed:      time.Now().UnixNano(),
+		FaceDown:  DefaultFaceDown,
+		MaxRounds: DefaultMaxRounds,
+		Winner:    NoWinner,
+		Battles:   []*WarBattle{},
+	}
+	if value, ok := options["seed"]; ok {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.ValidationError(fmt.Sprintf("invalid seed: %s", value), err)
+		}
+		state.Seed = seed
+	}
+	if err := intOption(options, "face_down", 0, 10, &state.FaceDown); err != nil {
+		return err
+	}
+	if err := intOption(options, "max_rounds", 1, MaxRounds, &state.MaxRounds); err != nil {
+		return err
+	}
+
+	cards := deck_repo.AsCards(strings.Join(deck_repo.NewFullDeck(), ","))
+	random := rand.New(rand.NewSource(state.Seed))
+	random.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+
+	deck, err := CreateDeck(session, "stock", &deck_repo.Deck{Cards: cards}, decks)
+	if err != nil {
+		return err
+	}
+	dealt, err := decks.DrawCards(deck.ID, deck.Remaining)
+	if err != nil {
+		return err
+	}
+
+	piles := make([][]*deck_repo.Card, len(session.Players))
+	for i, card := range dealt {
+		piles[i%len(piles)] = append(piles[i%len(piles)], card)
+	}
+	for seat, player := range session.Players {
+		player.SetHand(piles[seat])
+	}
+
+	return session.SetState(state)
+}
+
+// LegalActions returns the battle and the run actions while the game goes on. Any seat may play.
+func (e *WarEngine) LegalActions(session *games_repo.Session, seat int) []*Action {
+	return []*Action{
+		{Seat: seat, Type: BattleAction},
+		{Seat: seat, Type: RunAction},
+	}
+}
+
+// Apply plays a battle, or runs the battles to the end of the game.
+// Returns a ValidationError if the number of rounds of a run is not valid.
+func (e *WarEngine) Apply(session *games_repo.Session, action *Action, decks deck_repo.DeckRepository) error {
+	state := &WarState{}
+	if err := session.GetState(state); err != nil {
+		return err
+	}
+	rounds := 1
+	if action.Type == RunAction {
+		rounds = MaxRounds
+		if err := intOption(action.Params, "rounds", 1, MaxRounds, &rounds); err != nil {
+			return err
+		}
+	}
+
+	piles := [][]*deck_repo.Card{}
+	for _, player := range session.Players {
+		piles = append(piles, player.HandCards())
+	}
+
+	for i := 0; i < rounds && !warOver(state, piles); i++ {
+		battle(state, piles)
+	}
+
+	if warOver(state, piles) {
+		switch {
+		case len(piles[0]) > len(piles[1]):
+			state.Winner = 0
+		case len(piles[1]) > len(piles[0]):
+			state.Winner = 1
+		}
+	}
+
+	for seat, player := range session.Players {
+		player.SetHand(piles[seat])
+	}
+	return session.SetState(state)
+}
+
+// IsFinished returns true once a player has all of the cards, or after the round cap.
+func (e *WarEngine) IsFinished(session *games_repo.Session) bool {
+	state := &WarState{}
+	if err := session.GetState(state); err != nil {
+		return false
+	}
+
+	piles := [][]*deck_repo.Card{}
+	for _, player := range session.Players {
+		piles = append(piles, player.HandCards())
+	}
+	return warOver(state, piles)
+}
+
+// Scores returns the number of cards of each player.
+func (e *WarEngine) Scores(session *games_repo.Session) []int {
+	scores := []int{}
+	for _, player := range session.Players {
+		scores = append(scores, len(player.HandCards()))
+	}
+	return scores
+}
+
+// View returns the state of the game with the end of the transcript. The whole state is public.
+func (e *WarEngine) View(session *games_repo.Session, seat int) interface{} {
+	state := &WarState{}
+	if err := session.GetState(state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// battle plays a single battle between the piles, with all of its wars, and adds it to the transcript, dropping the
+// first battle of the transcript once it holds MaxBattles battles.
+func battle(state *WarState, piles [][]*deck_repo.Card) {
+	state.Rounds++
+	current := &WarBattle{
+		Round:  state.Rounds,
+		Up:     make([][]string, len(piles)),
+		Winner: NoWinner,
+	}
+	pots := make([][]*deck_repo.Card, len(piles))
+
+	for {
+		// turn up a card each; a player without cards loses the battle
+		short := []int{}
+		for seat := range piles {
+			if len(piles[seat]) == 0 {
+				short = append(short, seat)
+				continue
+			}
+			card := piles[seat][0]
+			piles[seat] = piles[seat][1:]
+			pots[seat] = append(pots[seat], card)
+			current.Up[seat] = append(current.Up[seat], card.Value)
+		}
+		if len(short) > 0 {
+			if len(short) == 1 {
+				current.Winner = 1 - short[0]
+			}
+			break
+		}
+
+		first, second := warRank(pots[0][len(pots[0])-1]), warRank(pots[1][len(pots[1])-1])
+		if first != second {
+			current.Winner = 0
+			if second > first {
+				current.Winner = 1
+			}
+			break
+		}
+
+		current.Wars++
+		if current.Down == nil {
+			current.Down = make([][]string, len(piles))
+		}
+		for seat := range piles {
+			down := state.FaceDown
+			if down > len(piles[seat]) {
+				down = len(piles[seat])
+			}
+			for _, card := range piles[seat][:down] {
+				current.Down[seat] = append(current.Down[seat], card.Value)
+			}
+			pots[seat] = append(pots[seat], piles[seat][:down]...)
+			piles[seat] = piles[seat][down:]
+		}
+	}
+
+	if current.Winner != NoWinner {
+		// the winner takes its own cards first, then the cards of the other player, in the order they were played
+		for _, seat := range []int{current.Winner, 1 - current.Winner} {
+			piles[current.Winner] = append(piles[current.Winner], pots[seat]...)
+		}
+	}
+	for _, pile := range piles {
+		current.Piles = append(current.Piles, len(pile))
+	}
+	if len(state.Battles) < MaxBattles {
+		state.Battles = append(state.Battles, current)
+		return
+	}
+	copy(state.Battles, state.Battles[1:])
+	state.Battles[len(state.Battles)-1] = current
+}
+
+// warOver returns true once a player has no cards, or after the round cap.
+func warOver(state *WarState, piles [][]*deck_repo.Card) bool {
+	if state.Rounds >= state.MaxRounds {
+		return true
+	}
+	for _, pile := range piles {
+		if len(pile) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// warRank returns the rank of the card, from the two (2) to the ace (14).
+func warRank(card *deck_repo.Card) int {
+	rank := rankOf(card)
+	if rank == deck_repo.Ranks[0] {
+		return len(deck_repo.Ranks) + 1
+	}
+	for i, code := range deck_repo.Ranks {
+		if code == rank {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// intOption reads the option into the value, if it is set.
+// Returns a ValidationError if the option is not a number between min and max.
+func intOption(options map[string]string, name string, min, max int, value *int) error {
+	option, ok := options[name]
+	if !ok {
+		return nil
+	}
+	number, err := strconv.Atoi(option)
+	if err != nil || number < min || number > max {
+		return errors.ValidationError(fmt.Sprintf("%s must be a number between %d and %d", name, min, max), err)
+	}
+	*value = number
+	return nil
+}
+
+func init() {
+	Register(&WarEngine{})
+}
@@ -0,0 +1,154 @@
+package games
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+func newWar(t *testing.T, options map[string]string) *games_repo.Session {
+	session, err := NewSession(War, []string{"alice", "bob"}, options, setupTest(t))
+	if err != nil {
+		t.Fatalf("Failed to create War session: %s", err.Error())
+	}
+	return session
+}
+
+// warWith creates a session of War with the given piles, top card first.
+func warWith(t *testing.T, first, second string, options map[string]string) *games_repo.Session {
+	session := newWar(t, options)
+	session.Players[0].Hand = first
+	session.Players[1].Hand = second
+	return session
+}
+
+func warState(t *testing.T, session *games_repo.Session) *WarState {
+	state := &WarState{}
+	if err := session.GetState(state); err != nil {
+		t.Fatalf("Failed to read the state: %s", err.Error())
+	}
+	return state
+}
+
+func TestWar_Setup(t *testing.T) {
+	session := newWar(t, map[string]string{"seed": "42"})
+
+	if len(session.Players[0].HandCards()) != 26 || len(session.Players[1].HandCards()) != 26 {
+		t.Fatalf("Expected 26 cards each, but got: %+v", session.Players)
+	}
+	if session.Players[0].Score != 26 || session.Turn != games_repo.NoTurn || session.DeckID("stock") == "" {
+		t.Errorf("Expected the piles counted as the scores, but got: %+v", session)
+	}
+
+	again := newWar(t, map[string]string{"seed": "42"})
+	if again.Players[0].Hand != session.Players[0].Hand || again.Players[1].Hand != session.Players[1].Hand {
+		t.Errorf("Expected the same seed to deal the same piles.")
+	}
+	other := newWar(t, map[string]string{"seed": "43"})
+	if other.Players[0].Hand == session.Players[0].Hand {
+		t.Errorf("Expected another seed to deal other piles.")
+	}
+
+	for _, options := range []map[string]string{{"seed": "x"}, {"face_down": "11"}, {"max_rounds": "0"}} {
+		if _, err := NewSession(War, []string{"alice", "bob"}, options, setupTest(t)); !errors.IsValidationError(err) {
+			t.Errorf("Expected a ValidationError for %v, but got: %v", options, err)
+		}
+	}
+}
+
+func TestWar_Battle(t *testing.T) {
+	decks := setupTest(t)
+	session := warWith(t, "KH,2C", "AS,3D", nil)
+
+	if err := Act(session, &Action{Seat: Dealer, Type: BattleAction}, decks); err != nil {
+		t.Fatalf("Failed to battle: %s", err.Error())
+	}
+	if session.Players[1].Hand != "3D,AS,KH" || session.Players[0].Hand != "2C" {
+		t.Errorf("Expected the ace to take the king, but got %s and %s.", session.Players[0].Hand, session.Players[1].Hand)
+	}
+	state := warState(t, session)
+	expected := &WarBattle{Round: 1, Up: [][]string{{"KH"}, {"AS"}}, Winner: 1, Piles: []int{1, 3}}
+	if len(state.Battles) != 1 || !reflect.DeepEqual(state.Battles[0], expected) {
+		t.Errorf("Expected the battle %+v in the transcript, but got: %+v", expected, state.Battles)
+	}
+
+	if err := Act(session, &Action{Seat: 0, Type: BattleAction}, decks); err != nil {
+		t.Fatalf("Failed to battle: %s", err.Error())
+	}
+	if !session.Finished || warState(t, session).Winner != 1 || session.Players[1].Score != 4 {
+		t.Errorf("Expected bob to win with all of the cards, but got: %+v", session.Players)
+	}
+}
+
+func TestWar_Wars(t *testing.T) {
+	decks := setupTest(t)
+	session := warWith(t, "7H,2C,3C,4C,9H,5C", "7S,2D,3D,4D,8S,6D", nil)
+
+	if err := Act(session, &Action{Seat: Dealer, Type: BattleAction}, decks); err != nil {
+		t.Fatalf("Failed to battle: %s", err.Error())
+	}
+	battle := warState(t, session).Battles[0]
+	if battle.Wars != 1 || battle.Winner != 0 || !reflect.DeepEqual(battle.Up, [][]string{{"7H", "9H"}, {"7S", "8S"}}) ||
+		!reflect.DeepEqual(battle.Down, [][]string{{"2C", "3C", "4C"}, {"2D", "3D", "4D"}}) {
+		t.Errorf("Expected a war won by the nine, but got: %+v", battle)
+	}
+	if session.Players[0].Hand != "5C,7H,2C,3C,4C,9H,7S,2D,3D,4D,8S" || session.Players[1].Hand != "6D" {
+		t.Errorf("Expected alice to take the cards, but got %s and %s.", session.Players[0].Hand, session.Players[1].Hand)
+	}
+
+	// bob runs out of cards in the middle of a war
+	session = warWith(t, "QH,2C,3C,4C,5C", "QS,2D", map[string]string{"face_down": "3"})
+	if err := Act(session, &Action{Seat: Dealer, Type: BattleAction}, decks); err != nil {
+		t.Fatalf("Failed to battle: %s", err.Error())
+	}
+	battle = warState(t, session).Battles[0]
+	if battle.Winner != 0 || !session.Finished || session.Players[0].Score != 7 {
+		t.Errorf("Expected alice to win when bob runs out in a war, but got: %+v, %+v", battle, session.Players)
+	}
+}
+
+func TestWar_Run(t *testing.T) {
+	decks := setupTest(t)
+	session := newWar(t, map[string]string{"seed": "7", "max_rounds": "100000"})
+	replay := newWar(t, map[string]string{"seed": "7", "max_rounds": "100000"})
+
+	if err := Act(session, &Action{Seat: Dealer, Type: RunAction, Params: map[string]string{"rounds": "10"}}, decks); err != nil {
+		t.Fatalf("Failed to run: %s", err.Error())
+	}
+	if state := warState(t, session); state.Rounds != 10 || len(state.Battles) != 10 || session.Finished {
+		t.Fatalf("Expected 10 battles, but got %d.", state.Rounds)
+	}
+	if err := Act(session, &Action{Seat: Dealer, Type: RunAction}, decks); err != nil {
+		t.Fatalf("Failed to run: %s", err.Error())
+	}
+	if err := Act(replay, &Action{Seat: Dealer, Type: RunAction}, decks); err != nil {
+		t.Fatalf("Failed to run: %s", err.Error())
+	}
+
+	state := warState(t, session)
+	if !session.Finished || state.Winner == NoWinner || session.Players[state.Winner].Score != 52 {
+		t.Errorf("Expected a winner with all of the cards, but got: %+v", session.Players)
+	}
+	if !reflect.DeepEqual(state.Battles, warState(t, replay).Battles) {
+		t.Errorf("Expected the same seed to play the same game.")
+	}
+	if state.Rounds <= MaxBattles || len(state.Battles) != MaxBattles || state.Battles[MaxBattles-1].Round != state.Rounds {
+		t.Errorf("Expected the last %d of the %d battles in the transcript, but got %d.", MaxBattles, state.Rounds, len(state.Battles))
+	}
+	if err := Act(session, &Action{Seat: Dealer, Type: BattleAction}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError after the game is over, but got: %v", err)
+	}
+
+	capped := newWar(t, map[string]string{"seed": "7", "max_rounds": "5"})
+	if err := Act(capped, &Action{Seat: Dealer, Type: RunAction}, decks); err != nil {
+		t.Fatalf("Failed to run: %s", err.Error())
+	}
+	if !capped.Finished || warState(t, capped).Rounds != 5 {
+		t.Errorf("Expected the game to be over after the round cap, but got %d rounds.", warState(t, capped).Rounds)
+	}
+	if err := Act(newWar(t, nil), &Action{Seat: Dealer, Type: RunAction, Params: map[string]string{"rounds": "0"}}, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for no rounds, but got: %v", err)
+	}
+}
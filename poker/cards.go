@@ -0,0 +1,142 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Card is a playing card, encoded as rank*4 + suit. The rank goes from 0 (two) to 12 (ace), and the suit follows
+// the order of deck_repo.Suits (clubs, diamonds, hearts, spades).
+type Card uint8
+
+// Number of ranks and suits in a standard deck of cards.
+const (
+	NumRanks = 13
+	NumSuits = 4
+)
+
+// pokerRanks maps the deck rank codes to the poker ranks, ordered from two (lowest) to ace (highest).
+var pokerRanks = map[string]int{
+	"2": 0, "3": 1, "4": 2, "5": 3, "6": 4, "7": 5, "8": 6, "9": 7, "10": 8, "J": 9, "Q": 10, "K": 11, "A": 12,
+}
+
+// rankCodes maps the poker ranks back to the deck rank codes.
+var rankCodes = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+// suitIndexes maps the deck suit codes to the suit index.
+var suitIndexes = map[string]int{}
+
+func init() {
+	for i, suit := range deck_repo.Suits {
+		suitIndexes[suit] = i
+	}
+}
+
+// NewCard creates a new Card from the poker rank (0 - two to 12 - ace) and the suit index.
+func NewCard(rank, suit int) Card {
+	return Card(rank*NumSuits + suit)
+}
+
+// Rank returns the poker rank of the card: 0 for two up to 12 for ace.
+func (c Card) Rank() int {
+	return int(c) / NumSuits
+}
+
+// Suit returns the index of the card suit in deck_repo.Suits.
+func (c Card) Suit() int {
+	return int(c) % NumSuits
+}
+
+// Code returns the card code as used in the decks, for example "AS" or "10H".
+func (c Card) Code() string {
+	return rankCodes[c.Rank()] + deck_repo.Suits[c.Suit()]
+}
+
+// String returns the card code.
+func (c Card) String() string {
+	return c.Code()
+}
+
+// FromDeckCards converts deck cards to poker cards.
+// Returns a ValidationError if any of the cards is invalid or the cards have duplicates (see deck_repo.ValidateDeckCards).
+func FromDeckCards(cards []*deck_repo.Card) ([]Card, error) {
+	if err := deck_repo.ValidateDeckCards(cards); err != nil {
+		return nil, err
+	}
+
+	result := make([]Card, 0, len(cards))
+	for _, card := range cards {
+		rankCode := card.Value[:len(card.Value)-1]
+		suitCode := card.Value[len(card.Value)-1:]
+		result = append(result, NewCard(pokerRanks[rankCode], suitIndexes[suitCode]))
+	}
+
+	return result, nil
+}
+
+// ParseCards parses card codes, like "AS" or "10H", into poker cards.
+// Returns a ValidationError if any of the codes is not a valid card or the cards have duplicates.
+func ParseCards(codes ...string) ([]Card, error) {
+	return FromDeckCards(deck_repo.AsCards(strings.Join(codes, ",")))
+}
+
+// MustParseCards parses comma-separated card codes into poker cards and panics if any of the codes is invalid.
+// Intended for static hands, like in tests.
+func MustParseCards(codes string) []Card {
+	cards, err := ParseCards(codes)
+	if err != nil {
+		panic(err)
+	}
+	return cards
+}
+
+// Codes returns the card codes of the cards.
+func Codes(cards []Card) []string {
+	codes := make([]string, 0, len(cards))
+	for _, card := range cards {
+		codes = append(codes, card.Code())
+	}
+	return codes
+}
+
+// Hand is a set of cards, encoded as a bit mask. Each suit takes 16 bits, and within each suit, the bit at
+// the position of the rank is set if the card is in the hand.
+type Hand uint64
+
+// NewHand creates a new Hand from the given cards.
+func NewHand(cards ...Card) Hand {
+	var hand Hand
+	for _, card := range cards {
+		hand |= card.Mask()
+	}
+	return hand
+}
+
+// Mask returns the bit mask of the card, as used in Hand.
+func (c Card) Mask() Hand {
+	return Hand(1) << (uint(c.Suit())*16 + uint(c.Rank()))
+}
+
+// Cards returns the cards in the hand, ordered by suit and rank.
+func (h Hand) Cards() []Card {
+	cards := []Card{}
+	for suit := 0; suit < NumSuits; suit++ {
+		for rank := 0; rank < NumRanks; rank++ {
+			if h&NewCard(rank, suit).Mask() != 0 {
+				cards = append(cards, NewCard(rank, suit))
+			}
+		}
+	}
+	return cards
+}
+
+// validateHandSize returns a ValidationError if the number of cards is not between min and max.
+func validateHandSize(cards []Card, min, max int) error {
+	if len(cards) < min || len(cards) > max {
+		return errors.ValidationError(fmt.Sprintf("expected between %d and %d cards, but got %d", min, max, len(cards)), nil)
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Defaults for the equity calculation.
+const (
+	// DefaultEquityIterations is the number of random boards dealt by the Monte Carlo simulation.
+	DefaultEquityIterations = 100000
+
+	// DefaultMaxExactEvaluations is the maximal number of hand evaluations for which the equity is calculated
+	// exactly, by enumerating every possible board. Above it, the equity is estimated with a Monte Carlo simulation.
+	DefaultMaxExactEvaluations = 20000000
+)
+
+// budgetCheckInterval is the number of boards a worker evaluates between two checks of the time budget.
+const budgetCheckInterval = 1024
+
+// equityChunk is the number of random boards dealt with the same random generator. Every chunk has its own generator,
+// seeded from the seed and the index of the chunk, so the boards do not depend on the number of workers.
+const equityChunk = 4096
+
+// EquityOptions holds the input of an equity calculation.
+type EquityOptions struct {
+	// Game is the poker game: Holdem or Omaha.
+	Game string
+
+	// Hands holds the hole cards of every player.
+	Hands [][]Card
+
+	// Board is the known part of the board: no cards, or three to five cards.
+	Board []Card
+
+	// Dead holds the cards known to be out of play, like folded or burned cards.
+	Dead []Card
+
+	// Iterations is the number of random boards dealt by the Monte Carlo simulation.
+	// Zero means DefaultEquityIterations.
+	Iterations int
+
+	// TimeBudget stops the Monte Carlo simulation early, once the time is up. Zero means no time budget.
+	TimeBudget time.Duration
+
+	// Seed seeds the random boards of the Monte Carlo simulation. The same seed and iterations always give the same
+	// result, whatever the number of workers, unless the simulation is truncated by the time budget (see
+	// EquityResult.Truncated).
+	Seed int64
+
+	// Workers is the number of goroutines evaluating the boards. Zero means the number of CPUs.
+	Workers int
+
+	// MaxExactEvaluations is the maximal number of hand evaluations for an exact calculation.
+	// Zero means DefaultMaxExactEvaluations, and a negative value always runs the Monte Carlo simulation.
+	MaxExactEvaluations int64
+}
+
+// PlayerEquity holds the equity of a single player.
+type PlayerEquity struct {
+	// Wins is the number of boards on which the player wins alone.
+	Wins uint64
+
+	// Ties is the number of boards on which the player shares the pot with other players.
+	Ties uint64
+
+	// Win is the probability to win alone.
+	Win float64
+
+	// Tie is the probability to share the pot.
+	Tie float64
+
+	// Equity is the expected share of the pot: the probability to win plus the expected share of the tied pots.
+	Equity float64
+}
+
+// EquityResult is the result of an equity calculation.
+type EquityResult struct {
+	// Players holds the equity of each player, in the order of the hands.
+	Players []PlayerEquity
+
+	// Boards is the number of evaluated boards.
+	Boards uint64
+
+	// Exact is true if every possible board was evaluated, and false for a Monte Carlo estimate.
+	Exact bool
+
+	// Truncated is true if the Monte Carlo simulation was stopped by the time budget before all of the iterations.
+	// A truncated estimate depends on the speed of the machine, so it cannot be repeated with the same seed.
+	Truncated bool
+}
+
+// equityTally accumulates the outcomes of the evaluated boards.
+type equityTally struct {
+	boards uint64
+	wins   []uint64
+	ties   []uint64
+	shares []float64
+}
+
+func newEquityTally(players int) *equityTally {
+	return &equityTally{
+		wins:   make([]uint64, players),
+		ties:   make([]uint64, players),
+		shares: make([]float64, players),
+	}
+}
+
+func (t *equityTally) add(other *equityTally) {
+	t.boards += other.boards
+	for i := range t.wins {
+		t.wins[i] += other.wins[i]
+		t.ties[i] += other.ties[i]
+		t.shares[i] += other.shares[i]
+	}
+}
+
+// equityCalculator evaluates the hands of the players against complete boards.
+type equityCalculator struct {
+	game      string
+	hands     [][]Card
+	handMasks []Hand
+	board     []Card
+	remaining []Card
+	missing   int
+}
+
+// evaluate scores every hand with the given complete board and records the outcome in the tally.
+func (c *equityCalculator) evaluate(board []Card, boardMask Hand, scores []Score, tally *equityTally) {
+	var best Score
+	winners := 0
+	for i := range c.hands {
+		if c.game == Omaha {
+			scores[i] = omahaScore(c.hands[i], board)
+		} else {
+			scores[i] = EvaluateHand(c.handMasks[i] | boardMask)
+		}
+		if winners == 0 || scores[i] > best {
+			best = scores[i]
+			winners = 1
+		} else if scores[i] == best {
+			winners++
+		}
+	}
+
+	tally.boards++
+	for i, score := range scores {
+		if score != best {
+			continue
+		}
+		if winners == 1 {
+			tally.wins[i]++
+		} else {
+			tally.ties[i]++
+		}
+		tally.shares[i] += 1 / float64(winners)
+	}
+}
+
+// enumerate evaluates every possible board where the first missing card is one of the remaining cards at
+// the positions first, first+step, first+2*step, and so on. This way, the boards are split between the workers.
+func (c *equityCalculator) enumerate(first, step int, tally *equityTally) {
+	board := make([]Card, len(c.board), 5)
+	copy(board, c.board)
+	boardMask := NewHand(c.board...)
+	scores := make([]Score, len(c.hands))
+
+	if c.missing == 0 {
+		if first == 0 {
+			c.evaluate(board, boardMask, scores, tally)
+		}
+		return
+	}
+
+	var deal func(from, left int, mask Hand)
+	deal = func(from, left int, mask Hand) {
+		if left == 0 {
+			c.evaluate(board, mask, scores, tally)
+			return
+		}
+		for i := from; i <= len(c.remaining)-left; i++ {
+			board = append(board, c.remaining[i])
+			deal(i+1, left-1, mask|c.remaining[i].Mask())
+			board = board[:len(board)-1]
+		}
+	}
+
+	for i := first; i <= len(c.remaining)-c.missing; i += step {
+		board = append(board, c.remaining[i])
+		deal(i+1, c.missing-1, boardMask|c.remaining[i].Mask())
+		board = board[:len(board)-1]
+	}
+}
+
+// simulate evaluates the given number of random boards, until the deadline (if not zero) is reached. Returns false if
+// the deadline was reached before all of the boards were evaluated.
+func (c *equityCalculator) simulate(random *rand.Rand, iterations int, deadline time.Time, tally *equityTally) bool {
+	board := make([]Card, len(c.board), 5)
+	copy(board, c.board)
+	boardMask := NewHand(c.board...)
+	scores := make([]Score, len(c.hands))
+	remaining := append([]Card{}, c.remaining...)
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		if !deadline.IsZero() && iteration%budgetCheckInterval == 0 && time.Now().After(deadline) {
+			return false
+		}
+
+		mask := boardMask
+		board = board[:len(c.board)]
+		for i := 0; i < c.missing; i++ {
+			j := i + random.Intn(len(remaining)-i)
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+			board = append(board, remaining[i])
+			mask |= remaining[i].Mask()
+		}
+
+		c.evaluate(board, mask, scores, tally)
+	}
+	return true
+}
+
+// chunkSeed returns the seed of the random generator of the chunk of random boards.
+func chunkSeed(seed int64, chunk int) int64 {
+	return seed ^ int64(uint64(chunk+1)*0x9E3779B97F4A7C15)
+}
+
+// CalculateEquity calculates the probabilities of every player to win or tie, given the hole cards of the players,
+// the known part of the board and the dead cards. The remaining cards are the cards of a full deck (see
+// deck_repo.NewFullDeck) that are not known. When the number of hand evaluations to enumerate every possible board
+// is small enough, the equity is calculated exactly, otherwise it is estimated with a seeded Monte Carlo simulation,
+// dealt in chunks of random boards. Either way, the boards are evaluated in parallel by multiple goroutines.
+// Returns a ValidationError if the game is not supported, there are less than two players, a card is known more
+// than once, or the number of hole or board cards is not valid for the game.
+func CalculateEquity(options *EquityOptions) (*EquityResult, error) {
+	calculator, err := newEquityCalculator(options)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	maxExactEvaluations := options.MaxExactEvaluations
+	if maxExactEvaluations == 0 {
+		maxExactEvaluations = DefaultMaxExactEvaluations
+	}
+	exact := maxExactEvaluations > 0 && calculator.evaluations() <= maxExactEvaluations
+
+	iterations := options.Iterations
+	if iterations <= 0 {
+		iterations = DefaultEquityIterations
+	}
+	var deadline time.Time
+	if options.TimeBudget > 0 {
+		deadline = time.Now().Add(options.TimeBudget)
+	}
+
+	// The exact calculation splits the boards between the workers, while the simulation deals the chunks to the
+	// workers as they come, and adds them up in order.
+	var tallies []*equityTally
+	if exact {
+		tallies = make([]*equityTally, workers)
+	} else {
+		tallies = make([]*equityTally, (iterations+equityChunk-1)/equityChunk)
+	}
+	var nextChunk int64 = -1
+
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			if exact {
+				tallies[worker] = newEquityTally(len(options.Hands))
+				calculator.enumerate(worker, workers, tallies[worker])
+				return
+			}
+			for {
+				chunk := int(atomic.AddInt64(&nextChunk, 1))
+				if chunk >= len(tallies) {
+					return
+				}
+				chunkIterations := equityChunk
+				if chunk == len(tallies)-1 {
+					chunkIterations = iterations - chunk*equityChunk
+				}
+				tallies[chunk] = newEquityTally(len(options.Hands))
+				random := rand.New(rand.NewSource(chunkSeed(options.Seed, chunk)))
+				if !calculator.simulate(random, chunkIterations, deadline, tallies[chunk]) {
+					return
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	total := newEquityTally(len(options.Hands))
+	for _, tally := range tallies {
+		if tally != nil {
+			total.add(tally)
+		}
+	}
+
+	result := &EquityResult{
+		Boards:    total.boards,
+		Exact:     exact,
+		Truncated: !exact && total.boards < uint64(iterations),
+	}
+	for i := range options.Hands {
+		player := PlayerEquity{
+			Wins: total.wins[i],
+			Ties: total.ties[i],
+		}
+		if total.boards > 0 {
+			player.Win = float64(total.wins[i]) / float64(total.boards)
+			player.Tie = float64(total.ties[i]) / float64(total.boards)
+			player.Equity = total.shares[i] / float64(total.boards)
+		}
+		result.Players = append(result.Players, player)
+	}
+
+	return result, nil
+}
+
+func newEquityCalculator(options *EquityOptions) (*equityCalculator, error) {
+	game := options.Game
+	if game == "" {
+		game = Holdem
+	}
+
+	minHole, maxHole := 2, 2
+	switch game {
+	case Holdem:
+	case Omaha:
+		minHole, maxHole = 4, 5
+	default:
+		return nil, errors.ValidationError(fmt.Sprintf("unsupported game: %s", game), nil)
+	}
+
+	if len(options.Hands) < 2 {
+		return nil, errors.ValidationError("the equity needs at least two players", nil)
+	}
+	if len(options.Board) > 5 || (len(options.Board) > 0 && len(options.Board) < 3) {
+		return nil, errors.ValidationError(fmt.Sprintf("the board must have no cards or three to five cards, but got %d", len(options.Board)), nil)
+	}
+
+	known := NewHand(options.Board...)
+	count := len(options.Board)
+	known |= NewHand(options.Dead...)
+	count += len(options.Dead)
+
+	calculator := &equityCalculator{
+		game:    game,
+		hands:   options.Hands,
+		board:   options.Board,
+		missing: 5 - len(options.Board),
+	}
+
+	for player, hole := range options.Hands {
+		if err := validateHandSize(hole, minHole, maxHole); err != nil {
+			return nil, errors.ValidationError(fmt.Sprintf("player %d: %s", player, err.Error()), err)
+		}
+		mask := NewHand(hole...)
+		calculator.handMasks = append(calculator.handMasks, mask)
+		known |= mask
+		count += len(hole)
+	}
+
+	if len(known.Cards()) != count {
+		return nil, errors.ValidationError("a card is known more than once", nil)
+	}
+
+	fullDeck, err := ParseCards(deck_repo.NewFullDeck()...)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range fullDeck {
+		if known&card.Mask() == 0 {
+			calculator.remaining = append(calculator.remaining, card)
+		}
+	}
+
+	if len(calculator.remaining) < calculator.missing {
+		return nil, errors.ValidationError("not enough remaining cards to complete the board", nil)
+	}
+
+	return calculator, nil
+}
+
+// evaluations returns the number of hand evaluations needed to enumerate every possible board.
+func (c *equityCalculator) evaluations() int64 {
+	boards := int64(1)
+	for i := 0; i < c.missing; i++ {
+		boards = boards * int64(len(c.remaining)-i) / int64(i+1)
+	}
+
+	perBoard := int64(len(c.hands))
+	if c.game == Omaha {
+		perBoard = 0
+		for _, hole := range c.hands {
+			// Every pair of hole cards with every three of the five board cards.
+			perBoard += int64(len(hole)*(len(hole)-1)/2) * 10
+		}
+	}
+
+	return boards * perBoard
+}
+
+// omahaScore scores the best Omaha hand made of exactly two hole cards and exactly three board cards.
+func omahaScore(hole, board []Card) Score {
+	var best Score
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			holeMask := hole[i].Mask() | hole[j].Mask()
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						score := EvaluateHand(holeMask | board[a].Mask() | board[b].Mask() | board[c].Mask())
+						if score > best {
+							best = score
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
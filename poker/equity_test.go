@@ -0,0 +1,150 @@
+package poker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculateEquity_Exact(t *testing.T) {
+	result, err := CalculateEquity(&EquityOptions{
+		Hands: [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")},
+	})
+	if err != nil {
+		t.Fatalf("Expected the equity to be calculated, but got error: %s", err.Error())
+	}
+
+	if !result.Exact || result.Boards != 1712304 {
+		t.Fatalf("Expected all 1712304 boards to be enumerated, but got %d (exact: %t).", result.Boards, result.Exact)
+	}
+	aces, kings := result.Players[0], result.Players[1]
+	if aces.Wins+kings.Wins+aces.Ties != result.Boards || aces.Ties != kings.Ties {
+		t.Error("Expected every board to be won by one player or tied by both.")
+	}
+	if math.Abs(aces.Equity-0.82) > 0.01 || math.Abs(aces.Equity+kings.Equity-1) > 1e-9 {
+		t.Errorf("Expected aces to have about 82%% equity against kings, but got %f.", aces.Equity)
+	}
+}
+
+func TestCalculateEquity_River(t *testing.T) {
+	result, err := CalculateEquity(&EquityOptions{
+		Hands: [][]Card{MustParseCards("AS,2D"), MustParseCards("AH,3D"), MustParseCards("KS,KD")},
+		Board: MustParseCards("AC,QC,JD,9H,8S"),
+	})
+	if err != nil {
+		t.Fatalf("Expected the equity to be calculated, but got error: %s", err.Error())
+	}
+
+	if result.Boards != 1 {
+		t.Fatalf("Expected a single board, but got %d.", result.Boards)
+	}
+	if result.Players[0].Equity != 0.5 || result.Players[1].Equity != 0.5 || result.Players[2].Equity != 0 {
+		t.Errorf("Expected the aces to split the pot, but got: %+v", result.Players)
+	}
+	if result.Players[0].Tie != 1 || result.Players[0].Win != 0 {
+		t.Errorf("Expected the aces to always tie, but got: %+v", result.Players[0])
+	}
+}
+
+func TestCalculateEquity_DeadCards(t *testing.T) {
+	// With both remaining kings dead, the pair of queens can only win with a queen or a straight.
+	result, err := CalculateEquity(&EquityOptions{
+		Hands: [][]Card{MustParseCards("KS,KH"), MustParseCards("QS,QH")},
+		Board: MustParseCards("2C,7D,9H,3S"),
+		Dead:  MustParseCards("KC,KD"),
+	})
+	if err != nil {
+		t.Fatalf("Expected the equity to be calculated, but got error: %s", err.Error())
+	}
+
+	if result.Boards != 52-10 {
+		t.Fatalf("Expected %d possible rivers, but got %d.", 52-10, result.Boards)
+	}
+	if result.Players[1].Wins != 2 {
+		t.Errorf("Expected the queens to win on the two remaining queens only, but got %d wins.", result.Players[1].Wins)
+	}
+}
+
+func TestCalculateEquity_MonteCarlo(t *testing.T) {
+	options := &EquityOptions{
+		Hands:               [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")},
+		Iterations:          200000,
+		Seed:                42,
+		Workers:             4,
+		MaxExactEvaluations: -1,
+	}
+
+	result, err := CalculateEquity(options)
+	if err != nil {
+		t.Fatalf("Expected the equity to be estimated, but got error: %s", err.Error())
+	}
+	if result.Exact || result.Boards != 200000 {
+		t.Fatalf("Expected 200000 random boards, but got %d (exact: %t).", result.Boards, result.Exact)
+	}
+	if math.Abs(result.Players[0].Equity-0.82) > 0.01 {
+		t.Errorf("Expected aces to have about 82%% equity against kings, but got %f.", result.Players[0].Equity)
+	}
+
+	if result.Truncated {
+		t.Error("Expected the simulation not to be truncated without a time budget.")
+	}
+
+	for _, workers := range []int{1, 3, 4} {
+		options.Workers = workers
+		again, _ := CalculateEquity(options)
+		if again.Players[0].Wins != result.Players[0].Wins || again.Players[1].Wins != result.Players[1].Wins ||
+			again.Players[0].Equity != result.Players[0].Equity {
+			t.Errorf("Expected the same seed to give the same result with %d workers.", workers)
+		}
+	}
+}
+
+func TestCalculateEquity_TimeBudget(t *testing.T) {
+	result, err := CalculateEquity(&EquityOptions{
+		Game:                Omaha,
+		Hands:               [][]Card{MustParseCards("AS,AH,KS,KH"), MustParseCards("QC,JC,10D,9D")},
+		Iterations:          1000000000,
+		TimeBudget:          50 * time.Millisecond,
+		MaxExactEvaluations: -1,
+	})
+	if err != nil {
+		t.Fatalf("Expected the equity to be estimated, but got error: %s", err.Error())
+	}
+	if result.Boards == 0 || result.Boards >= 1000000000 || !result.Truncated {
+		t.Errorf("Expected the simulation to be truncated by the time budget, but got %d boards.", result.Boards)
+	}
+}
+
+func TestCalculateEquity_Omaha(t *testing.T) {
+	// The nut flush draw with the board paired: the full house beats any flush.
+	result, err := CalculateEquity(&EquityOptions{
+		Game:  Omaha,
+		Hands: [][]Card{MustParseCards("AS,KS,2D,3C"), MustParseCards("9C,9D,8H,7H")},
+		Board: MustParseCards("9S,4S,4D,JC"),
+	})
+	if err != nil {
+		t.Fatalf("Expected the equity to be calculated, but got error: %s", err.Error())
+	}
+
+	if !result.Exact || result.Boards != 52-12 {
+		t.Fatalf("Expected %d possible rivers, but got %d.", 52-12, result.Boards)
+	}
+	if result.Players[0].Wins != 0 {
+		t.Errorf("Expected the flush draw to never beat the full house, but got %d wins.", result.Players[0].Wins)
+	}
+}
+
+func TestCalculateEquity_Invalid(t *testing.T) {
+	for name, options := range map[string]*EquityOptions{
+		"one player":      {Hands: [][]Card{MustParseCards("AS,AH")}},
+		"duplicate cards": {Hands: [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")}, Dead: MustParseCards("AS")},
+		"short board":     {Hands: [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")}, Board: MustParseCards("2C,3C")},
+		"hole cards":      {Hands: [][]Card{MustParseCards("AS,AH,2C"), MustParseCards("KS,KH")}},
+		"omaha hole":      {Game: Omaha, Hands: [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")}},
+		"unknown game":    {Game: "stud", Hands: [][]Card{MustParseCards("AS,AH"), MustParseCards("KS,KH")}},
+	} {
+		if _, err := CalculateEquity(options); err == nil {
+			t.Errorf("Expected an error for %s.", name)
+		}
+	}
+}
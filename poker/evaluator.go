@@ -0,0 +1,264 @@
+package poker
+
+import (
+	"math/bits"
+)
+
+// HandCategory is the category of a poker hand, like a pair or a flush.
+type HandCategory int
+
+// Poker hand categories, from the lowest to the highest.
+const (
+	HighCard HandCategory = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+	RoyalFlush
+)
+
+// categoryNames maps the hand categories to their names.
+var categoryNames = []string{
+	"HIGH_CARD",
+	"ONE_PAIR",
+	"TWO_PAIR",
+	"THREE_OF_A_KIND",
+	"STRAIGHT",
+	"FLUSH",
+	"FULL_HOUSE",
+	"FOUR_OF_A_KIND",
+	"STRAIGHT_FLUSH",
+	"ROYAL_FLUSH",
+}
+
+// String returns the name of the hand category, like "FULL_HOUSE".
+func (c HandCategory) String() string {
+	return categoryNames[c]
+}
+
+// Score is a comparable value of a poker hand: a higher score beats a lower score and equal scores tie.
+// The category is encoded in the bits above 20, followed by up to five ranks (4 bits each) that break ties
+// within the category: first the ranks of the made combination, then the kickers.
+type Score uint32
+
+// Category returns the category of the scored hand.
+func (s Score) Category() HandCategory {
+	return HandCategory(s >> 20)
+}
+
+// ranks returns the ranks encoded in the score, in order of importance.
+func (s Score) ranks() [5]int {
+	return [5]int{int(s>>16) & 0xF, int(s>>12) & 0xF, int(s>>8) & 0xF, int(s>>4) & 0xF, int(s) & 0xF}
+}
+
+func newScore(category HandCategory, ranks uint32) Score {
+	return Score(uint32(category)<<20 | ranks)
+}
+
+const (
+	// rankBits is a mask of the 13 rank bits of a suit in a Hand.
+	rankBits = 1<<NumRanks - 1
+
+	// wheel is the rank mask of the lowest straight: A-2-3-4-5.
+	wheel = 1<<12 | 0xF
+)
+
+// straightHigh maps a rank mask to the rank of the highest card of the highest straight in the mask,
+// or -1 if there is no straight.
+var straightHigh [1 << NumRanks]int8
+
+// topFive maps a rank mask to the encoded ranks of its (up to) five highest ranks, as used in Score.
+var topFive [1 << NumRanks]uint32
+
+func init() {
+	for mask := 0; mask < 1<<NumRanks; mask++ {
+		straightHigh[mask] = -1
+		for high := NumRanks - 1; high >= 4; high-- {
+			straight := 0x1F << (high - 4)
+			if mask&straight == straight {
+				straightHigh[mask] = int8(high)
+				break
+			}
+		}
+		if straightHigh[mask] < 0 && mask&wheel == wheel {
+			straightHigh[mask] = 3
+		}
+
+		topFive[mask] = topRanks(uint16(mask), 5, 5)
+	}
+}
+
+// topRanks encodes the n highest ranks of the mask, as the first of the slots rank positions in a Score.
+func topRanks(mask uint16, n int, slots int) uint32 {
+	var encoded uint32
+	shift := uint(slots-1) * 4
+	for i := 0; i < n && mask != 0; i++ {
+		rank := bits.Len16(mask) - 1
+		encoded |= uint32(rank) << shift
+		mask &^= 1 << rank
+		shift -= 4
+	}
+	return encoded
+}
+
+func topRank(mask uint16) uint16 {
+	return uint16(bits.Len16(mask) - 1)
+}
+
+// EvaluateHand scores a hand of five, six or seven cards by its best five-card combination.
+// The evaluation uses bit operations over the rank masks of the suits and lookup tables only,
+// so it does not allocate memory. The result is not meaningful for hands with less than five or more
+// than seven cards.
+func EvaluateHand(hand Hand) Score {
+	c := uint16(hand) & rankBits
+	d := uint16(hand>>16) & rankBits
+	h := uint16(hand>>32) & rankBits
+	s := uint16(hand>>48) & rankBits
+
+	// With at most seven cards, a flush excludes four of a kind and full house.
+	for _, suit := range [4]uint16{c, d, h, s} {
+		if bits.OnesCount16(suit) >= 5 {
+			if high := straightHigh[suit]; high >= 0 {
+				if high == NumRanks-1 {
+					return newScore(RoyalFlush, uint32(high)<<16)
+				}
+				return newScore(StraightFlush, uint32(high)<<16)
+			}
+			return newScore(Flush, topFive[suit])
+		}
+	}
+
+	ranks := c | d | h | s
+
+	quads := c & d & h & s
+	if quads != 0 {
+		quad := topRank(quads)
+		return newScore(FourOfAKind, uint32(quad)<<16|uint32(topRank(ranks&^(1<<quad)))<<12)
+	}
+
+	atLeastTwo := (c & d) | (c & h) | (c & s) | (d & h) | (d & s) | (h & s)
+	trips := (c & d & h) | (c & d & s) | (c & h & s) | (d & h & s)
+	pairs := atLeastTwo &^ trips
+
+	if trips != 0 {
+		trip := topRank(trips)
+		if rest := (trips &^ (1 << trip)) | pairs; rest != 0 {
+			return newScore(FullHouse, uint32(trip)<<16|uint32(topRank(rest))<<12)
+		}
+	}
+
+	if high := straightHigh[ranks]; high >= 0 {
+		return newScore(Straight, uint32(high)<<16)
+	}
+
+	if trips != 0 {
+		trip := topRank(trips)
+		return newScore(ThreeOfAKind, uint32(trip)<<16|topRanks(ranks&^(1<<trip), 2, 4))
+	}
+
+	if pairs != 0 {
+		high := topRank(pairs)
+		rest := pairs &^ (1 << high)
+		if rest != 0 {
+			low := topRank(rest)
+			return newScore(TwoPair, uint32(high)<<16|uint32(low)<<12|topRanks(ranks&^(1<<high|1<<low), 1, 3))
+		}
+		return newScore(OnePair, uint32(high)<<16|topRanks(ranks&^(1<<high), 3, 4))
+	}
+
+	return newScore(HighCard, topFive[ranks])
+}
+
+// HandValue is the evaluated value of a poker hand.
+type HandValue struct {
+	// Category is the category of the best five-card combination.
+	Category HandCategory
+
+	// Score is the comparable score of the hand.
+	Score Score
+
+	// Best is the best five-card combination, ordered by importance: first the cards of the made combination,
+	// then the kickers.
+	Best []Card
+}
+
+// Evaluate evaluates a hand of five, six or seven cards and returns its best five-card combination.
+// Returns a ValidationError if there are less than five or more than seven cards. The cards are expected to be
+// distinct (see ParseCards).
+func Evaluate(cards []Card) (*HandValue, error) {
+	if err := validateHandSize(cards, 5, 7); err != nil {
+		return nil, err
+	}
+
+	score := EvaluateHand(NewHand(cards...))
+
+	return &HandValue{
+		Category: score.Category(),
+		Score:    score,
+		Best:     bestFive(cards, score),
+	}, nil
+}
+
+// bestFive picks the five cards that make up the scored hand.
+func bestFive(cards []Card, score Score) []Card {
+	category := score.Category()
+	ranks := score.ranks()
+
+	var wanted []int
+	switch category {
+	case StraightFlush, RoyalFlush, Straight:
+		high := ranks[0]
+		if high == 3 {
+			wanted = []int{3, 2, 1, 0, NumRanks - 1}
+		} else {
+			wanted = []int{high, high - 1, high - 2, high - 3, high - 4}
+		}
+	case FourOfAKind:
+		wanted = []int{ranks[0], ranks[0], ranks[0], ranks[0], ranks[1]}
+	case FullHouse:
+		wanted = []int{ranks[0], ranks[0], ranks[0], ranks[1], ranks[1]}
+	case ThreeOfAKind:
+		wanted = []int{ranks[0], ranks[0], ranks[0], ranks[1], ranks[2]}
+	case TwoPair:
+		wanted = []int{ranks[0], ranks[0], ranks[1], ranks[1], ranks[2]}
+	case OnePair:
+		wanted = []int{ranks[0], ranks[0], ranks[1], ranks[2], ranks[3]}
+	default:
+		wanted = ranks[:]
+	}
+
+	suit := -1
+	if category == Flush || category == StraightFlush || category == RoyalFlush {
+		suit = flushSuit(cards)
+	}
+
+	used := make([]bool, len(cards))
+	best := make([]Card, 0, 5)
+	for _, rank := range wanted {
+		for i, card := range cards {
+			if used[i] || card.Rank() != rank || (suit >= 0 && card.Suit() != suit) {
+				continue
+			}
+			used[i] = true
+			best = append(best, card)
+			break
+		}
+	}
+
+	return best
+}
+
+func flushSuit(cards []Card) int {
+	counts := [NumSuits]int{}
+	for _, card := range cards {
+		counts[card.Suit()]++
+		if counts[card.Suit()] >= 5 {
+			return card.Suit()
+		}
+	}
+	return -1
+}
@@ -0,0 +1,171 @@
+package poker
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	hands := map[string]struct {
+		category HandCategory
+		best     string
+	}{
+		"AS,KS,QS,JS,10S":       {RoyalFlush, "AS,KS,QS,JS,10S"},
+		"9H,KH,QH,JH,10H,2C":    {StraightFlush, "KH,QH,JH,10H,9H"},
+		"AD,2D,3D,4D,5D,KC,KH":  {StraightFlush, "5D,4D,3D,2D,AD"},
+		"7C,7D,7H,7S,KD,KH,KS":  {FourOfAKind, "7C,7D,7H,7S,KD"},
+		"3C,3D,3H,9S,9D,9H":     {FullHouse, "9S,9D,9H,3C,3D"},
+		"2H,5H,9H,JH,QH,AH,KH":  {Flush, "AH,KH,QH,JH,9H"},
+		"AC,2D,3H,4S,5C":        {Straight, "5C,4S,3H,2D,AC"},
+		"10C,JD,QH,KS,AC,9D,8H": {Straight, "AC,KS,QH,JD,10C"},
+		"QC,QD,QH,2S,5C,9D,8H":  {ThreeOfAKind, "QC,QD,QH,9D,8H"},
+		"QC,QD,5H,5S,9C,9D,8H":  {TwoPair, "QC,QD,9C,9D,8H"},
+		"AC,AD,5H,4S,9C,JD,8H":  {OnePair, "AC,AD,JD,9C,8H"},
+		"AC,KD,5H,4S,9C,JD,8H":  {HighCard, "AC,KD,JD,9C,8H"},
+	}
+
+	for codes, expected := range hands {
+		value, err := Evaluate(MustParseCards(codes))
+		if err != nil {
+			t.Fatalf("Expected to evaluate %s, but got error: %s", codes, err.Error())
+		}
+		if value.Category != expected.category {
+			t.Errorf("Expected %s to be %s, but got %s.", codes, expected.category, value.Category)
+		}
+		if best := strings.Join(Codes(value.Best), ","); best != expected.best {
+			t.Errorf("Expected the best five cards of %s to be %s, but got %s.", codes, expected.best, best)
+		}
+	}
+}
+
+func TestEvaluate_TieBreaking(t *testing.T) {
+	ordered := []string{
+		"2C,3D,4H,5S,7C",  // lowest high card
+		"AC,QD,JH,9S,8C",  // high card with kickers
+		"AC,KD,JH,9S,8C",  // better high card
+		"2C,2D,4H,5S,7C",  // lowest pair
+		"2C,2D,AH,5S,7C",  // pair with better kicker
+		"KC,KD,2H,3S,4C",  // higher pair
+		"KC,KD,QH,QS,2C",  // two pair
+		"KC,KD,QH,QS,3C",  // two pair, better kicker
+		"AC,AD,2H,2S,3C",  // higher two pair
+		"3C,3D,3H,AS,KC",  // three of a kind
+		"AC,2D,3H,4S,5C",  // wheel, the lowest straight
+		"2C,3D,4H,5S,6C",  // six high straight
+		"2H,4H,6H,8H,10H", // flush
+		"2H,4H,6H,8H,JH",  // better flush
+		"2C,2D,2H,3S,3C",  // full house
+		"3C,3D,3H,2S,2C",  // better full house
+		"4C,4D,4H,4S,2C",  // four of a kind
+		"4C,4D,4H,4S,3C",  // four of a kind, better kicker
+		"AD,2D,3D,4D,5D",  // steel wheel
+		"9D,10D,JD,QD,KD", // king high straight flush
+		"10D,JD,QD,KD,AD", // royal flush
+	}
+
+	var previous Score
+	for i, codes := range ordered {
+		value, err := Evaluate(MustParseCards(codes))
+		if err != nil {
+			t.Fatalf("Expected to evaluate %s, but got error: %s", codes, err.Error())
+		}
+		if i > 0 && value.Score <= previous {
+			t.Errorf("Expected %s to beat %s.", codes, ordered[i-1])
+		}
+		previous = value.Score
+	}
+
+	tie1, _ := Evaluate(MustParseCards("AC,KD,JH,9S,8C,2D,3D"))
+	tie2, _ := Evaluate(MustParseCards("AH,KS,JD,9C,8D,2C,4H"))
+	if tie1.Score != tie2.Score {
+		t.Error("Expected hands with the same best five ranks to tie.")
+	}
+}
+
+func TestEvaluate_InvalidHandSize(t *testing.T) {
+	if _, err := Evaluate(MustParseCards("AC,KD,JH,9S")); err == nil {
+		t.Error("Expected an error for a hand with 4 cards.")
+	}
+	if _, err := Evaluate(MustParseCards("AC,KD,JH,9S,8C,7C,6C,5C")); err == nil {
+		t.Error("Expected an error for a hand with 8 cards.")
+	}
+}
+
+// TestEvaluateHand_AllFiveCardHands checks the category frequencies over all 2,598,960 five-card hands.
+func TestEvaluateHand_AllFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the evaluation of all five-card hands in short mode.")
+	}
+
+	expected := map[HandCategory]int{
+		HighCard:      1302540,
+		OnePair:       1098240,
+		TwoPair:       123552,
+		ThreeOfAKind:  54912,
+		Straight:      10200,
+		Flush:         5108,
+		FullHouse:     3744,
+		FourOfAKind:   624,
+		StraightFlush: 36,
+		RoyalFlush:    4,
+	}
+
+	counts := map[HandCategory]int{}
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			for c := b + 1; c < 52; c++ {
+				for d := c + 1; d < 52; d++ {
+					for e := d + 1; e < 52; e++ {
+						hand := NewHand(Card(a), Card(b), Card(c), Card(d), Card(e))
+						counts[EvaluateHand(hand).Category()]++
+					}
+				}
+			}
+		}
+	}
+
+	for category, count := range expected {
+		if counts[category] != count {
+			t.Errorf("Expected %d hands of %s, but got %d.", count, category, counts[category])
+		}
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	cards, err := ParseCards("AS", "10H", "2C")
+	if err != nil {
+		t.Fatalf("Expected to parse the cards, but got error: %s", err.Error())
+	}
+	if cards[0].Rank() != 12 || cards[1].Rank() != 8 || cards[2].Rank() != 0 {
+		t.Error("Expected the ranks to go from two (0) to ace (12).")
+	}
+	if strings.Join(Codes(cards), ",") != "AS,10H,2C" {
+		t.Error("Expected the cards to keep their codes.")
+	}
+
+	if _, err := ParseCards("AS", "AS"); err == nil {
+		t.Error("Expected an error for duplicate cards.")
+	}
+	if _, err := ParseCards("AS", "1X"); err == nil {
+		t.Error("Expected an error for invalid cards.")
+	}
+}
+
+func BenchmarkEvaluateHand(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	hands := make([]Hand, 1024)
+	for i := range hands {
+		deck := random.Perm(52)
+		for _, card := range deck[:7] {
+			hands[i] |= Card(card).Mask()
+		}
+	}
+	b.ResetTimer()
+
+	var score Score
+	for i := 0; i < b.N; i++ {
+		score += EvaluateHand(hands[i&1023])
+	}
+	_ = score
+}
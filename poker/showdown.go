@@ -0,0 +1,165 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+// Poker games supported by the showdown. The game determines how the hole cards and the board make up a hand.
+const (
+	// Holdem is Texas Hold'em: the best five cards out of the hole cards and the board.
+	Holdem = "holdem"
+
+	// Omaha is Omaha Hold'em: exactly two of the hole cards and exactly three cards of the board.
+	Omaha = "omaha"
+)
+
+// BestHand evaluates the best hand of a player with the given hole cards and board in the given game.
+// Returns a ValidationError if the game is not supported or the number of cards is not valid for the game.
+func BestHand(game string, hole, board []Card) (*HandValue, error) {
+	switch game {
+	case Holdem, "":
+		cards := make([]Card, 0, len(hole)+len(board))
+		cards = append(cards, hole...)
+		cards = append(cards, board...)
+		return Evaluate(cards)
+	case Omaha:
+		return EvaluateOmaha(hole, board)
+	default:
+		return nil, errors.ValidationError(fmt.Sprintf("unsupported game: %s", game), nil)
+	}
+}
+
+// EvaluateOmaha evaluates an Omaha hand: the best hand made of exactly two of the hole cards (four or five) and
+// exactly three cards of the board (three to five).
+func EvaluateOmaha(hole, board []Card) (*HandValue, error) {
+	if err := validateHandSize(hole, 4, 5); err != nil {
+		return nil, errors.ValidationError(fmt.Sprintf("omaha hole cards: %s", err.Error()), err)
+	}
+	if err := validateHandSize(board, 3, 5); err != nil {
+		return nil, errors.ValidationError(fmt.Sprintf("omaha board: %s", err.Error()), err)
+	}
+
+	var best Score
+	var bestCards []Card
+
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						score := EvaluateHand(NewHand(hole[i], hole[j], board[a], board[b], board[c]))
+						if bestCards == nil || score > best {
+							best = score
+							bestCards = []Card{hole[i], hole[j], board[a], board[b], board[c]}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return &HandValue{
+		Category: best.Category(),
+		Score:    best,
+		Best:     bestFive(bestCards, best),
+	}, nil
+}
+
+// PlayerResult is the showdown result of a single player.
+type PlayerResult struct {
+	// Player is the index of the player in the showdown.
+	Player int
+
+	// Hand is the best hand of the player.
+	Hand *HandValue
+
+	// Rank is the place of the player in the showdown, starting at 1 for the winners. Tied players share the rank.
+	Rank int
+
+	// Share is the part of the pot won by the player.
+	Share int64
+}
+
+// ShowdownResult is the result of a showdown between multiple players.
+type ShowdownResult struct {
+	// Players holds the result of each player, in the order of the players in the showdown.
+	Players []PlayerResult
+
+	// Ranking holds the indexes of the players ordered from the best to the worst hand.
+	Ranking []int
+
+	// Winners holds the indexes of the players with the best hand. More than one winner means a split pot.
+	Winners []int
+}
+
+// Showdown compares the hands of the players, made of their hole cards and the common board, and splits the pot
+// between the players with the best hand. If the pot cannot be split evenly, the odd chips are given one by one
+// to the winners, in the order of the players.
+// Returns a ValidationError if there are less than two players, any card appears more than once, or the cards of
+// a player do not make a valid hand for the game.
+func Showdown(game string, hands [][]Card, board []Card, pot int64) (*ShowdownResult, error) {
+	if len(hands) < 2 {
+		return nil, errors.ValidationError("a showdown needs at least two players", nil)
+	}
+
+	seen := NewHand(board...)
+	if len(board) != len(seen.Cards()) {
+		return nil, errors.ValidationError("duplicate cards on the board", nil)
+	}
+
+	result := &ShowdownResult{}
+
+	for player, hole := range hands {
+		holeHand := NewHand(hole...)
+		if len(hole) != len(holeHand.Cards()) || seen&holeHand != 0 {
+			return nil, errors.ValidationError(fmt.Sprintf("player %d holds a card that is already dealt", player), nil)
+		}
+		seen |= holeHand
+
+		hand, err := BestHand(game, hole, board)
+		if err != nil {
+			return nil, errors.ValidationError(fmt.Sprintf("player %d: %s", player, err.Error()), err)
+		}
+
+		result.Players = append(result.Players, PlayerResult{
+			Player: player,
+			Hand:   hand,
+		})
+		result.Ranking = append(result.Ranking, player)
+	}
+
+	sort.SliceStable(result.Ranking, func(i, j int) bool {
+		return result.Players[result.Ranking[i]].Hand.Score > result.Players[result.Ranking[j]].Hand.Score
+	})
+
+	rank := 0
+	var previous Score
+	for i, player := range result.Ranking {
+		score := result.Players[player].Hand.Score
+		if i == 0 || score != previous {
+			rank = i + 1
+		}
+		result.Players[player].Rank = rank
+		previous = score
+	}
+
+	for player := range result.Players {
+		if result.Players[player].Rank == 1 {
+			result.Winners = append(result.Winners, player)
+		}
+	}
+
+	share := pot / int64(len(result.Winners))
+	oddChips := pot % int64(len(result.Winners))
+	for i, player := range result.Winners {
+		result.Players[player].Share = share
+		if int64(i) < oddChips {
+			result.Players[player].Share++
+		}
+	}
+
+	return result, nil
+}
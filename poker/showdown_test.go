@@ -0,0 +1,87 @@
+package poker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShowdown(t *testing.T) {
+	result, err := Showdown(Holdem, [][]Card{
+		MustParseCards("AS,AD"),
+		MustParseCards("KS,KD"),
+		MustParseCards("7C,8C"),
+	}, MustParseCards("2C,9C,10C,KH,3D"), 100)
+	if err != nil {
+		t.Fatalf("Expected the showdown to succeed, but got error: %s", err.Error())
+	}
+
+	if len(result.Winners) != 1 || result.Winners[0] != 2 {
+		t.Fatalf("Expected the flush (player 2) to win, but got winners: %v", result.Winners)
+	}
+	if result.Players[2].Share != 100 || result.Players[0].Share != 0 {
+		t.Error("Expected the winner to take the whole pot.")
+	}
+	if result.Ranking[0] != 2 || result.Ranking[1] != 1 || result.Ranking[2] != 0 {
+		t.Errorf("Expected the ranking to be flush, set of kings, pair of aces, but got: %v", result.Ranking)
+	}
+	if result.Players[1].Rank != 2 || result.Players[0].Rank != 3 {
+		t.Error("Expected the players to be ranked by their hands.")
+	}
+}
+
+func TestShowdown_SplitPot(t *testing.T) {
+	result, err := Showdown(Holdem, [][]Card{
+		MustParseCards("AS,2D"),
+		MustParseCards("AH,3D"),
+		MustParseCards("KS,KD"),
+	}, MustParseCards("AC,QC,JD,9H,8S"), 101)
+	if err != nil {
+		t.Fatalf("Expected the showdown to succeed, but got error: %s", err.Error())
+	}
+
+	if len(result.Winners) != 2 {
+		t.Fatalf("Expected the two players with a pair of aces to split the pot, but got winners: %v", result.Winners)
+	}
+	if result.Players[0].Share != 51 || result.Players[1].Share != 50 || result.Players[2].Share != 0 {
+		t.Errorf("Expected the pot to be split 51/50, but got %d/%d.", result.Players[0].Share, result.Players[1].Share)
+	}
+	if result.Players[0].Rank != 1 || result.Players[1].Rank != 1 || result.Players[2].Rank != 3 {
+		t.Error("Expected the tied players to share the first place.")
+	}
+}
+
+func TestShowdown_Omaha(t *testing.T) {
+	// Player 0 has four spades, but needs exactly two of them, so no flush with two spades on the board.
+	result, err := Showdown(Omaha, [][]Card{
+		MustParseCards("AS,KS,QS,JS"),
+		MustParseCards("9D,9H,4C,5C"),
+	}, MustParseCards("2S,3S,9C,7D,8H"), 10)
+	if err != nil {
+		t.Fatalf("Expected the showdown to succeed, but got error: %s", err.Error())
+	}
+
+	if result.Players[0].Hand.Category != HighCard {
+		t.Errorf("Expected player 0 to only have high card, but got %s.", result.Players[0].Hand.Category)
+	}
+	if result.Players[1].Hand.Category != ThreeOfAKind || result.Winners[0] != 1 {
+		t.Errorf("Expected player 1 to win with three nines, but got %s.", result.Players[1].Hand.Category)
+	}
+	if best := strings.Join(Codes(result.Players[1].Hand.Best), ","); best != "9D,9H,9C,8H,7D" {
+		t.Errorf("Expected the best hand of player 1 to be 9D,9H,9C,8H,7D, but got %s.", best)
+	}
+}
+
+func TestShowdown_Invalid(t *testing.T) {
+	if _, err := Showdown(Holdem, [][]Card{MustParseCards("AS,AD")}, MustParseCards("2C,9C,10C"), 0); err == nil {
+		t.Error("Expected an error for a single player.")
+	}
+	if _, err := Showdown(Holdem, [][]Card{MustParseCards("AS,AD"), MustParseCards("AS,KD")}, MustParseCards("2C,9C,10C"), 0); err == nil {
+		t.Error("Expected an error for a card dealt twice.")
+	}
+	if _, err := Showdown(Holdem, [][]Card{MustParseCards("AS,AD"), MustParseCards("KS,KD")}, MustParseCards("2C,9C"), 0); err == nil {
+		t.Error("Expected an error for a hand with less than five cards.")
+	}
+	if _, err := Showdown("stud", [][]Card{MustParseCards("AS,AD"), MustParseCards("KS,KD")}, MustParseCards("2C,9C,10C"), 0); err == nil {
+		t.Error("Expected an error for an unsupported game.")
+	}
+}
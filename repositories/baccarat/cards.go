@@ -0,0 +1,32 @@
+package baccarat
+
+import (
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// rankPoints maps the rank codes to the baccarat value of the card. The ranks are ordered from the ace to the king
+// (see deck_repo.Ranks), so the value is the position of the rank, and 0 for the tens and the face cards.
+var rankPoints = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		if points := i + 1; points < 10 {
+			rankPoints[rank] = points
+		}
+	}
+}
+
+// Points returns the baccarat value of a card: the ace counts 1, the number cards their number and the tens and
+// face cards count 0.
+func Points(card *deck_repo.Card) int {
+	return rankPoints[card.Value[:len(card.Value)-1]]
+}
+
+// Total returns the total of the cards: the sum of their values, modulo 10.
+func Total(cards []*deck_repo.Card) int {
+	total := 0
+	for _, card := range cards {
+		total += Points(card)
+	}
+	return total % 10
+}
@@ -0,0 +1,80 @@
+package baccarat
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories/versioned"
+)
+
+// DBShoeRepository implements ShoeRepository storing the shoes in the database.
+type DBShoeRepository struct {
+	db *gorm.DB
+}
+
+// CreateShoe stores a new shoe. If the shoe has no ID, a new ID is generated.
+func (r *DBShoeRepository) CreateShoe(shoe *Shoe) (*Shoe, error) {
+	if shoe.ID == "" {
+		shoe.ID = uuid.NewString()
+	}
+	for _, round := range shoe.Rounds {
+		round.ShoeID = shoe.ID
+	}
+
+	if result := r.db.Create(shoe); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return shoe, nil
+}
+
+// GetShoe looks up a shoe by its ID, with the rounds dealt from it ordered by number.
+// If there is no shoe with the given ID, then a NotFoundError is returned.
+func (r *DBShoeRepository) GetShoe(shoeID string) (*Shoe, error) {
+	shoe := &Shoe{}
+
+	result := r.db.Preload("Rounds", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number")
+	}).Where("id=?", shoeID).First(shoe)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such shoe", nil)
+		}
+		return nil, result.Error
+	}
+
+	return shoe, nil
+}
+
+// AddRound stores the changed shoe and the new round within a single transaction. The shoe is updated only if its
+// version was not changed since it was read, otherwise a BadRequestError is returned.
+func (r *DBShoeRepository) AddRound(shoe *Shoe, round *Round) (*Shoe, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := versioned.Update(tx, shoe, &shoe.Version, "shoe", shoe.ID, "Rounds", "CreatedAt"); err != nil {
+			return err
+		}
+
+		round.ShoeID = shoe.ID
+		return tx.Create(round).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shoe, nil
+}
+
+// NewDBShoeRepository creates a new ShoeRepository with the given database connection.
+func NewDBShoeRepository(db *gorm.DB) ShoeRepository {
+	return &DBShoeRepository{
+		db: db,
+	}
+}
+
+// AutoMigrateBaccaratModels performs an automatic migration of the baccarat Gorm models in the database.
+func AutoMigrateBaccaratModels(db *gorm.DB) error {
+	return db.AutoMigrate(&Shoe{}, &Round{})
+}
@@ -0,0 +1,46 @@
+package baccarat
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestShoeRepository(t *testing.T) {
+	shoes, decks := setupTest(t)
+	shoe := newTestShoe(t, decks, "9H,5C,KS,2D,6H,2C,KC,3D,4S")
+
+	if _, err := shoes.CreateShoe(shoe); err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+	for i := 0; i < 2; i++ {
+		round := mustPlay(t, shoe, Bets{Banker: 10}, decks)
+		if _, err := shoes.AddRound(shoe, round); err != nil {
+			t.Fatalf("Failed to add round: %s", err.Error())
+		}
+	}
+
+	stored, err := shoes.GetShoe(shoe.ID)
+	if err != nil {
+		t.Fatalf("Failed to get shoe: %s", err.Error())
+	}
+	if stored.Version != 2 || stored.RoundNumber != 2 || stored.CardsDealt != 9 || len(stored.Rounds) != 2 {
+		t.Fatalf("Expected the stored shoe to have two rounds, but got: %+v", stored)
+	}
+	if stored.Rounds[0].Number != 1 || stored.Rounds[0].Outcome != PlayerOutcome || stored.Rounds[1].BankerCards != "2C,3D,4S" {
+		t.Errorf("Expected the rounds to be stored in order, but got: %+v, %+v", stored.Rounds[0], stored.Rounds[1])
+	}
+	if stored.Rounds[1].Bets.Banker != 10 {
+		t.Errorf("Expected the bets to be stored, but got: %+v", stored.Rounds[1].Bets)
+	}
+
+	// The shoe read before the update is stale.
+	shoe.Version = 0
+	if _, err := shoes.AddRound(shoe, &Round{Number: 3}); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := shoes.GetShoe("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing shoe, but got: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package baccarat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// DefaultDecks is the number of decks in a new shoe, unless given otherwise.
+const DefaultDecks = 8
+
+// CutCardFromEnd is the number of cards left in the shoe behind the cut card. It is more than the six cards a round
+// may take, so a round started before the cut card always completes.
+const CutCardFromEnd = 16
+
+// Payouts of the winning bets, to one.
+const (
+	// TiePayout is the payout of a tie bet, 8:1.
+	TiePayout = 8
+
+	// PairPayout is the payout of a pair bet, 11:1.
+	PairPayout = 11
+
+	// BankerCommission is the commission in percent taken from the banker bet when it wins, paying 0.95:1.
+	BankerCommission = 5
+)
+
+// NewShoe creates a new shoe with the given number of decks and a shuffled deck created with the DeckRepository.
+// Returns a ValidationError if the number of decks is not between 1 and deck_repo.MaxPacks.
+func NewShoe(decks int, deckRepository deck_repo.DeckRepository) (*Shoe, error) {
+	if decks < 1 || decks > deck_repo.MaxPacks {
+		return nil, errors.ValidationError(fmt.Sprintf("the number of decks must be between 1 and %d", deck_repo.MaxPacks), nil)
+	}
+
+	deck, err := deckRepository.CreateDeck(&deck_repo.Deck{
+		Shuffled: true,
+		Packs:    decks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Shoe{
+		Decks:   decks,
+		DeckID:  deck.ID,
+		CutCard: deck.Remaining - CutCardFromEnd,
+		Rounds:  []*Round{},
+	}, nil
+}
+
+// PlayRound deals a round from the shoe with the given bets: two cards to the player and to the banker, and a third
+// card to either according to the drawing rules. The round is added to the rounds of the shoe, and the shoe is
+// finished once the cut card comes out.
+// Returns a ValidationError if a bet is negative, and a BadRequestError if the shoe is finished.
+func PlayRound(shoe *Shoe, bets Bets, deckRepository deck_repo.DeckRepository) (*Round, error) {
+	if shoe.Finished {
+		return nil, errors.BadRequestError(fmt.Sprintf("the shoe %s is finished, please start a new shoe", shoe.ID), nil)
+	}
+	for _, bet := range []int64{bets.Player, bets.Banker, bets.Tie, bets.PlayerPair, bets.BankerPair} {
+		if bet < 0 {
+			return nil, errors.ValidationError("the bets must not be negative", nil)
+		}
+	}
+
+	cards, err := draw(shoe, deckRepository, 4)
+	if err != nil {
+		return nil, err
+	}
+	player := []*deck_repo.Card{cards[0], cards[2]}
+	banker := []*deck_repo.Card{cards[1], cards[3]}
+
+	round := &Round{
+		ShoeID:     shoe.ID,
+		Number:     shoe.RoundNumber + 1,
+		Natural:    Total(player) >= 8 || Total(banker) >= 8,
+		PlayerPair: isPair(player),
+		BankerPair: isPair(banker),
+		Bets:       bets,
+	}
+
+	if !round.Natural {
+		playerThird := -1
+		if Total(player) <= 5 {
+			cards, err := draw(shoe, deckRepository, 1)
+			if err != nil {
+				return nil, err
+			}
+			player = append(player, cards[0])
+			playerThird = Points(cards[0])
+		}
+		if bankerDraws(Total(banker), playerThird) {
+			cards, err := draw(shoe, deckRepository, 1)
+			if err != nil {
+				return nil, err
+			}
+			banker = append(banker, cards[0])
+		}
+	}
+
+	round.PlayerCards = joinCodes(player)
+	round.BankerCards = joinCodes(banker)
+	round.PlayerTotal = Total(player)
+	round.BankerTotal = Total(banker)
+	switch {
+	case round.PlayerTotal > round.BankerTotal:
+		round.Outcome = PlayerOutcome
+	case round.BankerTotal > round.PlayerTotal:
+		round.Outcome = BankerOutcome
+	default:
+		round.Outcome = TieOutcome
+	}
+
+	shoe.RoundNumber = round.Number
+	shoe.Finished = shoe.CardsDealt >= shoe.CutCard
+	shoe.Rounds = append(shoe.Rounds, round)
+	return round, nil
+}
+
+// bankerDraws applies the third card rule of the banker, given the total of the banker and the value of the third
+// card of the player, or -1 if the player stood.
+func bankerDraws(total, playerThird int) bool {
+	if playerThird < 0 {
+		return total <= 5
+	}
+	switch total {
+	case 0, 1, 2:
+		return true
+	case 3:
+		return playerThird != 8
+	case 4:
+		return playerThird >= 2 && playerThird <= 7
+	case 5:
+		return playerThird >= 4 && playerThird <= 7
+	case 6:
+		return playerThird == 6 || playerThird == 7
+	default:
+		return false
+	}
+}
+
+// Payouts returns the net result of each of the bets of the round: the winnings if the bet won, the bet as a
+// negative amount if it lost, and zero for the player and banker bets pushed on a tie.
+func Payouts(round *Round) Bets {
+	payouts := Bets{}
+
+	switch round.Outcome {
+	case PlayerOutcome:
+		payouts.Player = round.Bets.Player
+		payouts.Banker = -round.Bets.Banker
+	case BankerOutcome:
+		payouts.Player = -round.Bets.Player
+		payouts.Banker = round.Bets.Banker * (100 - BankerCommission) / 100
+	}
+	if round.Outcome == TieOutcome {
+		payouts.Tie = round.Bets.Tie * TiePayout
+	} else {
+		payouts.Tie = -round.Bets.Tie
+	}
+	payouts.PlayerPair = pairPayout(round.PlayerPair, round.Bets.PlayerPair)
+	payouts.BankerPair = pairPayout(round.BankerPair, round.Bets.BankerPair)
+
+	return payouts
+}
+
+func pairPayout(pair bool, bet int64) int64 {
+	if pair {
+		return bet * PairPayout
+	}
+	return -bet
+}
+
+func isPair(cards []*deck_repo.Card) bool {
+	first, second := cards[0].Value, cards[1].Value
+	return first[:len(first)-1] == second[:len(second)-1]
+}
+
+// draw deals cards from the shoe.
+func draw(shoe *Shoe, deckRepository deck_repo.DeckRepository, numCards int) ([]*deck_repo.Card, error) {
+	cards, err := deckRepository.DrawCards(shoe.DeckID, numCards)
+	if err != nil {
+		return nil, err
+	}
+	shoe.CardsDealt += numCards
+	return cards, nil
+}
+
+func joinCodes(cards []*deck_repo.Card) string {
+	codes := make([]string, 0, len(cards))
+	for _, card := range cards {
+		codes = append(codes, card.Value)
+	}
+	return strings.Join(codes, ",")
+}
@@ -0,0 +1,199 @@
+package baccarat
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTest(t *testing.T) (ShoeRepository, deck_repo.DeckRepository) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+	if err := AutoMigrateBaccaratModels(db); err != nil {
+		t.Fatalf("Failed to migrate baccarat models: %s", err.Error())
+	}
+
+	return NewDBShoeRepository(db), deck_repo.NewDBDeckRepository(db)
+}
+
+// newTestShoe creates a shoe dealing the given cards, in order: the player gets the first and the third card, the
+// banker the second and the fourth, and the third cards are drawn after these.
+func newTestShoe(t *testing.T, decks deck_repo.DeckRepository, cards string) *Shoe {
+	shoe, err := NewShoe(1, decks)
+	if err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+
+	deck, err := decks.CreateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards(cards)})
+	if err != nil {
+		t.Fatalf("Failed to create deck: %s", err.Error())
+	}
+	shoe.DeckID = deck.ID
+	return shoe
+}
+
+func mustPlay(t *testing.T, shoe *Shoe, bets Bets, decks deck_repo.DeckRepository) *Round {
+	round, err := PlayRound(shoe, bets, decks)
+	if err != nil {
+		t.Fatalf("Failed to play round: %s", err.Error())
+	}
+	return round
+}
+
+func TestTotal(t *testing.T) {
+	tests := []struct {
+		cards    string
+		expected int
+	}{
+		{"AS,8H", 9},
+		{"KS,QH", 0},
+		{"10D,5C", 5},
+		{"7S,8H", 5},
+		{"9S,9H,9D", 7},
+	}
+	for _, test := range tests {
+		if total := Total(deck_repo.AsCards(test.cards)); total != test.expected {
+			t.Errorf("Expected the total of %s to be %d, but got %d.", test.cards, test.expected, total)
+		}
+	}
+}
+
+func TestBankerDraws(t *testing.T) {
+	// the third card rule of the banker, by the total of the banker, for the third card of the player from 0 to 9
+	tableau := map[int]string{
+		0: "DDDDDDDDDD",
+		1: "DDDDDDDDDD",
+		2: "DDDDDDDDDD",
+		3: "DDDDDDDDSD",
+		4: "SSDDDDDDSS",
+		5: "SSSSDDDDSS",
+		6: "SSSSSSDDSS",
+		7: "SSSSSSSSSS",
+	}
+	for total, row := range tableau {
+		for playerThird, action := range row {
+			if draws := bankerDraws(total, playerThird); draws != (action == 'D') {
+				t.Errorf("Expected the banker with %d to draw=%t on a third card of %d.", total, action == 'D', playerThird)
+			}
+		}
+	}
+
+	for total := 0; total <= 7; total++ {
+		if draws := bankerDraws(total, -1); draws != (total <= 5) {
+			t.Errorf("Expected the banker with %d to draw=%t when the player stands.", total, total <= 5)
+		}
+	}
+}
+
+func TestPlayRound(t *testing.T) {
+	_, decks := setupTest(t)
+
+	tests := []struct {
+		name    string
+		cards   string
+		player  string
+		banker  string
+		outcome string
+		natural bool
+	}{
+		{"natural", "9H,5C,KS,2D,4S", "9H,KS", "5C,2D", PlayerOutcome, true},
+		{"player stands, banker draws", "6H,2C,KS,3D,4S", "6H,KS", "2C,3D,4S", BankerOutcome, false},
+		{"banker 3 stands on an 8", "2H,3C,3S,KD,8S,AC", "2H,3S,8S", "3C,KD", TieOutcome, false},
+		{"banker 6 draws on a 6", "AH,3C,4S,3D,6S,2C", "AH,4S,6S", "3C,3D,2C", BankerOutcome, false},
+		{"banker 4 stands on an ace", "2H,2C,2S,2D,AS,9C", "2H,2S,AS", "2C,2D", PlayerOutcome, false},
+		{"banker 7 stands", "2H,4C,3S,3D,3H,9C", "2H,3S,3H", "4C,3D", PlayerOutcome, false},
+	}
+
+	for _, test := range tests {
+		shoe := newTestShoe(t, decks, test.cards)
+		round := mustPlay(t, shoe, Bets{}, decks)
+
+		if round.PlayerCards != test.player || round.BankerCards != test.banker {
+			t.Errorf("%s: expected player %s and banker %s, but got %s and %s.", test.name, test.player, test.banker,
+				round.PlayerCards, round.BankerCards)
+		}
+		if round.Outcome != test.outcome || round.Natural != test.natural {
+			t.Errorf("%s: expected %s (natural=%t), but got %s (natural=%t).", test.name, test.outcome, test.natural,
+				round.Outcome, round.Natural)
+		}
+		if shoe.CardsDealt != len(round.Player())+len(round.Banker()) || shoe.RoundNumber != 1 || round.Number != 1 {
+			t.Errorf("%s: expected the dealt cards counted on the shoe, but got %+v", test.name, shoe)
+		}
+	}
+}
+
+func TestPlayRound_Pairs(t *testing.T) {
+	_, decks := setupTest(t)
+
+	round := mustPlay(t, newTestShoe(t, decks, "2H,2C,2S,2D,AS,9C"), Bets{}, decks)
+	if !round.PlayerPair || !round.BankerPair {
+		t.Errorf("Expected both pairs, but got: %+v", round)
+	}
+
+	round = mustPlay(t, newTestShoe(t, decks, "9H,5C,KS,5D"), Bets{}, decks)
+	if round.PlayerPair || !round.BankerPair {
+		t.Errorf("Expected only the banker pair, but got: %+v", round)
+	}
+}
+
+func TestPayouts(t *testing.T) {
+	bets := Bets{Player: 100, Banker: 100, Tie: 10, PlayerPair: 10, BankerPair: 10}
+
+	payouts := Payouts(&Round{Outcome: BankerOutcome, BankerPair: true, Bets: bets})
+	expected := Bets{Player: -100, Banker: 95, Tie: -10, PlayerPair: -10, BankerPair: 110}
+	if payouts != expected || payouts.Net() != 85 {
+		t.Errorf("Expected %+v, but got %+v", expected, payouts)
+	}
+
+	payouts = Payouts(&Round{Outcome: TieOutcome, PlayerPair: true, Bets: bets})
+	expected = Bets{Tie: 80, PlayerPair: 110, BankerPair: -10}
+	if payouts != expected {
+		t.Errorf("Expected the player and banker bets to push on a tie, %+v, but got %+v", expected, payouts)
+	}
+
+	payouts = Payouts(&Round{Outcome: PlayerOutcome, Bets: Bets{Player: 50}})
+	if payouts.Player != 50 || payouts.Net() != 50 {
+		t.Errorf("Expected the player bet to pay 1:1, but got %+v", payouts)
+	}
+}
+
+func TestPlayRound_ShoeFinished(t *testing.T) {
+	_, decks := setupTest(t)
+
+	shoe := newTestShoe(t, decks, "9H,5C,KS,2D,9S,5D,KC,2H")
+	shoe.CutCard = 4
+	mustPlay(t, shoe, Bets{}, decks)
+	if !shoe.Finished {
+		t.Fatalf("Expected the shoe to be finished after the cut card, but got: %+v", shoe)
+	}
+	if _, err := PlayRound(shoe, Bets{}, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a finished shoe, but got: %v", err)
+	}
+}
+
+func TestPlayRound_Invalid(t *testing.T) {
+	_, decks := setupTest(t)
+
+	if _, err := NewShoe(0, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for no decks, but got: %v", err)
+	}
+	if _, err := PlayRound(newTestShoe(t, decks, "9H,5C,KS,2D"), Bets{Tie: -1}, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for a negative bet, but got: %v", err)
+	}
+
+	shoe, err := NewShoe(DefaultDecks, decks)
+	if err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+	if shoe.CutCard != DefaultDecks*52-CutCardFromEnd {
+		t.Errorf("Expected the cut card %d cards from the end, but got %d.", CutCardFromEnd, shoe.CutCard)
+	}
+}
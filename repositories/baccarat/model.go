@@ -0,0 +1,132 @@
+package baccarat
+
+import (
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Outcomes of a round: the hand with the higher total wins.
+const (
+	PlayerOutcome = "PLAYER"
+	BankerOutcome = "BANKER"
+	TieOutcome    = "TIE"
+)
+
+// Bets holds the amounts bet on each of the spots of the table.
+type Bets struct {
+	// Player is the bet on the player hand winning.
+	Player int64
+
+	// Banker is the bet on the banker hand winning.
+	Banker int64
+
+	// Tie is the bet on a tie.
+	Tie int64
+
+	// PlayerPair is the bet on the first two cards of the player being a pair.
+	PlayerPair int64
+
+	// BankerPair is the bet on the first two cards of the banker being a pair.
+	BankerPair int64
+}
+
+// Net returns the sum of the amounts on all spots.
+func (b Bets) Net() int64 {
+	return b.Player + b.Banker + b.Tie + b.PlayerPair + b.BankerPair
+}
+
+// Shoe represents the database model for a baccarat shoe, with the history of the rounds dealt from it.
+type Shoe struct {
+	// ID is a unique identifier for this shoe, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this shoe was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this shoe was last updated.
+	UpdatedAt time.Time
+
+	// Decks is the number of decks (packs) in the shoe.
+	Decks int
+
+	// DeckID is the ID of the deck holding the cards of the shoe.
+	DeckID string
+
+	// CutCard is the number of cards dealt from the shoe after which the shoe is finished.
+	CutCard int
+
+	// CardsDealt is the number of cards dealt from the shoe.
+	CardsDealt int
+
+	// RoundNumber is the number of rounds dealt from the shoe.
+	RoundNumber int
+
+	// Finished is set once the cut card came out. No more rounds are dealt from a finished shoe.
+	Finished bool
+
+	// Version is incremented with every change of the shoe, and used to detect concurrent changes.
+	Version int
+
+	// Rounds holds the rounds dealt from the shoe, ordered by number.
+	Rounds []*Round `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Shoe.
+func (s *Shoe) TableName() string {
+	return "baccarat_shoes"
+}
+
+// Round represents the database model for a round of baccarat dealt from a shoe.
+type Round struct {
+	// ShoeID is the foreign key to the shoe.
+	ShoeID string `gorm:"primaryKey"`
+
+	// Number is the number of the round in the shoe, starting at 1.
+	Number int `gorm:"primaryKey;autoIncrement:false"`
+
+	// CreatedAt is the time when this round was dealt.
+	CreatedAt time.Time
+
+	// PlayerCards holds the codes of the cards of the player, comma separated, in the order they were dealt.
+	PlayerCards string
+
+	// BankerCards holds the codes of the cards of the banker, comma separated, in the order they were dealt.
+	BankerCards string
+
+	// PlayerTotal is the total of the player hand.
+	PlayerTotal int
+
+	// BankerTotal is the total of the banker hand.
+	BankerTotal int
+
+	// Outcome is the outcome of the round, like BankerOutcome.
+	Outcome string
+
+	// Natural is set if a hand was dealt a total of 8 or 9 with the first two cards.
+	Natural bool
+
+	// PlayerPair is set if the first two cards of the player are a pair.
+	PlayerPair bool
+
+	// BankerPair is set if the first two cards of the banker are a pair.
+	BankerPair bool
+
+	// Bets holds the bets of the round.
+	Bets `gorm:"embedded;embeddedPrefix:bet_"`
+}
+
+// TableName returns the name of the database table of Round.
+func (r *Round) TableName() string {
+	return "baccarat_rounds"
+}
+
+// Player returns the cards of the player.
+func (r *Round) Player() []*deck_repo.Card {
+	return deck_repo.AsCards(r.PlayerCards)
+}
+
+// Banker returns the cards of the banker.
+func (r *Round) Banker() []*deck_repo.Card {
+	return deck_repo.AsCards(r.BankerCards)
+}
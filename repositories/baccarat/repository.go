@@ -0,0 +1,17 @@
+package baccarat
+
+// ShoeRepository defines methods for storing the baccarat shoes and their rounds.
+type ShoeRepository interface {
+
+	// CreateShoe stores a new shoe. If the shoe has no ID, a new ID is generated.
+	CreateShoe(shoe *Shoe) (*Shoe, error)
+
+	// GetShoe looks up a shoe by its ID, with the rounds dealt from it ordered by number.
+	// If there is no shoe with the given ID, then a NotFoundError is returned.
+	GetShoe(shoeID string) (*Shoe, error)
+
+	// AddRound stores the changed shoe together with a new round dealt from it. The shoe is updated only if it was
+	// not changed since it was read (see Shoe.Version), otherwise a BadRequestError is returned and the round
+	// should be dealt again.
+	AddRound(shoe *Shoe, round *Round) (*Shoe, error)
+}
@@ -0,0 +1,87 @@
+package baccarat
+
+// RoadRows is the number of rows of the road maps. The road maps have as many columns as needed.
+const RoadRows = 6
+
+// RoadEntry is an entry of a road map, placed on the grid of the road map.
+type RoadEntry struct {
+	// Round is the number of the round.
+	Round int
+
+	// Outcome is the outcome of the round, like BankerOutcome.
+	Outcome string
+
+	// Ties is the number of ties right after the round, or before the first round of the big road.
+	Ties int
+
+	// PlayerPair is set if the first two cards of the player were a pair.
+	PlayerPair bool
+
+	// BankerPair is set if the first two cards of the banker were a pair.
+	BankerPair bool
+
+	// Column is the column of the entry on the grid, starting at 0 on the left.
+	Column int
+
+	// Row is the row of the entry on the grid, starting at 0 at the top.
+	Row int
+}
+
+func newRoadEntry(round *Round, column, row int) RoadEntry {
+	return RoadEntry{
+		Round:      round.Number,
+		Outcome:    round.Outcome,
+		PlayerPair: round.PlayerPair,
+		BankerPair: round.BankerPair,
+		Column:     column,
+		Row:        row,
+	}
+}
+
+// BeadPlate returns the bead plate of the rounds: every round in order, including the ties, filling the grid column
+// by column from the top.
+func BeadPlate(rounds []*Round) []RoadEntry {
+	entries := []RoadEntry{}
+	for i, round := range rounds {
+		entries = append(entries, newRoadEntry(round, i/RoadRows, i%RoadRows))
+	}
+	return entries
+}
+
+// BigRoad returns the big road of the rounds. Every streak of player or banker wins starts a new column at the top,
+// and goes down the column. A streak longer than the column, or reaching an entry of an earlier streak, turns right
+// (the dragon tail) and goes on to the right. The ties are not entries of their own, but are counted on the entry before them.
+func BigRoad(rounds []*Round) []RoadEntry {
+	entries := []RoadEntry{}
+	occupied := map[[2]int]bool{}
+	streak, column, row, ties := -1, 0, 0, 0
+	tail := false
+
+	for _, round := range rounds {
+		if round.Outcome == TieOutcome {
+			if len(entries) == 0 {
+				ties++
+			} else {
+				entries[len(entries)-1].Ties++
+			}
+			continue
+		}
+
+		switch {
+		case len(entries) == 0 || entries[len(entries)-1].Outcome != round.Outcome:
+			streak++
+			column, row, tail = streak, 0, false
+		case !tail && row+1 < RoadRows && !occupied[[2]int{column, row + 1}]:
+			row++
+		default:
+			column, tail = column+1, true
+		}
+
+		occupied[[2]int{column, row}] = true
+		entry := newRoadEntry(round, column, row)
+		entry.Ties, ties = ties, 0
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
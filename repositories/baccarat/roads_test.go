@@ -0,0 +1,69 @@
+package baccarat
+
+import (
+	"strings"
+	"testing"
+)
+
+// roundsOf creates rounds with the outcomes given by their initials: P, B or T.
+func roundsOf(outcomes string) []*Round {
+	names := map[rune]string{'P': PlayerOutcome, 'B': BankerOutcome, 'T': TieOutcome}
+	var rounds []*Round
+	for i, outcome := range strings.ReplaceAll(outcomes, " ", "") {
+		rounds = append(rounds, &Round{Number: i + 1, Outcome: names[outcome]})
+	}
+	return rounds
+}
+
+func TestBeadPlate(t *testing.T) {
+	entries := BeadPlate(roundsOf("TBB TPP PPP PP"))
+
+	if len(entries) != 11 {
+		t.Fatalf("Expected an entry for every round, but got %d.", len(entries))
+	}
+	if entries[0].Outcome != TieOutcome || entries[0].Column != 0 || entries[0].Row != 0 {
+		t.Errorf("Expected the tie first, but got: %+v", entries[0])
+	}
+	if entries[6].Round != 7 || entries[6].Column != 1 || entries[6].Row != 0 {
+		t.Errorf("Expected the seventh round at the top of the second column, but got: %+v", entries[6])
+	}
+}
+
+func TestBigRoad(t *testing.T) {
+	entries := BigRoad(roundsOf("TBB TPP PPP PPB BBB BBB P"))
+
+	expected := []struct {
+		outcome     string
+		column, row int
+		ties        int
+	}{
+		{BankerOutcome, 0, 0, 1},
+		{BankerOutcome, 0, 1, 1},
+		{PlayerOutcome, 1, 0, 0},
+		{PlayerOutcome, 1, 1, 0},
+		{PlayerOutcome, 1, 2, 0},
+		{PlayerOutcome, 1, 3, 0},
+		{PlayerOutcome, 1, 4, 0},
+		{PlayerOutcome, 1, 5, 0},
+		// the dragon tail
+		{PlayerOutcome, 2, 5, 0},
+		{BankerOutcome, 2, 0, 0},
+		{BankerOutcome, 2, 1, 0},
+		{BankerOutcome, 2, 2, 0},
+		{BankerOutcome, 2, 3, 0},
+		{BankerOutcome, 2, 4, 0},
+		// blocked by the tail of the previous streak
+		{BankerOutcome, 3, 4, 0},
+		{BankerOutcome, 4, 4, 0},
+		{PlayerOutcome, 3, 0, 0},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, but got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, entry := range entries {
+		if entry.Outcome != expected[i].outcome || entry.Column != expected[i].column || entry.Row != expected[i].row ||
+			entry.Ties != expected[i].ties {
+			t.Errorf("Expected entry %d to be %+v, but got %+v", i, expected[i], entry)
+		}
+	}
+}
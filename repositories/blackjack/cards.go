@@ -0,0 +1,65 @@
+package blackjack
+
+import (
+	"strings"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// rankPoints maps the rank codes to the blackjack value of the card. The ranks are ordered from the ace to the king
+// (see deck_repo.Ranks), so the value is the position of the rank, up to 10 for the face cards.
+var rankPoints = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		points := i + 1
+		if points > 10 {
+			points = 10
+		}
+		rankPoints[rank] = points
+	}
+}
+
+// Points returns the blackjack value of a card: the number for the number cards, 10 for the face cards and 1 for
+// an ace (which may count as 11, see Total). Returns 0 for an invalid card.
+func Points(card *deck_repo.Card) int {
+	if card.SuitName() == "" {
+		return 0
+	}
+	return rankPoints[card.Value[:len(card.Value)-1]]
+}
+
+// Total returns the best total of the cards, counting one ace as 11 if that does not bust the hand.
+// A total is soft if an ace is counted as 11.
+func Total(cards []*deck_repo.Card) (total int, soft bool) {
+	hasAce := false
+	for _, card := range cards {
+		points := Points(card)
+		if points == 1 {
+			hasAce = true
+		}
+		total += points
+	}
+
+	if hasAce && total+10 <= 21 {
+		return total + 10, true
+	}
+	return total, false
+}
+
+// IsBlackjack returns true if the cards are a natural: an ace and a ten-valued card.
+func IsBlackjack(cards []*deck_repo.Card) bool {
+	total, _ := Total(cards)
+	return len(cards) == 2 && total == 21
+}
+
+func joinCodes(codes string, cards []*deck_repo.Card) string {
+	values := []string{}
+	if codes != "" {
+		values = append(values, codes)
+	}
+	for _, card := range cards {
+		values = append(values, card.Value)
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,90 @@
+package blackjack
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories/versioned"
+)
+
+// DBTableRepository implements TableRepository storing the tables in the database.
+type DBTableRepository struct {
+	db *gorm.DB
+}
+
+// CreateTable stores a new table. If the table has no ID, a new ID is generated.
+func (r *DBTableRepository) CreateTable(table *Table) (*Table, error) {
+	if table.ID == "" {
+		table.ID = uuid.NewString()
+	}
+	for _, hand := range table.Hands {
+		hand.TableID = table.ID
+	}
+
+	if result := r.db.Create(table); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return table, nil
+}
+
+// GetTable looks up a table by its ID, with the hands of the current round ordered by position.
+// If there is no table with the given ID, then a NotFoundError is returned.
+func (r *DBTableRepository) GetTable(tableID string) (*Table, error) {
+	table := &Table{}
+
+	result := r.db.Preload("Hands", func(db *gorm.DB) *gorm.DB {
+		return db.Order("idx")
+	}).Where("id=?", tableID).First(table)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such table", nil)
+		}
+		return nil, result.Error
+	}
+
+	return table, nil
+}
+
+// UpdateTable stores the changed table and replaces its hands within a single transaction. The table is updated
+// only if its version was not changed since it was read, otherwise a BadRequestError is returned.
+func (r *DBTableRepository) UpdateTable(table *Table) (*Table, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := versioned.Update(tx, table, &table.Version, "table", table.ID, "Hands", "CreatedAt"); err != nil {
+			return err
+		}
+
+		// Splitting adds hands and a new round starts over, so the hands are replaced as a whole.
+		if result := tx.Where("table_id=?", table.ID).Delete(&Hand{}); result.Error != nil {
+			return result.Error
+		}
+		for _, hand := range table.Hands {
+			hand.TableID = table.ID
+			if result := tx.Create(hand); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// NewDBTableRepository creates a new TableRepository with the given database connection.
+func NewDBTableRepository(db *gorm.DB) TableRepository {
+	return &DBTableRepository{
+		db: db,
+	}
+}
+
+// AutoMigrateBlackjackModels performs an automatic migration of the blackjack Gorm models in the database.
+func AutoMigrateBlackjackModels(db *gorm.DB) error {
+	return db.AutoMigrate(&Table{}, &Hand{})
+}
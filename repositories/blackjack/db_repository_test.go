@@ -0,0 +1,42 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestTableRepository(t *testing.T) {
+	tables, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "8H,10C,8D,7S,3C,10D")
+
+	if _, err := tables.CreateTable(table); err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+	mustStart(t, table, 10, decks)
+	mustAct(t, table, SplitAction, decks)
+	if _, err := tables.UpdateTable(table); err != nil {
+		t.Fatalf("Failed to update table: %s", err.Error())
+	}
+
+	stored, err := tables.GetTable(table.ID)
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err.Error())
+	}
+	if stored.Stage != PlayerStage || stored.Version != 1 || stored.Decks != 6 || len(stored.Hands) != 2 {
+		t.Fatalf("Expected the stored table to have two hands, but got: %+v", stored)
+	}
+	if stored.Hands[0].Cards != "8H,3C" || stored.Hands[1].Cards != "8D,10D" || stored.Bankroll != 980 {
+		t.Errorf("Expected the hands to be stored in order, but got: %+v, %+v", stored.Hands[0], stored.Hands[1])
+	}
+
+	// The table read before the update is stale.
+	table.Version = 0
+	if _, err := tables.UpdateTable(table); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := tables.GetTable("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing table, but got: %v", err)
+	}
+}
@@ -0,0 +1,409 @@
+package blackjack
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Player actions in a round.
+const (
+	HitAction         = "HIT"
+	StandAction       = "STAND"
+	DoubleAction      = "DOUBLE"
+	SplitAction       = "SPLIT"
+	SurrenderAction   = "SURRENDER"
+	InsuranceAction   = "INSURANCE"
+	NoInsuranceAction = "NO_INSURANCE"
+)
+
+// DefaultRules returns the house rules of a common six-deck game: the dealer stands on soft 17, double after split
+// is allowed, there is no surrender and a blackjack pays 3:2.
+func DefaultRules() Rules {
+	return Rules{
+		Decks:                      6,
+		DoubleAfterSplit:           true,
+		BlackjackPayoutNumerator:   3,
+		BlackjackPayoutDenominator: 2,
+		Penetration:                0.75,
+		MaxHands:                   4,
+		MinBet:                     1,
+	}
+}
+
+// Validate returns a ValidationError if the rules are not valid.
+func (r *Rules) Validate() error {
+	if r.Decks < 1 || r.Decks > deck_repo.MaxPacks {
+		return errors.ValidationError(fmt.Sprintf("the number of decks must be between 1 and %d", deck_repo.MaxPacks), nil)
+	}
+	if r.BlackjackPayoutNumerator <= 0 || r.BlackjackPayoutDenominator <= 0 {
+		return errors.ValidationError("the blackjack payout must be positive", nil)
+	}
+	if r.Penetration < 0.25 || r.Penetration > 0.9 {
+		return errors.ValidationError("the penetration must be between 0.25 and 0.9", nil)
+	}
+	if r.MaxHands < 1 {
+		return errors.ValidationError("the maximal number of hands must be at least 1", nil)
+	}
+	if r.MinBet < 1 || (r.MaxBet > 0 && r.MaxBet < r.MinBet) {
+		return errors.ValidationError("the minimal bet must be positive and not greater than the maximal bet", nil)
+	}
+	return nil
+}
+
+// NewTable creates a new table with the given house rules and the bankroll of the player, with a new shuffled shoe
+// kept private by the table, created with the given PrivateDecks.
+// Returns a ValidationError if the rules are not valid or the bankroll is not positive.
+func NewTable(rules Rules, bankroll int64, decks deck_repo.PrivateDecks) (*Table, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if bankroll <= 0 {
+		return nil, errors.ValidationError("the bankroll must be positive", nil)
+	}
+
+	table := &Table{
+		Rules:    rules,
+		Bankroll: bankroll,
+		Stage:    WaitingStage,
+		Hands:    []*Hand{},
+	}
+	if err := newShoe(table, decks); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// StartRound starts a new round with the given bet: deals two cards to the player and two to the dealer, the second
+// one face down. When the cut card has come out, the round is dealt from a new shuffled shoe. If the dealer shows an
+// ace, the player is offered insurance. Otherwise, the dealer checks for a blackjack, and the round is settled at
+// once if either the dealer or the player has a blackjack.
+// Returns a BadRequestError if a round is in progress or the bet is not allowed.
+func StartRound(table *Table, bet int64, decks deck_repo.PrivateDecks) error {
+	if table.Stage != WaitingStage && table.Stage != SettledStage {
+		return errors.BadRequestError("a round is in progress", nil)
+	}
+	if bet < table.MinBet {
+		return errors.BadRequestError(fmt.Sprintf("the minimal bet is %d", table.MinBet), nil)
+	}
+	if table.MaxBet > 0 && bet > table.MaxBet {
+		return errors.BadRequestError(fmt.Sprintf("the maximal bet is %d", table.MaxBet), nil)
+	}
+	if bet > table.Bankroll {
+		return errors.BadRequestError(fmt.Sprintf("the bet is greater than the bankroll of %d", table.Bankroll), nil)
+	}
+
+	if table.CardsDealt >= table.CutCard {
+		if err := newShoe(table, decks); err != nil {
+			return err
+		}
+	}
+
+	cards, err := draw(table, decks, 4)
+	if err != nil {
+		return err
+	}
+
+	table.RoundNumber++
+	table.Bankroll -= bet
+	table.Insurance = 0
+	table.InsurancePayout = 0
+	table.ActiveHand = 0
+	table.Hands = []*Hand{{
+		TableID: table.ID,
+		Bet:     bet,
+		Cards:   joinCodes("", []*deck_repo.Card{cards[0], cards[2]}),
+	}}
+	table.DealerCards = joinCodes("", []*deck_repo.Card{cards[1], cards[3]})
+
+	if Points(cards[1]) == 1 {
+		table.Stage = InsuranceStage
+		return nil
+	}
+
+	return peek(table, decks)
+}
+
+// Act applies the action of the player on the active hand. Once all hands are played, the dealer plays
+// (automatically) and the round is settled.
+// Returns a BadRequestError if the action is not allowed.
+func Act(table *Table, action string, decks deck_repo.PrivateDecks) error {
+	switch table.Stage {
+	case InsuranceStage:
+		return insure(table, action, decks)
+	case PlayerStage:
+	default:
+		return errors.BadRequestError("there is no round in progress", nil)
+	}
+
+	hand := table.Hands[table.ActiveHand]
+	cards := hand.HandCards()
+
+	switch action {
+	case HitAction:
+		if err := hit(table, hand, decks); err != nil {
+			return err
+		}
+	case StandAction:
+		hand.Done = true
+	case DoubleAction:
+		if len(cards) != 2 {
+			return errors.BadRequestError("the player can double down only on the first two cards of a hand", nil)
+		}
+		if hand.Split && !table.DoubleAfterSplit {
+			return errors.BadRequestError("double after split is not allowed", nil)
+		}
+		if hand.Bet > table.Bankroll {
+			return errors.BadRequestError("not enough chips to double down", nil)
+		}
+		table.Bankroll -= hand.Bet
+		hand.Bet *= 2
+		hand.Doubled = true
+		if err := hit(table, hand, decks); err != nil {
+			return err
+		}
+		hand.Done = true
+	case SplitAction:
+		if err := split(table, decks); err != nil {
+			return err
+		}
+	case SurrenderAction:
+		if !table.Surrender {
+			return errors.BadRequestError("surrender is not allowed", nil)
+		}
+		if len(table.Hands) != 1 || len(cards) != 2 {
+			return errors.BadRequestError("the player can surrender only the initial hand", nil)
+		}
+		hand.Surrendered = true
+		hand.Done = true
+	default:
+		return errors.BadRequestError(fmt.Sprintf("unknown action: %s", action), nil)
+	}
+
+	return advance(table, decks)
+}
+
+// VisibleDealerCards returns the cards of the dealer visible to the player: the hole card stays face down until
+// the round is settled.
+func VisibleDealerCards(table *Table) []*deck_repo.Card {
+	cards := table.Dealer()
+	if table.Stage == SettledStage || len(cards) < 2 {
+		return cards
+	}
+	return cards[:1]
+}
+
+// insure takes or declines the insurance, then lets the dealer check for a blackjack. The insurance is half
+// the bet and pays 2:1 if the dealer has a blackjack.
+func insure(table *Table, action string, decks deck_repo.PrivateDecks) error {
+	switch action {
+	case InsuranceAction:
+		insurance := table.Hands[0].Bet / 2
+		if insurance > table.Bankroll {
+			return errors.BadRequestError("not enough chips to take insurance", nil)
+		}
+		table.Bankroll -= insurance
+		table.Insurance = insurance
+	case NoInsuranceAction:
+	default:
+		return errors.BadRequestError(fmt.Sprintf("the dealer shows an ace, take %s or %s", InsuranceAction, NoInsuranceAction), nil)
+	}
+
+	if IsBlackjack(table.Dealer()) {
+		table.InsurancePayout = 2 * table.Insurance
+		table.Bankroll += 3 * table.Insurance
+	} else {
+		table.InsurancePayout = -table.Insurance
+	}
+
+	return peek(table, decks)
+}
+
+// peek checks the hands for a blackjack after the deal. A blackjack of the dealer or the player ends the round.
+func peek(table *Table, decks deck_repo.PrivateDecks) error {
+	if IsBlackjack(table.Dealer()) || IsBlackjack(table.Hands[0].HandCards()) {
+		table.Hands[0].Done = true
+		settle(table)
+		return nil
+	}
+
+	table.Stage = PlayerStage
+	return nil
+}
+
+// split splits the active hand into two hands, each with one card of the pair and a new second card. Split aces
+// get only one card each.
+func split(table *Table, decks deck_repo.PrivateDecks) error {
+	hand := table.Hands[table.ActiveHand]
+	cards := hand.HandCards()
+
+	if len(cards) != 2 || Points(cards[0]) != Points(cards[1]) {
+		return errors.BadRequestError("only a pair can be split", nil)
+	}
+	if len(table.Hands) >= table.MaxHands {
+		return errors.BadRequestError(fmt.Sprintf("the player can have at most %d hands", table.MaxHands), nil)
+	}
+	if hand.Bet > table.Bankroll {
+		return errors.BadRequestError("not enough chips to split", nil)
+	}
+
+	table.Bankroll -= hand.Bet
+	hand.Split = true
+	hand.Cards = cards[0].Value
+	splitHand := &Hand{
+		TableID: table.ID,
+		Bet:     hand.Bet,
+		Split:   true,
+		Cards:   cards[1].Value,
+	}
+
+	hands := append([]*Hand{}, table.Hands[:table.ActiveHand+1]...)
+	hands = append(hands, splitHand)
+	table.Hands = append(hands, table.Hands[table.ActiveHand+1:]...)
+	for i, hand := range table.Hands {
+		hand.Idx = i
+	}
+
+	aces := Points(cards[0]) == 1
+	for _, splitHand := range []*Hand{hand, splitHand} {
+		if err := hit(table, splitHand, decks); err != nil {
+			return err
+		}
+		if aces {
+			splitHand.Done = true
+		}
+	}
+
+	return nil
+}
+
+// hit deals a card to the hand. The hand is done once it reaches 21 or busts.
+func hit(table *Table, hand *Hand, decks deck_repo.PrivateDecks) error {
+	cards, err := draw(table, decks, 1)
+	if err != nil {
+		return err
+	}
+	hand.Cards = joinCodes(hand.Cards, cards)
+
+	if total, _ := Total(hand.HandCards()); total >= 21 {
+		hand.Done = true
+	}
+	return nil
+}
+
+// advance moves to the next hand that is not done. Once all hands are done, the dealer plays and the round is
+// settled.
+func advance(table *Table, decks deck_repo.PrivateDecks) error {
+	for table.ActiveHand < len(table.Hands) && table.Hands[table.ActiveHand].Done {
+		table.ActiveHand++
+	}
+	if table.ActiveHand < len(table.Hands) {
+		return nil
+	}
+
+	// The dealer draws only if there is a hand left to beat.
+	for _, hand := range table.Hands {
+		if total, _ := Total(hand.HandCards()); !hand.Surrendered && total <= 21 {
+			if err := playDealer(table, decks); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	settle(table)
+	return nil
+}
+
+// playDealer draws cards for the dealer until 17 or more, hitting a soft 17 if the rules say so.
+func playDealer(table *Table, decks deck_repo.PrivateDecks) error {
+	for {
+		total, soft := Total(table.Dealer())
+		if total > 17 || (total == 17 && !(soft && table.HitSoft17)) {
+			return nil
+		}
+
+		cards, err := draw(table, decks, 1)
+		if err != nil {
+			return err
+		}
+		table.DealerCards = joinCodes(table.DealerCards, cards)
+	}
+}
+
+// settle settles the bets of all hands against the hand of the dealer.
+func settle(table *Table) {
+	dealerTotal, _ := Total(table.Dealer())
+	dealerBlackjack := IsBlackjack(table.Dealer())
+
+	for _, hand := range table.Hands {
+		total, _ := Total(hand.HandCards())
+		// A blackjack after a split counts as 21.
+		blackjack := IsBlackjack(hand.HandCards()) && !hand.Split
+
+		switch {
+		case hand.Surrendered:
+			hand.Outcome = SurrenderOutcome
+			hand.Payout = -(hand.Bet / 2)
+		case blackjack && dealerBlackjack:
+			hand.Outcome = PushOutcome
+			hand.Payout = 0
+		case blackjack:
+			hand.Outcome = BlackjackOutcome
+			hand.Payout = hand.Bet * table.BlackjackPayoutNumerator / table.BlackjackPayoutDenominator
+		case total > 21 || dealerBlackjack:
+			hand.Outcome = LoseOutcome
+			hand.Payout = -hand.Bet
+		case dealerTotal > 21 || total > dealerTotal:
+			hand.Outcome = WinOutcome
+			hand.Payout = hand.Bet
+		case total == dealerTotal:
+			hand.Outcome = PushOutcome
+			hand.Payout = 0
+		default:
+			hand.Outcome = LoseOutcome
+			hand.Payout = -hand.Bet
+		}
+
+		table.Bankroll += hand.Bet + hand.Payout
+		hand.Done = true
+	}
+
+	table.Stage = SettledStage
+}
+
+// newShoe replaces the shoe with a new shuffled private deck and places the cut card according to the penetration.
+func newShoe(table *Table, decks deck_repo.PrivateDecks) error {
+	shoe, shoeToken, err := decks.NewPrivateDeck(&deck_repo.Deck{
+		Shuffled: true,
+		Packs:    table.Decks,
+	})
+	if err != nil {
+		return err
+	}
+
+	table.ShoeID = shoe.ID
+	table.ShoeToken = shoeToken
+	table.CardsDealt = 0
+	table.CutCard = int(float64(shoe.Remaining) * table.Penetration)
+	return nil
+}
+
+// draw deals cards from the shoe. If the shoe runs out of cards in the middle of a round, the round goes on with
+// a new shoe.
+func draw(table *Table, decks deck_repo.PrivateDecks, numCards int) ([]*deck_repo.Card, error) {
+	if table.CardsDealt+numCards > table.Decks*len(deck_repo.NewFullDeck()) {
+		if err := newShoe(table, decks); err != nil {
+			return nil, err
+		}
+	}
+
+	cards, err := decks.Draw(table.ShoeToken, table.ShoeID, numCards)
+	if err != nil {
+		return nil, err
+	}
+	table.CardsDealt += numCards
+	return cards, nil
+}
@@ -0,0 +1,359 @@
+package blackjack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testShoeToken is the token of the dealer of every shoe created with testDecks.
+const testShoeToken = "dealer-token"
+
+// testDecks implements PrivateDecks with a DeckRepository, drawing only with testShoeToken.
+type testDecks struct {
+	deck_repo.DeckRepository
+}
+
+func (d *testDecks) NewPrivateDeck(deck *deck_repo.Deck) (*deck_repo.Deck, string, error) {
+	deck, err := d.CreateDeck(deck)
+	return deck, testShoeToken, err
+}
+
+func (d *testDecks) Draw(token, deckID string, count int) ([]*deck_repo.Card, error) {
+	if token != testShoeToken {
+		return nil, fmt.Errorf("invalid token for the deck %s", deckID)
+	}
+	return d.DrawCards(deckID, count)
+}
+
+func setupTest(t *testing.T) (TableRepository, *testDecks) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+	if err := AutoMigrateBlackjackModels(db); err != nil {
+		t.Fatalf("Failed to migrate blackjack models: %s", err.Error())
+	}
+
+	return NewDBTableRepository(db), &testDecks{deck_repo.NewDBDeckRepository(db)}
+}
+
+// newTestTable creates a table where the next round is dealt from a shoe with the given cards, in order: the
+// player gets the first and the third card, the dealer the second and the fourth.
+func newTestTable(t *testing.T, decks *testDecks, rules Rules, cards string) *Table {
+	table, err := NewTable(rules, 1000, decks)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+
+	shoe, err := decks.CreateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards(cards)})
+	if err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+	table.ShoeID = shoe.ID
+
+	return table
+}
+
+func mustStart(t *testing.T, table *Table, bet int64, decks *testDecks) {
+	if err := StartRound(table, bet, decks); err != nil {
+		t.Fatalf("Failed to start round: %s", err.Error())
+	}
+}
+
+func mustAct(t *testing.T, table *Table, action string, decks *testDecks) {
+	if err := Act(table, action, decks); err != nil {
+		t.Fatalf("Expected %s to be allowed, but got error: %s", action, err.Error())
+	}
+}
+
+func TestTotal(t *testing.T) {
+	cases := []struct {
+		cards string
+		total int
+		soft  bool
+	}{
+		{"AS,6H", 17, true},
+		{"AS,6H,10C", 17, false},
+		{"AS,AH", 12, true},
+		{"KS,QH", 20, false},
+		{"KS,QH,2C", 22, false},
+		{"AS,KD", 21, true},
+	}
+
+	for _, c := range cases {
+		total, soft := Total(deck_repo.AsCards(c.cards))
+		if total != c.total || soft != c.soft {
+			t.Errorf("Expected %s to total %d (soft: %v), but got %d (soft: %v).", c.cards, c.total, c.soft, total, soft)
+		}
+	}
+
+	if !IsBlackjack(deck_repo.AsCards("AS,KD")) || IsBlackjack(deck_repo.AsCards("7S,7D,7C")) {
+		t.Error("Expected only an ace and a ten-valued card to be a blackjack.")
+	}
+}
+
+func TestNewTable_InvalidRules(t *testing.T) {
+	_, decks := setupTest(t)
+
+	rules := DefaultRules()
+	rules.Decks = 9
+	if _, err := NewTable(rules, 100, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for too many decks, but got: %v", err)
+	}
+
+	rules = DefaultRules()
+	rules.Penetration = 1
+	if _, err := NewTable(rules, 100, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for a penetration of 1, but got: %v", err)
+	}
+
+	if _, err := NewTable(DefaultRules(), 0, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for an empty bankroll, but got: %v", err)
+	}
+}
+
+func TestNewTable_Shoe(t *testing.T) {
+	_, decks := setupTest(t)
+
+	table, err := NewTable(DefaultRules(), 100, decks)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+
+	shoe, err := decks.GetDeck(table.ShoeID)
+	if err != nil {
+		t.Fatalf("Failed to get shoe: %s", err.Error())
+	}
+	if shoe.Remaining != 6*52 || !shoe.Shuffled {
+		t.Errorf("Expected a shuffled shoe of 6 decks, but got: %+v", shoe)
+	}
+	if table.ShoeToken != testShoeToken {
+		t.Errorf("Expected the table to keep the token of the private shoe, but got %q.", table.ShoeToken)
+	}
+	if table.CutCard != 234 {
+		t.Errorf("Expected the cut card after 234 cards, but got %d.", table.CutCard)
+	}
+}
+
+func TestRound_DealerDraws(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "10H,9C,8D,7S,5H")
+
+	mustStart(t, table, 10, decks)
+	if table.Stage != PlayerStage || table.Bankroll != 990 {
+		t.Fatalf("Expected the player to act, but got: %+v", table)
+	}
+	if visible := VisibleDealerCards(table); len(visible) != 1 || visible[0].Value != "9C" {
+		t.Errorf("Expected only the upcard of the dealer to be visible, but got: %v", visible)
+	}
+
+	mustAct(t, table, StandAction, decks)
+	if table.Stage != SettledStage || table.DealerCards != "9C,7S,5H" {
+		t.Fatalf("Expected the dealer to draw to 21, but got: %+v", table)
+	}
+	if table.Hands[0].Outcome != LoseOutcome || table.Hands[0].Payout != -10 || table.Bankroll != 990 {
+		t.Errorf("Expected the hand to lose, but got: %+v (bankroll %d)", table.Hands[0], table.Bankroll)
+	}
+	if len(VisibleDealerCards(table)) != 3 {
+		t.Error("Expected all cards of the dealer to be visible once the round is settled.")
+	}
+
+	if err := Act(table, HitAction, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError when acting after the round, but got: %v", err)
+	}
+}
+
+func TestRound_HitSoft17(t *testing.T) {
+	cases := []struct {
+		hitSoft17 bool
+		dealer    string
+		outcome   string
+	}{
+		{false, "6S,AC", PushOutcome},
+		{true, "6S,AC,3H", LoseOutcome},
+	}
+
+	for _, c := range cases {
+		_, decks := setupTest(t)
+		rules := DefaultRules()
+		rules.HitSoft17 = c.hitSoft17
+		table := newTestTable(t, decks, rules, "10H,6S,7D,AC,3H")
+
+		mustStart(t, table, 10, decks)
+		mustAct(t, table, StandAction, decks)
+
+		if table.DealerCards != c.dealer || table.Hands[0].Outcome != c.outcome {
+			t.Errorf("Expected the dealer to end with %s (H17: %v), but got %s and %s.",
+				c.dealer, c.hitSoft17, table.DealerCards, table.Hands[0].Outcome)
+		}
+	}
+}
+
+func TestRound_Blackjack(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "AH,9C,KD,7S")
+
+	mustStart(t, table, 10, decks)
+	if table.Stage != SettledStage || table.Hands[0].Outcome != BlackjackOutcome || table.Hands[0].Payout != 15 {
+		t.Fatalf("Expected the blackjack to pay 3:2 at once, but got: %+v", table.Hands[0])
+	}
+	if table.Bankroll != 1015 || table.DealerCards != "9C,7S" {
+		t.Errorf("Expected the dealer not to draw, but got: %+v", table)
+	}
+}
+
+func TestRound_Insurance(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "10H,AC,9D,KS")
+
+	mustStart(t, table, 10, decks)
+	if table.Stage != InsuranceStage {
+		t.Fatalf("Expected insurance to be offered, but got stage %s.", table.Stage)
+	}
+	if err := Act(table, HitAction, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a hit before the insurance decision, but got: %v", err)
+	}
+
+	mustAct(t, table, InsuranceAction, decks)
+	if table.Stage != SettledStage || table.InsurancePayout != 10 {
+		t.Fatalf("Expected the insurance to pay 2:1 on the blackjack of the dealer, but got: %+v", table)
+	}
+	// Lost 10 on the hand, won 10 on the insurance.
+	if table.Hands[0].Outcome != LoseOutcome || table.Bankroll != 1000 {
+		t.Errorf("Expected the insurance to cover the lost bet, but got: %+v (bankroll %d)", table.Hands[0], table.Bankroll)
+	}
+}
+
+func TestRound_Double(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "6H,10C,5D,7S,10D")
+
+	mustStart(t, table, 10, decks)
+	mustAct(t, table, DoubleAction, decks)
+
+	hand := table.Hands[0]
+	if !hand.Doubled || hand.Bet != 20 || hand.Cards != "6H,5D,10D" {
+		t.Fatalf("Expected the bet to be doubled with one more card, but got: %+v", hand)
+	}
+	if hand.Outcome != WinOutcome || table.Bankroll != 1020 {
+		t.Errorf("Expected the doubled hand to win, but got: %+v (bankroll %d)", hand, table.Bankroll)
+	}
+}
+
+func TestRound_Split(t *testing.T) {
+	_, decks := setupTest(t)
+	rules := DefaultRules()
+	rules.DoubleAfterSplit = false
+	table := newTestTable(t, decks, rules, "8H,10C,8D,7S,3C,10D,9S")
+
+	mustStart(t, table, 10, decks)
+	mustAct(t, table, SplitAction, decks)
+
+	if len(table.Hands) != 2 || table.Hands[0].Cards != "8H,3C" || table.Hands[1].Cards != "8D,10D" {
+		t.Fatalf("Expected two hands with a new second card each, but got: %+v, %+v", table.Hands[0], table.Hands[1])
+	}
+	if err := Act(table, DoubleAction, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a double after split, but got: %v", err)
+	}
+
+	mustAct(t, table, HitAction, decks)
+	if table.ActiveHand != 0 {
+		t.Fatal("Expected the first hand to stay active.")
+	}
+	mustAct(t, table, StandAction, decks)
+	mustAct(t, table, StandAction, decks)
+
+	// 8,3,9 = 20 and 8,10 = 18 against 17.
+	if table.Stage != SettledStage || table.Hands[0].Outcome != WinOutcome || table.Hands[1].Outcome != WinOutcome {
+		t.Fatalf("Expected both hands to win, but got: %+v, %+v", table.Hands[0], table.Hands[1])
+	}
+	if table.Bankroll != 1020 {
+		t.Errorf("Expected a bankroll of 1020, but got %d.", table.Bankroll)
+	}
+}
+
+func TestRound_SplitAces(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "AH,10C,AD,7S,KC,5D")
+
+	mustStart(t, table, 10, decks)
+	mustAct(t, table, SplitAction, decks)
+
+	// Split aces get one card each, and an ace with a ten counts as 21, not as a blackjack.
+	if table.Stage != SettledStage {
+		t.Fatalf("Expected the round to be settled after splitting aces, but got stage %s.", table.Stage)
+	}
+	if table.Hands[0].Outcome != WinOutcome || table.Hands[0].Payout != 10 || table.Hands[1].Outcome != LoseOutcome {
+		t.Errorf("Expected 21 to win and 16 to lose, but got: %+v, %+v", table.Hands[0], table.Hands[1])
+	}
+}
+
+func TestRound_Surrender(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, DefaultRules(), "10H,10C,6D,7S,10D,9H,10S,7C,5H")
+
+	mustStart(t, table, 10, decks)
+	if err := Act(table, SurrenderAction, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a surrender without the rule, but got: %v", err)
+	}
+
+	table.Surrender = true
+	mustAct(t, table, SurrenderAction, decks)
+	if table.Hands[0].Outcome != SurrenderOutcome || table.Bankroll != 995 || table.DealerCards != "10C,7S" {
+		t.Errorf("Expected half the bet back without the dealer drawing, but got: %+v", table)
+	}
+
+	mustStart(t, table, 10, decks)
+	mustAct(t, table, HitAction, decks)
+	if table.Hands[0].Outcome != LoseOutcome || table.Bankroll != 985 {
+		t.Errorf("Expected the hand to bust, but got: %+v", table.Hands[0])
+	}
+}
+
+func TestStartRound_Bets(t *testing.T) {
+	_, decks := setupTest(t)
+	rules := DefaultRules()
+	rules.MinBet = 5
+	rules.MaxBet = 500
+	table := newTestTable(t, decks, rules, "10H,9C,8D,7S")
+
+	for _, bet := range []int64{0, 4, 501} {
+		if err := StartRound(table, bet, decks); !errors.IsBadRequestError(err) {
+			t.Errorf("Expected a BadRequestError for a bet of %d, but got: %v", bet, err)
+		}
+	}
+
+	table.Bankroll = 100
+	if err := StartRound(table, 200, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a bet greater than the bankroll, but got: %v", err)
+	}
+
+	mustStart(t, table, 100, decks)
+	if err := StartRound(table, 10, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError while a round is in progress, but got: %v", err)
+	}
+}
+
+func TestStartRound_Reshuffle(t *testing.T) {
+	_, decks := setupTest(t)
+	table, err := NewTable(DefaultRules(), 1000, decks)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+	shoeID := table.ShoeID
+
+	table.CardsDealt = table.CutCard
+	mustStart(t, table, 10, decks)
+
+	if table.ShoeID == shoeID || table.CardsDealt < 4 {
+		t.Errorf("Expected a new shoe once the cut card came out, but got: %+v", table)
+	}
+}
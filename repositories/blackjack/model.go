@@ -0,0 +1,175 @@
+package blackjack
+
+import (
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Stages of a round of blackjack.
+const (
+	// WaitingStage is the stage of a table before the first round.
+	WaitingStage = "WAITING"
+
+	// InsuranceStage is the stage after the deal when the dealer shows an ace: the player may take insurance.
+	InsuranceStage = "INSURANCE"
+
+	// PlayerStage is the stage in which the player plays the hands.
+	PlayerStage = "PLAYER"
+
+	// SettledStage is the end of a round: the dealer has played and the bets are settled.
+	SettledStage = "SETTLED"
+)
+
+// Outcomes of a hand.
+const (
+	WinOutcome       = "WIN"
+	LoseOutcome      = "LOSE"
+	PushOutcome      = "PUSH"
+	BlackjackOutcome = "BLACKJACK"
+	SurrenderOutcome = "SURRENDER"
+)
+
+// Rules holds the house rules of a table.
+type Rules struct {
+	// Decks is the number of decks (packs) in the shoe.
+	Decks int
+
+	// HitSoft17 is set if the dealer hits a soft 17 (H17). Otherwise, the dealer stands on all 17s (S17).
+	HitSoft17 bool
+
+	// DoubleAfterSplit is set if the player may double down after a split.
+	DoubleAfterSplit bool
+
+	// Surrender is set if the player may surrender the initial hand (late surrender) and lose only half the bet.
+	Surrender bool
+
+	// BlackjackPayoutNumerator and BlackjackPayoutDenominator make the payout of a blackjack, like 3:2 or 6:5.
+	BlackjackPayoutNumerator   int64
+	BlackjackPayoutDenominator int64
+
+	// Penetration is the part of the shoe dealt before the cut card comes out and the shoe is reshuffled.
+	Penetration float64
+
+	// MaxHands is the maximal number of hands the player may have by splitting.
+	MaxHands int
+
+	// MinBet and MaxBet limit the initial bet of a round.
+	MinBet int64
+	MaxBet int64
+}
+
+// Table represents the database model for a blackjack table with a single player playing against the dealer.
+type Table struct {
+	// ID is a unique identifier for this table, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this table was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this table was last updated.
+	UpdatedAt time.Time
+
+	// Rules are the house rules of the table.
+	Rules `gorm:"embedded"`
+
+	// ShoeID is the ID of the deck used as the shoe.
+	ShoeID string
+
+	// ShoeToken is the secret token of the dealer of the shoe, kept private by the table: only the table sees the
+	// cards of the shoe and draws from it. Never shown.
+	ShoeToken string
+
+	// CutCard is the number of cards dealt from the shoe after which the shoe is replaced by a new shuffled one.
+	CutCard int
+
+	// CardsDealt is the number of cards dealt from the shoe.
+	CardsDealt int
+
+	// Bankroll is the amount of chips of the player, not counting the bets in the current round.
+	Bankroll int64
+
+	// RoundNumber is the number of rounds played at this table.
+	RoundNumber int
+
+	// Stage is the stage of the current round, like PlayerStage.
+	Stage string
+
+	// DealerCards holds the codes of the cards of the dealer, comma separated. The second card is the hole card.
+	DealerCards string
+
+	// ActiveHand is the index of the hand the player is playing.
+	ActiveHand int
+
+	// Insurance is the insurance bet of the current round.
+	Insurance int64
+
+	// InsurancePayout is the net result of the insurance bet.
+	InsurancePayout int64
+
+	// Version is incremented with every change of the table, and used to detect concurrent changes.
+	Version int
+
+	// Hands holds the hands of the player in the current round, ordered by position. Splitting adds hands.
+	Hands []*Hand `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Table.
+func (t *Table) TableName() string {
+	return "blackjack_tables"
+}
+
+// Dealer returns the cards of the dealer.
+func (t *Table) Dealer() []*deck_repo.Card {
+	return asCards(t.DealerCards)
+}
+
+// Hand represents the database model for a hand of the player in a round of blackjack.
+type Hand struct {
+	// TableID is the foreign key to the table.
+	TableID string `gorm:"primaryKey"`
+
+	// Idx is the position of the hand, starting at 0.
+	Idx int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Cards holds the codes of the cards of the hand, comma separated.
+	Cards string
+
+	// Bet is the bet on the hand, including the double down.
+	Bet int64
+
+	// Doubled is set if the player doubled down on the hand.
+	Doubled bool
+
+	// Split is set if the hand was made by splitting a pair.
+	Split bool
+
+	// Done is set once the player cannot act on the hand anymore.
+	Done bool
+
+	// Surrendered is set if the player surrendered the hand.
+	Surrendered bool
+
+	// Outcome is the outcome of the hand once the round is settled, like WinOutcome.
+	Outcome string
+
+	// Payout is the net result of the hand: positive if won, negative if lost.
+	Payout int64
+}
+
+// TableName returns the name of the database table of Hand.
+func (h *Hand) TableName() string {
+	return "blackjack_hands"
+}
+
+// HandCards returns the cards of the hand.
+func (h *Hand) HandCards() []*deck_repo.Card {
+	return asCards(h.Cards)
+}
+
+func asCards(codes string) []*deck_repo.Card {
+	if codes == "" {
+		return []*deck_repo.Card{}
+	}
+	return deck_repo.AsCards(codes)
+}
@@ -0,0 +1,17 @@
+package blackjack
+
+// TableRepository defines methods for storing the blackjack tables.
+type TableRepository interface {
+
+	// CreateTable stores a new table. If the table has no ID, a new ID is generated.
+	CreateTable(table *Table) (*Table, error)
+
+	// GetTable looks up a table by its ID, with the hands of the current round ordered by position.
+	// If there is no table with the given ID, then a NotFoundError is returned.
+	GetTable(tableID string) (*Table, error)
+
+	// UpdateTable stores the changed table and replaces the hands of the current round. The table is updated only
+	// if it was not changed since it was read (see Table.Version), otherwise a BadRequestError is returned and
+	// the change should be retried.
+	UpdateTable(table *Table) (*Table, error)
+}
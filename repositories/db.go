@@ -2,35 +2,118 @@ package repositories
 
 import (
 	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/natemago/card-games-api/config"
+	baccarat_repo "github.com/natemago/card-games-api/repositories/baccarat"
+	blackjack_repo "github.com/natemago/card-games-api/repositories/blackjack"
 	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+	holdem_repo "github.com/natemago/card-games-api/repositories/holdem"
+	klondike_repo "github.com/natemago/card-games-api/repositories/klondike"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // MigrationHandlers is a list of functions that perform a database migrations for multiple models.
 var MigrationHandlers = []func(db *gorm.DB) error{
 	deck_repo.AutoMigrateDeckModels,
+	holdem_repo.AutoMigrateHoldemModels,
+	blackjack_repo.AutoMigrateBlackjackModels,
+	klondike_repo.AutoMigrateKlondikeModels,
+	tricks_repo.AutoMigrateTricksModels,
+	baccarat_repo.AutoMigrateBaccaratModels,
+	games_repo.AutoMigrateGamesModels,
 }
 
+// maxConnectBackoff caps the wait time between two consecutive attempts to connect to the database.
+const maxConnectBackoff = 30 * time.Second
+
 // OpenDatabase creates a new connection to the database based on the supplied database configuration config.DBConfig.
+// If the database is not reachable, it retries to connect up to config.ConnectRetries times, doubling the wait time
+// between the attempts. Once connected, the connection pool is configured with the values from the configuration.
 func OpenDatabase(config *config.DBConfig) (db *gorm.DB, err error) {
-	dialect, err := configureDialect(config)
+	logLevel, err := parseLogLevel(config.LogLevel)
 	if err != nil {
 		return nil, err
 	}
-	db, err = gorm.Open(dialect, &gorm.Config{})
-	if err != nil {
+
+	backoff := config.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		db, err = connect(config, logLevel)
+		if err == nil {
+			break
+		}
+		if attempt >= config.ConnectRetries {
+			return nil, err
+		}
+		log.Printf("Failed to connect to the database (attempt %d of %d): %s. Retrying in %s.",
+			attempt+1, config.ConnectRetries+1, err.Error(), backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+
+	if err := configurePool(db, config); err != nil {
 		return nil, err
 	}
+
 	return db, nil
 }
 
+func connect(config *config.DBConfig, logLevel logger.LogLevel) (*gorm.DB, error) {
+	dialect, err := configureDialect(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return gorm.Open(dialect, &gorm.Config{
+		Logger: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logLevel,
+			IgnoreRecordNotFoundError: true,
+		}),
+	})
+}
+
+func configurePool(db *gorm.DB, config *config.DBConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	if config.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	}
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	return nil
+}
+
 func configureDialect(config *config.DBConfig) (gorm.Dialector, error) {
 	switch config.Dialect {
 	case "postgres":
+		if config.StatementTimeout > 0 {
+			connConfig, err := pgx.ParseConfig(config.URL)
+			if err != nil {
+				return nil, err
+			}
+			connConfig.RuntimeParams["statement_timeout"] = fmt.Sprintf("%d", config.StatementTimeout.Milliseconds())
+			return postgres.New(postgres.Config{
+				Conn: stdlib.OpenDB(*connConfig),
+			}), nil
+		}
 		return postgres.Open(config.URL), nil
 	case "sqlite":
 		return sqlite.Open(config.URL), nil
@@ -39,6 +122,21 @@ func configureDialect(config *config.DBConfig) (gorm.Dialector, error) {
 	}
 }
 
+func parseLogLevel(level string) (logger.LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "silent":
+		return logger.Silent, nil
+	case "", "error":
+		return logger.Error, nil
+	case "warn":
+		return logger.Warn, nil
+	case "info":
+		return logger.Info, nil
+	default:
+		return logger.Silent, fmt.Errorf("unsupported SQL log level: %s", level)
+	}
+}
+
 // AutoMigrateModels executes the MigrationHandlers within a single transaction to auto-migrate the database models.
 func AutoMigrateModels(db *gorm.DB) error {
 	tx := db.Begin()
@@ -52,6 +150,7 @@ func AutoMigrateModels(db *gorm.DB) error {
 			return err
 		}
 	}
+
 	result := tx.Commit()
 	if result.Error != nil {
 		return result.Error
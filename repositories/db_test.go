@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"testing"
+	"time"
 
 	"github.com/natemago/card-games-api/config"
 )
@@ -45,3 +46,55 @@ func TestAutoMigrateModels(t *testing.T) {
 		t.Errorf("Expected to migrate the models, but got an error instead: %s", err.Error())
 	}
 }
+
+func TestOpenDatabase_PoolConfiguration(t *testing.T) {
+	db, err := OpenDatabase(&config.DBConfig{
+		Dialect:      "sqlite",
+		URL:          "file::memory:?cache=shared",
+		MaxOpenConns: 3,
+		LogLevel:     "silent",
+	})
+	if err != nil {
+		t.Fatalf("Expected to open a database connection, but got an error instead: %s", err.Error())
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Expected to get the underlying database connection, but got an error instead: %s", err.Error())
+	}
+	if sqlDB.Stats().MaxOpenConnections != 3 {
+		t.Errorf("Expected max open connections to be 3, but got %d instead.", sqlDB.Stats().MaxOpenConnections)
+	}
+}
+
+func TestOpenDatabase_InvalidLogLevel(t *testing.T) {
+	_, err := OpenDatabase(&config.DBConfig{
+		Dialect:  "sqlite",
+		URL:      "file::memory:?cache=shared",
+		LogLevel: "verbose",
+	})
+	if err == nil {
+		t.Fatal("Expected to get an unsupported log level error.")
+	}
+	if err.Error() != "unsupported SQL log level: verbose" {
+		t.Errorf("Expected a valid unsupported log level error, but got '%s' instead.", err.Error())
+	}
+}
+
+func TestOpenDatabase_ConnectRetries(t *testing.T) {
+	start := time.Now()
+	_, err := OpenDatabase(&config.DBConfig{
+		Dialect:        "sqlite",
+		URL:            "/non-existing-directory/card-games.db",
+		ConnectRetries: 2,
+		ConnectBackoff: 10 * time.Millisecond,
+		LogLevel:       "silent",
+	})
+	if err == nil {
+		t.Fatal("Expected to fail connecting to the database.")
+	}
+	// Waits 10ms before the first retry and 20ms before the second.
+	if time.Since(start) < 30*time.Millisecond {
+		t.Error("Expected to retry connecting with backoff.")
+	}
+}
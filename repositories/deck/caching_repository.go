@@ -0,0 +1,266 @@
+package deck
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CacheStats holds the counters of a deck cache.
+type CacheStats struct {
+	// Hits is the number of deck lookups served from the cache.
+	Hits uint64
+
+	// Misses is the number of deck lookups that had to go to the underlying repository.
+	Misses uint64
+
+	// Size is the number of decks currently in the cache.
+	Size int
+}
+
+// cacheEntry is a single cached deck in the LRU list.
+type cacheEntry struct {
+	deckID    string
+	deck      *Deck
+	expiresAt time.Time
+}
+
+// deckCache is a bounded LRU cache of decks, where each deck expires after a TTL.
+type deckCache struct {
+	size int
+	ttl  time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+
+	// version is incremented on every invalidation. A deck looked up from the underlying repository is cached only
+	// if no invalidation happened during the lookup, so a stale deck never makes it into the cache.
+	version uint64
+
+	hits   uint64
+	misses uint64
+}
+
+func (c *deckCache) get(deckID string) (*Deck, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[deckID]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, deckID)
+		c.misses++
+		return nil, false
+	}
+
+	c.lru.MoveToFront(element)
+	c.hits++
+	return copyDeck(entry.deck), true
+}
+
+func (c *deckCache) currentVersion() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.version
+}
+
+func (c *deckCache) put(deck *Deck, version uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if version != c.version {
+		return
+	}
+
+	entry := &cacheEntry{
+		deckID:    deck.ID,
+		deck:      copyDeck(deck),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+
+	if element, ok := c.entries[deck.ID]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[deck.ID] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).deckID)
+	}
+}
+
+func (c *deckCache) invalidate(deckIDs ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.version++
+
+	for _, deckID := range deckIDs {
+		if element, ok := c.entries[deckID]; ok {
+			c.lru.Remove(element)
+			delete(c.entries, deckID)
+		}
+	}
+}
+
+func (c *deckCache) stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return CacheStats{
+		Hits:   c.hits,
+		Misses: c.misses,
+		Size:   c.lru.Len(),
+	}
+}
+
+// copyDeck makes a deep copy of the deck, so the cached deck cannot be changed by the callers.
+func copyDeck(deck *Deck) *Deck {
+	deckCopy := *deck
+	deckCopy.Cards = make([]*Card, 0, len(deck.Cards))
+	for _, card := range deck.Cards {
+		cardCopy := *card
+		deckCopy.Cards = append(deckCopy.Cards, &cardCopy)
+	}
+	return &deckCopy
+}
+
+// CachingDeckRepository is a DeckRepository decorator that caches the decks looked up with GetDeck in a bounded
+// LRU cache. Every change of a deck made through the decorator invalidates the cached deck.
+// Within a transaction, the cache is bypassed and the changed decks are invalidated once the transaction is done.
+type CachingDeckRepository struct {
+	repository DeckRepository
+	cache      *deckCache
+
+	// touched collects the ids of the decks changed within a transaction. Nil outside of a transaction.
+	touched *[]string
+}
+
+// CreateDeck creates new deck of cards with the underlying repository. The new deck is not cached.
+func (c *CachingDeckRepository) CreateDeck(deck *Deck) (*Deck, error) {
+	return c.repository.CreateDeck(deck)
+}
+
+// GetDeck looks up a deck of cards by its ID. The deck is served from the cache if present and not expired,
+// otherwise it is looked up with the underlying repository and cached.
+// If there is no deck with the given ID, then a NotFound error is returned.
+func (c *CachingDeckRepository) GetDeck(deckID string) (*Deck, error) {
+	if c.touched != nil {
+		return c.repository.GetDeck(deckID)
+	}
+
+	if deck, ok := c.cache.get(deckID); ok {
+		return deck, nil
+	}
+
+	version := c.cache.currentVersion()
+
+	deck, err := c.repository.GetDeck(deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.put(deck, version)
+
+	return deck, nil
+}
+
+// DrawCards draws a number of cards from the deck with the underlying repository and invalidates the cached deck.
+func (c *CachingDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, error) {
+	defer c.invalidate(deckID)
+	return c.repository.DrawCards(deckID, numCards)
+}
+
+// ShuffleDeck shuffles the remaining cards in the deck with the underlying repository and invalidates the cached deck.
+func (c *CachingDeckRepository) ShuffleDeck(deckID string) (*Deck, error) {
+	defer c.invalidate(deckID)
+	return c.repository.ShuffleDeck(deckID)
+}
+
+// ExportDeck returns the deck with all of its cards from the underlying repository. The cache is not used.
+func (c *CachingDeckRepository) ExportDeck(deckID string) (*Deck, error) {
+	return c.repository.ExportDeck(deckID)
+}
+
+// ImportDeck stores a previously exported deck with the underlying repository and invalidates the cached deck.
+func (c *CachingDeckRepository) ImportDeck(deck *Deck) (*Deck, error) {
+	defer c.invalidate(deck.ID)
+	return c.repository.ImportDeck(deck)
+}
+
+// ListDeckIDs returns the IDs of all stored decks from the underlying repository.
+func (c *CachingDeckRepository) ListDeckIDs() ([]string, error) {
+	return c.repository.ListDeckIDs()
+}
+
+// Transaction executes the given function within a single transaction of the underlying repository.
+// The decks changed within the transaction are invalidated once the transaction is committed or rolled back.
+func (c *CachingDeckRepository) Transaction(fn func(repository DeckRepository) error) error {
+	if c.touched != nil {
+		return c.repository.Transaction(func(repository DeckRepository) error {
+			return fn(&CachingDeckRepository{
+				repository: repository,
+				cache:      c.cache,
+				touched:    c.touched,
+			})
+		})
+	}
+
+	touched := []string{}
+	defer func() {
+		c.cache.invalidate(touched...)
+	}()
+
+	return c.repository.Transaction(func(repository DeckRepository) error {
+		return fn(&CachingDeckRepository{
+			repository: repository,
+			cache:      c.cache,
+			touched:    &touched,
+		})
+	})
+}
+
+func (c *CachingDeckRepository) database() *gorm.DB {
+	return boundDatabase(c.repository, nil)
+}
+
+func (c *CachingDeckRepository) invalidate(deckID string) {
+	if c.touched != nil {
+		*c.touched = append(*c.touched, deckID)
+		return
+	}
+	c.cache.invalidate(deckID)
+}
+
+// Stats returns the cache hit and miss counters and the current number of cached decks.
+func (c *CachingDeckRepository) Stats() CacheStats {
+	return c.cache.stats()
+}
+
+// NewCachingDeckRepository creates a new CachingDeckRepository that caches up to size decks of the given repository,
+// each for at most ttl.
+func NewCachingDeckRepository(repository DeckRepository, size int, ttl time.Duration) *CachingDeckRepository {
+	return &CachingDeckRepository{
+		repository: repository,
+		cache: &deckCache{
+			size:    size,
+			ttl:     ttl,
+			entries: map[string]*list.Element{},
+			lru:     list.New(),
+		},
+	}
+}
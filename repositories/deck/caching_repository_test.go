@@ -0,0 +1,139 @@
+package deck
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachingDeckRepository_GetDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCachingDeckRepository(NewDBDeckRepository(td.DB), 10, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		deck, err := deckRepo.GetDeck(td.FullDeckID)
+		if err != nil {
+			t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+		}
+		if len(deck.Cards) != 52 {
+			t.Fatal("Expected to get the full deck.")
+		}
+		// Changing the returned deck must not change the cached deck.
+		deck.Cards = deck.Cards[:1]
+	}
+
+	stats := deckRepo.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Size != 1 {
+		t.Errorf("Expected 2 hits, 1 miss and 1 cached deck, but got: %+v", stats)
+	}
+}
+
+func TestCachingDeckRepository_Invalidation(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCachingDeckRepository(NewDBDeckRepository(td.DB), 10, time.Minute)
+
+	if _, err := deckRepo.GetDeck(td.FullDeckID); err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+
+	if _, err := deckRepo.DrawCards(td.FullDeckID, 2); err != nil {
+		t.Fatalf("Expected to draw cards, but got error: %s", err.Error())
+	}
+
+	deck, err := deckRepo.GetDeck(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	if deck.Remaining != 50 || len(deck.Cards) != 50 {
+		t.Errorf("Expected the drawn cards to invalidate the cached deck, but the deck has %d cards.", len(deck.Cards))
+	}
+
+	err = deckRepo.Transaction(func(repository DeckRepository) error {
+		_, err := repository.ShuffleDeck(td.FullDeckID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected to shuffle the deck, but got error: %s", err.Error())
+	}
+
+	deck, err = deckRepo.GetDeck(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	if !deck.Shuffled {
+		t.Error("Expected the shuffle within a transaction to invalidate the cached deck.")
+	}
+}
+
+func TestCachingDeckRepository_Eviction(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCachingDeckRepository(NewDBDeckRepository(td.DB), 1, time.Minute)
+
+	deckRepo.GetDeck(td.FullDeckID)
+	deckRepo.GetDeck(td.PartialDeckID)
+	deckRepo.GetDeck(td.FullDeckID)
+
+	stats := deckRepo.Stats()
+	if stats.Misses != 3 || stats.Size != 1 {
+		t.Errorf("Expected the least recently used deck to be evicted, but got: %+v", stats)
+	}
+}
+
+func TestCachingDeckRepository_TTL(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCachingDeckRepository(NewDBDeckRepository(td.DB), 10, 10*time.Millisecond)
+
+	deckRepo.GetDeck(td.FullDeckID)
+	time.Sleep(20 * time.Millisecond)
+	deckRepo.GetDeck(td.FullDeckID)
+
+	if stats := deckRepo.Stats(); stats.Misses != 2 {
+		t.Errorf("Expected the cached deck to expire, but got: %+v", stats)
+	}
+}
+
+func TestCachingDeckRepository_ConcurrentDraws(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCachingDeckRepository(NewCompactDBDeckRepository(td.DB), 10, time.Minute)
+
+	created, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a deck, but got error: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			deckRepo.DrawCards(created.ID, 1)
+		}()
+		go func() {
+			defer wg.Done()
+			deckRepo.GetDeck(created.ID)
+		}()
+	}
+	wg.Wait()
+
+	cached, err := deckRepo.GetDeck(created.ID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	stored, err := NewCompactDBDeckRepository(td.DB).GetDeck(created.ID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	if cached.Remaining != stored.Remaining || !compare(cached.Cards, stored.Cards) {
+		t.Errorf("Expected the cached deck (%d cards) to match the stored deck (%d cards).", cached.Remaining, stored.Remaining)
+	}
+}
@@ -40,8 +40,18 @@ var SuitsNames = map[string]string{
 	"S": "SPADES",
 }
 
+// cardCodes maps each card value to its one byte code, used to encode the order of the cards in a deck.
+var cardCodes = map[string]byte{}
+
+// cardValues maps each one byte card code back to the card value.
+var cardValues = NewFullDeck()
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
+
+	for i, card := range cardValues {
+		cardCodes[card] = byte(i)
+	}
 }
 
 // NewFullDeck returns a new full deck of 52 cards, sorted in order.
@@ -69,20 +79,33 @@ func ShuffleDeck(deck []*Card) {
 	}
 }
 
+// MaxPacks is the maximal number of packs in a single deck (shoe).
+const MaxPacks = 8
+
 // ValidateDeckCards validates if the cards are actually valid cards and there are no duplicates in the deck.
 func ValidateDeckCards(cards []*Card) error {
+	return ValidateShoeCards(cards, 1)
+}
+
+// ValidateShoeCards validates if the cards are actually valid cards and no card appears more than packs times,
+// as in a shoe made of the given number of packs.
+func ValidateShoeCards(cards []*Card, packs int) error {
+	if packs < 1 || packs > MaxPacks {
+		return errors.ValidationError(fmt.Sprintf("the number of packs must be between 1 and %d", MaxPacks), nil)
+	}
+
 	var invalidCards []string
-	seen := map[string]bool{}
+	seen := map[string]int{}
 	for _, card := range cards {
 		if card.RankName() == "" || card.SuitName() == "" {
 			invalidCards = append(invalidCards, card.Value)
 			continue
 		}
-		if _, ok := seen[card.Value]; ok {
+		if seen[card.Value] == packs {
 			// duplicate
 			invalidCards = append(invalidCards, card.Value)
 		}
-		seen[card.Value] = true
+		seen[card.Value]++
 	}
 
 	if len(invalidCards) > 0 {
@@ -92,6 +115,16 @@ func ValidateDeckCards(cards []*Card) error {
 	return nil
 }
 
+// AssignPacks numbers the copies of every card value in the given order, so the first copy of a card comes from
+// pack 1, the second copy from pack 2 and so on (see Card.Pack).
+func AssignPacks(cards []*Card) {
+	copies := map[string]int{}
+	for _, card := range cards {
+		copies[card.Value]++
+		card.Pack = copies[card.Value]
+	}
+}
+
 // AsCards parses a string of comma separated values into a deck of cards.
 // Note that after parsing, some of the cards may hold invalid value or the deck might
 // have duplicate cards. See ValidateDeckCards to validate the deck.
@@ -110,3 +143,55 @@ func AsCards(cardsStr string) []*Card {
 
 	return result
 }
+
+// JoinCards joins the values of the cards into a string of comma separated values, the inverse of AsCards.
+func JoinCards(cards []*Card) string {
+	values := make([]string, len(cards))
+	for i, card := range cards {
+		values[i] = card.Value
+	}
+	return strings.Join(values, ",")
+}
+
+// EncodeCards encodes the cards into a compact byte sequence, one byte per card, preserving the order of the cards.
+// The byte code of a card is its position in the full deck in order (see NewFullDeck).
+// Returns a ValidationError if any of the cards is not a valid card.
+func EncodeCards(cards []*Card) ([]byte, error) {
+	encoded := make([]byte, len(cards))
+	var invalidCards []string
+
+	for i, card := range cards {
+		code, ok := cardCodes[card.Value]
+		if !ok {
+			invalidCards = append(invalidCards, card.Value)
+			continue
+		}
+		encoded[i] = code
+	}
+
+	if len(invalidCards) > 0 {
+		return nil, errors.ValidationError(fmt.Sprintf("invalid cards values: %s", strings.Join(invalidCards, ", ")), nil)
+	}
+
+	return encoded, nil
+}
+
+// DecodeCards decodes a byte sequence produced by EncodeCards back into cards belonging to the deck with the given ID.
+// The index of each card is set to its position in the encoded sequence, starting at offset.
+func DecodeCards(deckID string, encoded []byte, offset int) []*Card {
+	cards := make([]*Card, 0, len(encoded))
+
+	for i, code := range encoded {
+		value := ""
+		if int(code) < len(cardValues) {
+			value = cardValues[code]
+		}
+		cards = append(cards, &Card{
+			DeckID: deckID,
+			Value:  value,
+			Idx:    offset + i,
+		})
+	}
+
+	return cards
+}
@@ -129,3 +129,60 @@ func TestAsCards(t *testing.T) {
 		t.Error("Expected to get the correct cards in order.")
 	}
 }
+
+func TestEncodeCards(t *testing.T) {
+	cards := AsCards("AC,10H,KS")
+
+	encoded, err := EncodeCards(cards)
+	if err != nil {
+		t.Fatalf("Expected to encode the cards, but got error instead: %s", err.Error())
+	}
+	if len(encoded) != 3 {
+		t.Fatalf("Expected exactly one byte per card, but got %d bytes.", len(encoded))
+	}
+
+	decoded := DecodeCards("deck", encoded, 5)
+	if !compare(cards, decoded) {
+		t.Error("Expected to decode the same cards in the same order.")
+	}
+	for i, card := range decoded {
+		if card.DeckID != "deck" || card.Idx != 5+i {
+			t.Errorf("Expected the decoded card %s to belong to the deck at index %d.", card.Value, 5+i)
+		}
+	}
+}
+
+func TestEncodeCards_InvalidCards(t *testing.T) {
+	_, err := EncodeCards(AsCards("AC,1X,KS,ZZ"))
+	if err == nil {
+		t.Fatal("Expected to get a validation error.")
+	}
+	if err.Error() != "invalid cards values: 1X, ZZ" {
+		t.Errorf("Expected correct validation message, but got '%s' instead.", err.Error())
+	}
+}
+
+func TestValidateShoeCards(t *testing.T) {
+	shoe := AsCards("AC,AC,10H,AC")
+
+	if err := ValidateShoeCards(shoe, 3); err != nil {
+		t.Errorf("Expected three copies of a card to be valid in a shoe of three packs, but got error: %s", err.Error())
+	}
+	if err := ValidateShoeCards(shoe, 2); err == nil || err.Error() != "invalid cards values: AC" {
+		t.Errorf("Expected the third copy of a card to be invalid in a shoe of two packs, but got: %v", err)
+	}
+	if err := ValidateShoeCards(shoe, MaxPacks+1); err == nil {
+		t.Error("Expected an error for too many packs.")
+	}
+}
+
+func TestAssignPacks(t *testing.T) {
+	cards := AsCards("AC,10H,AC,AC,10H")
+	AssignPacks(cards)
+
+	for i, expected := range []int{1, 1, 2, 3, 2} {
+		if cards[i].Pack != expected {
+			t.Errorf("Expected card %d (%s) to be from pack %d, but got %d.", i, cards[i].Value, expected, cards[i].Pack)
+		}
+	}
+}
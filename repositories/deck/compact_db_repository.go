@@ -0,0 +1,414 @@
+package deck
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+// maxDrawAttempts is the number of times a change of the deck is retried when the deck was concurrently modified.
+const maxDrawAttempts = 5
+
+// CompactDBDeckRepository implements DeckRepository storing each deck as a single row (see CompactDeck).
+// Drawing cards from a deck only moves the draw pointer, so it is a single row update regardless of
+// the number of drawn cards. Every change is applied only if the deck was not changed since it was read
+// (see CompactDeck.Version) and retried otherwise.
+type CompactDBDeckRepository struct {
+	db *gorm.DB
+}
+
+// CreateDeck creates a new deck of cards.
+// To generate a full 52 deck of cards in order, supply a pointer to an empty Deck struct.
+// By setting Deck.Shuffled to true, it will generate a shuffled deck,
+// Setting Deck.Cards to a non-empty array of Card, it will generate a deck with the supplied cards only.
+// If cards are supplied, it may return a ValidationError if some of the cards have multiple values or are
+// duplicates.
+func (d *CompactDBDeckRepository) CreateDeck(deck *Deck) (*Deck, error) {
+	if err := prepareDeck(deck); err != nil {
+		return nil, err
+	}
+
+	order, err := EncodeCards(deck.Cards)
+	if err != nil {
+		return nil, err
+	}
+
+	compactDeck := &CompactDeck{
+		ID:        deck.ID,
+		Shuffled:  deck.Shuffled,
+		Remaining: deck.Remaining,
+		Packs:     deck.Packs,
+		Order:     order,
+	}
+
+	if err := d.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Create(compactDeck); result.Error != nil {
+			return result.Error
+		}
+		return enqueueWebhooks(tx, WebhookDeckCreated, deck)
+	}); err != nil {
+		return nil, err
+	}
+
+	deck.CreatedAt = compactDeck.CreatedAt
+	deck.UpdatedAt = compactDeck.UpdatedAt
+	for _, card := range deck.Cards {
+		card.DeckID = deck.ID
+	}
+
+	return deck, nil
+}
+
+// GetDeck looks up a Deck by its ID and returns a reference to a populated Deck, holding only the remaining cards.
+// If there is no deck with the given ID, then a NotFound error is returned.
+func (d *CompactDBDeckRepository) GetDeck(deckID string) (*Deck, error) {
+	compactDeck, err := d.getCompactDeck(d.db, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Deck{
+		ID:        compactDeck.ID,
+		CreatedAt: compactDeck.CreatedAt,
+		UpdatedAt: compactDeck.UpdatedAt,
+		Shuffled:  compactDeck.Shuffled,
+		Remaining: compactDeck.Remaining,
+		Packs:     compactDeck.Packs,
+		Cards:     DecodeCards(compactDeck.ID, compactDeck.Order[compactDeck.DrawPointer:], compactDeck.DrawPointer),
+	}, nil
+}
+
+func (d *CompactDBDeckRepository) getCompactDeck(db *gorm.DB, deckID string) (*CompactDeck, error) {
+	compactDeck := &CompactDeck{}
+	result := db.Where("id=?", deckID).First(compactDeck)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such deck", nil)
+		}
+		return nil, result.Error
+	}
+	return compactDeck, nil
+}
+
+// DrawCards draws a number of cards from the deck.
+// Once drawn, the cards will no longer be in the deck.
+// Returns a list of the drawn cards.
+// If there is no deck with the given deckID, then a NotFoundError will be returned.
+// If the number of cards is less then one, then just one card will be returned.
+// If the number of cards to be drawn is greater than the number of remaining cards in the deck,
+// then a BadRequestError will be returned.
+// The draw pointer is moved with a conditional update, so if the deck is modified concurrently, the draw is
+// retried with the fresh state of the deck. If the deck keeps being modified, a BadRequestError is returned.
+func (d *CompactDBDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, error) {
+	if numCards < 1 {
+		numCards = 1
+	}
+
+	for attempt := 0; attempt < maxDrawAttempts; attempt++ {
+		compactDeck, err := d.getCompactDeck(d.db, deckID)
+		if err != nil {
+			return nil, err
+		}
+
+		if numCards > compactDeck.Remaining {
+			return nil, api_errors.BadRequestError("not enough cards in deck", nil)
+		}
+
+		drawPointer := compactDeck.DrawPointer + numCards
+		remaining := compactDeck.Remaining - numCards
+		updated, err := d.updateCompactDeck(compactDeck, map[string]interface{}{
+			"draw_pointer": drawPointer,
+			"remaining":    remaining,
+		}, func(tx *gorm.DB) error {
+			if remaining > 0 {
+				return nil
+			}
+			return enqueueWebhooks(tx, WebhookDeckEmptied, &Deck{
+				ID:       compactDeck.ID,
+				Shuffled: compactDeck.Shuffled,
+				Packs:    compactDeck.Packs,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			return DecodeCards(deckID, compactDeck.Order[compactDeck.DrawPointer:drawPointer], compactDeck.DrawPointer), nil
+		}
+	}
+
+	return nil, api_errors.BadRequestError(fmt.Sprintf("deck %s was changed concurrently, please retry", deckID), nil)
+}
+
+// ShuffleDeck shuffles the remaining cards in the deck. The drawn cards are not put back into the deck.
+// Returns the deck with the remaining cards in the new order.
+// If there is no deck with the given deckID, then a NotFoundError will be returned.
+// If the deck keeps being modified concurrently, then a BadRequestError will be returned.
+func (d *CompactDBDeckRepository) ShuffleDeck(deckID string) (*Deck, error) {
+	for attempt := 0; attempt < maxDrawAttempts; attempt++ {
+		compactDeck, err := d.getCompactDeck(d.db, deckID)
+		if err != nil {
+			return nil, err
+		}
+
+		order := append([]byte{}, compactDeck.Order...)
+		remaining := order[compactDeck.DrawPointer:]
+		rand.Shuffle(len(remaining), func(i, j int) {
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+		})
+
+		updated, err := d.updateCompactDeck(compactDeck, map[string]interface{}{
+			"order":    order,
+			"shuffled": true,
+		}, func(tx *gorm.DB) error {
+			return enqueueWebhooks(tx, WebhookDeckShuffled, &Deck{
+				ID:        compactDeck.ID,
+				Shuffled:  true,
+				Remaining: compactDeck.Remaining,
+				Packs:     compactDeck.Packs,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			return &Deck{
+				ID:        compactDeck.ID,
+				CreatedAt: compactDeck.CreatedAt,
+				UpdatedAt: time.Now(),
+				Shuffled:  true,
+				Remaining: compactDeck.Remaining,
+				Packs:     compactDeck.Packs,
+				Cards:     DecodeCards(deckID, remaining, compactDeck.DrawPointer),
+			}, nil
+		}
+	}
+
+	return nil, api_errors.BadRequestError(fmt.Sprintf("deck %s was changed concurrently, please retry", deckID), nil)
+}
+
+// ExportDeck looks up a deck of cards by its ID and returns the deck with all of its cards, including the drawn
+// ones, ordered by their position in the deck.
+// If there is no deck with the given ID, then a NotFound error is returned.
+func (d *CompactDBDeckRepository) ExportDeck(deckID string) (*Deck, error) {
+	compactDeck, err := d.getCompactDeck(d.db, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := DecodeCards(compactDeck.ID, compactDeck.Order, 0)
+	for _, card := range cards[:compactDeck.DrawPointer] {
+		card.Drawn = true
+	}
+	AssignPacks(cards)
+
+	return &Deck{
+		ID:        compactDeck.ID,
+		CreatedAt: compactDeck.CreatedAt,
+		UpdatedAt: compactDeck.UpdatedAt,
+		Shuffled:  compactDeck.Shuffled,
+		Remaining: compactDeck.Remaining,
+		Packs:     compactDeck.Packs,
+		Cards:     cards,
+	}, nil
+}
+
+// ImportDeck stores a previously exported deck as is: with its ID, drawn cards and the order of the cards.
+// The cards are ordered by their position, and as the compact layout can only draw cards from the top of the deck,
+// all of the drawn cards must precede the remaining cards, otherwise a ValidationError is returned.
+// Returns a BadRequestError if a deck with the same ID already exists.
+func (d *CompactDBDeckRepository) ImportDeck(deck *Deck) (*Deck, error) {
+	if err := prepareImport(deck); err != nil {
+		return nil, err
+	}
+
+	cards := append([]*Card{}, deck.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Idx < cards[j].Idx
+	})
+
+	drawPointer := 0
+	for i, card := range cards {
+		if !card.Drawn {
+			continue
+		}
+		if i != drawPointer {
+			return nil, api_errors.ValidationError(fmt.Sprintf("cannot import deck %s in the compact storage layout: drawn card %s is not at the top of the deck", deck.ID, card.Value), nil)
+		}
+		drawPointer++
+	}
+
+	order, err := EncodeCards(cards)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkDeckNotExists(tx, &CompactDeck{}, deck.ID); err != nil {
+			return err
+		}
+
+		result := tx.Create(&CompactDeck{
+			ID:          deck.ID,
+			CreatedAt:   deck.CreatedAt,
+			UpdatedAt:   deck.UpdatedAt,
+			Shuffled:    deck.Shuffled,
+			Remaining:   len(cards) - drawPointer,
+			Packs:       deck.Packs,
+			Order:       order,
+			DrawPointer: drawPointer,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ListDeckIDs returns the IDs of all stored decks, ordered by the time of creation.
+func (d *CompactDBDeckRepository) ListDeckIDs() ([]string, error) {
+	deckIDs := []string{}
+
+	result := d.db.Model(&CompactDeck{}).Order("created_at").Pluck("id", &deckIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deckIDs, nil
+}
+
+// updateCompactDeck updates the deck with the given values only if the deck was not changed since it was read.
+// Once the update is applied, calls afterUpdate within the same transaction.
+// Returns false if the deck was changed in the meantime, so the update was not applied.
+func (d *CompactDBDeckRepository) updateCompactDeck(compactDeck *CompactDeck, values map[string]interface{}, afterUpdate func(tx *gorm.DB) error) (bool, error) {
+	values["version"] = compactDeck.Version + 1
+	values["updated_at"] = time.Now()
+
+	updated := false
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&CompactDeck{}).
+			Where("id = ? AND version = ?", compactDeck.ID, compactDeck.Version).
+			Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if updated = result.RowsAffected == 1; !updated {
+			return nil
+		}
+		return afterUpdate(tx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return updated, nil
+}
+
+// Transaction executes the given function within a single database transaction. The DeckRepository passed to the
+// function is bound to the transaction. If the function returns an error, the transaction is rolled back.
+// Nested calls create nested transactions (save points), so a failure can be rolled back without aborting the
+// outer transaction.
+func (d *CompactDBDeckRepository) Transaction(fn func(repository DeckRepository) error) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&CompactDBDeckRepository{db: tx})
+	})
+}
+
+func (d *CompactDBDeckRepository) database() *gorm.DB {
+	return d.db
+}
+
+// MigrateToCompactStorage copies all decks stored in the cards layout (one row per card, see Card) into the compact
+// layout (see CompactDeck). Decks that already exist in the compact layout are skipped. The original rows are kept.
+// The whole migration is performed within a single transaction. Returns the number of migrated decks.
+func MigrateToCompactStorage(db *gorm.DB) (int, error) {
+	migrated := 0
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var deckIDs []string
+		result := tx.Model(&Deck{}).
+			Where("id NOT IN (?)", tx.Model(&CompactDeck{}).Select("id")).
+			Order("created_at").
+			Pluck("id", &deckIDs)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		for _, deckID := range deckIDs {
+			compactDeck, err := toCompactDeck(tx, deckID)
+			if err != nil {
+				return err
+			}
+			if result := tx.Create(compactDeck); result.Error != nil {
+				return result.Error
+			}
+			migrated++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return migrated, nil
+}
+
+func toCompactDeck(tx *gorm.DB, deckID string) (*CompactDeck, error) {
+	deck := &Deck{}
+	if result := tx.Where("id=?", deckID).First(deck); result.Error != nil {
+		return nil, result.Error
+	}
+
+	cards := []*Card{}
+	if result := tx.Where("deck_id = ?", deckID).Order("idx").Find(&cards); result.Error != nil {
+		return nil, result.Error
+	}
+
+	// Cards are drawn from the top of the deck, so all drawn cards must precede the remaining ones.
+	drawPointer := 0
+	for i, card := range cards {
+		if !card.Drawn {
+			continue
+		}
+		if i != drawPointer {
+			return nil, fmt.Errorf("cannot migrate deck %s: drawn card %s is not at the top of the deck", deckID, card.Value)
+		}
+		drawPointer++
+	}
+
+	order, err := EncodeCards(cards)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompactDeck{
+		ID:          deck.ID,
+		CreatedAt:   deck.CreatedAt,
+		UpdatedAt:   deck.UpdatedAt,
+		Shuffled:    deck.Shuffled,
+		Remaining:   len(cards) - drawPointer,
+		Packs:       deck.Packs,
+		Order:       order,
+		DrawPointer: drawPointer,
+	}, nil
+}
+
+// NewCompactDBDeckRepository creates a new DeckRepository with the given database connection, that stores the decks
+// in the compact storage layout.
+func NewCompactDBDeckRepository(db *gorm.DB) DeckRepository {
+	return &CompactDBDeckRepository{
+		db: db,
+	}
+}
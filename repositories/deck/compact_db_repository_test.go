@@ -0,0 +1,271 @@
+package deck
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestCompactCreateDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	result, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a new full deck, but got error: %s", err.Error())
+	}
+	if result.Remaining != 52 || len(result.Cards) != 52 {
+		t.Error("Expected the deck to have 52 cards remaining.")
+	}
+	if !cardsInOrder(result.Cards) {
+		t.Error("Expected all cards to be in proper order.")
+	}
+
+	stored := &CompactDeck{}
+	if err := td.DB.Where("id = ?", result.ID).First(stored).Error; err != nil {
+		t.Fatalf("Expected the deck to be stored, but got error: %s", err.Error())
+	}
+	if len(stored.Order) != 52 || stored.DrawPointer != 0 {
+		t.Error("Expected the deck order to be stored as 52 bytes with the draw pointer at the top.")
+	}
+
+	result, err = deckRepo.CreateDeck(&Deck{
+		Shuffled: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected to create a new shuffled deck, but got error: %s", err.Error())
+	}
+	if cardsInOrder(result.Cards) {
+		t.Error("Expected the cards to be shuffled.")
+	}
+}
+
+func TestCompactCreateDeck_InvalidCards(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	_, err := deckRepo.CreateDeck(&Deck{
+		Cards: AsCards("2C,TA,2C"),
+	})
+	if err == nil {
+		t.Fatalf("Expected to get validation error.")
+	}
+	if !errors.IsValidationError(err) {
+		t.Error("Expected the actual error to be ValidationError.")
+	}
+}
+
+func TestCompactGetDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	created, err := deckRepo.CreateDeck(&Deck{
+		Cards: AsCards("KD,2C,5H"),
+	})
+	if err != nil {
+		t.Fatalf("Expected to create a partial deck, but got error: %s", err.Error())
+	}
+
+	result, err := deckRepo.GetDeck(created.ID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error instead: %s", err.Error())
+	}
+	if result.Remaining != 3 || !compare(result.Cards, AsCards("KD,2C,5H")) {
+		t.Error("Expected to get the partial deck in the given order.")
+	}
+
+	_, err = deckRepo.GetDeck("00000000-0000-0000-0000-000000000000")
+	if !errors.IsNotFoundError(err) {
+		t.Error("Expected the error to be NotFoundError.")
+	}
+}
+
+func TestCompactDrawCards(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	created, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a full deck, but got error: %s", err.Error())
+	}
+
+	result, err := deckRepo.DrawCards(created.ID, 1)
+	if err != nil {
+		t.Fatalf("Expected to draw 1 card, but got an error instead: %s", err.Error())
+	}
+	if len(result) != 1 || result[0].Value != "AC" {
+		t.Fatal("Expected to draw exactly the 'AC' card.")
+	}
+
+	result, err = deckRepo.DrawCards(created.ID, 10)
+	if err != nil {
+		t.Fatalf("Expected to draw 10 cards, but got an error instead: %s", err.Error())
+	}
+	if !compare(result, AsCards("2C,3C,4C,5C,6C,7C,8C,9C,10C,JC")) {
+		t.Error("Expected to draw the next 10 cards in order.")
+	}
+
+	deck, err := deckRepo.GetDeck(created.ID)
+	if err != nil {
+		t.Fatal("Expected to get the deck back.")
+	}
+	if deck.Remaining != 41 || len(deck.Cards) != 41 {
+		t.Errorf("Expected the deck to have 41 remaining cards, but it has: %d", deck.Remaining)
+	}
+	if deck.Cards[0].Value != "QC" {
+		t.Errorf("Expected the next card to be 'QC', but got '%s'.", deck.Cards[0].Value)
+	}
+
+	_, err = deckRepo.DrawCards(created.ID, 42)
+	if !errors.IsBadRequestError(err) {
+		t.Error("Expected the overdraw error to be BadRequestError.")
+	}
+}
+
+func TestMigrateToCompactStorage(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	cardsRepo := NewDBDeckRepository(td.DB)
+	if _, err := cardsRepo.DrawCards(td.FullDeckID, 3); err != nil {
+		t.Fatalf("Expected to draw cards, but got error: %s", err.Error())
+	}
+
+	if _, err := MigrateToCompactStorage(td.DB); err != nil {
+		t.Fatalf("Expected to migrate the decks, but got error: %s", err.Error())
+	}
+
+	compactRepo := NewCompactDBDeckRepository(td.DB)
+
+	deck, err := compactRepo.GetDeck(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Expected to find the migrated deck, but got error: %s", err.Error())
+	}
+	if deck.Remaining != 49 || len(deck.Cards) != 49 || deck.Cards[0].Value != "4C" {
+		t.Error("Expected the migrated deck to keep the drawn cards out of the deck.")
+	}
+
+	deck, err = compactRepo.GetDeck(td.PartialDeckID)
+	if err != nil {
+		t.Fatalf("Expected to find the migrated deck, but got error: %s", err.Error())
+	}
+	if !compare(deck.Cards, AsCards("2C,5H,KD")) {
+		t.Error("Expected the migrated partial deck to keep the order of the cards.")
+	}
+
+	migrated, err := MigrateToCompactStorage(td.DB)
+	if err != nil {
+		t.Fatalf("Expected to run the migration again, but got error: %s", err.Error())
+	}
+	if migrated != 0 {
+		t.Errorf("Expected already migrated decks to be skipped, but migrated %d decks.", migrated)
+	}
+}
+
+func TestCompactShuffleDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	created, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a full deck, but got error: %s", err.Error())
+	}
+	drawn, err := deckRepo.DrawCards(created.ID, 2)
+	if err != nil {
+		t.Fatalf("Expected to draw 2 cards, but got an error instead: %s", err.Error())
+	}
+
+	result, err := deckRepo.ShuffleDeck(created.ID)
+	if err != nil {
+		t.Fatalf("Expected to shuffle the deck, but got an error instead: %s", err.Error())
+	}
+	if !result.Shuffled || result.Remaining != 50 {
+		t.Error("Expected the deck to be shuffled and to keep the 50 remaining cards.")
+	}
+
+	deck, err := deckRepo.GetDeck(created.ID)
+	if err != nil {
+		t.Fatal("Expected to get the deck back.")
+	}
+	if !compare(deck.Cards, result.Cards) {
+		t.Error("Expected the deck to keep the remaining cards in the shuffled order.")
+	}
+	for _, card := range deck.Cards {
+		if card.Value == drawn[0].Value || card.Value == drawn[1].Value {
+			t.Errorf("Expected the drawn card %s not to be put back into the deck.", card.Value)
+		}
+	}
+}
+
+func TestCompactTransaction_Rollback(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	created, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a full deck, but got error: %s", err.Error())
+	}
+
+	err = deckRepo.Transaction(func(repository DeckRepository) error {
+		if _, err := repository.DrawCards(created.ID, 50); err != nil {
+			return err
+		}
+		_, err := repository.DrawCards(created.ID, 5)
+		return err
+	})
+	if !errors.IsBadRequestError(err) {
+		t.Fatal("Expected the transaction to fail with an overdraw error.")
+	}
+
+	deck, err := deckRepo.GetDeck(created.ID)
+	if err != nil {
+		t.Fatal("Expected to get the deck back.")
+	}
+	if deck.Remaining != 52 {
+		t.Errorf("Expected the draw to be rolled back, but the deck has %d remaining cards.", deck.Remaining)
+	}
+}
+
+func TestCompactDrawCards_ConcurrentChanges(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewCompactDBDeckRepository(td.DB)
+
+	created, err := deckRepo.CreateDeck(&Deck{})
+	if err != nil {
+		t.Fatalf("Expected to create a full deck, but got error: %s", err.Error())
+	}
+
+	// change the deck before every conditional update, as a concurrent writer would
+	callback := td.DB.Callback().Update()
+	if err := callback.Before("gorm:update").Register("test:change_deck", func(tx *gorm.DB) {
+		if tx.Statement.Table == "compact_decks" {
+			tx.Session(&gorm.Session{NewDB: true}).Exec("UPDATE compact_decks SET version = version + 1 WHERE id = ?", created.ID)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to register the callback: %s", err.Error())
+	}
+	defer callback.Remove("test:change_deck")
+
+	if _, err := deckRepo.DrawCards(created.ID, 2); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError when drawing, got: %v", err)
+	}
+	if _, err := deckRepo.ShuffleDeck(created.ID); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError when shuffling, got: %v", err)
+	}
+}
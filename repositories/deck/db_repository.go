@@ -2,6 +2,8 @@ package deck
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -22,38 +24,17 @@ type DBDeckRepository struct {
 // If cards are supplied, it may return a ValidationError if some of the cards have multiple values or are
 // duplicates.
 func (d *DBDeckRepository) CreateDeck(deck *Deck) (*Deck, error) {
-	if deck.ID == "" {
-		deck.ID = uuid.New().String()
-	}
-
-	if deck.Cards == nil {
-		cards := NewFullDeck()
-		for i, card := range cards {
-			deck.Cards = append(deck.Cards, &Card{
-				DeckID: deck.ID,
-				Value:  card,
-				Idx:    i,
-			})
-		}
-	}
-
-	if err := ValidateDeckCards(deck.Cards); err != nil {
+	if err := prepareDeck(deck); err != nil {
 		return nil, err
 	}
 
-	if deck.Shuffled {
-		ShuffleDeck(deck.Cards)
-	}
-
-	deck.Remaining = len(deck.Cards)
-
 	if err := d.db.Transaction(func(tx *gorm.DB) error {
 		result := tx.Create(&deck)
 		if result.Error != nil {
 			return result.Error
 		}
 
-		return nil
+		return enqueueWebhooks(tx, WebhookDeckCreated, deck)
 	}); err != nil {
 		return nil, err
 	}
@@ -77,7 +58,7 @@ func (d *DBDeckRepository) GetDeck(deckID string) (*Deck, error) {
 
 	cards := []*Card{}
 
-	result = d.db.Debug().Where("deck_id = ? AND drawn IS FALSE", deckID).Order("idx").Find(&cards)
+	result = d.db.Where("deck_id = ? AND drawn IS FALSE", deckID).Order("idx").Find(&cards)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -100,7 +81,7 @@ func (d *DBDeckRepository) GetDeck(deckID string) (*Deck, error) {
 func (d *DBDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, error) {
 	var drawn []*Card
 	if err := d.db.Transaction(func(tx *gorm.DB) error {
-		deck, err := d.GetDeck(deckID)
+		deck, err := (&DBDeckRepository{db: tx}).GetDeck(deckID)
 		if err != nil {
 			return err
 		}
@@ -116,7 +97,7 @@ func (d *DBDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, erro
 		drawn = deck.Cards[0:numCards]
 		for _, card := range drawn {
 			card.Drawn = true
-			result := d.db.Save(card)
+			result := tx.Save(card)
 			if result.Error != nil {
 				return result.Error
 			}
@@ -124,10 +105,13 @@ func (d *DBDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, erro
 
 		deck.Remaining -= numCards
 
-		result := d.db.Save(deck)
+		result := tx.Omit("Cards").Save(deck)
 		if result.Error != nil {
 			return result.Error
 		}
+		if deck.Remaining == 0 {
+			return enqueueWebhooks(tx, WebhookDeckEmptied, deck)
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -136,6 +120,192 @@ func (d *DBDeckRepository) DrawCards(deckID string, numCards int) ([]*Card, erro
 	return drawn, nil
 }
 
+// ShuffleDeck shuffles the remaining cards in the deck. The drawn cards are not put back into the deck.
+// Returns the deck with the remaining cards in the new order.
+// If there is no deck with the given deckID, then a NotFoundError will be returned.
+func (d *DBDeckRepository) ShuffleDeck(deckID string) (*Deck, error) {
+	var deck *Deck
+	if err := d.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		deck, err = (&DBDeckRepository{db: tx}).GetDeck(deckID)
+		if err != nil {
+			return err
+		}
+
+		// The remaining cards take the positions of the remaining cards before the shuffle,
+		// so they stay after the drawn cards.
+		positions := make([]int, len(deck.Cards))
+		for i, card := range deck.Cards {
+			positions[i] = card.Idx
+		}
+
+		ShuffleDeck(deck.Cards)
+
+		for i, card := range deck.Cards {
+			card.Idx = positions[i]
+			result := tx.Model(card).Update("idx", card.Idx)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		deck.Shuffled = true
+
+		result := tx.Omit("Cards").Save(deck)
+		if result.Error != nil {
+			return result.Error
+		}
+		return enqueueWebhooks(tx, WebhookDeckShuffled, deck)
+	}); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ExportDeck looks up a deck of cards by its ID and returns the deck with all of its cards, including the drawn
+// ones, ordered by their position in the deck.
+// If there is no deck with the given ID, then a NotFound error is returned.
+func (d *DBDeckRepository) ExportDeck(deckID string) (*Deck, error) {
+	deck := &Deck{}
+
+	result := d.db.Where("id=?", deckID).Preload("Cards", func(db *gorm.DB) *gorm.DB {
+		return db.Order("idx")
+	}).First(deck)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such deck", nil)
+		}
+		return nil, result.Error
+	}
+
+	return deck, nil
+}
+
+// ImportDeck stores a previously exported deck as is: with its ID, drawn cards and the positions of the cards.
+// Returns a BadRequestError if a deck with the same ID already exists.
+func (d *DBDeckRepository) ImportDeck(deck *Deck) (*Deck, error) {
+	if err := prepareImport(deck); err != nil {
+		return nil, err
+	}
+
+	if err := d.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkDeckNotExists(tx, &Deck{}, deck.ID); err != nil {
+			return err
+		}
+
+		result := tx.Create(deck)
+		if result.Error != nil {
+			return result.Error
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ListDeckIDs returns the IDs of all stored decks, ordered by the time of creation.
+func (d *DBDeckRepository) ListDeckIDs() ([]string, error) {
+	deckIDs := []string{}
+
+	result := d.db.Model(&Deck{}).Order("created_at").Pluck("id", &deckIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deckIDs, nil
+}
+
+// prepareImport validates the cards of an imported deck and numbers the copies of the cards by their position in
+// the deck, as decks exported before shoes were supported do not hold the pack of the cards.
+func prepareImport(deck *Deck) error {
+	if deck.Packs == 0 {
+		deck.Packs = 1
+	}
+	if err := ValidateShoeCards(deck.Cards, deck.Packs); err != nil {
+		return err
+	}
+
+	cards := append([]*Card{}, deck.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Idx < cards[j].Idx
+	})
+	AssignPacks(cards)
+
+	return nil
+}
+
+// checkDeckNotExists returns a BadRequestError if there is a deck with the given ID in the table of the given model.
+func checkDeckNotExists(tx *gorm.DB, model interface{}, deckID string) error {
+	var count int64
+
+	result := tx.Model(model).Where("id = ?", deckID).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return api_errors.BadRequestError(fmt.Sprintf("deck already exists: %s", deckID), nil)
+	}
+
+	return nil
+}
+
+// Transaction executes the given function within a single database transaction. The DeckRepository passed to the
+// function is bound to the transaction. If the function returns an error, the transaction is rolled back.
+// Nested calls create nested transactions (save points), so a failure can be rolled back without aborting the
+// outer transaction.
+func (d *DBDeckRepository) Transaction(fn func(repository DeckRepository) error) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DBDeckRepository{db: tx})
+	})
+}
+
+func (d *DBDeckRepository) database() *gorm.DB {
+	return d.db
+}
+
+// prepareDeck prepares a new deck to be stored. Generates the deck ID and the full deck of cards (one full pack
+// after another, for a shoe) if not supplied, validates the cards and shuffles them if the deck should be shuffled.
+func prepareDeck(deck *Deck) error {
+	if deck.ID == "" {
+		deck.ID = uuid.New().String()
+	}
+	if deck.Packs == 0 {
+		deck.Packs = 1
+	}
+
+	if deck.Cards == nil && deck.Packs >= 1 && deck.Packs <= MaxPacks {
+		for pack := 0; pack < deck.Packs; pack++ {
+			for _, card := range NewFullDeck() {
+				deck.Cards = append(deck.Cards, &Card{
+					DeckID: deck.ID,
+					Value:  card,
+				})
+			}
+		}
+	}
+
+	if err := ValidateShoeCards(deck.Cards, deck.Packs); err != nil {
+		return err
+	}
+	AssignPacks(deck.Cards)
+
+	if deck.Shuffled {
+		ShuffleDeck(deck.Cards)
+	} else {
+		for i, card := range deck.Cards {
+			card.Idx = i
+		}
+	}
+
+	deck.Remaining = len(deck.Cards)
+
+	return nil
+}
+
 // NewDBDeckRepository creates a new DeckRepository with the given database connection.
 func NewDBDeckRepository(db *gorm.DB) DeckRepository {
 	return &DBDeckRepository{
@@ -149,9 +319,21 @@ func AutoMigrateDeckModels(db *gorm.DB) error {
 		return err
 	}
 
+	if err := migrateCardsPrimaryKey(db); err != nil {
+		return err
+	}
+
 	if err := db.AutoMigrate(&Card{}); err != nil {
 		return err
 	}
 
+	if err := db.AutoMigrate(&CompactDeck{}); err != nil {
+		return err
+	}
+
+	if err := db.AutoMigrate(&Dealer{}, &Player{}, &Pile{}, &Event{}, &Webhook{}, &WebhookDelivery{}); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package deck
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/natemago/card-games-api/errors"
@@ -289,3 +290,124 @@ func cardsInOrder(cards []*Card) bool {
 	}
 	return true
 }
+
+func TestShuffleDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewDBDeckRepository(td.DB)
+
+	if _, err := deckRepo.DrawCards(td.FullDeckID, 2); err != nil {
+		t.Fatalf("Expected to draw 2 cards, but got an error instead: %s", err.Error())
+	}
+
+	result, err := deckRepo.ShuffleDeck(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Expected to shuffle the deck, but got an error instead: %s", err.Error())
+	}
+	if !result.Shuffled || result.Remaining != 50 {
+		t.Error("Expected the deck to be shuffled and to keep the 50 remaining cards.")
+	}
+
+	deck, err := deckRepo.GetDeck(td.FullDeckID)
+	if err != nil {
+		t.Fatal("Expected to get the deck back.")
+	}
+	if len(deck.Cards) != 50 || !compare(deck.Cards, result.Cards) {
+		t.Error("Expected the deck to keep the remaining cards in the shuffled order.")
+	}
+	if compare(deck.Cards, AsCards(strings.Join(NewFullDeck()[2:], ","))) {
+		t.Error("Expected the remaining cards to be shuffled.")
+	}
+
+	_, err = deckRepo.ShuffleDeck("00000000-0000-0000-0000-000000000000")
+	if !errors.IsNotFoundError(err) {
+		t.Error("Expected the error to be NotFoundError.")
+	}
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	deckRepo := NewDBDeckRepository(td.DB)
+
+	var createdID string
+	err := deckRepo.Transaction(func(repository DeckRepository) error {
+		deck, err := repository.CreateDeck(&Deck{})
+		if err != nil {
+			return err
+		}
+		createdID = deck.ID
+
+		if _, err := repository.DrawCards(td.PartialDeckID, 2); err != nil {
+			return err
+		}
+
+		_, err = repository.DrawCards(td.PartialDeckID, 2)
+		return err
+	})
+	if !errors.IsBadRequestError(err) {
+		t.Fatal("Expected the transaction to fail with an overdraw error.")
+	}
+
+	if _, err := deckRepo.GetDeck(createdID); !errors.IsNotFoundError(err) {
+		t.Error("Expected the created deck to be rolled back.")
+	}
+
+	deck, err := deckRepo.GetDeck(td.PartialDeckID)
+	if err != nil {
+		t.Fatal("Expected to get the deck back.")
+	}
+	if deck.Remaining != 3 {
+		t.Errorf("Expected the draw to be rolled back, but the deck has %d remaining cards.", deck.Remaining)
+	}
+}
+
+func TestCreateDeck_Shoe(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	for layout, newRepository := range storageLayouts {
+		deckRepo := newRepository(td.DB)
+
+		shoe, err := deckRepo.CreateDeck(&Deck{Shuffled: true, Packs: 6})
+		if err != nil {
+			t.Fatalf("[%s] Expected to create a shoe, but got error: %s", layout, err.Error())
+		}
+		if shoe.Remaining != 6*52 || shoe.Packs != 6 {
+			t.Fatalf("[%s] Expected a shoe of 6 packs with %d cards, but got %d cards.", layout, 6*52, shoe.Remaining)
+		}
+
+		drawn, err := deckRepo.DrawCards(shoe.ID, 100)
+		if err != nil {
+			t.Fatalf("[%s] Expected to draw from the shoe, but got error: %s", layout, err.Error())
+		}
+		stored, err := deckRepo.GetDeck(shoe.ID)
+		if err != nil {
+			t.Fatalf("[%s] Expected to get the shoe, but got error: %s", layout, err.Error())
+		}
+		if len(drawn) != 100 || stored.Remaining != 6*52-100 || len(stored.Cards) != 6*52-100 || stored.Packs != 6 {
+			t.Errorf("[%s] Expected %d cards to remain in the shoe, but got %d.", layout, 6*52-100, len(stored.Cards))
+		}
+
+		exported, err := deckRepo.ExportDeck(shoe.ID)
+		if err != nil {
+			t.Fatalf("[%s] Expected to export the shoe, but got error: %s", layout, err.Error())
+		}
+		decks, err := NewDeckExport(exported).ToDecks(true)
+		if err != nil {
+			t.Fatalf("[%s] Expected to convert the exported shoe, but got error: %s", layout, err.Error())
+		}
+		if _, err := deckRepo.ImportDeck(decks[0]); err != nil {
+			t.Fatalf("[%s] Expected to import the shoe, but got error: %s", layout, err.Error())
+		}
+
+		if _, err := deckRepo.CreateDeck(&Deck{Packs: 2, Cards: AsCards("AC,AC,AC")}); !errors.IsValidationError(err) {
+			t.Errorf("[%s] Expected a ValidationError for three copies of a card in two packs, but got: %v", layout, err)
+		}
+		if _, err := deckRepo.CreateDeck(&Deck{Packs: MaxPacks + 1}); !errors.IsValidationError(err) {
+			t.Errorf("[%s] Expected a ValidationError for too many packs, but got: %v", layout, err)
+		}
+	}
+}
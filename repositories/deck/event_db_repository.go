@@ -0,0 +1,42 @@
+package deck
+
+import "gorm.io/gorm"
+
+// DBEventRepository implements EventRepository storing the event log of the decks in the database.
+type DBEventRepository struct {
+	db *gorm.DB
+}
+
+// AppendEvent stores the event at the end of the event log of its deck, assigning it the next ID.
+func (r *DBEventRepository) AppendEvent(event *Event) (*Event, error) {
+	if result := r.db.Create(event); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return event, nil
+}
+
+// ListEvents returns up to limit events of a deck with an ID greater than afterID, in the order they happened.
+func (r *DBEventRepository) ListEvents(deckID string, afterID uint64, limit int) ([]*Event, error) {
+	events := []*Event{}
+
+	result := r.db.Where("deck_id=? and id>?", deckID, afterID).Order("id").Limit(limit).Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+// Bind returns the EventRepository bound to the transaction of the DeckRepository passed to the function of
+// DeckRepository.Transaction. Returns the repository itself if the DeckRepository is not stored in a database.
+func (r *DBEventRepository) Bind(repository DeckRepository) EventRepository {
+	return &DBEventRepository{db: boundDatabase(repository, r.db)}
+}
+
+// NewDBEventRepository creates a new EventRepository with the given database connection.
+func NewDBEventRepository(db *gorm.DB) EventRepository {
+	return &DBEventRepository{
+		db: db,
+	}
+}
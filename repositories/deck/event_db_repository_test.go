@@ -0,0 +1,70 @@
+package deck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestEvents(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBEventRepository(td.DB)
+
+	first, err := repo.AppendEvent(&Event{DeckID: td.FullDeckID, Type: "drawn", Remaining: 50, Count: 2, Cards: "AC,2C"})
+	if err != nil {
+		t.Fatalf("Failed to append an event: %s", err.Error())
+	}
+	if _, err := repo.AppendEvent(&Event{DeckID: td.PartialDeckID, Type: "shuffled", Remaining: 3}); err != nil {
+		t.Fatalf("Failed to append an event: %s", err.Error())
+	}
+	second, err := repo.AppendEvent(&Event{DeckID: td.FullDeckID, Type: "shuffled", Remaining: 50})
+	if err != nil {
+		t.Fatalf("Failed to append an event: %s", err.Error())
+	}
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Errorf("Expected increasing event IDs, but got %d and %d.", first.ID, second.ID)
+	}
+
+	events, err := repo.ListEvents(td.FullDeckID, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to list the events: %s", err.Error())
+	}
+	if len(events) != 2 || events[0].ID != first.ID || events[0].Cards != "AC,2C" || events[1].ID != second.ID {
+		t.Errorf("Expected the events of the deck in order, but got: %+v", events)
+	}
+
+	if events, _ = repo.ListEvents(td.FullDeckID, first.ID, 10); len(events) != 1 || events[0].ID != second.ID {
+		t.Errorf("Expected only the events after the first one, but got: %+v", events)
+	}
+	if events, _ = repo.ListEvents(td.FullDeckID, 0, 1); len(events) != 1 || events[0].ID != first.ID {
+		t.Errorf("Expected the number of events to be limited, but got: %+v", events)
+	}
+}
+
+func TestEvents_Bind(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBEventRepository(td.DB)
+	decks := NewCachingDeckRepository(NewDBDeckRepository(td.DB), 10, time.Minute)
+
+	err := decks.Transaction(func(decks DeckRepository) error {
+		if _, err := decks.DrawCards(td.FullDeckID, 1); err != nil {
+			return err
+		}
+		if _, err := repo.Bind(decks).AppendEvent(&Event{DeckID: td.FullDeckID, Type: "drawn", Remaining: 51, Count: 1}); err != nil {
+			return err
+		}
+		return errors.BadRequestError("roll back", nil)
+	})
+	if !errors.IsBadRequestError(err) {
+		t.Fatalf("Expected the transaction to be rolled back, but got: %v", err)
+	}
+
+	events, err := repo.ListEvents(td.FullDeckID, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to list the events: %s", err.Error())
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected the event to be rolled back with the draw, but got: %+v", events)
+	}
+}
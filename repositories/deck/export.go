@@ -0,0 +1,163 @@
+package deck
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/natemago/card-games-api/errors"
+)
+
+// DeckExportVersion is the current version of the deck export document format.
+const DeckExportVersion = 1
+
+// DeckExport is a versioned document holding full decks of cards, including the drawn cards,
+// used to backup decks and to move decks between environments and storage layouts.
+type DeckExport struct {
+	// Version is the version of the document format.
+	Version int `json:"version"`
+
+	// ExportedAt is the time when the decks were exported.
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Decks is the list of exported decks.
+	Decks []DeckDocument `json:"decks"`
+}
+
+// DeckDocument holds an exported deck of cards.
+type DeckDocument struct {
+	// ID is the id of the deck.
+	ID string `json:"id"`
+
+	// CreatedAt is the time when the deck was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the time when the deck was last updated.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Shuffled flag - whether the deck is shuffled.
+	Shuffled bool `json:"shuffled"`
+
+	// Remaining is the number of remaining cards in the deck.
+	Remaining int `json:"remaining"`
+
+	// Packs is the number of packs of cards the deck is made of. Omitted for a single pack.
+	Packs int `json:"packs,omitempty"`
+
+	// Cards is the list of all cards in the deck, including the drawn ones, in order.
+	Cards []CardDocument `json:"cards"`
+}
+
+// CardDocument holds an exported card.
+type CardDocument struct {
+	// Value is the card code, like "AC" or "10H".
+	Value string `json:"value"`
+
+	// Drawn is a flag whether the card was drawn from the deck.
+	Drawn bool `json:"drawn"`
+
+	// Idx is the position of the card in the deck.
+	Idx int `json:"idx"`
+}
+
+// NewDeckExport creates a new export document holding the given decks.
+// The decks must hold all of their cards, including the drawn ones (see DeckRepository.ExportDeck).
+func NewDeckExport(decks ...*Deck) *DeckExport {
+	export := &DeckExport{
+		Version:    DeckExportVersion,
+		ExportedAt: time.Now(),
+		Decks:      []DeckDocument{},
+	}
+
+	for _, deck := range decks {
+		document := DeckDocument{
+			ID:        deck.ID,
+			CreatedAt: deck.CreatedAt,
+			UpdatedAt: deck.UpdatedAt,
+			Shuffled:  deck.Shuffled,
+			Remaining: deck.Remaining,
+			Packs:     deck.Packs,
+			Cards:     []CardDocument{},
+		}
+		for _, card := range deck.Cards {
+			document.Cards = append(document.Cards, CardDocument{
+				Value: card.Value,
+				Drawn: card.Drawn,
+				Idx:   card.Idx,
+			})
+		}
+		export.Decks = append(export.Decks, document)
+	}
+
+	return export
+}
+
+// ToDecks validates the export document and converts it back to decks of cards ready to be imported.
+// If remapIDs is set, every deck gets a newly generated ID, otherwise the original IDs are preserved.
+// Returns a ValidationError if the document version is not supported, or if any of the decks has invalid cards,
+// more copies of a card than packs, duplicate card positions or a number of remaining cards that does not match the cards.
+func (e *DeckExport) ToDecks(remapIDs bool) ([]*Deck, error) {
+	if e.Version != DeckExportVersion {
+		return nil, errors.ValidationError(fmt.Sprintf("unsupported export version: %d", e.Version), nil)
+	}
+
+	decks := []*Deck{}
+
+	for _, document := range e.Decks {
+		deckID := document.ID
+		if remapIDs || deckID == "" {
+			deckID = uuid.New().String()
+		}
+
+		deck := &Deck{
+			ID:        deckID,
+			CreatedAt: document.CreatedAt,
+			UpdatedAt: document.UpdatedAt,
+			Shuffled:  document.Shuffled,
+			Remaining: document.Remaining,
+			Packs:     document.Packs,
+			Cards:     []*Card{},
+		}
+
+		positions := map[int]bool{}
+		remaining := 0
+		for _, cardDocument := range document.Cards {
+			if positions[cardDocument.Idx] {
+				return nil, errors.ValidationError(fmt.Sprintf("deck %s: duplicate card position: %d", document.ID, cardDocument.Idx), nil)
+			}
+			positions[cardDocument.Idx] = true
+
+			if !cardDocument.Drawn {
+				remaining++
+			}
+
+			deck.Cards = append(deck.Cards, &Card{
+				DeckID: deckID,
+				Value:  cardDocument.Value,
+				Drawn:  cardDocument.Drawn,
+				Idx:    cardDocument.Idx,
+			})
+		}
+
+		if deck.Packs == 0 {
+			deck.Packs = 1
+		}
+		if err := ValidateShoeCards(deck.Cards, deck.Packs); err != nil {
+			return nil, errors.ValidationError(fmt.Sprintf("deck %s: %s", document.ID, err.Error()), err)
+		}
+
+		if remaining != document.Remaining {
+			return nil, errors.ValidationError(fmt.Sprintf("deck %s: expected %d remaining cards, but the deck has %d cards not drawn", document.ID, document.Remaining, remaining), nil)
+		}
+
+		sort.Slice(deck.Cards, func(i, j int) bool {
+			return deck.Cards[i].Idx < deck.Cards[j].Idx
+		})
+		AssignPacks(deck.Cards)
+
+		decks = append(decks, deck)
+	}
+
+	return decks, nil
+}
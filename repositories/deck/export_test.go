@@ -0,0 +1,138 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestDeckExport_ToDecks(t *testing.T) {
+	export := NewDeckExport(&Deck{
+		ID:        "deck-1",
+		Remaining: 2,
+		Cards: []*Card{
+			{Value: "KD", Idx: 2},
+			{Value: "AC", Idx: 0, Drawn: true},
+			{Value: "5H", Idx: 1},
+		},
+	})
+
+	decks, err := export.ToDecks(false)
+	if err != nil {
+		t.Fatalf("Expected to convert the document, but got error: %s", err.Error())
+	}
+	if len(decks) != 1 || decks[0].ID != "deck-1" {
+		t.Fatal("Expected to get the deck with the original id.")
+	}
+	if !compare(decks[0].Cards, AsCards("AC,5H,KD")) || !decks[0].Cards[0].Drawn {
+		t.Error("Expected the cards to be ordered by position and to keep the drawn state.")
+	}
+
+	decks, err = export.ToDecks(true)
+	if err != nil {
+		t.Fatalf("Expected to convert the document, but got error: %s", err.Error())
+	}
+	if decks[0].ID == "deck-1" || decks[0].Cards[0].DeckID != decks[0].ID {
+		t.Error("Expected the deck to get a new id.")
+	}
+}
+
+func TestDeckExport_ToDecks_Invalid(t *testing.T) {
+	documents := map[string]*DeckExport{
+		"unsupported export version: 2": {
+			Version: 2,
+		},
+		"deck d: invalid cards values: AC": {
+			Version: DeckExportVersion,
+			Decks: []DeckDocument{
+				{ID: "d", Remaining: 2, Cards: []CardDocument{{Value: "AC", Idx: 0}, {Value: "AC", Idx: 1}}},
+			},
+		},
+		"deck d: duplicate card position: 0": {
+			Version: DeckExportVersion,
+			Decks: []DeckDocument{
+				{ID: "d", Remaining: 2, Cards: []CardDocument{{Value: "AC", Idx: 0}, {Value: "KC", Idx: 0}}},
+			},
+		},
+		"deck d: expected 2 remaining cards, but the deck has 1 cards not drawn": {
+			Version: DeckExportVersion,
+			Decks: []DeckDocument{
+				{ID: "d", Remaining: 2, Cards: []CardDocument{{Value: "AC", Idx: 0, Drawn: true}, {Value: "KC", Idx: 1}}},
+			},
+		},
+	}
+
+	for message, document := range documents {
+		_, err := document.ToDecks(false)
+		if !errors.IsValidationError(err) {
+			t.Errorf("Expected a validation error '%s', but got: %v", message, err)
+			continue
+		}
+		if err.Error() != message {
+			t.Errorf("Expected the validation error '%s', but got '%s' instead.", message, err.Error())
+		}
+	}
+}
+
+func TestExportImportDeck(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	for layout, newRepository := range storageLayouts {
+		deckRepo := newRepository(td.DB)
+
+		created, err := deckRepo.CreateDeck(&Deck{Shuffled: true})
+		if err != nil {
+			t.Fatalf("[%s] Expected to create a deck, but got error: %s", layout, err.Error())
+		}
+		if _, err := deckRepo.DrawCards(created.ID, 5); err != nil {
+			t.Fatalf("[%s] Expected to draw cards, but got error: %s", layout, err.Error())
+		}
+
+		exported, err := deckRepo.ExportDeck(created.ID)
+		if err != nil {
+			t.Fatalf("[%s] Expected to export the deck, but got error: %s", layout, err.Error())
+		}
+		if len(exported.Cards) != 52 || !exported.Cards[4].Drawn || exported.Cards[5].Drawn {
+			t.Errorf("[%s] Expected to export all cards with the first 5 drawn.", layout)
+		}
+
+		if _, err := deckRepo.ImportDeck(exported); !errors.IsBadRequestError(err) {
+			t.Errorf("[%s] Expected importing an existing deck to fail with BadRequestError, but got: %v", layout, err)
+		}
+
+		decks, err := NewDeckExport(exported).ToDecks(true)
+		if err != nil {
+			t.Fatalf("[%s] Expected to convert the document, but got error: %s", layout, err.Error())
+		}
+		if _, err := deckRepo.ImportDeck(decks[0]); err != nil {
+			t.Fatalf("[%s] Expected to import the deck, but got error: %s", layout, err.Error())
+		}
+
+		original, _ := deckRepo.GetDeck(created.ID)
+		imported, err := deckRepo.GetDeck(decks[0].ID)
+		if err != nil {
+			t.Fatalf("[%s] Expected to get the imported deck, but got error: %s", layout, err.Error())
+		}
+		if imported.Remaining != 47 || !compare(original.Cards, imported.Cards) {
+			t.Errorf("[%s] Expected the imported deck to have the same remaining cards in the same order.", layout)
+		}
+	}
+}
+
+func TestCompactImportDeck_DrawnCardNotOnTop(t *testing.T) {
+	td, tearDown := setupTest(t)
+	defer tearDown(t)
+
+	_, err := NewCompactDBDeckRepository(td.DB).ImportDeck(&Deck{
+		ID:        "not-on-top",
+		Remaining: 1,
+		Cards: []*Card{
+			{Value: "AC", Idx: 0},
+			{Value: "KC", Idx: 1, Drawn: true},
+		},
+	})
+	if !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError, but got: %v", err)
+	}
+}
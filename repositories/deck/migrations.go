@@ -0,0 +1,109 @@
+package deck
+
+import (
+	"fmt"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"gorm.io/gorm"
+)
+
+// singlePackCard is the Card as stored before decks could be made of more than one pack, keyed by the deck and the
+// value of the card only. Used to restore the cards table by RevertCardsPrimaryKey.
+type singlePackCard struct {
+	DeckID string `gorm:"primaryKey"`
+	Value  string `gorm:"primaryKey"`
+	Drawn  bool
+	Idx    int
+}
+
+// TableName sets the table of the single pack cards to the cards table.
+func (singlePackCard) TableName() string {
+	return "cards"
+}
+
+// migrateCardsPrimaryKey adds the pack to the primary key of a cards table created before decks could be made of
+// more than one pack, so the same card value can appear more than once in a deck. The existing cards are all
+// from the first pack. PostgreSQL alters the primary key in place, while SQLite cannot alter a primary key, so
+// the table is rebuilt. The migration is performed within a single transaction, and is reverted by
+// RevertCardsPrimaryKey.
+func migrateCardsPrimaryKey(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&Card{}) || migrator.HasColumn(&Card{}, "Pack") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			return execAll(tx,
+				"ALTER TABLE cards ADD COLUMN pack bigint NOT NULL DEFAULT 1",
+				"ALTER TABLE cards DROP CONSTRAINT cards_pkey",
+				"ALTER TABLE cards ADD PRIMARY KEY (deck_id, value, pack)",
+			)
+		}
+
+		migrator := tx.Migrator()
+		if err := migrator.RenameTable("cards", "cards_single_pack"); err != nil {
+			return err
+		}
+		if err := migrator.CreateTable(&Card{}); err != nil {
+			return err
+		}
+		if err := execAll(tx, "INSERT INTO cards (deck_id, value, pack, drawn, idx) SELECT deck_id, value, 1, drawn, idx FROM cards_single_pack"); err != nil {
+			return err
+		}
+		return migrator.DropTable("cards_single_pack")
+	})
+}
+
+// RevertCardsPrimaryKey reverts the migration of the cards table made by AutoMigrateDeckModels to hold decks of more
+// than one pack: the pack is removed from the cards and from their primary key, so the cards table can be used by a
+// version of the API from before the shoes. The packs of the decks are kept, as the older versions ignore them.
+// The cards of a deck can be reverted only if they are all from the first pack, so if any deck has cards of another
+// pack, returns a ValidationError and nothing is reverted. The migration is performed within a single transaction.
+func RevertCardsPrimaryKey(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&Card{}) || !migrator.HasColumn(&Card{}, "Pack") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var shoes int64
+		if result := tx.Model(&Card{}).Where("pack <> ?", 1).Distinct("deck_id").Count(&shoes); result.Error != nil {
+			return result.Error
+		}
+		if shoes > 0 {
+			return api_errors.ValidationError(
+				fmt.Sprintf("%d decks have cards of more than one pack, and cannot be stored without the pack", shoes), nil)
+		}
+
+		if tx.Dialector.Name() == "postgres" {
+			return execAll(tx,
+				"ALTER TABLE cards DROP CONSTRAINT cards_pkey",
+				"ALTER TABLE cards ADD PRIMARY KEY (deck_id, value)",
+				"ALTER TABLE cards DROP COLUMN pack",
+			)
+		}
+
+		migrator := tx.Migrator()
+		if err := migrator.RenameTable("cards", "cards_multi_pack"); err != nil {
+			return err
+		}
+		if err := migrator.CreateTable(&singlePackCard{}); err != nil {
+			return err
+		}
+		if err := execAll(tx, "INSERT INTO cards (deck_id, value, drawn, idx) SELECT deck_id, value, drawn, idx FROM cards_multi_pack"); err != nil {
+			return err
+		}
+		return migrator.DropTable("cards_multi_pack")
+	})
+}
+
+// execAll executes the statements in order, and stops at the first error.
+func execAll(db *gorm.DB, statements ...string) error {
+	for _, statement := range statements {
+		if result := db.Exec(statement); result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupSinglePackTest creates the decks and cards tables as created before decks could be made of more than one
+// pack, in a new database with the given name, with the deck "old" of two cards, one of them drawn.
+func setupSinglePackTest(t *testing.T, name string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+name+"?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+
+	for _, statement := range []string{
+		"CREATE TABLE decks (id text, created_at datetime, updated_at datetime, shuffled numeric, remaining integer, PRIMARY KEY (id))",
+		"CREATE TABLE cards (deck_id text, value text, drawn numeric, idx integer, PRIMARY KEY (deck_id, value))",
+		"INSERT INTO decks (id, shuffled, remaining) VALUES ('old', false, 1)",
+		"INSERT INTO cards (deck_id, value, drawn, idx) VALUES ('old', 'AC', true, 0), ('old', 'KH', false, 1)",
+	} {
+		if result := db.Exec(statement); result.Error != nil {
+			t.Fatalf("Failed to create the single pack tables: %s", result.Error.Error())
+		}
+	}
+
+	return db
+}
+
+func TestAutoMigrateDeckModels_SinglePackCards(t *testing.T) {
+	db := setupSinglePackTest(t, "single_pack")
+
+	if err := AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Expected to migrate the cards table, but got error: %s", err.Error())
+	}
+
+	deckRepo := NewDBDeckRepository(db)
+	deck, err := deckRepo.GetDeck("old")
+	if err != nil {
+		t.Fatalf("Expected to get the migrated deck, but got error: %s", err.Error())
+	}
+	if len(deck.Cards) != 1 || deck.Cards[0].Value != "KH" || deck.Cards[0].Pack != 1 {
+		t.Errorf("Expected the migrated cards to be from the first pack, but got: %+v", deck.Cards)
+	}
+
+	if _, err := deckRepo.CreateDeck(&Deck{Packs: 2, Cards: AsCards("AC,AC")}); err != nil {
+		t.Errorf("Expected the migrated table to hold two copies of a card, but got error: %s", err.Error())
+	}
+	if _, err := deckRepo.DrawCards("old", 1); err != nil {
+		t.Errorf("Expected to draw from the migrated deck, but got error: %s", err.Error())
+	}
+}
+
+func TestRevertCardsPrimaryKey(t *testing.T) {
+	db := setupSinglePackTest(t, "revert_packs")
+
+	if err := AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Expected to migrate the cards table, but got error: %s", err.Error())
+	}
+	if _, err := NewDBDeckRepository(db).CreateDeck(&Deck{Cards: AsCards("2S,3S")}); err != nil {
+		t.Fatalf("Failed to create deck: %s", err.Error())
+	}
+
+	if err := RevertCardsPrimaryKey(db); err != nil {
+		t.Fatalf("Expected to revert the cards table, but got error: %s", err.Error())
+	}
+	if db.Migrator().HasColumn(&Card{}, "Pack") {
+		t.Fatal("Expected the pack to be removed from the cards table.")
+	}
+
+	var cards []*singlePackCard
+	if result := db.Where("deck_id = ?", "old").Order("idx").Find(&cards); result.Error != nil {
+		t.Fatalf("Failed to list the reverted cards: %s", result.Error.Error())
+	}
+	if len(cards) != 2 || cards[0].Value != "AC" || !cards[0].Drawn || cards[1].Value != "KH" || cards[1].Drawn {
+		t.Errorf("Expected the reverted cards to be kept as they were, but got: %+v", cards)
+	}
+	var count int64
+	if result := db.Model(&singlePackCard{}).Count(&count); result.Error != nil || count != 4 {
+		t.Errorf("Expected the cards of all decks to be kept, but got %d cards.", count)
+	}
+
+	result := db.Exec("INSERT INTO cards (deck_id, value, drawn, idx) VALUES ('old', 'AC', false, 2)")
+	if result.Error == nil {
+		t.Error("Expected the reverted primary key to reject a second copy of a card.")
+	}
+
+	// Reverting again changes nothing, and the next start migrates the table again.
+	if err := RevertCardsPrimaryKey(db); err != nil {
+		t.Fatalf("Expected to revert the reverted cards table again, but got error: %s", err.Error())
+	}
+	if err := AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Expected to migrate the reverted cards table, but got error: %s", err.Error())
+	}
+	deck, err := NewDBDeckRepository(db).GetDeck("old")
+	if err != nil {
+		t.Fatalf("Expected to get the migrated deck, but got error: %s", err.Error())
+	}
+	if len(deck.Cards) != 1 || deck.Cards[0].Value != "KH" || deck.Cards[0].Pack != 1 {
+		t.Errorf("Expected the migrated cards to be from the first pack, but got: %+v", deck.Cards)
+	}
+}
+
+func TestRevertCardsPrimaryKey_Shoe(t *testing.T) {
+	db := setupSinglePackTest(t, "revert_packs_shoe")
+
+	if err := AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Expected to migrate the cards table, but got error: %s", err.Error())
+	}
+	if _, err := NewDBDeckRepository(db).CreateDeck(&Deck{Packs: 2, Cards: AsCards("AC,AC")}); err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+
+	err := RevertCardsPrimaryKey(db)
+	if err == nil {
+		t.Fatal("Expected an error for reverting the cards of a shoe.")
+	}
+	if !errors.IsValidationError(err) {
+		t.Errorf("Expected a validation error, but got: %s", err.Error())
+	}
+
+	if !db.Migrator().HasColumn(&Card{}, "Pack") {
+		t.Fatal("Expected the cards table to be left as it was.")
+	}
+	var count int64
+	if result := db.Model(&Card{}).Count(&count); result.Error != nil || count != 4 {
+		t.Errorf("Expected all of the cards to be kept, but got %d cards.", count)
+	}
+}
@@ -1,6 +1,7 @@
 package deck
 
 import (
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,10 @@ type Deck struct {
 	// Remaining is the number of remaining cards in the deck.
 	Remaining int
 
+	// Packs is the number of packs of cards the deck is made of. A deck of more than one pack is a shoe, where
+	// every card may appear up to Packs times. Zero means a single pack.
+	Packs int
+
 	// Cards is the list of actual cards, in the given order (proper or shuffled) in the deck.
 	Cards []*Card
 }
@@ -33,6 +38,9 @@ type Card struct {
 	// Value is the actual value of the card (code). For example: "AC", "10S", "KH" etc.
 	Value string `gorm:"primaryKey"`
 
+	// Pack is the pack the card comes from, starting at 1. It tells apart the copies of a card in a shoe.
+	Pack int `gorm:"primaryKey;autoIncrement:false;default:1"`
+
 	// Drawn is a flag whether this card was drawn or not.
 	Drawn bool
 
@@ -65,3 +73,248 @@ func (c *Card) RankName() string {
 	}
 	return rankName
 }
+
+// CompactDeck represents the database model for a deck of cards stored in the compact storage layout.
+// Instead of one row per card, the order of the cards is stored as a single byte-encoded column (see EncodeCards),
+// and the cards before the DrawPointer are the ones already drawn from the deck.
+type CompactDeck struct {
+	// ID is a unique identifier for this deck, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this deck was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this deck was last updated.
+	UpdatedAt time.Time
+
+	// Shuffled flag - whether this deck is shuffled.
+	Shuffled bool
+
+	// Remaining is the number of remaining cards in the deck.
+	Remaining int
+
+	// Packs is the number of packs of cards the deck is made of.
+	Packs int
+
+	// Order holds the encoded cards of the deck, one byte per card, in the given order (proper or shuffled).
+	Order []byte
+
+	// DrawPointer is the position in Order of the next card to be drawn.
+	DrawPointer int
+
+	// Version is incremented on every change of the deck. Used to detect concurrent changes of the deck.
+	Version int
+}
+
+// Dealer represents the database model for the dealer of a deck. The dealer deals the cards of the deck to the
+// players and sees all of the cards, including the hands of the players.
+type Dealer struct {
+	// DeckID is the foreign key to the dealt deck.
+	DeckID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when the dealer was created.
+	CreatedAt time.Time
+
+	// TokenHash is the hash of the secret token of the dealer (see HashToken). The token itself is not stored.
+	TokenHash string
+
+	// Private hides the cards of the deck from everyone but the dealer, and lets only the dealer draw from and shuffle
+	// the deck, even before the deck has players. Set for the decks owned by a game, like the deck of a table.
+	Private bool
+}
+
+// TableName returns the name of the database table of Dealer.
+func (d *Dealer) TableName() string {
+	return "deck_dealers"
+}
+
+// Player represents the database model for a player holding a hand of cards dealt from a deck. Only the player and
+// the dealer see the cards in the hand.
+type Player struct {
+	// ID is a unique identifier for this player, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when the player joined the deck.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when the player was last updated.
+	UpdatedAt time.Time
+
+	// DeckID is the foreign key to the deck the player is dealt from.
+	DeckID string `gorm:"index"`
+
+	// Name is the name of the player.
+	Name string
+
+	// TokenHash is the hash of the secret token of the player (see HashToken). The token itself is not stored.
+	TokenHash string `gorm:"uniqueIndex"`
+
+	// Hand holds the codes of the cards in the hand of the player, comma separated.
+	Hand string
+
+	// Version is incremented on every change of the player. Used to detect concurrent changes of the hand.
+	Version int
+}
+
+// TableName returns the name of the database table of Player.
+func (p *Player) TableName() string {
+	return "deck_players"
+}
+
+// HandCards returns the cards in the hand of the player.
+func (p *Player) HandCards() []*Card {
+	return AsCards(p.Hand)
+}
+
+// Pile represents the database model for a public pile of face-up cards on the table, like a discard pile. Every
+// player sees the cards of the piles.
+type Pile struct {
+	// DeckID is the foreign key to the deck the cards come from.
+	DeckID string `gorm:"primaryKey"`
+
+	// Name is the name of the pile, unique for the deck.
+	Name string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when the pile was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when the pile was last updated.
+	UpdatedAt time.Time
+
+	// Cards holds the codes of the cards of the pile, comma separated, with the top card last.
+	Cards string
+}
+
+// TableName returns the name of the database table of Pile.
+func (p *Pile) TableName() string {
+	return "deck_piles"
+}
+
+// PileCards returns the cards of the pile, with the top card last.
+func (p *Pile) PileCards() []*Card {
+	return AsCards(p.Cards)
+}
+
+// Event represents the database model for a change of a deck, kept in the event log of the deck so the clients
+// can catch up with the changes they missed.
+type Event struct {
+	// ID is the sequence number of the event, increasing with every event.
+	ID uint64 `gorm:"primaryKey;autoIncrement"`
+
+	// DeckID is the foreign key to the changed deck.
+	DeckID string `gorm:"index"`
+
+	// CreatedAt is the time of the change.
+	CreatedAt time.Time
+
+	// Type is the type of the change, like "drawn".
+	Type string
+
+	// Remaining is the number of remaining cards in the deck after the change.
+	Remaining int
+
+	// Count is the number of drawn or moved cards.
+	Count int
+
+	// Cards holds the codes of the public cards of the change, comma separated.
+	Cards string
+
+	// PlayerID is the id of the player, for the cards drawn into or moved from a hand.
+	PlayerID string
+
+	// Pile is the name of the pile the cards were moved onto.
+	Pile string
+}
+
+// TableName returns the name of the database table of Event.
+func (e *Event) TableName() string {
+	return "deck_events"
+}
+
+// Webhook represents the database model for a webhook subscription: the events of the decks are posted to the URL of
+// the webhook, signed with its secret.
+type Webhook struct {
+	// ID is the id of the webhook.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when the webhook was created.
+	CreatedAt time.Time
+
+	// DeckID is the id of the deck the webhook subscribes to. Empty for a global webhook, that subscribes to every deck.
+	DeckID string `gorm:"index"`
+
+	// URL is the URL the events are posted to.
+	URL string
+
+	// Secret is the secret key used to sign the payloads (see SignPayload).
+	Secret string
+
+	// Events holds the subscribed event types, comma separated. Empty subscribes to every event type.
+	Events string
+}
+
+// TableName returns the name of the database table of Webhook.
+func (w *Webhook) TableName() string {
+	return "deck_webhooks"
+}
+
+// Subscribes returns true if the webhook subscribes to the event type.
+func (w *Webhook) Subscribes(eventType string) bool {
+	if w.Events == "" {
+		return true
+	}
+	for _, subscribed := range strings.Split(w.Events, ",") {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery represents the database model for the delivery of an event to a webhook. The deliveries are the
+// outbox of the webhooks: they are stored in the same transaction as the change of the deck, and delivered later.
+type WebhookDelivery struct {
+	// ID is the id of the delivery, increasing with every delivery.
+	ID uint64 `gorm:"primaryKey;autoIncrement"`
+
+	// CreatedAt is the time of the event.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time of the last delivery attempt.
+	UpdatedAt time.Time
+
+	// WebhookID is the foreign key to the webhook.
+	WebhookID string `gorm:"index"`
+
+	// DeckID is the id of the changed deck.
+	DeckID string
+
+	// Event is the type of the event, like WebhookDeckCreated.
+	Event string
+
+	// Payload is the JSON payload posted to the webhook (see WebhookPayload).
+	Payload string
+
+	// Attempts is the number of delivery attempts made.
+	Attempts int
+
+	// NextAttemptAt is the time of the next delivery attempt.
+	NextAttemptAt time.Time `gorm:"index"`
+
+	// DeliveredAt is the time when the event was delivered. Nil until the event is delivered.
+	DeliveredAt *time.Time
+
+	// Dead is true once the delivery is given up on, after too many failed attempts.
+	Dead bool
+
+	// LastError is the error of the last failed delivery attempt.
+	LastError string
+
+	// Version is the version of the delivery, increased with every change.
+	Version int
+}
+
+// TableName returns the name of the database table of WebhookDelivery.
+func (w *WebhookDelivery) TableName() string {
+	return "deck_webhook_deliveries"
+}
@@ -0,0 +1,180 @@
+package deck
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+// DBPlayerRepository implements PlayerRepository storing the dealers, players and piles in the database.
+type DBPlayerRepository struct {
+	db *gorm.DB
+}
+
+// CreateDealer stores the dealer of a deck.
+// Returns a BadRequestError if the deck already has a dealer.
+func (r *DBPlayerRepository) CreateDealer(dealer *Dealer) (*Dealer, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if result := tx.Model(&Dealer{}).Where("deck_id=?", dealer.DeckID).Count(&count); result.Error != nil {
+			return result.Error
+		}
+		if count > 0 {
+			return api_errors.BadRequestError(fmt.Sprintf("deck %s already has a dealer", dealer.DeckID), nil)
+		}
+		return tx.Create(dealer).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dealer, nil
+}
+
+// GetDealer looks up the dealer of a deck.
+// If the deck has no dealer, then a NotFoundError is returned.
+func (r *DBPlayerRepository) GetDealer(deckID string) (*Dealer, error) {
+	dealer := &Dealer{}
+
+	result := r.db.Where("deck_id=?", deckID).First(dealer)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such dealer", nil)
+		}
+		return nil, result.Error
+	}
+
+	return dealer, nil
+}
+
+// CreatePlayer stores a new player of a deck. If the player has no ID, a new ID is generated.
+func (r *DBPlayerRepository) CreatePlayer(player *Player) (*Player, error) {
+	if player.ID == "" {
+		player.ID = uuid.NewString()
+	}
+
+	if result := r.db.Create(player); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return player, nil
+}
+
+// GetPlayer looks up a player of a deck by its ID.
+// If there is no such player, then a NotFoundError is returned.
+func (r *DBPlayerRepository) GetPlayer(deckID, playerID string) (*Player, error) {
+	return r.findPlayer("deck_id=? AND id=?", deckID, playerID)
+}
+
+// FindPlayer looks up a player of a deck by the hash of its token.
+// If there is no such player, then a NotFoundError is returned.
+func (r *DBPlayerRepository) FindPlayer(deckID, tokenHash string) (*Player, error) {
+	return r.findPlayer("deck_id=? AND token_hash=?", deckID, tokenHash)
+}
+
+func (r *DBPlayerRepository) findPlayer(query string, args ...interface{}) (*Player, error) {
+	player := &Player{}
+
+	result := r.db.Where(query, args...).First(player)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such player", nil)
+		}
+		return nil, result.Error
+	}
+
+	return player, nil
+}
+
+// ListPlayers returns the players of a deck, in the order they joined the deck.
+func (r *DBPlayerRepository) ListPlayers(deckID string) ([]*Player, error) {
+	players := []*Player{}
+
+	if result := r.db.Where("deck_id=?", deckID).Order("created_at, id").Find(&players); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return players, nil
+}
+
+// UpdatePlayer stores the changed hand of the player. The player is updated only if its version was not changed
+// since it was read, otherwise a BadRequestError is returned.
+func (r *DBPlayerRepository) UpdatePlayer(player *Player) (*Player, error) {
+	if err := updatePlayer(r.db, player); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// ListPiles returns the public piles of a deck, ordered by name.
+func (r *DBPlayerRepository) ListPiles(deckID string) ([]*Pile, error) {
+	piles := []*Pile{}
+
+	if result := r.db.Where("deck_id=?", deckID).Order("name").Find(&piles); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return piles, nil
+}
+
+// MoveToPile stores the changed hand of the player and puts the cards on top of the named pile of the deck of the
+// player, within a single transaction. The pile is created if the deck has no pile with the name.
+func (r *DBPlayerRepository) MoveToPile(player *Player, pileName string, cards []*Card) (*Pile, error) {
+	pile := &Pile{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updatePlayer(tx, player); err != nil {
+			return err
+		}
+
+		result := tx.Where("deck_id=? AND name=?", player.DeckID, pileName).Limit(1).Find(pile)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			pile = &Pile{DeckID: player.DeckID, Name: pileName, Cards: JoinCards(cards)}
+			return tx.Create(pile).Error
+		}
+
+		pile.Cards = JoinCards(append(pile.PileCards(), cards...))
+		return tx.Model(pile).Where("deck_id=? AND name=?", pile.DeckID, pile.Name).Update("cards", pile.Cards).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pile, nil
+}
+
+// Bind returns the PlayerRepository bound to the transaction of the DeckRepository passed to the function of
+// DeckRepository.Transaction. Returns the repository itself if the DeckRepository is not stored in a database.
+func (r *DBPlayerRepository) Bind(repository DeckRepository) PlayerRepository {
+	return &DBPlayerRepository{db: boundDatabase(repository, r.db)}
+}
+
+func updatePlayer(db *gorm.DB, player *Player) error {
+	version := player.Version
+	player.Version++
+
+	result := db.Model(player).Omit("CreatedAt").Where("version=?", version).Select("*").Updates(player)
+	if result.Error != nil {
+		player.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		player.Version = version
+		return api_errors.BadRequestError(fmt.Sprintf("player %s was changed concurrently, please retry", player.ID), nil)
+	}
+
+	return nil
+}
+
+// NewDBPlayerRepository creates a new PlayerRepository with the given database connection.
+func NewDBPlayerRepository(db *gorm.DB) PlayerRepository {
+	return &DBPlayerRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,142 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestDealer(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBPlayerRepository(td.DB)
+
+	if _, err := repo.GetDealer(td.FullDeckID); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError before the dealer is created, but got: %v", err)
+	}
+
+	if _, err := repo.CreateDealer(&Dealer{DeckID: td.FullDeckID, TokenHash: HashToken("secret")}); err != nil {
+		t.Fatalf("Failed to create the dealer: %s", err.Error())
+	}
+	if _, err := repo.CreateDealer(&Dealer{DeckID: td.FullDeckID, TokenHash: HashToken("other")}); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a second dealer, but got: %v", err)
+	}
+
+	dealer, err := repo.GetDealer(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Failed to get the dealer: %s", err.Error())
+	}
+	if dealer.TokenHash != HashToken("secret") || dealer.TokenHash == "secret" {
+		t.Errorf("Expected the hash of the token to be stored, but got: %s", dealer.TokenHash)
+	}
+}
+
+func TestPlayers(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBPlayerRepository(td.DB)
+
+	alice, err := repo.CreatePlayer(&Player{DeckID: td.FullDeckID, Name: "alice", TokenHash: HashToken("alice-players")})
+	if err != nil {
+		t.Fatalf("Failed to create a player: %s", err.Error())
+	}
+	if _, err := repo.CreatePlayer(&Player{DeckID: td.FullDeckID, Name: "bob", TokenHash: HashToken("bob-players")}); err != nil {
+		t.Fatalf("Failed to create a player: %s", err.Error())
+	}
+
+	players, err := repo.ListPlayers(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Failed to list the players: %s", err.Error())
+	}
+	if len(players) != 2 || players[0].ID != alice.ID || players[1].Name != "bob" {
+		t.Errorf("Expected alice and bob, but got: %+v", players)
+	}
+
+	if found, err := repo.FindPlayer(td.FullDeckID, HashToken("alice-players")); err != nil || found.ID != alice.ID {
+		t.Errorf("Expected to find alice by the token, but got: %+v, %v", found, err)
+	}
+	if _, err := repo.FindPlayer(td.PartialDeckID, HashToken("alice-players")); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a player of another deck, but got: %v", err)
+	}
+	if _, err := repo.GetPlayer(td.FullDeckID, "no-such-player"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError, but got: %v", err)
+	}
+
+	alice.Hand = "AS,KH"
+	if _, err := repo.UpdatePlayer(alice); err != nil {
+		t.Fatalf("Failed to update the player: %s", err.Error())
+	}
+	stale, _ := repo.GetPlayer(td.FullDeckID, alice.ID)
+	stale.Version--
+	if _, err := repo.UpdatePlayer(stale); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+	if player, _ := repo.GetPlayer(td.FullDeckID, alice.ID); player.Hand != "AS,KH" || len(player.HandCards()) != 2 {
+		t.Errorf("Expected the hand of alice to be stored, but got: %+v", player)
+	}
+}
+
+func TestMoveToPile(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBPlayerRepository(td.DB)
+
+	alice, err := repo.CreatePlayer(&Player{DeckID: td.FullDeckID, Name: "alice", TokenHash: HashToken("alice-pile"), Hand: "AS,KH,2C"})
+	if err != nil {
+		t.Fatalf("Failed to create a player: %s", err.Error())
+	}
+
+	alice.Hand = "KH,2C"
+	if _, err := repo.MoveToPile(alice, "discard", AsCards("AS")); err != nil {
+		t.Fatalf("Failed to move to the pile: %s", err.Error())
+	}
+	alice.Hand = "2C"
+	pile, err := repo.MoveToPile(alice, "discard", AsCards("KH"))
+	if err != nil {
+		t.Fatalf("Failed to move to the pile: %s", err.Error())
+	}
+	if pile.Cards != "AS,KH" {
+		t.Errorf("Expected the king on top of the ace, but got: %s", pile.Cards)
+	}
+
+	// a failed update of the player leaves the pile as it was
+	alice.Version--
+	alice.Hand = ""
+	if _, err := repo.MoveToPile(alice, "discard", AsCards("2C")); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	piles, err := repo.ListPiles(td.FullDeckID)
+	if err != nil {
+		t.Fatalf("Failed to list the piles: %s", err.Error())
+	}
+	if len(piles) != 1 || piles[0].Cards != "AS,KH" {
+		t.Errorf("Expected the discard pile unchanged, but got: %+v", piles)
+	}
+}
+
+func TestPlayers_Bind(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBPlayerRepository(td.DB)
+	decks := NewCompactDBDeckRepository(td.DB)
+
+	var deckID string
+	err := decks.Transaction(func(decks DeckRepository) error {
+		deck, err := decks.CreateDeck(&Deck{})
+		if err != nil {
+			return err
+		}
+		deckID = deck.ID
+		if _, err := repo.Bind(decks).CreateDealer(&Dealer{DeckID: deck.ID, TokenHash: HashToken("bound")}); err != nil {
+			return err
+		}
+		return errors.BadRequestError("roll back", nil)
+	})
+	if !errors.IsBadRequestError(err) {
+		t.Fatalf("Expected the transaction to be rolled back, but got: %v", err)
+	}
+
+	if _, err := decks.GetDeck(deckID); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected the deck to be rolled back, but got: %v", err)
+	}
+	if _, err := repo.GetDealer(deckID); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected the dealer to be rolled back with the deck, but got: %v", err)
+	}
+}
@@ -1,5 +1,22 @@
 package deck
 
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Storage layouts for the decks of cards.
+const (
+	// CardsStorageLayout stores every card of a deck as a separate row (see Card).
+	CardsStorageLayout = "cards"
+
+	// CompactStorageLayout stores the whole deck as a single row, with the order of the cards encoded in a single
+	// column (see CompactDeck).
+	CompactStorageLayout = "compact"
+)
+
 // DeckRepository defines methods for managing a deck of cards, like creating, showing the deck or drawing a card from it.
 type DeckRepository interface {
 
@@ -25,4 +42,160 @@ type DeckRepository interface {
 	// After the cards are drawn, the remaining number of cards in the deck will decrease by the number of
 	// drawn cards.
 	DrawCards(deckID string, numCards int) ([]*Card, error)
+
+	// ShuffleDeck shuffles the remaining cards in the deck. The drawn cards are not put back into the deck.
+	// Returns the deck with the remaining cards in the new order.
+	// If there is no deck with the given deckID, then a NotFoundError will be returned.
+	ShuffleDeck(deckID string) (*Deck, error)
+
+	// ExportDeck looks up a deck of cards by its ID and returns the deck with all of its cards, including the drawn
+	// ones, ordered by their position in the deck.
+	// If there is no deck with the given ID, then a NotFound error is returned.
+	ExportDeck(deckID string) (*Deck, error)
+
+	// ImportDeck stores a previously exported deck as is: with its ID, drawn cards and the positions of the cards.
+	// Returns a ValidationError if the deck cannot be stored in the storage layout of the repository,
+	// and a BadRequestError if a deck with the same ID already exists.
+	ImportDeck(deck *Deck) (*Deck, error)
+
+	// ListDeckIDs returns the IDs of all stored decks, ordered by the time of creation.
+	ListDeckIDs() ([]string, error)
+
+	// Transaction executes the given function within a single database transaction. The DeckRepository passed to
+	// the function is bound to the transaction. If the function returns an error, the transaction is rolled back.
+	// Nested calls create nested transactions (save points), so a failure can be rolled back without aborting the
+	// outer transaction.
+	Transaction(fn func(repository DeckRepository) error) error
+}
+
+// PrivateDecks creates the decks owned by the games, kept private by the games, and draws their cards. Implemented
+// by the DeckService of the deck API.
+type PrivateDecks interface {
+	// NewPrivateDeck creates a deck that only the holder of the returned token sees and draws from.
+	NewPrivateDeck(deck *Deck) (*Deck, string, error)
+
+	// Draw draws the number of cards from the deck, as the holder of the token.
+	Draw(token, deckID string, count int) ([]*Card, error)
+}
+
+// NewDeckRepository creates a new DeckRepository with the given database connection, that stores the decks in
+// the given storage layout. An empty layout defaults to CardsStorageLayout.
+func NewDeckRepository(db *gorm.DB, storageLayout string) (DeckRepository, error) {
+	switch storageLayout {
+	case "", CardsStorageLayout:
+		return NewDBDeckRepository(db), nil
+	case CompactStorageLayout:
+		return NewCompactDBDeckRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage layout: %s", storageLayout)
+	}
+}
+
+// databaseRepository is a DeckRepository stored in a database.
+type databaseRepository interface {
+	database() *gorm.DB
+}
+
+// boundDatabase returns the database connection of the repository, bound to the transaction of the repository if it
+// was passed to the function of DeckRepository.Transaction. Returns the given default connection if the repository
+// is not stored in a database.
+func boundDatabase(repository DeckRepository, db *gorm.DB) *gorm.DB {
+	if repository, ok := repository.(databaseRepository); ok {
+		if bound := repository.database(); bound != nil {
+			return bound
+		}
+	}
+	return db
+}
+
+// PlayerRepository defines methods for managing the dealers, the players and the public piles of the decks of cards.
+type PlayerRepository interface {
+
+	// CreateDealer stores the dealer of a deck.
+	// Returns a BadRequestError if the deck already has a dealer.
+	CreateDealer(dealer *Dealer) (*Dealer, error)
+
+	// GetDealer looks up the dealer of a deck.
+	// If the deck has no dealer, then a NotFoundError is returned.
+	GetDealer(deckID string) (*Dealer, error)
+
+	// CreatePlayer stores a new player of a deck. If the player has no ID, a new ID is generated.
+	CreatePlayer(player *Player) (*Player, error)
+
+	// GetPlayer looks up a player of a deck by its ID.
+	// If there is no such player, then a NotFoundError is returned.
+	GetPlayer(deckID, playerID string) (*Player, error)
+
+	// FindPlayer looks up a player of a deck by the hash of its token (see HashToken).
+	// If there is no such player, then a NotFoundError is returned.
+	FindPlayer(deckID, tokenHash string) (*Player, error)
+
+	// ListPlayers returns the players of a deck, in the order they joined the deck.
+	ListPlayers(deckID string) ([]*Player, error)
+
+	// UpdatePlayer stores the changed hand of the player. The player is updated only if its version was not changed
+	// since it was read, otherwise a BadRequestError is returned.
+	UpdatePlayer(player *Player) (*Player, error)
+
+	// ListPiles returns the public piles of a deck, ordered by name.
+	ListPiles(deckID string) ([]*Pile, error)
+
+	// MoveToPile stores the changed hand of the player and puts the cards on top of the named pile of the deck of the
+	// player, within a single transaction. The pile is created if the deck has no pile with the name. The player is
+	// updated only if its version was not changed since it was read, otherwise a BadRequestError is returned.
+	MoveToPile(player *Player, pileName string, cards []*Card) (*Pile, error)
+
+	// Bind returns the PlayerRepository bound to the transaction of the DeckRepository passed to the function of
+	// DeckRepository.Transaction, so the players change together with the decks.
+	Bind(repository DeckRepository) PlayerRepository
+}
+
+// EventRepository defines methods for managing the event log of the decks of cards.
+type EventRepository interface {
+
+	// AppendEvent stores the event at the end of the event log of its deck, assigning it the next ID.
+	AppendEvent(event *Event) (*Event, error)
+
+	// ListEvents returns up to limit events of a deck with an ID greater than afterID, in the order they happened.
+	ListEvents(deckID string, afterID uint64, limit int) ([]*Event, error)
+
+	// Bind returns the EventRepository bound to the transaction of the DeckRepository passed to the function of
+	// DeckRepository.Transaction, so the events are logged together with the changes of the decks.
+	Bind(repository DeckRepository) EventRepository
+}
+
+// WebhookRepository defines methods for managing the webhook subscriptions and the deliveries of the events to them.
+// The deliveries are stored by the DeckRepository, in the same transaction as the change of the deck.
+type WebhookRepository interface {
+
+	// CreateWebhook stores a new webhook. If the webhook has no ID, a new ID is generated.
+	CreateWebhook(webhook *Webhook) (*Webhook, error)
+
+	// GetWebhook looks up a webhook by its ID.
+	// If there is no such webhook, then a NotFoundError is returned.
+	GetWebhook(webhookID string) (*Webhook, error)
+
+	// ListWebhooks returns the webhooks subscribed to a deck, in the order they were created. An empty deckID lists
+	// the global webhooks.
+	ListWebhooks(deckID string) ([]*Webhook, error)
+
+	// DeleteWebhook deletes a webhook, with its deliveries that were not made yet.
+	// If there is no such webhook, then a NotFoundError is returned.
+	DeleteWebhook(webhookID string) error
+
+	// PendingDeliveries returns up to limit deliveries due for an attempt at the given time, in the order of the
+	// events.
+	PendingDeliveries(now time.Time, limit int) ([]*WebhookDelivery, error)
+
+	// UpdateDelivery stores the changed delivery. The delivery is updated only if its version was not changed since
+	// it was read, otherwise a BadRequestError is returned.
+	UpdateDelivery(delivery *WebhookDelivery) (*WebhookDelivery, error)
+
+	// ListDeadDeliveries returns the deliveries given up on (the dead letters), in the order of the events.
+	ListDeadDeliveries() ([]*WebhookDelivery, error)
+
+	// RetryDelivery schedules a delivery given up on for a new round of attempts, right away.
+	// If there is no such delivery, then a NotFoundError is returned. If the delivery is not dead, a BadRequestError
+	// is returned.
+	RetryDelivery(deliveryID uint64) (*WebhookDelivery, error)
 }
@@ -0,0 +1,89 @@
+package deck
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// storageLayouts are the deck repositories compared in the benchmarks.
+var storageLayouts = map[string]func(db *gorm.DB) DeckRepository{
+	CardsStorageLayout:   NewDBDeckRepository,
+	CompactStorageLayout: NewCompactDBDeckRepository,
+}
+
+func setupBenchmark(b *testing.B, layout string) DeckRepository {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:bench_%s_%s?mode=memory&cache=shared", layout, b.Name())), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		b.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := AutoMigrateDeckModels(db); err != nil {
+		b.Fatalf("Failed to migrate the models: %s", err.Error())
+	}
+	return storageLayouts[layout](db)
+}
+
+func BenchmarkCreateDeck(b *testing.B) {
+	for _, layout := range []string{CardsStorageLayout, CompactStorageLayout} {
+		b.Run(layout, func(b *testing.B) {
+			deckRepo := setupBenchmark(b, layout)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := deckRepo.CreateDeck(&Deck{Shuffled: true}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOpenDeck(b *testing.B) {
+	for _, layout := range []string{CardsStorageLayout, CompactStorageLayout} {
+		b.Run(layout, func(b *testing.B) {
+			deckRepo := setupBenchmark(b, layout)
+			deck, err := deckRepo.CreateDeck(&Deck{Shuffled: true})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := deckRepo.GetDeck(deck.ID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDrawCards(b *testing.B) {
+	for _, layout := range []string{CardsStorageLayout, CompactStorageLayout} {
+		for _, count := range []int{1, 5} {
+			b.Run(fmt.Sprintf("%s/count=%d", layout, count), func(b *testing.B) {
+				deckRepo := setupBenchmark(b, layout)
+				var deck *Deck
+
+				for i := 0; i < b.N; i++ {
+					if deck == nil || deck.Remaining < count {
+						b.StopTimer()
+						var err error
+						if deck, err = deckRepo.CreateDeck(&Deck{Shuffled: true}); err != nil {
+							b.Fatal(err)
+						}
+						b.StartTimer()
+					}
+					if _, err := deckRepo.DrawCards(deck.ID, count); err != nil {
+						b.Fatal(err)
+					}
+					deck.Remaining -= count
+				}
+			})
+		}
+	}
+}
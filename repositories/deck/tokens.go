@@ -0,0 +1,26 @@
+package deck
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// tokenSize is the number of random bytes of a token.
+const tokenSize = 32
+
+// NewToken generates a new random secret token, hex encoded.
+func NewToken() (string, error) {
+	token := make([]byte, tokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// HashToken returns the SHA-256 hash of the token, hex encoded. Only the hashes of the tokens are stored, so the
+// tokens cannot be recovered from the database.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,145 @@
+package deck
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+// DBWebhookRepository implements WebhookRepository storing the webhooks and their deliveries in the database.
+type DBWebhookRepository struct {
+	db *gorm.DB
+}
+
+// CreateWebhook stores a new webhook. If the webhook has no ID, a new ID is generated.
+func (r *DBWebhookRepository) CreateWebhook(webhook *Webhook) (*Webhook, error) {
+	if webhook.ID == "" {
+		webhook.ID = uuid.New().String()
+	}
+
+	if result := r.db.Create(webhook); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook looks up a webhook by its ID.
+// If there is no such webhook, then a NotFoundError is returned.
+func (r *DBWebhookRepository) GetWebhook(webhookID string) (*Webhook, error) {
+	webhook := &Webhook{}
+
+	if result := r.db.Where("id=?", webhookID).First(webhook); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such webhook", nil)
+		}
+		return nil, result.Error
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks returns the webhooks subscribed to a deck, in the order they were created. An empty deckID lists the
+// global webhooks.
+func (r *DBWebhookRepository) ListWebhooks(deckID string) ([]*Webhook, error) {
+	webhooks := []*Webhook{}
+
+	if result := r.db.Where("deck_id=?", deckID).Order("created_at, id").Find(&webhooks); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook, with its deliveries that were not made yet.
+// If there is no such webhook, then a NotFoundError is returned.
+func (r *DBWebhookRepository) DeleteWebhook(webhookID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id=?", webhookID).Delete(&Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return api_errors.NotFoundError("no such webhook", nil)
+		}
+
+		return tx.Where("webhook_id=? and delivered_at is null", webhookID).Delete(&WebhookDelivery{}).Error
+	})
+}
+
+// PendingDeliveries returns up to limit deliveries due for an attempt at the given time, in the order of the events.
+func (r *DBWebhookRepository) PendingDeliveries(now time.Time, limit int) ([]*WebhookDelivery, error) {
+	deliveries := []*WebhookDelivery{}
+
+	result := r.db.Where("delivered_at is null and dead=? and next_attempt_at<=?", false, now).
+		Order("id").Limit(limit).Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the changed delivery. The delivery is updated only if its version was not changed since it
+// was read, otherwise a BadRequestError is returned.
+func (r *DBWebhookRepository) UpdateDelivery(delivery *WebhookDelivery) (*WebhookDelivery, error) {
+	version := delivery.Version
+	delivery.Version++
+
+	result := r.db.Model(delivery).Omit("CreatedAt").Where("version=?", version).Select("*").Updates(delivery)
+	if result.Error != nil {
+		delivery.Version = version
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		delivery.Version = version
+		return nil, api_errors.BadRequestError(fmt.Sprintf("delivery %d was changed concurrently, please retry", delivery.ID), nil)
+	}
+
+	return delivery, nil
+}
+
+// ListDeadDeliveries returns the deliveries given up on (the dead letters), in the order of the events.
+func (r *DBWebhookRepository) ListDeadDeliveries() ([]*WebhookDelivery, error) {
+	deliveries := []*WebhookDelivery{}
+
+	if result := r.db.Where("dead=?", true).Order("id").Find(&deliveries); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return deliveries, nil
+}
+
+// RetryDelivery schedules a delivery given up on for a new round of attempts, right away.
+// If there is no such delivery, then a NotFoundError is returned. If the delivery is not dead, a BadRequestError is
+// returned.
+func (r *DBWebhookRepository) RetryDelivery(deliveryID uint64) (*WebhookDelivery, error) {
+	delivery := &WebhookDelivery{}
+	if result := r.db.Where("id=?", deliveryID).First(delivery); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such delivery", nil)
+		}
+		return nil, result.Error
+	}
+	if !delivery.Dead {
+		return nil, api_errors.BadRequestError(fmt.Sprintf("delivery %d was not given up on", deliveryID), nil)
+	}
+
+	delivery.Dead = false
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+
+	return r.UpdateDelivery(delivery)
+}
+
+// NewDBWebhookRepository creates a new WebhookRepository with the given database connection.
+func NewDBWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &DBWebhookRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,177 @@
+package deck
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+// webhookDeliveries returns the pending deliveries of the webhook.
+func webhookDeliveries(t *testing.T, repo WebhookRepository, webhookID string) []*WebhookDelivery {
+	pending, err := repo.PendingDeliveries(time.Now().Add(time.Second), 1000)
+	if err != nil {
+		t.Fatalf("Failed to list the pending deliveries: %s", err.Error())
+	}
+	deliveries := []*WebhookDelivery{}
+	for _, delivery := range pending {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries
+}
+
+func TestWebhooks(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBWebhookRepository(td.DB)
+
+	webhook, err := repo.CreateWebhook(&Webhook{DeckID: td.FullDeckID, URL: "http://localhost/hook", Secret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create a webhook: %s", err.Error())
+	}
+	if webhook.ID == "" {
+		t.Error("Expected the webhook to get an ID.")
+	}
+
+	webhooks, err := repo.ListWebhooks(td.FullDeckID)
+	if err != nil || len(webhooks) != 1 || webhooks[0].ID != webhook.ID {
+		t.Errorf("Expected the webhook of the deck, but got: %v %v", webhooks, err)
+	}
+	if webhooks, _ := repo.ListWebhooks(td.PartialDeckID); len(webhooks) != 0 {
+		t.Errorf("Expected no webhooks for another deck, but got: %v", webhooks)
+	}
+
+	if err := repo.DeleteWebhook(webhook.ID); err != nil {
+		t.Fatalf("Failed to delete the webhook: %s", err.Error())
+	}
+	if _, err := repo.GetWebhook(webhook.ID); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a deleted webhook, but got: %v", err)
+	}
+	if err := repo.DeleteWebhook(webhook.ID); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError when deleting twice, but got: %v", err)
+	}
+
+	if !(&Webhook{}).Subscribes(WebhookDeckEmptied) || (&Webhook{Events: WebhookDeckCreated}).Subscribes(WebhookDeckEmptied) {
+		t.Error("Expected the webhook to subscribe to the listed events only, or to all of them.")
+	}
+}
+
+func TestWebhooks_Outbox(t *testing.T) {
+	for _, layout := range []string{CardsStorageLayout, CompactStorageLayout} {
+		t.Run(layout, func(t *testing.T) {
+			td, _ := setupTest(t)
+			deckRepo, _ := NewDeckRepository(td.DB, layout)
+			repo := NewDBWebhookRepository(td.DB)
+
+			global, err := repo.CreateWebhook(&Webhook{URL: "http://localhost/global", Secret: "secret"})
+			if err != nil {
+				t.Fatalf("Failed to create a webhook: %s", err.Error())
+			}
+			defer repo.DeleteWebhook(global.ID)
+
+			deck, err := deckRepo.CreateDeck(&Deck{Cards: AsCards("AS,KS")})
+			if err != nil {
+				t.Fatalf("Failed to create a deck: %s", err.Error())
+			}
+			webhook, err := repo.CreateWebhook(&Webhook{DeckID: deck.ID, URL: "http://localhost/deck", Secret: "secret",
+				Events: WebhookDeckEmptied})
+			if err != nil {
+				t.Fatalf("Failed to create a webhook: %s", err.Error())
+			}
+
+			// a rolled back change delivers nothing
+			deckRepo.Transaction(func(repository DeckRepository) error {
+				repository.DrawCards(deck.ID, 2)
+				return errors.BadRequestError("rollback", nil)
+			})
+			if _, err := deckRepo.ShuffleDeck(deck.ID); err != nil {
+				t.Fatalf("Failed to shuffle the deck: %s", err.Error())
+			}
+			if _, err := deckRepo.DrawCards(deck.ID, 1); err != nil {
+				t.Fatalf("Failed to draw a card: %s", err.Error())
+			}
+			if _, err := deckRepo.DrawCards(deck.ID, 1); err != nil {
+				t.Fatalf("Failed to draw a card: %s", err.Error())
+			}
+
+			events := []string{}
+			for _, delivery := range webhookDeliveries(t, repo, global.ID) {
+				if delivery.DeckID == deck.ID {
+					events = append(events, delivery.Event)
+				}
+			}
+			if len(events) != 3 || events[0] != WebhookDeckCreated || events[1] != WebhookDeckShuffled || events[2] != WebhookDeckEmptied {
+				t.Errorf("Expected the created, shuffled and emptied events, but got: %v", events)
+			}
+
+			deliveries := webhookDeliveries(t, repo, webhook.ID)
+			if len(deliveries) != 1 || deliveries[0].Event != WebhookDeckEmptied {
+				t.Fatalf("Expected only the emptied event for the deck webhook, but got: %+v", deliveries)
+			}
+			payload := &WebhookPayload{}
+			if err := json.Unmarshal([]byte(deliveries[0].Payload), payload); err != nil {
+				t.Fatalf("Failed to parse the payload: %s", err.Error())
+			}
+			if payload.DeckID != deck.ID || payload.Event != WebhookDeckEmptied || payload.Remaining != 0 || !payload.Shuffled {
+				t.Errorf("Expected the payload of the emptied deck, but got: %+v", payload)
+			}
+
+			if err := repo.DeleteWebhook(webhook.ID); err != nil {
+				t.Fatalf("Failed to delete the webhook: %s", err.Error())
+			}
+			if deliveries := webhookDeliveries(t, repo, webhook.ID); len(deliveries) != 0 {
+				t.Errorf("Expected the pending deliveries of a deleted webhook to be deleted, but got: %+v", deliveries)
+			}
+		})
+	}
+}
+
+func TestWebhooks_DeadDeliveries(t *testing.T) {
+	td, _ := setupTest(t)
+	repo := NewDBWebhookRepository(td.DB)
+
+	webhook, _ := repo.CreateWebhook(&Webhook{DeckID: td.PartialDeckID, URL: "http://localhost/dead", Secret: "secret"})
+	if _, err := NewDBDeckRepository(td.DB).ShuffleDeck(td.PartialDeckID); err != nil {
+		t.Fatalf("Failed to shuffle the deck: %s", err.Error())
+	}
+	delivery := webhookDeliveries(t, repo, webhook.ID)[0]
+
+	stale := *delivery
+	delivery.Attempts = 5
+	delivery.Dead = true
+	delivery.LastError = "connection refused"
+	if _, err := repo.UpdateDelivery(delivery); err != nil {
+		t.Fatalf("Failed to update the delivery: %s", err.Error())
+	}
+	if _, err := repo.UpdateDelivery(&stale); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a stale delivery, but got: %v", err)
+	}
+	if _, err := repo.RetryDelivery(delivery.ID + 1000); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for an unknown delivery, but got: %v", err)
+	}
+
+	if deliveries := webhookDeliveries(t, repo, webhook.ID); len(deliveries) != 0 {
+		t.Errorf("Expected no pending deliveries, but got: %+v", deliveries)
+	}
+	dead, err := repo.ListDeadDeliveries()
+	if err != nil || len(dead) == 0 || dead[len(dead)-1].ID != delivery.ID || dead[len(dead)-1].LastError != "connection refused" {
+		t.Errorf("Expected the dead delivery, but got: %v %v", dead, err)
+	}
+
+	retried, err := repo.RetryDelivery(delivery.ID)
+	if err != nil {
+		t.Fatalf("Failed to retry the delivery: %s", err.Error())
+	}
+	if retried.Dead || retried.Attempts != 0 {
+		t.Errorf("Expected the delivery to be pending again, but got: %+v", retried)
+	}
+	if deliveries := webhookDeliveries(t, repo, webhook.ID); len(deliveries) != 1 {
+		t.Errorf("Expected the retried delivery to be pending, but got: %+v", deliveries)
+	}
+	if _, err := repo.RetryDelivery(delivery.ID); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a delivery that is not dead, but got: %v", err)
+	}
+	repo.DeleteWebhook(webhook.ID)
+}
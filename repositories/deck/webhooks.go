@@ -0,0 +1,95 @@
+package deck
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Types of the events delivered to the webhooks.
+const (
+	// WebhookDeckCreated is delivered when a deck is created.
+	WebhookDeckCreated = "deck.created"
+
+	// WebhookDeckEmptied is delivered when the last card is drawn from a deck.
+	WebhookDeckEmptied = "deck.emptied"
+
+	// WebhookDeckShuffled is delivered when the remaining cards of a deck are shuffled.
+	WebhookDeckShuffled = "deck.shuffled"
+)
+
+// WebhookEvents lists the types of the events delivered to the webhooks.
+var WebhookEvents = []string{WebhookDeckCreated, WebhookDeckEmptied, WebhookDeckShuffled}
+
+// WebhookPayload is the JSON payload posted to a webhook.
+type WebhookPayload struct {
+	// Event is the type of the event, like WebhookDeckCreated.
+	Event string `json:"event"`
+
+	// DeckID is the id of the changed deck.
+	DeckID string `json:"deck_id"`
+
+	// Time is the time of the change.
+	Time time.Time `json:"time"`
+
+	// Remaining is the number of remaining cards in the deck after the change.
+	Remaining int `json:"remaining"`
+
+	// Shuffled tells whether the deck is shuffled.
+	Shuffled bool `json:"shuffled"`
+
+	// Packs is the number of packs in the deck.
+	Packs int `json:"packs"`
+}
+
+// SignPayload returns the signature of the payload: the hex encoded HMAC-SHA256 of the payload, keyed with the secret
+// of the webhook.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// enqueueWebhooks stores the deliveries of the event of the deck to the webhooks subscribed to it, within the
+// transaction of the change of the deck.
+func enqueueWebhooks(tx *gorm.DB, event string, deck *Deck) error {
+	webhooks := []*Webhook{}
+	if result := tx.Where("deck_id=? or deck_id=?", deck.ID, "").Find(&webhooks); result.Error != nil {
+		return result.Error
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(&WebhookPayload{
+		Event:     event,
+		DeckID:    deck.ID,
+		Time:      now,
+		Remaining: deck.Remaining,
+		Shuffled:  deck.Shuffled,
+		Packs:     deck.Packs,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		delivery := &WebhookDelivery{
+			WebhookID:     webhook.ID,
+			DeckID:        deck.ID,
+			Event:         event,
+			Payload:       string(payload),
+			NextAttemptAt: now,
+		}
+		if result := tx.Create(delivery); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package games
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories/versioned"
+)
+
+// DBSessionRepository implements SessionRepository storing the sessions in the database.
+type DBSessionRepository struct {
+	db *gorm.DB
+}
+
+// CreateSession stores a new session with its players and decks. If the session has no ID, a new ID is generated.
+func (r *DBSessionRepository) CreateSession(session *Session) (*Session, error) {
+	if session.ID == "" {
+		session.ID = uuid.NewString()
+	}
+	for _, player := range session.Players {
+		player.SessionID = session.ID
+	}
+	for _, deck := range session.Decks {
+		deck.SessionID = session.ID
+	}
+
+	if result := r.db.Create(session); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return session, nil
+}
+
+// GetSession looks up a session by its ID, with the players ordered by seat and the decks ordered by name.
+// If there is no session with the given ID, then a NotFoundError is returned.
+func (r *DBSessionRepository) GetSession(sessionID string) (*Session, error) {
+	session := &Session{}
+
+	result := r.db.Preload("Players", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seat")
+	}).Preload("Decks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Where("id=?", sessionID).First(session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such session", nil)
+		}
+		return nil, result.Error
+	}
+
+	return session, nil
+}
+
+// UpdateSession stores the changed session, its players and its decks within a single transaction. Decks added to
+// the session since it was read are created. The session is updated only if its version was not changed since it
+// was read, otherwise a BadRequestError is returned.
+func (r *DBSessionRepository) UpdateSession(session *Session) (*Session, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := versioned.Update(tx, session, &session.Version, "session", session.ID, "Players", "Decks", "CreatedAt"); err != nil {
+			return err
+		}
+
+		for _, player := range session.Players {
+			if err := versioned.UpdateSeat(tx, player, "session_id", player.SessionID, player.Seat); err != nil {
+				return err
+			}
+		}
+
+		for _, deck := range session.Decks {
+			deck.SessionID = session.ID
+			result := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(deck)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// NewDBSessionRepository creates a new SessionRepository with the given database connection.
+func NewDBSessionRepository(db *gorm.DB) SessionRepository {
+	return &DBSessionRepository{
+		db: db,
+	}
+}
+
+// AutoMigrateGamesModels performs an automatic migration of the game session Gorm models in the database.
+func AutoMigrateGamesModels(db *gorm.DB) error {
+	return db.AutoMigrate(&Session{}, &Player{}, &SessionDeck{})
+}
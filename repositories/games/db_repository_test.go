@@ -0,0 +1,75 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTest(t *testing.T) SessionRepository {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := AutoMigrateGamesModels(db); err != nil {
+		t.Fatalf("Failed to migrate game session models: %s", err.Error())
+	}
+
+	return NewDBSessionRepository(db)
+}
+
+func TestSessionRepository(t *testing.T) {
+	sessions := setupTest(t)
+	session := &Session{
+		Type:    "test",
+		Players: []*Player{{Seat: 0, Name: "alice", Hand: "2C,3C"}, {Seat: 1, Name: "bob"}},
+		Decks:   []*SessionDeck{{Name: "stock", DeckID: "deck-1"}},
+	}
+	if err := session.SetState(map[string]int{"round": 1}); err != nil {
+		t.Fatalf("Failed to set state: %s", err.Error())
+	}
+
+	if _, err := sessions.CreateSession(session); err != nil {
+		t.Fatalf("Failed to create session: %s", err.Error())
+	}
+	session.Players[0].Hand = "3C"
+	session.Players[1].Score = 2
+	session.Turn = 1
+	session.Decks = append(session.Decks, &SessionDeck{Name: "discard", DeckID: "deck-2"})
+	if err := session.SetState(map[string]int{"round": 2}); err != nil {
+		t.Fatalf("Failed to set state: %s", err.Error())
+	}
+	if _, err := sessions.UpdateSession(session); err != nil {
+		t.Fatalf("Failed to update session: %s", err.Error())
+	}
+
+	stored, err := sessions.GetSession(session.ID)
+	if err != nil {
+		t.Fatalf("Failed to get session: %s", err.Error())
+	}
+	if stored.Version != 1 || stored.Turn != 1 || len(stored.Players) != 2 || len(stored.Decks) != 2 {
+		t.Fatalf("Expected the stored session to be updated, but got: %+v", stored)
+	}
+	if stored.Player(0).Hand != "3C" || stored.Player(1).Score != 2 || stored.Player(2) != nil {
+		t.Errorf("Expected the players to be stored, but got: %+v, %+v", stored.Players[0], stored.Players[1])
+	}
+	if stored.DeckID("stock") != "deck-1" || stored.DeckID("discard") != "deck-2" || stored.DeckID("missing") != "" {
+		t.Errorf("Expected the decks to be stored, but got: %+v", stored.Decks)
+	}
+	state := map[string]int{}
+	if err := stored.GetState(&state); err != nil || state["round"] != 2 {
+		t.Errorf("Expected the state to be stored, but got: %v (%v)", state, err)
+	}
+
+	// The session read before the update is stale.
+	session.Version = 0
+	if _, err := sessions.UpdateSession(session); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := sessions.GetSession("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing session, but got: %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package games
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// NoTurn is the turn of a session in which no player is to act, like a finished session.
+const NoTurn = -1
+
+// Session represents the database model for a session of a turn-based game. The rules of the game are implemented by
+// a rule engine (see games.RuleEngine), registered by the type of the game.
+type Session struct {
+	// ID is a unique identifier for this session, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this session was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this session was last updated.
+	UpdatedAt time.Time
+
+	// Type is the type of the game, the name of its rule engine, like "war".
+	Type string `gorm:"index"`
+
+	// Turn is the seat of the player to act, or NoTurn.
+	Turn int
+
+	// Actions is the number of actions applied in this session.
+	Actions int
+
+	// Finished is set once the game is over. No more actions are applied to a finished session.
+	Finished bool
+
+	// State holds the state of the game that is specific to the rule engine, as JSON (see GetState and SetState).
+	State string
+
+	// Version is incremented with every change of the session, and used to detect concurrent changes.
+	Version int
+
+	// Players holds the players of the session, ordered by seat.
+	Players []*Player `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Decks holds the decks owned by the session, ordered by name.
+	Decks []*SessionDeck `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Session.
+func (s *Session) TableName() string {
+	return "games_sessions"
+}
+
+// GetState reads the state of the rule engine into the value. Does nothing if the state was never set.
+func (s *Session) GetState(value interface{}) error {
+	if s.State == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(s.State), value)
+}
+
+// SetState stores the value as the state of the rule engine.
+func (s *Session) SetState(value interface{}) error {
+	state, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.State = string(state)
+	return nil
+}
+
+// Player returns the player at the seat, or nil if there is no such seat.
+func (s *Session) Player(seat int) *Player {
+	if seat < 0 || seat >= len(s.Players) {
+		return nil
+	}
+	return s.Players[seat]
+}
+
+// TokenHashes returns the hashes of the tokens of the players, by seat.
+func (s *Session) TokenHashes() []string {
+	hashes := []string{}
+	for _, player := range s.Players {
+		hashes = append(hashes, player.TokenHash)
+	}
+	return hashes
+}
+
+// DeckID returns the ID of the named deck of the session, or "" if the session has no such deck.
+func (s *Session) DeckID(name string) string {
+	for _, deck := range s.Decks {
+		if deck.Name == name {
+			return deck.DeckID
+		}
+	}
+	return ""
+}
+
+// Player represents the database model for a player of a session.
+type Player struct {
+	// SessionID is the foreign key to the session.
+	SessionID string `gorm:"primaryKey"`
+
+	// Seat is the position of the player, starting at 0.
+	Seat int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Name is the name of the player.
+	Name string
+
+	// Hand holds the codes of the cards in the hand of the player, comma separated. Only the player sees the hand.
+	Hand string
+
+	// Score is the score of the player, as given by the rule engine.
+	Score int
+
+	// TokenHash is the hash of the secret token of the player (see deck_repo.HashToken). The token itself is not
+	// stored.
+	TokenHash string `gorm:"index"`
+}
+
+// TableName returns the name of the database table of Player.
+func (p *Player) TableName() string {
+	return "games_players"
+}
+
+// HandCards returns the cards in the hand of the player.
+func (p *Player) HandCards() []*deck_repo.Card {
+	if p.Hand == "" {
+		return []*deck_repo.Card{}
+	}
+	return deck_repo.AsCards(p.Hand)
+}
+
+// SetHand stores the cards as the hand of the player.
+func (p *Player) SetHand(cards []*deck_repo.Card) {
+	p.Hand = JoinCodes("", cards...)
+}
+
+// SessionDeck represents the database model for a deck owned by a session. The cards are stored by the
+// DeckRepository; the session keeps the ID of the deck under a name given by the rule engine, like "stock".
+type SessionDeck struct {
+	// SessionID is the foreign key to the session.
+	SessionID string `gorm:"primaryKey"`
+
+	// Name is the name of the deck in the session.
+	Name string `gorm:"primaryKey"`
+
+	// DeckID is the ID of the deck.
+	DeckID string
+}
+
+// TableName returns the name of the database table of SessionDeck.
+func (d *SessionDeck) TableName() string {
+	return "games_session_decks"
+}
+
+// JoinCodes appends the codes of the cards to the comma-separated codes.
+func JoinCodes(codes string, cards ...*deck_repo.Card) string {
+	values := []string{}
+	if codes != "" {
+		values = append(values, codes)
+	}
+	for _, card := range cards {
+		values = append(values, card.Value)
+	}
+	return strings.Join(values, ",")
+}
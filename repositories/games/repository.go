@@ -0,0 +1,17 @@
+package games
+
+// SessionRepository defines methods for storing the sessions of turn-based games.
+type SessionRepository interface {
+
+	// CreateSession stores a new session with its players and decks. If the session has no ID, a new ID is generated.
+	CreateSession(session *Session) (*Session, error)
+
+	// GetSession looks up a session by its ID, with the players ordered by seat and the decks ordered by name.
+	// If there is no session with the given ID, then a NotFoundError is returned.
+	GetSession(sessionID string) (*Session, error)
+
+	// UpdateSession stores the changed session, its players and its decks. The session is updated only if it was not
+	// changed since it was read (see Session.Version), otherwise a BadRequestError is returned and the change should
+	// be retried.
+	UpdateSession(session *Session) (*Session, error)
+}
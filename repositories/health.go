@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultPingTimeout is the time to wait for the database to respond to a ping when no check interval is set.
+const defaultPingTimeout = 5 * time.Second
+
+// DBHealthCheck periodically pings the database and keeps track of whether the database is reachable.
+type DBHealthCheck struct {
+	db       *gorm.DB
+	interval time.Duration
+
+	mutex     sync.RWMutex
+	healthy   bool
+	lastCheck time.Time
+	lastError error
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// Check pings the database once and updates the health status.
+// Returns the ping error, if the database is not reachable.
+func (h *DBHealthCheck) Check() error {
+	timeout := h.interval
+	if timeout <= 0 || timeout > defaultPingTimeout {
+		timeout = defaultPingTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := h.ping(ctx)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.healthy = err == nil
+	h.lastCheck = time.Now()
+	h.lastError = err
+
+	return err
+}
+
+func (h *DBHealthCheck) ping(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Start checks the database health immediately, then keeps checking it periodically in the background,
+// until Stop is called. If the check interval is not positive, the database is checked only once.
+func (h *DBHealthCheck) Start() {
+	h.Check()
+
+	if h.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.Check()
+			case <-h.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic health check.
+func (h *DBHealthCheck) Stop() {
+	h.once.Do(func() {
+		close(h.stop)
+	})
+}
+
+// Status returns the result of the last health check: whether the database was reachable, when it was checked
+// and the error, if the check failed.
+func (h *DBHealthCheck) Status() (healthy bool, lastCheck time.Time, err error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return h.healthy, h.lastCheck, h.lastError
+}
+
+// NewDBHealthCheck creates a new DBHealthCheck for the given database that checks the database every interval.
+func NewDBHealthCheck(db *gorm.DB, interval time.Duration) *DBHealthCheck {
+	return &DBHealthCheck{
+		db:       db,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/config"
+)
+
+func TestDBHealthCheck(t *testing.T) {
+	db, err := OpenDatabase(&config.DBConfig{
+		Dialect: "sqlite",
+		URL:     "file::memory:?cache=shared",
+	})
+	if err != nil {
+		t.Fatalf("Expected to open a database connection, but got an error instead: %s", err.Error())
+	}
+
+	healthCheck := NewDBHealthCheck(db, 0)
+
+	if healthy, _, _ := healthCheck.Status(); healthy {
+		t.Error("Expected the database not to be reported healthy before the first check.")
+	}
+
+	healthCheck.Start()
+	defer healthCheck.Stop()
+
+	healthy, lastCheck, err := healthCheck.Status()
+	if !healthy {
+		t.Errorf("Expected the database to be healthy, but got error: %v", err)
+	}
+	if lastCheck.IsZero() {
+		t.Error("Expected the time of the last check to be set.")
+	}
+}
+
+func TestDBHealthCheck_Unreachable(t *testing.T) {
+	db, err := OpenDatabase(&config.DBConfig{
+		Dialect: "sqlite",
+		URL:     "file::memory:?cache=shared",
+	})
+	if err != nil {
+		t.Fatalf("Expected to open a database connection, but got an error instead: %s", err.Error())
+	}
+
+	healthCheck := NewDBHealthCheck(db, 0)
+
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+
+	if err := healthCheck.Check(); err == nil {
+		t.Fatal("Expected the health check to fail for a closed database.")
+	}
+	if healthy, _, _ := healthCheck.Status(); healthy {
+		t.Error("Expected the database to be reported unhealthy.")
+	}
+}
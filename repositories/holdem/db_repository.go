@@ -0,0 +1,85 @@
+package holdem
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories/versioned"
+)
+
+// DBTableRepository implements TableRepository storing the tables in the database.
+type DBTableRepository struct {
+	db *gorm.DB
+}
+
+// CreateTable stores a new table with its players. If the table has no ID, a new ID is generated.
+func (r *DBTableRepository) CreateTable(table *Table) (*Table, error) {
+	if table.ID == "" {
+		table.ID = uuid.NewString()
+	}
+	for _, player := range table.Players {
+		player.TableID = table.ID
+	}
+
+	if result := r.db.Create(table); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return table, nil
+}
+
+// GetTable looks up a table by its ID, with the players ordered by seat.
+// If there is no table with the given ID, then a NotFoundError is returned.
+func (r *DBTableRepository) GetTable(tableID string) (*Table, error) {
+	table := &Table{}
+
+	result := r.db.Preload("Players", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seat")
+	}).Where("id=?", tableID).First(table)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such table", nil)
+		}
+		return nil, result.Error
+	}
+
+	return table, nil
+}
+
+// UpdateTable stores the changed table and its players within a single transaction. The table is updated only
+// if its version was not changed since it was read, otherwise a BadRequestError is returned.
+func (r *DBTableRepository) UpdateTable(table *Table) (*Table, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := versioned.Update(tx, table, &table.Version, "table", table.ID, "Players", "CreatedAt"); err != nil {
+			return err
+		}
+
+		for _, player := range table.Players {
+			if err := versioned.UpdateSeat(tx, player, "table_id", player.TableID, player.Seat); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// NewDBTableRepository creates a new TableRepository with the given database connection.
+func NewDBTableRepository(db *gorm.DB) TableRepository {
+	return &DBTableRepository{
+		db: db,
+	}
+}
+
+// AutoMigrateHoldemModels performs an automatic migration of the Texas Hold'em Gorm models in the database.
+func AutoMigrateHoldemModels(db *gorm.DB) error {
+	return db.AutoMigrate(&Table{}, &Player{})
+}
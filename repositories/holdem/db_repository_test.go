@@ -0,0 +1,44 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestTableRepository(t *testing.T) {
+	tables, decks := setupTest(t)
+	table := newTestTable(t, decks, "AS,KS,AH,KH", 1000, 500)
+	table.Players[0].Name = "alice"
+
+	if _, err := tables.CreateTable(table); err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+	if _, err := tables.UpdateTable(table); err != nil {
+		t.Fatalf("Failed to update table: %s", err.Error())
+	}
+
+	stored, err := tables.GetTable(table.ID)
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err.Error())
+	}
+	if stored.Stage != PreflopStage || stored.Version != 1 || len(stored.Players) != 2 {
+		t.Fatalf("Expected the stored table to be in the preflop stage, but got: %+v", stored)
+	}
+	if stored.Players[0].Name != "alice" || stored.Players[0].HoleCards != "KS,KH" || stored.Players[1].Stack != 490 {
+		t.Errorf("Expected the players to be stored, but got: %+v, %+v", stored.Players[0], stored.Players[1])
+	}
+
+	// The table read before the update is stale.
+	table.Version = 0
+	if _, err := tables.UpdateTable(table); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := tables.GetTable("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing table, but got: %v", err)
+	}
+}
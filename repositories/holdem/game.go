@@ -0,0 +1,417 @@
+package holdem
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/poker"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Player actions in a betting round.
+const (
+	FoldAction  = "FOLD"
+	CheckAction = "CHECK"
+	CallAction  = "CALL"
+	RaiseAction = "RAISE"
+	AllInAction = "ALL_IN"
+)
+
+// Limits of the number of players at a table.
+const (
+	MinPlayers = 2
+	MaxPlayers = 10
+)
+
+// HoleCards is the number of hole cards dealt to each player.
+const HoleCards = 2
+
+// streets maps each betting stage to the next stage and the number of community cards dealt for it.
+var streets = map[string]struct {
+	next  string
+	cards int
+}{
+	PreflopStage: {next: FlopStage, cards: 3},
+	FlopStage:    {next: TurnStage, cards: 1},
+	TurnStage:    {next: RiverStage, cards: 1},
+}
+
+// Pot is a pot of chips that can be won only by the eligible players. There is a main pot, and a side pot for
+// every player that is all-in for less than the others.
+type Pot struct {
+	// Amount is the amount of chips in the pot.
+	Amount int64
+
+	// Seats holds the seats of the players that can win the pot.
+	Seats []int
+}
+
+// NewTable creates a new table with the given blinds and players, bound to a new shuffled private deck created with
+// the given PrivateDecks. The players are seated in the given order.
+// Returns a ValidationError if the blinds are not positive, the small blind is greater than the big blind,
+// the number of players is not between MinPlayers and MaxPlayers, or any of the players has no chips.
+func NewTable(smallBlind, bigBlind int64, players []*Player, decks deck_repo.PrivateDecks) (*Table, error) {
+	if smallBlind <= 0 || bigBlind < smallBlind {
+		return nil, errors.ValidationError("the blinds must be positive and the small blind must not be greater than the big blind", nil)
+	}
+	if len(players) < MinPlayers || len(players) > MaxPlayers {
+		return nil, errors.ValidationError(fmt.Sprintf("a table must have between %d and %d players", MinPlayers, MaxPlayers), nil)
+	}
+	for seat, player := range players {
+		if player.Stack <= 0 {
+			return nil, errors.ValidationError(fmt.Sprintf("the player at seat %d has no chips", seat), nil)
+		}
+		player.Seat = seat
+	}
+
+	deck, deckToken, err := decks.NewPrivateDeck(&deck_repo.Deck{Shuffled: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{
+		DeckID:     deck.ID,
+		DeckToken:  deckToken,
+		SmallBlind: smallBlind,
+		BigBlind:   bigBlind,
+		Stage:      WaitingStage,
+		ButtonSeat: -1,
+		ActingSeat: -1,
+		Players:    players,
+	}, nil
+}
+
+// StartHand starts a new hand at the table: moves the button, posts the blinds and deals the hole cards, one card
+// at a time to each player starting left of the button. Every hand after the first one is dealt from a new
+// shuffled private deck. Players without chips sit out.
+// Returns a BadRequestError if a hand is in progress or less than two players have chips.
+func StartHand(table *Table, decks deck_repo.PrivateDecks) error {
+	if table.Stage != WaitingStage && table.Stage != ShowdownStage {
+		return errors.BadRequestError("a hand is in progress", nil)
+	}
+
+	inHand := 0
+	for _, player := range table.Players {
+		*player = Player{
+			TableID:   player.TableID,
+			Seat:      player.Seat,
+			Name:      player.Name,
+			TokenHash: player.TokenHash,
+			Stack:     player.Stack,
+			InHand:    player.Stack > 0,
+		}
+		if player.InHand {
+			inHand++
+		}
+	}
+	if inHand < MinPlayers {
+		return errors.BadRequestError("not enough players with chips to start a hand", nil)
+	}
+
+	if table.HandNumber > 0 {
+		deck, deckToken, err := decks.NewPrivateDeck(&deck_repo.Deck{Shuffled: true})
+		if err != nil {
+			return err
+		}
+		table.DeckID = deck.ID
+		table.DeckToken = deckToken
+	}
+
+	table.HandNumber++
+	table.Board = ""
+	table.Burned = ""
+	table.ButtonSeat = nextSeat(table, table.ButtonSeat, (*Player).active)
+
+	smallBlindSeat := nextSeat(table, table.ButtonSeat, (*Player).active)
+	if inHand == 2 {
+		// Heads-up, the button posts the small blind.
+		smallBlindSeat = table.ButtonSeat
+	}
+	bigBlindSeat := nextSeat(table, smallBlindSeat, (*Player).active)
+
+	bet(table.Players[smallBlindSeat], table.SmallBlind)
+	table.Players[smallBlindSeat].LastAction = "SMALL_BLIND"
+	bet(table.Players[bigBlindSeat], table.BigBlind)
+	table.Players[bigBlindSeat].LastAction = "BIG_BLIND"
+	table.CurrentBet = table.BigBlind
+	table.MinRaise = table.BigBlind
+
+	cards, err := decks.Draw(table.DeckToken, table.DeckID, HoleCards*inHand)
+	if err != nil {
+		return err
+	}
+	seat := table.ButtonSeat
+	for _, card := range cards {
+		seat = nextSeat(table, seat, (*Player).active)
+		player := table.Players[seat]
+		player.HoleCards = joinCodes(player.HoleCards, []*deck_repo.Card{card})
+	}
+
+	table.Stage = PreflopStage
+	table.ActingSeat = bigBlindSeat
+	return advance(table)
+}
+
+// DealNext deals the next street: burns a card and deals the flop, the turn or the river. Once the river is dealt
+// and nobody can bet anymore, the hand goes to the showdown.
+// Returns a BadRequestError if there is no hand in progress, the betting round is not complete or the river is
+// already dealt.
+func DealNext(table *Table, decks deck_repo.PrivateDecks) error {
+	street, ok := streets[table.Stage]
+	if !ok {
+		return errors.BadRequestError(fmt.Sprintf("cannot deal in the %s stage", table.Stage), nil)
+	}
+	if table.ActingSeat >= 0 {
+		return errors.BadRequestError("the betting round is not complete", nil)
+	}
+
+	cards, err := decks.Draw(table.DeckToken, table.DeckID, street.cards+1)
+	if err != nil {
+		return err
+	}
+	table.Burned = joinCodes(table.Burned, cards[:1])
+	table.Board = joinCodes(table.Board, cards[1:])
+	table.Stage = street.next
+
+	table.ActingSeat = table.ButtonSeat
+	return advance(table)
+}
+
+// Act applies the action of the player at the given seat. For a raise, amount is the total bet of the player
+// in the betting round after the raise (the "raise to" amount). A raise must be at least as big as the previous
+// raise in the betting round, unless the player goes all-in. Any bet greater than the current bet, including
+// an all-in for less than a full raise, requires the other players to act again.
+// Returns a BadRequestError if it is not the turn of the player or the action is not allowed.
+func Act(table *Table, seat int, action string, amount int64) error {
+	if table.ActingSeat < 0 {
+		return errors.BadRequestError("there is no player to act", nil)
+	}
+	if seat != table.ActingSeat {
+		return errors.BadRequestError(fmt.Sprintf("it is not the turn of the player at seat %d", seat), nil)
+	}
+
+	player := table.Players[seat]
+	toCall := table.CurrentBet - player.Bet
+
+	switch action {
+	case FoldAction:
+		player.Folded = true
+	case CheckAction:
+		if toCall > 0 {
+			return errors.BadRequestError(fmt.Sprintf("cannot check, there is a bet of %d to call", toCall), nil)
+		}
+	case CallAction:
+		if toCall == 0 {
+			return errors.BadRequestError("there is no bet to call", nil)
+		}
+		bet(player, toCall)
+	case RaiseAction:
+		if amount-player.Bet > player.Stack {
+			return errors.BadRequestError(fmt.Sprintf("cannot raise to %d, the player has only %d behind", amount, player.Stack), nil)
+		}
+		if amount < table.CurrentBet+table.MinRaise && amount-player.Bet < player.Stack {
+			return errors.BadRequestError(fmt.Sprintf("the minimal raise is to %d", table.CurrentBet+table.MinRaise), nil)
+		}
+		bet(player, amount-player.Bet)
+	case AllInAction:
+		bet(player, player.Stack)
+	default:
+		return errors.BadRequestError(fmt.Sprintf("unknown action: %s", action), nil)
+	}
+
+	if player.Bet > table.CurrentBet {
+		if raise := player.Bet - table.CurrentBet; raise >= table.MinRaise {
+			table.MinRaise = raise
+		}
+		table.CurrentBet = player.Bet
+		for _, other := range table.Players {
+			other.Acted = false
+		}
+	}
+	player.Acted = true
+	player.LastAction = action
+
+	return advance(table)
+}
+
+// Pots returns the main pot and the side pots of the current hand, including the bets of the current betting
+// round. A side pot is split off at the amount committed by every player that is all-in. The chips of the folded
+// players go to the pots they contributed to.
+func Pots(table *Table) []Pot {
+	levels := []int64{}
+	var highest int64
+	for _, player := range table.Players {
+		if player.active() && player.AllIn {
+			levels = append(levels, player.Committed)
+		}
+		if player.active() && player.Committed > highest {
+			highest = player.Committed
+		}
+	}
+	if highest > 0 {
+		levels = append(levels, highest)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i] < levels[j]
+	})
+
+	unique := levels[:0]
+	for _, level := range levels {
+		if len(unique) == 0 || unique[len(unique)-1] != level {
+			unique = append(unique, level)
+		}
+	}
+	levels = unique
+
+	pots := []Pot{}
+	var previous int64
+	for i, level := range levels {
+		pot := Pot{}
+		for _, player := range table.Players {
+			pot.Amount += min(player.Committed, level) - min(player.Committed, previous)
+			if i == len(levels)-1 {
+				// The chips of the folded players above the highest level go to the last pot.
+				pot.Amount += player.Committed - min(player.Committed, level)
+			}
+			if player.active() && player.Committed >= level {
+				pot.Seats = append(pot.Seats, player.Seat)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	return pots
+}
+
+// advance moves the turn to the next player that has to act, starting after the acting seat. If the betting round
+// is complete, the bets are collected and the acting seat is cleared. The hand ends when only one player is left,
+// or at the end of the betting on the river.
+func advance(table *Table) error {
+	active, canAct := 0, 0
+	for _, player := range table.Players {
+		if player.active() {
+			active++
+		}
+		if player.canAct() {
+			canAct++
+		}
+	}
+
+	if active == 1 {
+		return showdown(table)
+	}
+
+	needsToAct := func(player *Player) bool {
+		if !player.canAct() || (canAct == 1 && player.Bet >= table.CurrentBet) {
+			return false
+		}
+		return !player.Acted || player.Bet < table.CurrentBet
+	}
+
+	if next := nextSeat(table, table.ActingSeat, needsToAct); next >= 0 {
+		table.ActingSeat = next
+		return nil
+	}
+
+	table.ActingSeat = -1
+	table.CurrentBet = 0
+	table.MinRaise = table.BigBlind
+	for _, player := range table.Players {
+		player.Bet = 0
+		player.Acted = false
+	}
+
+	if table.Stage == RiverStage {
+		return showdown(table)
+	}
+	return nil
+}
+
+// showdown awards the pots of the hand. Every pot is won by the eligible players with the best hand, and the odd
+// chips of a split pot go to the winners in the order of the seats, starting left of the button.
+func showdown(table *Table) error {
+	board, err := poker.FromDeckCards(table.BoardCards())
+	if err != nil {
+		return err
+	}
+
+	for _, pot := range Pots(table) {
+		seats := seatsFromButton(table, pot.Seats)
+		if len(seats) == 1 {
+			table.Players[seats[0]].Won += pot.Amount
+			continue
+		}
+
+		hands := [][]poker.Card{}
+		for _, seat := range seats {
+			hand, err := poker.FromDeckCards(table.Players[seat].Cards())
+			if err != nil {
+				return err
+			}
+			hands = append(hands, hand)
+		}
+
+		result, err := poker.Showdown(poker.Holdem, hands, board, pot.Amount)
+		if err != nil {
+			return err
+		}
+		for i, player := range result.Players {
+			table.Players[seats[i]].Won += player.Share
+			table.Players[seats[i]].HandCategory = player.Hand.Category.String()
+		}
+	}
+
+	for _, player := range table.Players {
+		player.Stack += player.Won
+		player.Bet = 0
+	}
+	table.Stage = ShowdownStage
+	table.ActingSeat = -1
+	table.CurrentBet = 0
+
+	return nil
+}
+
+// nextSeat returns the first seat after the given seat, going around the table, with a player matching
+// the predicate, or -1 if there is no such player.
+func nextSeat(table *Table, seat int, predicate func(*Player) bool) int {
+	for i := 1; i <= len(table.Players); i++ {
+		next := (seat + i + len(table.Players)) % len(table.Players)
+		if predicate(table.Players[next]) {
+			return next
+		}
+	}
+	return -1
+}
+
+// seatsFromButton orders the seats starting left of the button.
+func seatsFromButton(table *Table, seats []int) []int {
+	ordered := append([]int{}, seats...)
+	distance := func(seat int) int {
+		return (seat - table.ButtonSeat - 1 + len(table.Players)) % len(table.Players)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return distance(ordered[i]) < distance(ordered[j])
+	})
+	return ordered
+}
+
+// bet moves the amount from the stack of the player to the bet, going all-in if there are not enough chips.
+func bet(player *Player, amount int64) {
+	if amount >= player.Stack {
+		amount = player.Stack
+		player.AllIn = true
+	}
+	player.Stack -= amount
+	player.Bet += amount
+	player.Committed += amount
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
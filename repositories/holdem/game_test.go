@@ -0,0 +1,284 @@
+package holdem
+
+import (
+	"fmt"
+	"testing"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testDeckToken is the token of the dealer of every deck created with testDecks.
+const testDeckToken = "dealer-token"
+
+// testDecks implements PrivateDecks with a DeckRepository, drawing only with testDeckToken.
+type testDecks struct {
+	deck_repo.DeckRepository
+}
+
+func (d *testDecks) NewPrivateDeck(deck *deck_repo.Deck) (*deck_repo.Deck, string, error) {
+	deck, err := d.CreateDeck(deck)
+	return deck, testDeckToken, err
+}
+
+func (d *testDecks) Draw(token, deckID string, count int) ([]*deck_repo.Card, error) {
+	if token != testDeckToken {
+		return nil, fmt.Errorf("invalid token for the deck %s", deckID)
+	}
+	return d.DrawCards(deckID, count)
+}
+
+func setupTest(t *testing.T) (TableRepository, *testDecks) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+	if err := AutoMigrateHoldemModels(db); err != nil {
+		t.Fatalf("Failed to migrate Texas Hold'em models: %s", err.Error())
+	}
+
+	return NewDBTableRepository(db), &testDecks{deck_repo.NewDBDeckRepository(db)}
+}
+
+// newTestTable creates a table where the first hand is dealt from a deck with the given cards, in order.
+func newTestTable(t *testing.T, decks *testDecks, cards string, stacks ...int64) *Table {
+	players := []*Player{}
+	for _, stack := range stacks {
+		players = append(players, &Player{Stack: stack})
+	}
+
+	table, err := NewTable(5, 10, players, decks)
+	if err != nil {
+		t.Fatalf("Failed to create table: %s", err.Error())
+	}
+
+	deck, err := decks.CreateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards(cards)})
+	if err != nil {
+		t.Fatalf("Failed to create deck: %s", err.Error())
+	}
+	table.DeckID = deck.ID
+
+	return table
+}
+
+func mustAct(t *testing.T, table *Table, seat int, action string, amount int64) {
+	if err := Act(table, seat, action, amount); err != nil {
+		t.Fatalf("Expected %s of seat %d to be allowed, but got error: %s", action, seat, err.Error())
+	}
+}
+
+func mustDeal(t *testing.T, table *Table, decks *testDecks) {
+	if err := DealNext(table, decks); err != nil {
+		t.Fatalf("Expected to deal the next street, but got error: %s", err.Error())
+	}
+}
+
+func TestHeadsUpHand(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, "AS,KS,AH,KH,3C,7D,8C,2D,4C,4H,5C,QS", 1000, 1000)
+
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+
+	// Heads-up, the button posts the small blind and acts first before the flop.
+	if table.ButtonSeat != 0 || table.ActingSeat != 0 || table.Players[0].Bet != 5 || table.Players[1].Bet != 10 {
+		t.Fatalf("Expected the button to post the small blind and act first, but got: %+v", table)
+	}
+	if table.Players[0].HoleCards != "KS,KH" || table.Players[1].HoleCards != "AS,AH" {
+		t.Fatalf("Expected the hole cards to be dealt starting left of the button, but got %s and %s.",
+			table.Players[0].HoleCards, table.Players[1].HoleCards)
+	}
+
+	mustAct(t, table, 0, CallAction, 0)
+	if table.ActingSeat != 1 {
+		t.Fatal("Expected the big blind to have the option.")
+	}
+	mustAct(t, table, 1, CheckAction, 0)
+	if table.ActingSeat != -1 || table.Stage != PreflopStage {
+		t.Fatal("Expected the betting round to be complete.")
+	}
+
+	mustDeal(t, table, decks)
+	if table.Stage != FlopStage || table.Board != "7D,8C,2D" || table.Burned != "3C" {
+		t.Fatalf("Expected the flop to be dealt after a burn card, but got board %s, burned %s.", table.Board, table.Burned)
+	}
+	if table.ActingSeat != 1 {
+		t.Fatal("Expected the big blind to act first after the flop.")
+	}
+
+	mustAct(t, table, 1, CheckAction, 0)
+	mustAct(t, table, 0, RaiseAction, 20)
+	mustAct(t, table, 1, CallAction, 0)
+
+	mustDeal(t, table, decks)
+	mustAct(t, table, 1, CheckAction, 0)
+	mustAct(t, table, 0, CheckAction, 0)
+
+	mustDeal(t, table, decks)
+	if table.Board != "7D,8C,2D,4H,QS" || table.Burned != "3C,4C,5C" {
+		t.Fatalf("Expected the whole board to be dealt, but got board %s, burned %s.", table.Board, table.Burned)
+	}
+	mustAct(t, table, 1, CheckAction, 0)
+	mustAct(t, table, 0, CheckAction, 0)
+
+	if table.Stage != ShowdownStage {
+		t.Fatalf("Expected the hand to go to the showdown, but got %s.", table.Stage)
+	}
+	if table.Players[1].Won != 60 || table.Players[1].Stack != 1030 || table.Players[0].Stack != 970 {
+		t.Errorf("Expected the aces to win the pot of 60, but got stacks %d and %d.", table.Players[0].Stack, table.Players[1].Stack)
+	}
+	if table.Players[1].HandCategory != "ONE_PAIR" {
+		t.Errorf("Expected the winner to have one pair, but got %s.", table.Players[1].HandCategory)
+	}
+}
+
+func TestSidePots(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, "KH,2C,AH,KD,7S,AD,3C,9S,10H,4D,5C,6H,8C,2H", 100, 300, 300)
+
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+	if table.ActingSeat != 0 {
+		t.Fatalf("Expected the player left of the big blind to act first, but got seat %d.", table.ActingSeat)
+	}
+
+	mustAct(t, table, 0, AllInAction, 0)
+	mustAct(t, table, 1, RaiseAction, 300)
+	mustAct(t, table, 2, CallAction, 0)
+
+	pots := Pots(table)
+	if len(pots) != 2 || pots[0].Amount != 300 || len(pots[0].Seats) != 3 || pots[1].Amount != 400 || len(pots[1].Seats) != 2 {
+		t.Fatalf("Expected a main pot of 300 and a side pot of 400, but got: %+v", pots)
+	}
+
+	// Everybody is all-in, so the streets are dealt without betting.
+	for _, stage := range []string{FlopStage, TurnStage} {
+		mustDeal(t, table, decks)
+		if table.Stage != stage || table.ActingSeat != -1 {
+			t.Fatalf("Expected the %s to be dealt without betting, but got %s.", stage, table.Stage)
+		}
+	}
+	mustDeal(t, table, decks)
+
+	if table.Stage != ShowdownStage {
+		t.Fatalf("Expected the hand to go to the showdown, but got %s.", table.Stage)
+	}
+	if table.Players[0].Stack != 300 || table.Players[1].Stack != 400 || table.Players[2].Stack != 0 {
+		t.Errorf("Expected the aces to win the main pot and the kings the side pot, but got stacks %d, %d, %d.",
+			table.Players[0].Stack, table.Players[1].Stack, table.Players[2].Stack)
+	}
+
+	// The player without chips sits out of the next hand.
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start the next hand: %s", err.Error())
+	}
+	if table.Players[2].InHand || table.ButtonSeat != 1 || table.HandNumber != 2 {
+		t.Errorf("Expected the button to move and the busted player to sit out, but got: %+v", table)
+	}
+}
+
+func TestFold(t *testing.T) {
+	tables, decks := setupTest(t)
+	table := newTestTable(t, decks, "AS,KS,QS,AH,KH,QH", 1000, 1000, 1000)
+	deckID := table.DeckID
+	table.Players[0].TokenHash = deck_repo.HashToken("north")
+
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+	mustAct(t, table, 0, FoldAction, 0)
+	mustAct(t, table, 1, FoldAction, 0)
+
+	if table.Stage != ShowdownStage || table.Players[2].Stack != 1005 || table.Players[1].Stack != 995 {
+		t.Fatalf("Expected the big blind to win the blinds, but got: %+v", table.Players)
+	}
+
+	if _, err := tables.CreateTable(table); err != nil {
+		t.Fatalf("Failed to store the table: %s", err.Error())
+	}
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start the next hand: %s", err.Error())
+	}
+	if table.DeckID == deckID || table.DeckToken != testDeckToken {
+		t.Error("Expected the next hand to be dealt from a new private deck.")
+	}
+	if table.Players[0].TokenHash != deck_repo.HashToken("north") {
+		t.Errorf("Expected the players to keep their tokens, but got: %+v", table.Players[0])
+	}
+}
+
+func TestInvalidActions(t *testing.T) {
+	_, decks := setupTest(t)
+	table := newTestTable(t, decks, "AS,KS,AH,KH,3C,7D,8C,2D", 1000, 1000)
+
+	if err := Act(table, 0, CheckAction, 0); err == nil {
+		t.Error("Expected an error for acting before the hand is started.")
+	}
+	if err := StartHand(table, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+	if err := StartHand(table, decks); err == nil {
+		t.Error("Expected an error for starting a hand during a hand.")
+	}
+	if err := Act(table, 1, CheckAction, 0); err == nil {
+		t.Error("Expected an error for acting out of turn.")
+	}
+	if err := Act(table, 0, CheckAction, 0); err == nil {
+		t.Error("Expected an error for checking facing a bet.")
+	}
+	if err := Act(table, 0, RaiseAction, 15); err == nil {
+		t.Error("Expected an error for raising less than the minimal raise.")
+	}
+	if err := Act(table, 0, RaiseAction, 2000); err == nil {
+		t.Error("Expected an error for raising more than the stack.")
+	}
+	if err := Act(table, 0, "BLUFF", 0); err == nil {
+		t.Error("Expected an error for an unknown action.")
+	}
+	if err := DealNext(table, decks); err == nil {
+		t.Error("Expected an error for dealing before the betting round is complete.")
+	}
+
+	mustAct(t, table, 0, RaiseAction, 30)
+	if err := Act(table, 1, RaiseAction, 40); err == nil {
+		t.Error("Expected an error for re-raising less than the previous raise.")
+	}
+	mustAct(t, table, 1, RaiseAction, 50)
+	if table.CurrentBet != 50 || table.MinRaise != 20 || table.ActingSeat != 0 {
+		t.Errorf("Expected the raise to reopen the betting, but got: %+v", table)
+	}
+}
+
+func TestNewTable_Invalid(t *testing.T) {
+	_, decks := setupTest(t)
+
+	if _, err := NewTable(10, 5, []*Player{{Stack: 100}, {Stack: 100}}, decks); err == nil {
+		t.Error("Expected an error for a small blind greater than the big blind.")
+	}
+	if _, err := NewTable(5, 10, []*Player{{Stack: 100}}, decks); err == nil {
+		t.Error("Expected an error for a single player.")
+	}
+	if _, err := NewTable(5, 10, []*Player{{Stack: 100}, {Stack: 0}}, decks); err == nil {
+		t.Error("Expected an error for a player without chips.")
+	}
+}
+
+func TestPots_FoldedPlayers(t *testing.T) {
+	table := &Table{Players: []*Player{
+		{Seat: 0, InHand: true, Folded: true, Committed: 100},
+		{Seat: 1, InHand: true, AllIn: true, Committed: 50},
+		{Seat: 2, InHand: true, Committed: 200},
+		{Seat: 3, InHand: true, Committed: 200},
+	}}
+
+	pots := Pots(table)
+	if len(pots) != 2 || pots[0].Amount != 200 || len(pots[0].Seats) != 3 || pots[1].Amount != 350 || len(pots[1].Seats) != 2 {
+		t.Errorf("Expected a main pot of 200 and a side pot of 350 without the folded player, but got: %+v", pots)
+	}
+}
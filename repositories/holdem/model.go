@@ -0,0 +1,195 @@
+package holdem
+
+import (
+	"strings"
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Stages of a hand of Texas Hold'em.
+const (
+	// WaitingStage is the stage of a table between two hands.
+	WaitingStage = "WAITING"
+
+	// PreflopStage is the first betting round, after the hole cards are dealt.
+	PreflopStage = "PREFLOP"
+
+	// FlopStage is the betting round after the first three community cards are dealt.
+	FlopStage = "FLOP"
+
+	// TurnStage is the betting round after the fourth community card is dealt.
+	TurnStage = "TURN"
+
+	// RiverStage is the last betting round, after the fifth community card is dealt.
+	RiverStage = "RIVER"
+
+	// ShowdownStage is the end of a hand: the pots are awarded and a new hand can be started.
+	ShowdownStage = "SHOWDOWN"
+)
+
+// Table represents the database model for a table of Texas Hold'em.
+type Table struct {
+	// ID is a unique identifier for this table, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this table was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this table was last updated.
+	UpdatedAt time.Time
+
+	// DeckID is the ID of the deck the current hand is dealt from. Every hand is dealt from a new shuffled deck.
+	DeckID string
+
+	// DeckToken is the secret token of the dealer of the deck, kept private by the table: only the table sees the
+	// cards of the deck and draws from it. Never shown.
+	DeckToken string
+
+	// SmallBlind is the amount of the small blind.
+	SmallBlind int64
+
+	// BigBlind is the amount of the big blind. It is also the minimal bet.
+	BigBlind int64
+
+	// HandNumber is the number of hands started at this table.
+	HandNumber int
+
+	// Stage is the stage of the current hand, like PreflopStage.
+	Stage string
+
+	// ButtonSeat is the seat of the dealer button, or -1 before the first hand.
+	ButtonSeat int
+
+	// ActingSeat is the seat of the player to act, or -1 if the betting round is complete.
+	ActingSeat int
+
+	// CurrentBet is the highest bet in the current betting round.
+	CurrentBet int64
+
+	// MinRaise is the minimal amount by which the current bet can be raised.
+	MinRaise int64
+
+	// Board holds the codes of the community cards, comma separated.
+	Board string
+
+	// Burned holds the codes of the burned cards, comma separated.
+	Burned string
+
+	// Version is incremented with every change of the table, and used to detect concurrent changes.
+	Version int
+
+	// Players holds the players at the table, ordered by seat.
+	Players []*Player `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Table.
+func (t *Table) TableName() string {
+	return "holdem_tables"
+}
+
+// BoardCards returns the community cards.
+func (t *Table) BoardCards() []*deck_repo.Card {
+	return asCards(t.Board)
+}
+
+// BurnedCards returns the burned cards.
+func (t *Table) BurnedCards() []*deck_repo.Card {
+	return asCards(t.Burned)
+}
+
+// TokenHashes returns the hashes of the tokens of the players, by seat.
+func (t *Table) TokenHashes() []string {
+	hashes := []string{}
+	for _, player := range t.Players {
+		hashes = append(hashes, player.TokenHash)
+	}
+	return hashes
+}
+
+// Player represents the database model for a player seated at a table of Texas Hold'em.
+type Player struct {
+	// TableID is the foreign key to the table.
+	TableID string `gorm:"primaryKey"`
+
+	// Seat is the position of the player at the table, starting at 0.
+	Seat int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Name is the name of the player.
+	Name string
+
+	// TokenHash is the hash of the secret token of the player (see deck_repo.HashToken). The token itself is not
+	// stored.
+	TokenHash string `gorm:"index"`
+
+	// Stack is the amount of chips the player has behind, not counting the bets in the current hand.
+	Stack int64
+
+	// HoleCards holds the codes of the hole cards, comma separated.
+	HoleCards string
+
+	// Bet is the amount the player has bet in the current betting round.
+	Bet int64
+
+	// Committed is the total amount the player has put in the pot in the current hand, including Bet.
+	Committed int64
+
+	// InHand is set if the player was dealt in the current hand. Players without chips sit out.
+	InHand bool
+
+	// Folded is set if the player has folded in the current hand.
+	Folded bool
+
+	// AllIn is set if the player has no chips left to bet in the current hand.
+	AllIn bool
+
+	// Acted is set if the player has acted in the current betting round since the last raise.
+	Acted bool
+
+	// LastAction is the last action of the player in the current hand, like "CALL".
+	LastAction string
+
+	// HandCategory is the category of the best hand of the player at the showdown, like "FLUSH".
+	HandCategory string
+
+	// Won is the amount the player won in the last showdown.
+	Won int64
+}
+
+// TableName returns the name of the database table of Player.
+func (p *Player) TableName() string {
+	return "holdem_players"
+}
+
+// Cards returns the hole cards of the player.
+func (p *Player) Cards() []*deck_repo.Card {
+	return asCards(p.HoleCards)
+}
+
+// active returns true if the player is still in the current hand.
+func (p *Player) active() bool {
+	return p.InHand && !p.Folded
+}
+
+// canAct returns true if the player can still bet in the current hand.
+func (p *Player) canAct() bool {
+	return p.active() && !p.AllIn
+}
+
+func asCards(codes string) []*deck_repo.Card {
+	if codes == "" {
+		return []*deck_repo.Card{}
+	}
+	return deck_repo.AsCards(codes)
+}
+
+func joinCodes(codes string, cards []*deck_repo.Card) string {
+	values := []string{}
+	if codes != "" {
+		values = append(values, codes)
+	}
+	for _, card := range cards {
+		values = append(values, card.Value)
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,16 @@
+package holdem
+
+// TableRepository defines methods for storing the tables of Texas Hold'em.
+type TableRepository interface {
+
+	// CreateTable stores a new table with its players. If the table has no ID, a new ID is generated.
+	CreateTable(table *Table) (*Table, error)
+
+	// GetTable looks up a table by its ID, with the players ordered by seat.
+	// If there is no table with the given ID, then a NotFoundError is returned.
+	GetTable(tableID string) (*Table, error)
+
+	// UpdateTable stores the changed table and its players. The table is updated only if it was not changed since
+	// it was read (see Table.Version), otherwise a BadRequestError is returned and the change should be retried.
+	UpdateTable(table *Table) (*Table, error)
+}
@@ -0,0 +1,84 @@
+package klondike
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories/versioned"
+)
+
+// DBGameRepository implements GameRepository storing the games in the database.
+type DBGameRepository struct {
+	db *gorm.DB
+}
+
+// CreateGame stores a new game with its piles. If the game has no ID, a new ID is generated.
+func (r *DBGameRepository) CreateGame(game *Game) (*Game, error) {
+	if game.ID == "" {
+		game.ID = uuid.NewString()
+	}
+	for _, pile := range game.Piles {
+		pile.GameID = game.ID
+	}
+
+	if result := r.db.Create(game); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return game, nil
+}
+
+// GetGame looks up a game by its ID, with its piles.
+// If there is no game with the given ID, then a NotFoundError is returned.
+func (r *DBGameRepository) GetGame(gameID string) (*Game, error) {
+	game := &Game{}
+
+	result := r.db.Preload("Piles").Where("id=?", gameID).First(game)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, api_errors.NotFoundError("no such game", nil)
+		}
+		return nil, result.Error
+	}
+
+	return game, nil
+}
+
+// UpdateGame stores the changed game and its piles within a single transaction. The game is updated only
+// if its version was not changed since it was read, otherwise a BadRequestError is returned.
+func (r *DBGameRepository) UpdateGame(game *Game) (*Game, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := versioned.Update(tx, game, &game.Version, "game", game.ID, "Piles", "CreatedAt"); err != nil {
+			return err
+		}
+
+		for _, pile := range game.Piles {
+			result := tx.Model(&Pile{}).Where("game_id=? AND name=?", pile.GameID, pile.Name).Select("*").Updates(pile)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// NewDBGameRepository creates a new GameRepository with the given database connection.
+func NewDBGameRepository(db *gorm.DB) GameRepository {
+	return &DBGameRepository{
+		db: db,
+	}
+}
+
+// AutoMigrateKlondikeModels performs an automatic migration of the Klondike Gorm models in the database.
+func AutoMigrateKlondikeModels(db *gorm.DB) error {
+	return db.AutoMigrate(&Game{}, &Pile{})
+}
@@ -0,0 +1,46 @@
+package klondike
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+func TestGameRepository(t *testing.T) {
+	games, _ := setupTest(t)
+	game := Deal(1, fullDeck())
+
+	if _, err := games.CreateGame(game); err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	if err := Draw(game); err != nil {
+		t.Fatalf("Failed to draw: %s", err.Error())
+	}
+	if _, err := games.UpdateGame(game); err != nil {
+		t.Fatalf("Failed to update game: %s", err.Error())
+	}
+
+	stored, err := games.GetGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %s", err.Error())
+	}
+	if stored.Version != 1 || stored.Moves != 1 || len(stored.Piles) != 13 {
+		t.Fatalf("Expected the stored game to have 13 piles, but got: %+v", stored)
+	}
+	if stored.Pile(WastePile).Cards != "KS" || stored.Pile(StockPile).FaceDown != 23 {
+		t.Errorf("Expected the piles to be stored, but got: %+v, %+v", stored.Pile(WastePile), stored.Pile(StockPile))
+	}
+	if stored.Pile(TableauPile(6)).FaceDown != 6 {
+		t.Errorf("Expected the face-down cards to be stored, but got: %+v", stored.Pile(TableauPile(6)))
+	}
+
+	// The game read before the update is stale.
+	game.Version = 0
+	if _, err := games.UpdateGame(game); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := games.GetGame("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing game, but got: %v", err)
+	}
+}
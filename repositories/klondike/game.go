@@ -0,0 +1,290 @@
+package klondike
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// rankIndex maps the rank codes to their position, from the ace (0) to the king (12).
+var rankIndex = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		rankIndex[rank] = i
+	}
+}
+
+// NewGame deals a new game from a new shuffled private deck created with the given PrivateDecks, so the order of the
+// face-down cards is known only to the game. The draw count is the number of cards turned from the stock at once,
+// 1 or 3.
+// Returns a ValidationError if the draw count is not valid.
+func NewGame(drawCount int, decks deck_repo.PrivateDecks) (*Game, error) {
+	if drawCount != 1 && drawCount != 3 {
+		return nil, errors.ValidationError("the draw count must be 1 or 3", nil)
+	}
+
+	deck, deckToken, err := decks.NewPrivateDeck(&deck_repo.Deck{
+		Shuffled: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cards, err := decks.Draw(deckToken, deck.ID, deck.Remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	game := Deal(drawCount, cards)
+	game.DeckID = deck.ID
+	return game, nil
+}
+
+// Deal deals a game from the given cards, in order: the tableau is dealt row by row, the first pile getting one card
+// and the last one seven, with only the top card of every pile face up. The remaining cards make the stock.
+func Deal(drawCount int, cards []*deck_repo.Card) *Game {
+	tableau := make([][]*deck_repo.Card, NumTableauPiles)
+	next := 0
+	for row := 0; row < NumTableauPiles; row++ {
+		for i := row; i < NumTableauPiles && next < len(cards); i++ {
+			tableau[i] = append(tableau[i], cards[next])
+			next++
+		}
+	}
+
+	game := &Game{
+		DrawCount: drawCount,
+		Status:    PlayingStatus,
+		Piles:     []*Pile{},
+	}
+
+	stock := &Pile{Name: StockPile}
+	stock.setCards(cards[next:])
+	stock.FaceDown = len(cards) - next
+	game.Piles = append(game.Piles, stock, &Pile{Name: WastePile})
+
+	for i := 0; i < NumFoundations; i++ {
+		game.Piles = append(game.Piles, &Pile{Name: FoundationPile(i)})
+	}
+	for i, cards := range tableau {
+		pile := &Pile{Name: TableauPile(i)}
+		pile.setCards(cards)
+		if len(cards) > 0 {
+			pile.FaceDown = len(cards) - 1
+		}
+		game.Piles = append(game.Piles, pile)
+	}
+
+	return game
+}
+
+// Move moves the top count cards from one pile to another. Moving from the stock to the waste turns the next cards
+// of the stock (see Draw). Only the face-up cards of a tableau pile can be moved, and more than one card can be
+// moved only between tableau piles. When the last face-up card is moved off a tableau pile, the next card is turned
+// face up.
+// Returns a BadRequestError if the move is not allowed by the rules.
+func Move(game *Game, from, to string, count int) error {
+	if game.Status != PlayingStatus {
+		return errors.BadRequestError("the game is over", nil)
+	}
+	if from == StockPile {
+		if to != WastePile {
+			return errors.BadRequestError("the cards from the stock can only be turned to the waste", nil)
+		}
+		return Draw(game)
+	}
+
+	source := game.Pile(from)
+	target := game.Pile(to)
+	if source == nil {
+		return errors.BadRequestError(fmt.Sprintf("no such pile: %s", from), nil)
+	}
+	if target == nil || target == source || to == StockPile || to == WastePile {
+		return errors.BadRequestError(fmt.Sprintf("cannot move cards to pile: %s", to), nil)
+	}
+	if count == 0 {
+		count = 1
+	}
+	if count < 0 || (count > 1 && !(isTableau(from) && isTableau(to))) {
+		return errors.BadRequestError("only one card can be moved, except between tableau piles", nil)
+	}
+
+	faceUp := source.FaceUpCards()
+	if count > len(faceUp) {
+		return errors.BadRequestError(fmt.Sprintf("there are only %d face-up cards in pile %s", len(faceUp), from), nil)
+	}
+	moved := faceUp[len(faceUp)-count:]
+
+	if isTableau(to) {
+		if !canBuildTableau(target, moved[0]) {
+			return errors.BadRequestError(fmt.Sprintf("cannot move %s to pile %s", moved[0].Value, to), nil)
+		}
+	} else if !canBuildFoundation(target, moved[0]) {
+		return errors.BadRequestError(fmt.Sprintf("cannot move %s to pile %s", moved[0].Value, to), nil)
+	}
+
+	moveCards(source, target, count)
+	game.Moves++
+	if isWon(game) {
+		game.Status = WonStatus
+	}
+	return nil
+}
+
+// Draw turns the next cards of the stock, one or three at a time depending on the draw count of the game, face up
+// on the waste. When the stock is empty, the waste is turned over into the stock.
+// Returns a BadRequestError if both the stock and the waste are empty.
+func Draw(game *Game) error {
+	if game.Status != PlayingStatus {
+		return errors.BadRequestError("the game is over", nil)
+	}
+
+	stock := game.Pile(StockPile)
+	waste := game.Pile(WastePile)
+	stockCards := stock.PileCards()
+	wasteCards := waste.PileCards()
+
+	if len(stockCards) == 0 {
+		if len(wasteCards) == 0 {
+			return errors.BadRequestError("the stock and the waste are empty", nil)
+		}
+		stock.setCards(reversed(wasteCards))
+		stock.FaceDown = len(wasteCards)
+		waste.setCards(nil)
+		game.Passes++
+		game.Moves++
+		return nil
+	}
+
+	count := game.DrawCount
+	if count > len(stockCards) {
+		count = len(stockCards)
+	}
+	// The cards are turned one by one, so the last card turned ends on top of the waste.
+	turned := reversed(stockCards[len(stockCards)-count:])
+	stock.setCards(stockCards[:len(stockCards)-count])
+	stock.FaceDown = len(stockCards) - count
+	waste.setCards(append(wasteCards, turned...))
+	game.Moves++
+	return nil
+}
+
+// CanAutoComplete returns true if the game can be finished by moving the cards to the foundations without any
+// decision of the player: the stock and the waste are empty and all the cards in the tableau are face up.
+func CanAutoComplete(game *Game) bool {
+	if game.Status != PlayingStatus {
+		return false
+	}
+	if game.Pile(StockPile).Cards != "" || game.Pile(WastePile).Cards != "" {
+		return false
+	}
+	for i := 0; i < NumTableauPiles; i++ {
+		if game.Pile(TableauPile(i)).FaceDown > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AutoComplete moves all cards from the tableau to the foundations, which wins the game.
+// Returns a BadRequestError if the game cannot be auto-completed (see CanAutoComplete).
+func AutoComplete(game *Game) error {
+	if !CanAutoComplete(game) {
+		return errors.BadRequestError("the game cannot be auto-completed", nil)
+	}
+
+	for moved := true; moved; {
+		moved = false
+		for i := 0; i < NumTableauPiles; i++ {
+			source := game.Pile(TableauPile(i))
+			cards := source.PileCards()
+			if len(cards) == 0 {
+				continue
+			}
+			for j := 0; j < NumFoundations; j++ {
+				target := game.Pile(FoundationPile(j))
+				if canBuildFoundation(target, cards[len(cards)-1]) {
+					moveCards(source, target, 1)
+					game.Moves++
+					moved = true
+					break
+				}
+			}
+		}
+	}
+
+	if isWon(game) {
+		game.Status = WonStatus
+	}
+	return nil
+}
+
+// canBuildTableau returns true if the card can be placed on the tableau pile: a king on an empty pile, or a card
+// one rank lower and of the opposite color than the top card.
+func canBuildTableau(pile *Pile, card *deck_repo.Card) bool {
+	cards := pile.PileCards()
+	if len(cards) == 0 {
+		return rank(card) == len(deck_repo.Ranks)-1
+	}
+	top := cards[len(cards)-1]
+	return rank(card) == rank(top)-1 && isRed(card) != isRed(top)
+}
+
+// canBuildFoundation returns true if the card can be placed on the foundation: an ace on an empty foundation, or
+// the next rank of the same suit.
+func canBuildFoundation(pile *Pile, card *deck_repo.Card) bool {
+	cards := pile.PileCards()
+	if len(cards) == 0 {
+		return rank(card) == 0
+	}
+	top := cards[len(cards)-1]
+	return rank(card) == rank(top)+1 && suit(card) == suit(top)
+}
+
+// moveCards moves the top count cards from the source to the target pile, turning the next card of the source face up.
+func moveCards(source, target *Pile, count int) {
+	cards := source.PileCards()
+	moved := cards[len(cards)-count:]
+	rest := cards[:len(cards)-count]
+
+	target.setCards(append(target.PileCards(), moved...))
+	source.setCards(rest)
+	if source.FaceDown >= len(rest) && len(rest) > 0 {
+		source.FaceDown = len(rest) - 1
+	}
+}
+
+func isWon(game *Game) bool {
+	for i := 0; i < NumFoundations; i++ {
+		if len(game.Pile(FoundationPile(i)).PileCards()) != len(deck_repo.Ranks) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTableau(name string) bool {
+	return strings.HasPrefix(name, tableauPrefix)
+}
+
+func rank(card *deck_repo.Card) int {
+	return rankIndex[card.Value[:len(card.Value)-1]]
+}
+
+func suit(card *deck_repo.Card) string {
+	return card.Value[len(card.Value)-1:]
+}
+
+func isRed(card *deck_repo.Card) bool {
+	return suit(card) == "D" || suit(card) == "H"
+}
+
+func reversed(cards []*deck_repo.Card) []*deck_repo.Card {
+	result := make([]*deck_repo.Card, 0, len(cards))
+	for i := len(cards) - 1; i >= 0; i-- {
+		result = append(result, cards[i])
+	}
+	return result
+}
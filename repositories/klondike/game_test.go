@@ -0,0 +1,273 @@
+package klondike
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testDeckToken is the token of the dealer of every deck created with testDecks.
+const testDeckToken = "dealer-token"
+
+// testDecks implements PrivateDecks with a DeckRepository, drawing only with testDeckToken.
+type testDecks struct {
+	deck_repo.DeckRepository
+}
+
+func (d *testDecks) NewPrivateDeck(deck *deck_repo.Deck) (*deck_repo.Deck, string, error) {
+	deck, err := d.CreateDeck(deck)
+	return deck, testDeckToken, err
+}
+
+func (d *testDecks) Draw(token, deckID string, count int) ([]*deck_repo.Card, error) {
+	if token != testDeckToken {
+		return nil, fmt.Errorf("invalid token for the deck %s", deckID)
+	}
+	return d.DrawCards(deckID, count)
+}
+
+func setupTest(t *testing.T) (GameRepository, *testDecks) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+	if err := AutoMigrateKlondikeModels(db); err != nil {
+		t.Fatalf("Failed to migrate Klondike models: %s", err.Error())
+	}
+
+	return NewDBGameRepository(db), &testDecks{deck_repo.NewDBDeckRepository(db)}
+}
+
+// newTestGame creates a game with empty piles, except for the given piles with the given cards, all face up.
+func newTestGame(t *testing.T, drawCount int, piles map[string]string) *Game {
+	game := Deal(drawCount, []*deck_repo.Card{})
+	for name, cards := range piles {
+		pile := game.Pile(name)
+		if pile == nil {
+			t.Fatalf("No such pile: %s", name)
+		}
+		pile.Cards = cards
+	}
+	return game
+}
+
+// fullDeck returns the cards of an unshuffled deck, from AC to KS.
+func fullDeck() []*deck_repo.Card {
+	return deck_repo.AsCards(strings.Join(deck_repo.NewFullDeck(), ","))
+}
+
+func mustMove(t *testing.T, game *Game, from, to string, count int) {
+	if err := Move(game, from, to, count); err != nil {
+		t.Fatalf("Expected the move from %s to %s to be allowed, but got error: %s", from, to, err.Error())
+	}
+}
+
+func TestDeal(t *testing.T) {
+	game := Deal(1, fullDeck())
+
+	for i := 0; i < NumTableauPiles; i++ {
+		pile := game.Pile(TableauPile(i))
+		if len(pile.PileCards()) != i+1 || pile.FaceDown != i || len(pile.FaceUpCards()) != 1 {
+			t.Errorf("Expected tableau pile %d to have %d cards with the top one face up, but got: %+v", i, i+1, pile)
+		}
+	}
+	// The first row goes from the first to the last pile.
+	if game.Pile(TableauPile(0)).Cards != "AC" || game.Pile(TableauPile(6)).PileCards()[0].Value != "7C" {
+		t.Errorf("Expected the tableau to be dealt row by row, but got: %+v", game.Piles)
+	}
+
+	stock := game.Pile(StockPile)
+	if len(stock.PileCards()) != 24 || stock.FaceDown != 24 || game.Pile(WastePile).Cards != "" {
+		t.Errorf("Expected the remaining 24 cards in the stock, but got: %+v", stock)
+	}
+	if game.Status != PlayingStatus || len(game.Piles) != 13 {
+		t.Errorf("Expected a new game with 13 piles, but got: %+v", game)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	_, decks := setupTest(t)
+
+	game, err := NewGame(3, decks)
+	if err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	if game.DeckID == "" || game.DrawCount != 3 || len(game.Pile(StockPile).PileCards()) != 24 {
+		t.Errorf("Expected a game dealt from a new deck, but got: %+v", game)
+	}
+
+	if _, err := NewGame(2, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for a draw count of 2, but got: %v", err)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	game := newTestGame(t, 3, map[string]string{StockPile: "2C,3C,4C,5C"})
+
+	mustMove(t, game, StockPile, WastePile, 0)
+	if game.Pile(StockPile).Cards != "2C" || game.Pile(WastePile).Cards != "5C,4C,3C" {
+		t.Fatalf("Expected three cards turned to the waste, but got %s and %s.",
+			game.Pile(StockPile).Cards, game.Pile(WastePile).Cards)
+	}
+
+	mustMove(t, game, StockPile, WastePile, 0)
+	if game.Pile(StockPile).Cards != "" || game.Pile(WastePile).Cards != "5C,4C,3C,2C" {
+		t.Fatalf("Expected the last card turned to the waste, but got: %s", game.Pile(WastePile).Cards)
+	}
+
+	// The waste is turned over into the stock, in the original order.
+	mustMove(t, game, StockPile, WastePile, 0)
+	if game.Pile(StockPile).Cards != "2C,3C,4C,5C" || game.Pile(WastePile).Cards != "" || game.Passes != 1 {
+		t.Errorf("Expected the waste turned over into the stock, but got: %+v", game)
+	}
+	if game.Moves != 3 {
+		t.Errorf("Expected 3 moves, but got %d.", game.Moves)
+	}
+
+	empty := newTestGame(t, 1, nil)
+	if err := Draw(empty); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for an empty stock and waste, but got: %v", err)
+	}
+}
+
+func TestMove_Tableau(t *testing.T) {
+	game := newTestGame(t, 1, map[string]string{
+		TableauPile(0): "AC,9S,8H,7C",
+		TableauPile(1): "10D",
+		TableauPile(2): "KS",
+		WastePile:      "6H",
+	})
+	game.Pile(TableauPile(0)).FaceDown = 1
+
+	// 9S is black on a red 10.
+	mustMove(t, game, TableauPile(0), TableauPile(1), 3)
+	if game.Pile(TableauPile(1)).Cards != "10D,9S,8H,7C" {
+		t.Fatalf("Expected the sequence moved onto 10D, but got: %s", game.Pile(TableauPile(1)).Cards)
+	}
+	if pile := game.Pile(TableauPile(0)); pile.Cards != "AC" || pile.FaceDown != 0 {
+		t.Errorf("Expected the next card to be turned face up, but got: %+v", pile)
+	}
+
+	mustMove(t, game, TableauPile(0), FoundationPile(0), 0)
+	if err := Move(game, TableauPile(0), FoundationPile(0), 0); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a move from an empty pile, but got: %v", err)
+	}
+
+	cases := []struct {
+		from  string
+		to    string
+		count int
+	}{
+		// 6H on 7C is red on black, but moves go only to the top of the pile.
+		{WastePile, TableauPile(2), 1},
+		// Same color.
+		{TableauPile(1), TableauPile(2), 0},
+		// Not a king on an empty pile.
+		{TableauPile(1), TableauPile(0), 1},
+		// Only the top card to a foundation.
+		{TableauPile(1), FoundationPile(1), 2},
+		// More cards than face up.
+		{TableauPile(1), TableauPile(0), 5},
+		// Not a pile.
+		{TableauPile(1), "tableau-9", 1},
+		{WastePile, StockPile, 1},
+		{StockPile, TableauPile(0), 1},
+	}
+	for _, c := range cases {
+		if err := Move(game, c.from, c.to, c.count); !errors.IsBadRequestError(err) {
+			t.Errorf("Expected a BadRequestError for %d cards from %s to %s, but got: %v", c.count, c.from, c.to, err)
+		}
+	}
+
+	// A king on an empty pile.
+	mustMove(t, game, TableauPile(2), TableauPile(0), 1)
+	mustMove(t, game, WastePile, TableauPile(1), 1)
+	if game.Pile(TableauPile(0)).Cards != "KS" || game.Pile(TableauPile(1)).Cards != "10D,9S,8H,7C,6H" {
+		t.Errorf("Expected the king and 6H to be moved, but got: %+v", game.Piles)
+	}
+}
+
+func TestMove_Foundation(t *testing.T) {
+	game := newTestGame(t, 1, map[string]string{
+		TableauPile(0): "2H,AS",
+		TableauPile(1): "3H",
+		WastePile:      "2S",
+	})
+
+	mustMove(t, game, TableauPile(0), FoundationPile(2), 1)
+	if err := Move(game, TableauPile(0), FoundationPile(2), 1); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for 2H on AS, but got: %v", err)
+	}
+	mustMove(t, game, WastePile, FoundationPile(2), 1)
+
+	// Cards may be moved back from a foundation to the tableau.
+	if err := Move(game, FoundationPile(2), TableauPile(0), 1); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for 2S on 2H, but got: %v", err)
+	}
+	mustMove(t, game, FoundationPile(2), TableauPile(1), 1)
+	if game.Pile(TableauPile(1)).Cards != "3H,2S" || game.Pile(FoundationPile(2)).Cards != "AS" {
+		t.Errorf("Expected 2S to be moved back to the tableau, but got: %+v", game.Piles)
+	}
+	if err := Move(game, TableauPile(1), TableauPile(1), 1); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a move to the same pile, but got: %v", err)
+	}
+}
+
+func TestAutoComplete(t *testing.T) {
+	game := newTestGame(t, 1, map[string]string{
+		FoundationPile(0): "AC,2C,3C,4C,5C,6C,7C,8C,9C,10C,JC,QC",
+		FoundationPile(1): "AD,2D,3D,4D,5D,6D,7D,8D,9D,10D,JD",
+		FoundationPile(2): "AH,2H,3H,4H,5H,6H,7H,8H,9H,10H,JH,QH,KH",
+		FoundationPile(3): "AS,2S,3S,4S,5S,6S,7S,8S,9S,10S,JS",
+		TableauPile(0):    "KC,QD",
+		TableauPile(1):    "KD,QS",
+		WastePile:         "KS",
+	})
+
+	if CanAutoComplete(game) {
+		t.Fatal("Expected no auto-complete with cards in the waste.")
+	}
+	if err := AutoComplete(game); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for an auto-complete with cards in the waste, but got: %v", err)
+	}
+
+	mustMove(t, game, WastePile, TableauPile(2), 1)
+	if !CanAutoComplete(game) {
+		t.Fatal("Expected the game to be auto-completed.")
+	}
+	if err := AutoComplete(game); err != nil {
+		t.Fatalf("Failed to auto-complete: %s", err.Error())
+	}
+	if game.Status != WonStatus || game.Moves != 6 {
+		t.Errorf("Expected the game to be won in 6 moves, but got: %+v", game)
+	}
+
+	if err := Draw(game); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a move after the game is over, but got: %v", err)
+	}
+}
+
+func TestMove_Win(t *testing.T) {
+	game := newTestGame(t, 1, map[string]string{
+		FoundationPile(0): "AC,2C,3C,4C,5C,6C,7C,8C,9C,10C,JC,QC,KC",
+		FoundationPile(1): "AD,2D,3D,4D,5D,6D,7D,8D,9D,10D,JD,QD,KD",
+		FoundationPile(2): "AH,2H,3H,4H,5H,6H,7H,8H,9H,10H,JH,QH,KH",
+		FoundationPile(3): "AS,2S,3S,4S,5S,6S,7S,8S,9S,10S,JS,QS",
+		StockPile:         "KS",
+	})
+	game.Pile(StockPile).FaceDown = 1
+
+	mustMove(t, game, StockPile, WastePile, 0)
+	mustMove(t, game, WastePile, FoundationPile(3), 1)
+	if game.Status != WonStatus {
+		t.Errorf("Expected the game to be won, but got: %+v", game)
+	}
+}
@@ -0,0 +1,133 @@
+package klondike
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Statuses of a game of Klondike.
+const (
+	// PlayingStatus is the status of a game in progress.
+	PlayingStatus = "PLAYING"
+
+	// WonStatus is the status of a game with all cards on the foundations.
+	WonStatus = "WON"
+)
+
+// Names of the piles. There are 4 foundations and 7 tableau piles, see FoundationPile and TableauPile.
+const (
+	StockPile = "stock"
+	WastePile = "waste"
+
+	foundationPrefix = "foundation-"
+	tableauPrefix    = "tableau-"
+)
+
+// NumFoundations is the number of foundation piles, one per suit.
+const NumFoundations = 4
+
+// NumTableauPiles is the number of tableau piles.
+const NumTableauPiles = 7
+
+// FoundationPile returns the name of the foundation pile with the given index, starting at 0.
+func FoundationPile(index int) string {
+	return fmt.Sprintf("%s%d", foundationPrefix, index)
+}
+
+// TableauPile returns the name of the tableau pile with the given index, starting at 0.
+func TableauPile(index int) string {
+	return fmt.Sprintf("%s%d", tableauPrefix, index)
+}
+
+// Game represents the database model for a game of Klondike solitaire.
+type Game struct {
+	// ID is a unique identifier for this game, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this game was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this game was last updated.
+	UpdatedAt time.Time
+
+	// DeckID is the ID of the shuffled deck the game was dealt from. The deck is kept private by the game: never shown.
+	DeckID string
+
+	// DrawCount is the number of cards turned from the stock to the waste at once: 1 or 3.
+	DrawCount int
+
+	// Status is the status of the game, like PlayingStatus.
+	Status string
+
+	// Moves is the number of moves made in the game.
+	Moves int
+
+	// Passes is the number of times the waste was turned over into the stock.
+	Passes int
+
+	// Version is incremented with every change of the game, and used to detect concurrent changes.
+	Version int
+
+	// Piles holds the stock, the waste, the foundations and the tableau piles.
+	Piles []*Pile `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Game.
+func (g *Game) TableName() string {
+	return "klondike_games"
+}
+
+// Pile returns the pile with the given name, or nil if there is no such pile.
+func (g *Game) Pile(name string) *Pile {
+	for _, pile := range g.Piles {
+		if pile.Name == name {
+			return pile
+		}
+	}
+	return nil
+}
+
+// Pile represents the database model for a pile of cards in a game of Klondike.
+type Pile struct {
+	// GameID is the foreign key to the game.
+	GameID string `gorm:"primaryKey"`
+
+	// Name is the name of the pile, like StockPile or TableauPile(0).
+	Name string `gorm:"primaryKey"`
+
+	// Cards holds the codes of the cards in the pile, comma separated, from the bottom to the top of the pile.
+	Cards string
+
+	// FaceDown is the number of cards at the bottom of the pile that are face down. All cards in the stock are
+	// face down.
+	FaceDown int
+}
+
+// TableName returns the name of the database table of Pile.
+func (p *Pile) TableName() string {
+	return "klondike_piles"
+}
+
+// PileCards returns the cards in the pile, from the bottom to the top.
+func (p *Pile) PileCards() []*deck_repo.Card {
+	if p.Cards == "" {
+		return []*deck_repo.Card{}
+	}
+	return deck_repo.AsCards(p.Cards)
+}
+
+// FaceUpCards returns the cards in the pile that are face up, from the bottom to the top.
+func (p *Pile) FaceUpCards() []*deck_repo.Card {
+	return p.PileCards()[p.FaceDown:]
+}
+
+func (p *Pile) setCards(cards []*deck_repo.Card) {
+	values := make([]string, 0, len(cards))
+	for _, card := range cards {
+		values = append(values, card.Value)
+	}
+	p.Cards = strings.Join(values, ",")
+}
@@ -0,0 +1,16 @@
+package klondike
+
+// GameRepository defines methods for storing the games of Klondike solitaire.
+type GameRepository interface {
+
+	// CreateGame stores a new game with its piles. If the game has no ID, a new ID is generated.
+	CreateGame(game *Game) (*Game, error)
+
+	// GetGame looks up a game by its ID, with its piles.
+	// If there is no game with the given ID, then a NotFoundError is returned.
+	GetGame(gameID string) (*Game, error)
+
+	// UpdateGame stores the changed game and its piles. The game is updated only if it was not changed since
+	// it was read (see Game.Version), otherwise a BadRequestError is returned and the change should be retried.
+	UpdateGame(game *Game) (*Game, error)
+}
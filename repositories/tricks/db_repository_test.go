@@ -0,0 +1,64 @@
+package tricks
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTest(t *testing.T) GameRepository {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := AutoMigrateTricksModels(db); err != nil {
+		t.Fatalf("Failed to migrate trick-taking game models: %s", err.Error())
+	}
+
+	return NewDBGameRepository(db)
+}
+
+func TestGameRepository(t *testing.T) {
+	games := setupTest(t)
+	game := &Game{
+		Variant: "hearts",
+		Stage:   PlayingStage,
+		Players: []*Player{},
+	}
+	for seat, hand := range []string{"2C,3C", "4C,5C", "6C,7C", "8C,9C"} {
+		game.Players = append(game.Players, &Player{Seat: seat, Hand: hand, Bid: NoBid})
+	}
+
+	if _, err := games.CreateGame(game); err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	game.Players[0].Hand = "3C"
+	game.Trick = "2C"
+	game.Turn = 1
+	if _, err := games.UpdateGame(game); err != nil {
+		t.Fatalf("Failed to update game: %s", err.Error())
+	}
+
+	stored, err := games.GetGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %s", err.Error())
+	}
+	if stored.Version != 1 || stored.Trick != "2C" || stored.Turn != 1 || len(stored.Players) != 4 {
+		t.Fatalf("Expected the stored game to have the trick, but got: %+v", stored)
+	}
+	if stored.Players[0].Hand != "3C" || stored.Players[3].Hand != "8C,9C" || stored.Players[0].Bid != NoBid {
+		t.Errorf("Expected the players to be stored, but got: %+v, %+v", stored.Players[0], stored.Players[3])
+	}
+
+	// The game read before the update is stale.
+	game.Version = 0
+	if _, err := games.UpdateGame(game); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a concurrent change, but got: %v", err)
+	}
+
+	if _, err := games.GetGame("missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected a NotFoundError for a missing game, but got: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package tricks
+
+import (
+	"strings"
+	"time"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Stages of a hand of a trick-taking game.
+const (
+	// BiddingStage is the stage after the deal in which the players bid, in games with bidding.
+	BiddingStage = "BIDDING"
+
+	// PlayingStage is the stage in which the players play the tricks.
+	PlayingStage = "PLAYING"
+
+	// HandOverStage is the end of a hand: the hand is scored and the next hand can be dealt.
+	HandOverStage = "HAND_OVER"
+
+	// GameOverStage is the end of the game.
+	GameOverStage = "GAME_OVER"
+)
+
+// NoBid is the bid of a player that has not bid yet.
+const NoBid = -1
+
+// Game represents the database model for a game of a trick-taking game, like Hearts or Spades.
+type Game struct {
+	// ID is a unique identifier for this game, usually an UUID v4.
+	ID string `gorm:"primaryKey"`
+
+	// CreatedAt is the time when this game was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time when this game was last updated.
+	UpdatedAt time.Time
+
+	// Variant is the name of the rule set of the game, like "hearts".
+	Variant string
+
+	// DeckID is the ID of the deck the current hand is dealt from. Every hand is dealt from a new shuffled deck.
+	DeckID string
+
+	// Stage is the stage of the current hand, like PlayingStage.
+	Stage string
+
+	// HandNumber is the number of hands dealt in this game.
+	HandNumber int
+
+	// Dealer is the seat of the dealer of the current hand.
+	Dealer int
+
+	// Leader is the seat of the player that led the current trick.
+	Leader int
+
+	// Turn is the seat of the player to bid or to play.
+	Turn int
+
+	// Trick holds the codes of the cards played to the current trick, comma separated, starting with the card of
+	// the leader.
+	Trick string
+
+	// LastTrick holds the codes of the cards of the last complete trick, starting with the card of its leader.
+	LastTrick string
+
+	// LastTrickLeader is the seat of the player that led the last complete trick.
+	LastTrickLeader int
+
+	// LastTrickWinner is the seat of the player that won the last complete trick.
+	LastTrickWinner int
+
+	// TricksPlayed is the number of complete tricks in the current hand.
+	TricksPlayed int
+
+	// Broken is set once a card of the suit that may not be led at first (like hearts in Hearts) was played
+	// in the current hand.
+	Broken bool
+
+	// Version is incremented with every change of the game, and used to detect concurrent changes.
+	Version int
+
+	// Players holds the players of the game, ordered by seat.
+	Players []*Player `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// TableName returns the name of the database table of Game.
+func (g *Game) TableName() string {
+	return "tricks_games"
+}
+
+// TrickCards returns the cards played to the current trick, starting with the card of the leader.
+func (g *Game) TrickCards() []*deck_repo.Card {
+	return asCards(g.Trick)
+}
+
+// LastTrickCards returns the cards of the last complete trick, starting with the card of its leader.
+func (g *Game) LastTrickCards() []*deck_repo.Card {
+	return asCards(g.LastTrick)
+}
+
+// TokenHashes returns the hashes of the tokens of the players, by seat.
+func (g *Game) TokenHashes() []string {
+	hashes := []string{}
+	for _, player := range g.Players {
+		hashes = append(hashes, player.TokenHash)
+	}
+	return hashes
+}
+
+// Player represents the database model for a player of a trick-taking game.
+type Player struct {
+	// GameID is the foreign key to the game.
+	GameID string `gorm:"primaryKey"`
+
+	// Seat is the position of the player, starting at 0. The play goes in the order of the seats.
+	Seat int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Name is the name of the player.
+	Name string
+
+	// Hand holds the codes of the cards in the hand of the player, comma separated.
+	Hand string
+
+	// Taken holds the codes of the cards of the tricks the player won in the current hand, comma separated.
+	Taken string
+
+	// Bid is the bid of the player in the current hand, or NoBid.
+	Bid int
+
+	// Tricks is the number of tricks the player won in the current hand.
+	Tricks int
+
+	// HandScore is the score of the player in the last scored hand.
+	HandScore int
+
+	// Score is the total score of the player in the game.
+	Score int
+
+	// Bags is the number of overtricks the player (or the partnership) has accumulated, in games that count them.
+	Bags int
+
+	// TokenHash is the hash of the secret token of the player (see deck_repo.HashToken). The token itself is not
+	// stored.
+	TokenHash string `gorm:"index"`
+}
+
+// TableName returns the name of the database table of Player.
+func (p *Player) TableName() string {
+	return "tricks_players"
+}
+
+// HandCards returns the cards in the hand of the player.
+func (p *Player) HandCards() []*deck_repo.Card {
+	return asCards(p.Hand)
+}
+
+// TakenCards returns the cards of the tricks the player won in the current hand.
+func (p *Player) TakenCards() []*deck_repo.Card {
+	return asCards(p.Taken)
+}
+
+// JoinCodes appends the codes of the cards to the comma-separated codes.
+func JoinCodes(codes string, cards ...*deck_repo.Card) string {
+	values := []string{}
+	if codes != "" {
+		values = append(values, codes)
+	}
+	for _, card := range cards {
+		values = append(values, card.Value)
+	}
+	return strings.Join(values, ",")
+}
+
+func asCards(codes string) []*deck_repo.Card {
+	if codes == "" {
+		return []*deck_repo.Card{}
+	}
+	return deck_repo.AsCards(codes)
+}
@@ -0,0 +1,16 @@
+package tricks
+
+// GameRepository defines methods for storing the games of trick-taking card games.
+type GameRepository interface {
+
+	// CreateGame stores a new game with its players. If the game has no ID, a new ID is generated.
+	CreateGame(game *Game) (*Game, error)
+
+	// GetGame looks up a game by its ID, with the players ordered by seat.
+	// If there is no game with the given ID, then a NotFoundError is returned.
+	GetGame(gameID string) (*Game, error)
+
+	// UpdateGame stores the changed game and its players. The game is updated only if it was not changed since
+	// it was read (see Game.Version), otherwise a BadRequestError is returned and the change should be retried.
+	UpdateGame(game *Game) (*Game, error)
+}
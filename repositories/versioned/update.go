@@ -0,0 +1,38 @@
+// Package versioned stores the changes of the game models that detect concurrent changes by their version: a model is
+// updated only if its version was not changed since it was read, and every update increments the version.
+package versioned
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+// Update stores the changed model within the transaction, only if the version of the row is still the version the
+// model was read with. The version of the model is incremented, and restored if the model is not updated. The
+// associations and the creation time given in omit are not stored.
+// If the row was changed concurrently, returns a BadRequestError naming the kind and the ID of the model.
+func Update(tx *gorm.DB, model interface{}, version *int, kind, id string, omit ...string) error {
+	read := *version
+	*version++
+
+	result := tx.Model(model).Omit(omit...).Where("version=?", read).Select("*").Updates(model)
+	if result.Error != nil {
+		*version = read
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		*version = read
+		return api_errors.BadRequestError(fmt.Sprintf("%s %s was changed concurrently, please retry", kind, id), nil)
+	}
+	return nil
+}
+
+// UpdateSeat stores the changed player of the seat within the transaction. The players are keyed by the ID of the
+// model they play in, held in the column, and by the seat. The seat may be zero, which gorm takes for a missing
+// primary key, so the row is selected explicitly.
+func UpdateSeat(tx *gorm.DB, player interface{}, column, id string, seat int) error {
+	return tx.Model(player).Where(column+"=? AND seat=?", id, seat).Select("*").Updates(player).Error
+}
@@ -0,0 +1,67 @@
+package versioned
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testGame struct {
+	ID      string `gorm:"primaryKey"`
+	Round   int
+	Version int
+	Players []*testPlayer `gorm:"foreignKey:GameID"`
+}
+
+type testPlayer struct {
+	GameID string `gorm:"primaryKey"`
+	Seat   int    `gorm:"primaryKey;autoIncrement:false"`
+	Score  int
+}
+
+func TestUpdate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:versioned?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := db.AutoMigrate(&testGame{}, &testPlayer{}); err != nil {
+		t.Fatalf("Failed to migrate: %s", err.Error())
+	}
+	game := &testGame{ID: "game", Players: []*testPlayer{{Seat: 0}, {Seat: 1}}}
+	if result := db.Create(game); result.Error != nil {
+		t.Fatalf("Failed to create the game: %s", result.Error.Error())
+	}
+	stale := &testGame{ID: "game"}
+
+	game.Round = 1
+	game.Players[0].Score = 5
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := Update(tx, game, &game.Version, "game", game.ID, "Players"); err != nil {
+			return err
+		}
+		return UpdateSeat(tx, game.Players[0], "game_id", game.ID, game.Players[0].Seat)
+	})
+	if err != nil || game.Version != 1 {
+		t.Fatalf("Expected the game to be updated to version 1, but got version %d and error: %v", game.Version, err)
+	}
+
+	stored := &testGame{}
+	if result := db.Preload("Players").First(stored, "id=?", "game"); result.Error != nil {
+		t.Fatalf("Failed to get the game: %s", result.Error.Error())
+	}
+	if stored.Round != 1 || stored.Version != 1 || stored.Players[0].Score != 5 || stored.Players[1].Score != 0 {
+		t.Errorf("Expected the changes of the game and of the seat 0 to be stored, but got: %+v, %+v, %+v", stored,
+			stored.Players[0], stored.Players[1])
+	}
+
+	stale.Round = 2
+	err = Update(db, stale, &stale.Version, "game", stale.ID, "Players")
+	if !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a stale version, but got: %v", err)
+	}
+	if stale.Version != 0 {
+		t.Errorf("Expected the version of the stale game to be restored, but got %d.", stale.Version)
+	}
+}
@@ -0,0 +1,138 @@
+package baccarat
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// CreateShoeRequest represents the request of a CreateShoe call.
+type CreateShoeRequest struct {
+	// Decks is the number of decks in the shoe, 8 by default.
+	Decks int `json:"decks"`
+}
+
+// BetsRequest holds the bets in a RoundRequest. Spots without a bet are left out.
+type BetsRequest struct {
+	// Player is the bet on the player hand winning.
+	Player int64 `json:"player"`
+
+	// Banker is the bet on the banker hand winning.
+	Banker int64 `json:"banker"`
+
+	// Tie is the bet on a tie.
+	Tie int64 `json:"tie"`
+
+	// PlayerPair is the bet on the first two cards of the player being a pair.
+	PlayerPair int64 `json:"player_pair"`
+
+	// BankerPair is the bet on the first two cards of the banker being a pair.
+	BankerPair int64 `json:"banker_pair"`
+}
+
+// RoundRequest represents the request of a PlayRound call.
+type RoundRequest struct {
+	// ShoeID is the ID of the shoe to deal the round from. If not set, the round is dealt from a new shoe.
+	ShoeID string `json:"shoe_id"`
+
+	// Decks is the number of decks of the new shoe, if no shoe is given. 8 by default.
+	Decks int `json:"decks"`
+
+	// Bets holds the bets of the round.
+	Bets BetsRequest `json:"bets"`
+}
+
+// ShoeResponse represents a shoe.
+type ShoeResponse struct {
+	// ShoeID is the ID of the shoe.
+	ShoeID string `json:"shoe_id"`
+
+	// Decks is the number of decks in the shoe.
+	Decks int `json:"decks"`
+
+	// CardsDealt is the number of cards dealt from the shoe.
+	CardsDealt int `json:"cards_dealt"`
+
+	// CutCard is the number of cards dealt after which the shoe is finished.
+	CutCard int `json:"cut_card"`
+
+	// Rounds is the number of rounds dealt from the shoe.
+	Rounds int `json:"rounds"`
+
+	// Finished is set once the cut card came out. No more rounds are dealt from a finished shoe.
+	Finished bool `json:"finished"`
+}
+
+// HandResponse represents the hand of the player or the banker in a RoundResponse.
+type HandResponse struct {
+	// Cards are the cards of the hand, in the order they were dealt. The third card, if any, is the last one.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// Total is the total of the hand.
+	Total int `json:"total"`
+
+	// Pair is set if the first two cards are a pair.
+	Pair bool `json:"pair"`
+}
+
+// RoundResponse represents a round of baccarat.
+type RoundResponse struct {
+	// Shoe is the shoe the round was dealt from, after the round.
+	Shoe ShoeResponse `json:"shoe"`
+
+	// Round is the number of the round in the shoe.
+	Round int `json:"round"`
+
+	// Player is the player hand.
+	Player HandResponse `json:"player"`
+
+	// Banker is the banker hand.
+	Banker HandResponse `json:"banker"`
+
+	// Outcome is the outcome of the round: PLAYER, BANKER or TIE.
+	Outcome string `json:"outcome"`
+
+	// Natural is set if a hand was dealt a total of 8 or 9 with the first two cards.
+	Natural bool `json:"natural"`
+
+	// Bets holds the bets of the round.
+	Bets BetsRequest `json:"bets"`
+
+	// Payouts holds the net result of each of the bets: positive if won, negative if lost, zero if pushed.
+	Payouts BetsRequest `json:"payouts"`
+
+	// Payout is the net result of all bets.
+	Payout int64 `json:"payout"`
+}
+
+// RoadEntryResponse represents an entry of a road map.
+type RoadEntryResponse struct {
+	// Round is the number of the round.
+	Round int `json:"round"`
+
+	// Outcome is the outcome of the round: PLAYER, BANKER or TIE.
+	Outcome string `json:"outcome"`
+
+	// Ties is the number of ties right after the round, or before the first round of the big road.
+	Ties int `json:"ties,omitempty"`
+
+	// PlayerPair is set if the first two cards of the player were a pair.
+	PlayerPair bool `json:"player_pair"`
+
+	// BankerPair is set if the first two cards of the banker were a pair.
+	BankerPair bool `json:"banker_pair"`
+
+	// Column is the column of the entry, starting at 0 on the left.
+	Column int `json:"column"`
+
+	// Row is the row of the entry, from 0 at the top to 5 at the bottom.
+	Row int `json:"row"`
+}
+
+// RoadsResponse represents the response of a GetRoads call.
+type RoadsResponse struct {
+	// Shoe is the shoe of the road maps.
+	Shoe ShoeResponse `json:"shoe"`
+
+	// BeadPlate holds every round in order, filling the columns from the top.
+	BeadPlate []RoadEntryResponse `json:"bead_plate"`
+
+	// BigRoad holds the streaks of the player and the banker wins, a streak per column.
+	BigRoad []RoadEntryResponse `json:"big_road"`
+}
@@ -0,0 +1,11 @@
+package baccarat
+
+import "github.com/gin-gonic/gin"
+
+// SetupShoeServiceRouting sets up the routing for ShoeService with gin router.
+func SetupShoeServiceRouting(group *gin.RouterGroup, shoeService *ShoeService) {
+	group.POST("/baccarat/shoes", shoeService.CreateShoe)
+	group.GET("/baccarat/shoes/:shoeId", shoeService.GetShoe)
+	group.GET("/baccarat/shoes/:shoeId/roads", shoeService.GetRoads)
+	group.POST("/baccarat/rounds", shoeService.PlayRound)
+}
@@ -0,0 +1,195 @@
+package baccarat
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	baccarat_repo "github.com/natemago/card-games-api/repositories/baccarat"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// ShoeService represents the REST API service for the baccarat shoes and rounds.
+// Uses the ShoeRepository to store the shoes with their rounds, and the DeckRepository for the cards.
+type ShoeService struct {
+	Shoes baccarat_repo.ShoeRepository
+	Decks deck_repo.DeckRepository
+}
+
+// CreateShoe creates a new shuffled shoe with the number of decks given as a CreateShoeRequest JSON body.
+// If the number of decks is not valid, returns a 400 Bad Request error response.
+func (s *ShoeService) CreateShoe(ctx *gin.Context) {
+	request := &CreateShoeRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	shoe, err := s.newShoe(request.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toShoeResponse(shoe))
+}
+
+// GetShoe looks up a shoe by its ID and returns its state.
+// If the shoe does not exist, returns a 404 Not Found error response.
+func (s *ShoeService) GetShoe(ctx *gin.Context) {
+	shoe, err := s.Shoes.GetShoe(ctx.Param("shoeId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toShoeResponse(shoe))
+}
+
+// GetRoads returns the road maps of the rounds dealt from a shoe: the bead plate and the big road.
+// If the shoe does not exist, returns a 404 Not Found error response.
+func (s *ShoeService) GetRoads(ctx *gin.Context) {
+	shoe, err := s.Shoes.GetShoe(ctx.Param("shoeId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &RoadsResponse{
+		Shoe:      toShoeResponse(shoe),
+		BeadPlate: toRoadResponse(baccarat_repo.BeadPlate(shoe.Rounds)),
+		BigRoad:   toRoadResponse(baccarat_repo.BigRoad(shoe.Rounds)),
+	})
+}
+
+// PlayRound deals a round with the bets given as a RoundRequest JSON body. The drawing rules are applied
+// automatically, and the bets are settled. Without a shoe in the request, the round is dealt from a new shoe.
+// Returns the round with the cards dealt to the player and the banker, the outcome and the payouts.
+// If the shoe does not exist, returns a 404 Not Found error response. If the shoe is finished or a bet is not
+// valid, returns a 400 Bad Request error response.
+func (s *ShoeService) PlayRound(ctx *gin.Context) {
+	request := &RoundRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	var shoe *baccarat_repo.Shoe
+	var err error
+	if request.ShoeID == "" {
+		shoe, err = s.newShoe(request.Decks)
+	} else {
+		shoe, err = s.Shoes.GetShoe(request.ShoeID)
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	round, err := baccarat_repo.PlayRound(shoe, baccarat_repo.Bets{
+		Player:     request.Bets.Player,
+		Banker:     request.Bets.Banker,
+		Tie:        request.Bets.Tie,
+		PlayerPair: request.Bets.PlayerPair,
+		BankerPair: request.Bets.BankerPair,
+	}, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	shoe, err = s.Shoes.AddRound(shoe, round)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toRoundResponse(shoe, round))
+}
+
+// newShoe creates and stores a new shoe with the given number of decks, or DefaultDecks if not set.
+func (s *ShoeService) newShoe(decks int) (*baccarat_repo.Shoe, error) {
+	if decks == 0 {
+		decks = baccarat_repo.DefaultDecks
+	}
+
+	shoe, err := baccarat_repo.NewShoe(decks, s.Decks)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Shoes.CreateShoe(shoe)
+}
+
+func toShoeResponse(shoe *baccarat_repo.Shoe) ShoeResponse {
+	return ShoeResponse{
+		ShoeID:     shoe.ID,
+		Decks:      shoe.Decks,
+		CardsDealt: shoe.CardsDealt,
+		CutCard:    shoe.CutCard,
+		Rounds:     shoe.RoundNumber,
+		Finished:   shoe.Finished,
+	}
+}
+
+func toRoundResponse(shoe *baccarat_repo.Shoe, round *baccarat_repo.Round) *RoundResponse {
+	payouts := baccarat_repo.Payouts(round)
+
+	return &RoundResponse{
+		Shoe:  toShoeResponse(shoe),
+		Round: round.Number,
+		Player: HandResponse{
+			Cards: deck_api.ToCardResponses(round.Player()),
+			Total: round.PlayerTotal,
+			Pair:  round.PlayerPair,
+		},
+		Banker: HandResponse{
+			Cards: deck_api.ToCardResponses(round.Banker()),
+			Total: round.BankerTotal,
+			Pair:  round.BankerPair,
+		},
+		Outcome: round.Outcome,
+		Natural: round.Natural,
+		Bets:    toBetsResponse(round.Bets),
+		Payouts: toBetsResponse(payouts),
+		Payout:  payouts.Net(),
+	}
+}
+
+func toBetsResponse(bets baccarat_repo.Bets) BetsRequest {
+	return BetsRequest{
+		Player:     bets.Player,
+		Banker:     bets.Banker,
+		Tie:        bets.Tie,
+		PlayerPair: bets.PlayerPair,
+		BankerPair: bets.BankerPair,
+	}
+}
+
+func toRoadResponse(entries []baccarat_repo.RoadEntry) []RoadEntryResponse {
+	response := []RoadEntryResponse{}
+
+	for _, entry := range entries {
+		response = append(response, RoadEntryResponse{
+			Round:      entry.Round,
+			Outcome:    entry.Outcome,
+			Ties:       entry.Ties,
+			PlayerPair: entry.PlayerPair,
+			BankerPair: entry.BankerPair,
+			Column:     entry.Column,
+			Row:        entry.Row,
+		})
+	}
+
+	return response
+}
+
+// NewShoeService creates a new pointer to a ShoeService using the given repositories.
+func NewShoeService(shoeRepository baccarat_repo.ShoeRepository, deckRepository deck_repo.DeckRepository) *ShoeService {
+	return &ShoeService{
+		Shoes: shoeRepository,
+		Decks: deckRepository,
+	}
+}
@@ -0,0 +1,124 @@
+package baccarat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	baccarat_repo "github.com/natemago/card-games-api/repositories/baccarat"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) *gin.Engine {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupShoeServiceRouting(router.Group("/v1"),
+		NewShoeService(baccarat_repo.NewDBShoeRepository(db), deck_repo.NewDBDeckRepository(db)))
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, body string, expectedCode int, resp interface{}) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if resp == nil {
+		return
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the response, but got error: %s", err.Error())
+	}
+}
+
+func TestPlayRound(t *testing.T) {
+	router := setupTest(t)
+
+	round := &RoundResponse{}
+	call(t, router, "POST", "/v1/baccarat/rounds", `{"bets": {"banker": 100, "tie": 10}}`, http.StatusCreated, round)
+
+	if round.Shoe.ShoeID == "" || round.Shoe.Decks != baccarat_repo.DefaultDecks || round.Round != 1 {
+		t.Fatalf("Expected the first round of a new shoe, but got: %+v", round)
+	}
+	player, banker := len(round.Player.Cards), len(round.Banker.Cards)
+	if player < 2 || player > 3 || banker < 2 || banker > 3 || round.Shoe.CardsDealt != player+banker {
+		t.Errorf("Expected two or three cards per hand, all counted on the shoe, but got: %+v", round)
+	}
+	if round.Bets.Banker != 100 || round.Bets.Tie != 10 {
+		t.Errorf("Expected the bets in the round, but got: %+v", round.Bets)
+	}
+	payout := round.Payouts.Player + round.Payouts.Banker + round.Payouts.Tie + round.Payouts.PlayerPair +
+		round.Payouts.BankerPair
+	if round.Payout != payout {
+		t.Errorf("Expected the net payout %d, but got %d.", payout, round.Payout)
+	}
+
+	for i := 2; i <= 5; i++ {
+		call(t, router, "POST", "/v1/baccarat/rounds", fmt.Sprintf(`{"shoe_id": "%s"}`, round.Shoe.ShoeID),
+			http.StatusCreated, round)
+		if round.Round != i {
+			t.Errorf("Expected round %d, but got %d.", i, round.Round)
+		}
+	}
+
+	shoe := &ShoeResponse{}
+	call(t, router, "GET", "/v1/baccarat/shoes/"+round.Shoe.ShoeID, "", http.StatusOK, shoe)
+	if shoe.Rounds != 5 || shoe.CardsDealt != round.Shoe.CardsDealt {
+		t.Errorf("Expected the shoe after 5 rounds, but got: %+v", shoe)
+	}
+
+	roads := &RoadsResponse{}
+	call(t, router, "GET", "/v1/baccarat/shoes/"+shoe.ShoeID+"/roads", "", http.StatusOK, roads)
+	if len(roads.BeadPlate) != 5 {
+		t.Errorf("Expected every round on the bead plate, but got: %+v", roads.BeadPlate)
+	}
+	entries := 0
+	for _, entry := range roads.BigRoad {
+		entries += 1 + entry.Ties
+	}
+	if len(roads.BigRoad) > 0 && entries != 5 {
+		t.Errorf("Expected every round on the big road, counting the ties, but got: %+v", roads.BigRoad)
+	}
+}
+
+func TestCreateShoe(t *testing.T) {
+	router := setupTest(t)
+
+	shoe := &ShoeResponse{}
+	call(t, router, "POST", "/v1/baccarat/shoes", `{"decks": 6}`, http.StatusCreated, shoe)
+	if shoe.Decks != 6 || shoe.CutCard != 6*52-baccarat_repo.CutCardFromEnd || shoe.Finished {
+		t.Errorf("Expected a new shoe of 6 decks, but got: %+v", shoe)
+	}
+
+	call(t, router, "POST", "/v1/baccarat/shoes", `{"decks": 9}`, http.StatusBadRequest, nil)
+	call(t, router, "GET", "/v1/baccarat/shoes/missing", "", http.StatusNotFound, nil)
+	call(t, router, "GET", "/v1/baccarat/shoes/missing/roads", "", http.StatusNotFound, nil)
+	call(t, router, "POST", "/v1/baccarat/rounds", `{"shoe_id": "missing"}`, http.StatusNotFound, nil)
+	call(t, router, "POST", "/v1/baccarat/rounds", fmt.Sprintf(`{"shoe_id": "%s", "bets": {"player": -5}}`, shoe.ShoeID),
+		http.StatusBadRequest, nil)
+}
@@ -0,0 +1,156 @@
+package blackjack
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// RulesRequest holds the house rules in a CreateTable request. Rules that are not set keep their default value.
+type RulesRequest struct {
+	// Decks is the number of decks in the shoe, 6 by default.
+	Decks *int `json:"decks"`
+
+	// HitSoft17 makes the dealer hit a soft 17. By default, the dealer stands on all 17s.
+	HitSoft17 *bool `json:"hit_soft_17"`
+
+	// DoubleAfterSplit allows doubling down after a split, which is allowed by default.
+	DoubleAfterSplit *bool `json:"double_after_split"`
+
+	// Surrender allows the player to surrender the initial hand, which is not allowed by default.
+	Surrender *bool `json:"surrender"`
+
+	// BlackjackPayout is the payout of a blackjack, like "3:2" (the default) or "6:5".
+	BlackjackPayout string `json:"blackjack_payout"`
+
+	// Penetration is the part of the shoe dealt before it is reshuffled, 0.75 by default.
+	Penetration *float64 `json:"penetration"`
+
+	// MaxHands is the maximal number of hands by splitting, 4 by default.
+	MaxHands *int `json:"max_hands"`
+
+	// MinBet is the minimal bet, 1 by default.
+	MinBet *int64 `json:"min_bet"`
+
+	// MaxBet is the maximal bet. By default, the bet is limited only by the bankroll.
+	MaxBet *int64 `json:"max_bet"`
+}
+
+// CreateTableRequest represents the request of a CreateTable call.
+type CreateTableRequest struct {
+	// Bankroll is the amount of chips the player buys in with.
+	Bankroll int64 `json:"bankroll"`
+
+	// Rules holds the house rules of the table.
+	Rules RulesRequest `json:"rules"`
+}
+
+// RoundRequest represents the request of a StartRound call.
+type RoundRequest struct {
+	// Bet is the initial bet of the round.
+	Bet int64 `json:"bet"`
+}
+
+// ActionRequest represents the request of an Act call.
+type ActionRequest struct {
+	// Action is the action: HIT, STAND, DOUBLE, SPLIT, SURRENDER, INSURANCE or NO_INSURANCE.
+	Action string `json:"action"`
+}
+
+// RulesResponse holds the house rules in a TableResponse.
+type RulesResponse struct {
+	// Decks is the number of decks in the shoe.
+	Decks int `json:"decks"`
+
+	// HitSoft17 is true if the dealer hits a soft 17.
+	HitSoft17 bool `json:"hit_soft_17"`
+
+	// DoubleAfterSplit is true if doubling down after a split is allowed.
+	DoubleAfterSplit bool `json:"double_after_split"`
+
+	// Surrender is true if the player may surrender the initial hand.
+	Surrender bool `json:"surrender"`
+
+	// BlackjackPayout is the payout of a blackjack, like "3:2".
+	BlackjackPayout string `json:"blackjack_payout"`
+
+	// Penetration is the part of the shoe dealt before it is reshuffled.
+	Penetration float64 `json:"penetration"`
+
+	// MaxHands is the maximal number of hands by splitting.
+	MaxHands int `json:"max_hands"`
+
+	// MinBet is the minimal bet.
+	MinBet int64 `json:"min_bet"`
+
+	// MaxBet is the maximal bet, or 0 if there is no limit.
+	MaxBet int64 `json:"max_bet"`
+}
+
+// HandResponse represents a hand of the player in a TableResponse.
+type HandResponse struct {
+	// Cards are the cards of the hand.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// Total is the best total of the hand.
+	Total int `json:"total"`
+
+	// Soft is true if an ace counts as 11 in the total.
+	Soft bool `json:"soft"`
+
+	// Bet is the bet on the hand.
+	Bet int64 `json:"bet"`
+
+	// Doubled is true if the player doubled down on the hand.
+	Doubled bool `json:"doubled"`
+
+	// Split is true if the hand was made by splitting a pair.
+	Split bool `json:"split"`
+
+	// Done is true once the player cannot act on the hand anymore.
+	Done bool `json:"done"`
+
+	// Outcome is the outcome of the hand once the round is settled: WIN, LOSE, PUSH, BLACKJACK or SURRENDER.
+	Outcome string `json:"outcome,omitempty"`
+
+	// Payout is the net result of the hand once the round is settled.
+	Payout int64 `json:"payout"`
+}
+
+// TableResponse represents the state of a blackjack table.
+type TableResponse struct {
+	// TableID is the ID of the table.
+	TableID string `json:"table_id"`
+
+	// Rules holds the house rules of the table.
+	Rules RulesResponse `json:"rules"`
+
+	// CardsDealt is the number of cards dealt from the shoe.
+	CardsDealt int `json:"cards_dealt"`
+
+	// CutCard is the number of cards dealt after which the shoe is reshuffled.
+	CutCard int `json:"cut_card"`
+
+	// Bankroll is the amount of chips of the player, not counting the bets in the current round.
+	Bankroll int64 `json:"bankroll"`
+
+	// RoundNumber is the number of rounds played at the table.
+	RoundNumber int `json:"round_number"`
+
+	// Stage is the stage of the current round: WAITING, INSURANCE, PLAYER or SETTLED.
+	Stage string `json:"stage"`
+
+	// DealerCards are the visible cards of the dealer. The hole card is hidden until the round is settled.
+	DealerCards []deck_api.CardResponse `json:"dealer_cards"`
+
+	// DealerTotal is the total of the visible cards of the dealer.
+	DealerTotal int `json:"dealer_total"`
+
+	// ActiveHand is the index of the hand the player is playing.
+	ActiveHand int `json:"active_hand"`
+
+	// Insurance is the insurance bet of the current round.
+	Insurance int64 `json:"insurance"`
+
+	// InsurancePayout is the net result of the insurance bet.
+	InsurancePayout int64 `json:"insurance_payout"`
+
+	// Hands holds the hands of the player in the current round.
+	Hands []HandResponse `json:"hands"`
+}
@@ -0,0 +1,11 @@
+package blackjack
+
+import "github.com/gin-gonic/gin"
+
+// SetupTableServiceRouting sets up the routing for TableService with gin router.
+func SetupTableServiceRouting(group *gin.RouterGroup, tableService *TableService) {
+	group.POST("/blackjack/tables", tableService.CreateTable)
+	group.GET("/blackjack/tables/:tableId", tableService.GetTable)
+	group.POST("/blackjack/tables/:tableId/rounds", tableService.StartRound)
+	group.POST("/blackjack/tables/:tableId/actions", tableService.Act)
+}
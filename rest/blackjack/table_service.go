@@ -0,0 +1,220 @@
+package blackjack
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	blackjack_repo "github.com/natemago/card-games-api/repositories/blackjack"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// TableService represents the REST API service for the blackjack tables.
+// Uses the TableRepository to store the tables, and the DeckService to deal the cards from shoes kept private by the
+// tables.
+type TableService struct {
+	Tables blackjack_repo.TableRepository
+	Decks  *deck_api.DeckService
+}
+
+// CreateTable creates a new table with a new shuffled shoe, kept private by the table.
+// Accepts a CreateTableRequest JSON body with the bankroll of the player and the house rules.
+// If the bankroll or the rules are not valid, returns a 400 Bad Request error response.
+func (s *TableService) CreateTable(ctx *gin.Context) {
+	request := &CreateTableRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	rules, err := toRules(&request.Rules)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	table, err := blackjack_repo.NewTable(rules, request.Bankroll, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	table, err = s.Tables.CreateTable(table)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toTableResponse(table))
+}
+
+// GetTable looks up a table by its ID and returns its state.
+// If the table does not exist, returns a 404 Not Found error response.
+func (s *TableService) GetTable(ctx *gin.Context) {
+	table, err := s.Tables.GetTable(ctx.Param("tableId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTableResponse(table))
+}
+
+// StartRound starts a new round with the bet given as a RoundRequest JSON body, and deals the initial cards.
+// If a round is in progress or the bet is not allowed, returns a 400 Bad Request error response.
+func (s *TableService) StartRound(ctx *gin.Context) {
+	request := &RoundRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateTable(ctx, func(table *blackjack_repo.Table) error {
+		return blackjack_repo.StartRound(table, request.Bet, s.Decks)
+	})
+}
+
+// Act applies the action of the player, given as an ActionRequest JSON body, on the active hand. Once all hands
+// are played, the dealer plays and the round is settled.
+// If the action is not allowed, returns a 400 Bad Request error response.
+func (s *TableService) Act(ctx *gin.Context) {
+	request := &ActionRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateTable(ctx, func(table *blackjack_repo.Table) error {
+		return blackjack_repo.Act(table, strings.ToUpper(request.Action), s.Decks)
+	})
+}
+
+// updateTable looks up the table from the path, applies the change and stores the table.
+func (s *TableService) updateTable(ctx *gin.Context, change func(table *blackjack_repo.Table) error) {
+	table, err := s.Tables.GetTable(ctx.Param("tableId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := change(table); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	table, err = s.Tables.UpdateTable(table)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTableResponse(table))
+}
+
+// toRules applies the rules from the request over the default rules.
+func toRules(request *RulesRequest) (blackjack_repo.Rules, error) {
+	rules := blackjack_repo.DefaultRules()
+
+	if request.Decks != nil {
+		rules.Decks = *request.Decks
+	}
+	if request.HitSoft17 != nil {
+		rules.HitSoft17 = *request.HitSoft17
+	}
+	if request.DoubleAfterSplit != nil {
+		rules.DoubleAfterSplit = *request.DoubleAfterSplit
+	}
+	if request.Surrender != nil {
+		rules.Surrender = *request.Surrender
+	}
+	if request.Penetration != nil {
+		rules.Penetration = *request.Penetration
+	}
+	if request.MaxHands != nil {
+		rules.MaxHands = *request.MaxHands
+	}
+	if request.MinBet != nil {
+		rules.MinBet = *request.MinBet
+	}
+	if request.MaxBet != nil {
+		rules.MaxBet = *request.MaxBet
+	}
+
+	if request.BlackjackPayout != "" {
+		parts := strings.Split(request.BlackjackPayout, ":")
+		if len(parts) != 2 {
+			return rules, errors.ValidationError(fmt.Sprintf("invalid blackjack payout: %s", request.BlackjackPayout), nil)
+		}
+		numerator, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return rules, errors.ValidationError(fmt.Sprintf("invalid blackjack payout: %s", request.BlackjackPayout), err)
+		}
+		denominator, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return rules, errors.ValidationError(fmt.Sprintf("invalid blackjack payout: %s", request.BlackjackPayout), err)
+		}
+		rules.BlackjackPayoutNumerator = numerator
+		rules.BlackjackPayoutDenominator = denominator
+	}
+
+	return rules, nil
+}
+
+func toTableResponse(table *blackjack_repo.Table) *TableResponse {
+	dealerCards := blackjack_repo.VisibleDealerCards(table)
+	dealerTotal, _ := blackjack_repo.Total(dealerCards)
+
+	response := &TableResponse{
+		TableID: table.ID,
+		Rules: RulesResponse{
+			Decks:            table.Decks,
+			HitSoft17:        table.HitSoft17,
+			DoubleAfterSplit: table.DoubleAfterSplit,
+			Surrender:        table.Surrender,
+			BlackjackPayout:  fmt.Sprintf("%d:%d", table.BlackjackPayoutNumerator, table.BlackjackPayoutDenominator),
+			Penetration:      table.Penetration,
+			MaxHands:         table.MaxHands,
+			MinBet:           table.MinBet,
+			MaxBet:           table.MaxBet,
+		},
+		CardsDealt:      table.CardsDealt,
+		CutCard:         table.CutCard,
+		Bankroll:        table.Bankroll,
+		RoundNumber:     table.RoundNumber,
+		Stage:           table.Stage,
+		DealerCards:     deck_api.ToCardResponses(dealerCards),
+		DealerTotal:     dealerTotal,
+		ActiveHand:      table.ActiveHand,
+		Insurance:       table.Insurance,
+		InsurancePayout: table.InsurancePayout,
+		Hands:           []HandResponse{},
+	}
+
+	for _, hand := range table.Hands {
+		total, soft := blackjack_repo.Total(hand.HandCards())
+		response.Hands = append(response.Hands, HandResponse{
+			Cards:   deck_api.ToCardResponses(hand.HandCards()),
+			Total:   total,
+			Soft:    soft,
+			Bet:     hand.Bet,
+			Doubled: hand.Doubled,
+			Split:   hand.Split,
+			Done:    hand.Done,
+			Outcome: hand.Outcome,
+			Payout:  hand.Payout,
+		})
+	}
+
+	return response
+}
+
+// NewTableService creates a new pointer to a TableService using the given TableRepository and DeckService.
+func NewTableService(tableRepository blackjack_repo.TableRepository, decks *deck_api.DeckService) *TableService {
+	return &TableService{
+		Tables: tableRepository,
+		Decks:  decks,
+	}
+}
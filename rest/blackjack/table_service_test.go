@@ -0,0 +1,149 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	blackjack_repo "github.com/natemago/card-games-api/repositories/blackjack"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) (*gin.Engine, *TableService) {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	decks := deck_api.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	service := NewTableService(blackjack_repo.NewDBTableRepository(db), decks)
+	SetupTableServiceRouting(router.Group("/v1"), service)
+
+	return router, service
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, body string, expectedCode int) *TableResponse {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if expectedCode >= 400 {
+		return nil
+	}
+
+	resp := &TableResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the table, but got error: %s", err.Error())
+	}
+	return resp
+}
+
+// stackShoe replaces the shoe of the table with a deck with the given cards, in order.
+func stackShoe(t *testing.T, service *TableService, tableID, cards string) {
+	table, err := service.Tables.GetTable(tableID)
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err.Error())
+	}
+	shoe, shoeToken, err := service.Decks.NewPrivateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards(cards)})
+	if err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+	table.ShoeID = shoe.ID
+	table.ShoeToken = shoeToken
+	if _, err := service.Tables.UpdateTable(table); err != nil {
+		t.Fatalf("Failed to update table: %s", err.Error())
+	}
+}
+
+func TestTableFlow(t *testing.T) {
+	router, service := setupTest(t)
+
+	table := call(t, router, "POST", "/v1/blackjack/tables", `{
+		"bankroll": 500,
+		"rules": {"decks": 2, "hit_soft_17": true, "blackjack_payout": "6:5", "penetration": 0.5}
+	}`, http.StatusCreated)
+	if table.Stage != "WAITING" || table.Rules.Decks != 2 || !table.Rules.HitSoft17 || table.Rules.BlackjackPayout != "6:5" {
+		t.Fatalf("Expected a new table with the given rules, but got: %+v", table)
+	}
+	if !table.Rules.DoubleAfterSplit || table.Rules.MaxHands != 4 || table.CutCard != 52 {
+		t.Errorf("Expected the default rules for the rest, but got: %+v", table)
+	}
+	path := "/v1/blackjack/tables/" + table.TableID
+
+	stored, err := service.Tables.GetTable(table.TableID)
+	if err != nil {
+		t.Fatalf("Failed to get table: %s", err.Error())
+	}
+	if _, err := service.Decks.Draw("", stored.ShoeID, 1); err == nil {
+		t.Error("Expected only the table to draw from its shoe.")
+	}
+
+	stackShoe(t, service, table.TableID, "9H,10C,8D,7S,2C")
+
+	table = call(t, router, "POST", path+"/rounds", `{"bet": 20}`, http.StatusOK)
+	if table.Stage != "PLAYER" || table.Bankroll != 480 || len(table.Hands) != 1 || table.Hands[0].Total != 17 {
+		t.Fatalf("Expected the initial cards to be dealt, but got: %+v", table)
+	}
+	if len(table.DealerCards) != 1 || table.DealerCards[0].Code != "10C" || table.DealerTotal != 10 {
+		t.Errorf("Expected the hole card of the dealer to be hidden, but got: %+v", table.DealerCards)
+	}
+
+	call(t, router, "POST", path+"/actions", `{"action": "SPLIT"}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/rounds", `{"bet": 20}`, http.StatusBadRequest)
+
+	table = call(t, router, "POST", path+"/actions", `{"action": "stand"}`, http.StatusOK)
+	if table.Stage != "SETTLED" || len(table.DealerCards) != 2 || table.DealerTotal != 17 {
+		t.Fatalf("Expected the dealer to stand on 17, but got: %+v", table)
+	}
+	if table.Hands[0].Outcome != "PUSH" || table.Bankroll != 500 {
+		t.Errorf("Expected a push, but got: %+v (bankroll %d)", table.Hands[0], table.Bankroll)
+	}
+
+	table = call(t, router, "GET", path, "", http.StatusOK)
+	if table.RoundNumber != 1 || table.CardsDealt != 4 {
+		t.Errorf("Expected the table to be stored, but got: %+v", table)
+	}
+}
+
+func TestCreateTable_Invalid(t *testing.T) {
+	router, _ := setupTest(t)
+
+	for _, body := range []string{
+		`{"bankroll": 0}`,
+		`{"bankroll": 100, "rules": {"decks": 9}}`,
+		`{"bankroll": 100, "rules": {"blackjack_payout": "3-2"}}`,
+		`{"bankroll": 100, "rules": {"blackjack_payout": "3:0"}}`,
+		`{"bankroll": 100, "rules": {"min_bet": 10, "max_bet": 5}}`,
+	} {
+		call(t, router, "POST", "/v1/blackjack/tables", body, http.StatusBadRequest)
+	}
+}
+
+func TestGetTable_NotFound(t *testing.T) {
+	router, _ := setupTest(t)
+
+	call(t, router, "GET", "/v1/blackjack/tables/missing", "", http.StatusNotFound)
+	call(t, router, "POST", "/v1/blackjack/tables/missing/rounds", `{"bet": 10}`, http.StatusNotFound)
+}
@@ -0,0 +1,108 @@
+package bridge
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/bridge"
+	"github.com/natemago/card-games-api/errors"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// Limits of the deal generation, so that a single request cannot keep the server busy.
+const (
+	// MaxBoards is the maximal number of boards dealt at once.
+	MaxBoards = 64
+
+	// MaxAttempts is the maximal (and default) number of shuffles tried for a single board.
+	MaxAttempts = bridge.DefaultMaxAttempts
+)
+
+// BridgeService represents the REST API service for dealing bridge boards.
+type BridgeService struct{}
+
+// Deal deals bridge boards meeting the given constraints, by shuffling until every deal meets them.
+// Accepts a DealRequest JSON body with the number of boards, the constraints and an optional seed.
+// Returns the boards with the hands, and all boards as a PBN file. With the query parameter "format=pbn", returns
+// only the PBN file.
+// If a constraint is not valid, or no deal meeting the constraints is found, returns a 400 Bad Request error response.
+func (s *BridgeService) Deal(ctx *gin.Context) {
+	request := &DealRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	if request.Boards == 0 {
+		request.Boards = 1
+	}
+	if request.Boards < 0 || request.Boards > MaxBoards {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("the number of boards must be between 1 and %d", MaxBoards), nil))
+		return
+	}
+	if request.MaxAttempts <= 0 || request.MaxAttempts > MaxAttempts {
+		request.MaxAttempts = MaxAttempts
+	}
+	seed := request.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	constraints, err := bridge.ParseConstraints(request.Constraints)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	deals, err := bridge.GenerateDeals(&bridge.DealOptions{
+		Boards:      request.Boards,
+		FirstBoard:  request.FirstBoard,
+		Constraints: constraints,
+		Seed:        seed,
+		MaxAttempts: request.MaxAttempts,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	pbn := bridge.FormatPBN(deals)
+	if format, _ := ctx.GetQuery("format"); format == "pbn" {
+		ctx.String(http.StatusOK, pbn)
+		return
+	}
+
+	response := &DealResponse{
+		Seed:   seed,
+		Boards: []BoardResponse{},
+		PBN:    pbn,
+	}
+	for _, deal := range deals {
+		board := BoardResponse{
+			Board:      deal.Board,
+			Dealer:     deal.Dealer,
+			Vulnerable: deal.Vulnerable,
+			Deal:       deal.PBN(),
+			Attempts:   deal.Attempts,
+			Hands:      []HandResponse{},
+		}
+		for i, hand := range deal.Hands {
+			board.Hands = append(board.Hands, HandResponse{
+				Seat:  bridge.Seats[i],
+				Cards: deck_api.ToCardResponses(hand),
+				HCP:   hand.HCP(),
+				Shape: hand.Shape(),
+			})
+		}
+		response.Boards = append(response.Boards, board)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// NewBridgeService creates a new pointer to a BridgeService.
+func NewBridgeService() *BridgeService {
+	return &BridgeService{}
+}
@@ -0,0 +1,80 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+)
+
+func setupTest() *gin.Engine {
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupBridgeServiceRouting(router.Group("/v1"), NewBridgeService())
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, path, body string, expectedCode int) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s, but got %d instead: %s", expectedCode, path, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestDeal(t *testing.T) {
+	router := setupTest()
+
+	w := call(t, router, "/v1/bridge/deals", `{
+		"boards": 2,
+		"first_board": 3,
+		"constraints": ["N: hcp 15-17, balanced", "S: spades 5+"],
+		"seed": 7
+	}`, http.StatusOK)
+
+	resp := &DealResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the deals, but got error: %s", err.Error())
+	}
+	if resp.Seed != 7 || len(resp.Boards) != 2 {
+		t.Fatalf("Expected two boards, but got: %+v", resp)
+	}
+
+	board := resp.Boards[0]
+	if board.Board != 3 || board.Dealer != "S" || board.Vulnerable != "EW" || !strings.HasPrefix(board.Deal, "S:") {
+		t.Errorf("Expected board 3 dealt by South with EW vulnerable, but got: %+v", board)
+	}
+	if len(board.Hands) != 4 || board.Hands[0].Seat != "N" || len(board.Hands[0].Cards) != 13 {
+		t.Fatalf("Expected the hands from North to West, but got: %+v", board.Hands)
+	}
+	if board.Hands[0].HCP < 15 || board.Hands[0].HCP > 17 || board.Hands[2].Shape[0] < 5 {
+		t.Errorf("Expected the hands to meet the constraints, but got: %+v, %+v", board.Hands[0], board.Hands[2])
+	}
+	if !strings.Contains(resp.PBN, `[Board "4"]`) {
+		t.Errorf("Expected both boards in the PBN, but got:\n%s", resp.PBN)
+	}
+
+	w = call(t, router, "/v1/bridge/deals?format=pbn", `{"boards": 2, "first_board": 3, "seed": 7,
+		"constraints": ["N: hcp 15-17, balanced", "S: spades 5+"]}`, http.StatusOK)
+	if w.Body.String() != resp.PBN {
+		t.Errorf("Expected the same PBN for the same seed, but got:\n%s", w.Body.String())
+	}
+}
+
+func TestDeal_Invalid(t *testing.T) {
+	router := setupTest()
+
+	call(t, router, "/v1/bridge/deals", `{"constraints": ["N: hcp lots"]}`, http.StatusBadRequest)
+	call(t, router, "/v1/bridge/deals", `{"boards": 65}`, http.StatusBadRequest)
+	call(t, router, "/v1/bridge/deals", `{"constraints": ["N: hcp 37", "S: hcp 37"], "max_attempts": 100}`, http.StatusBadRequest)
+}
@@ -0,0 +1,69 @@
+package bridge
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// DealRequest represents the request of a Deal call.
+type DealRequest struct {
+	// Boards is the number of boards to deal. Default is 1.
+	Boards int `json:"boards"`
+
+	// FirstBoard is the number of the first board, which determines the dealer and the vulnerability. Default is 1.
+	FirstBoard int `json:"first_board"`
+
+	// Constraints holds the constraints every deal must meet, like "N: hcp 15-17, balanced".
+	Constraints []string `json:"constraints"`
+
+	// Seed seeds the shuffles. Zero means a random seed.
+	Seed int64 `json:"seed"`
+
+	// MaxAttempts is the maximal number of shuffles tried for a single board.
+	MaxAttempts int `json:"max_attempts"`
+}
+
+// HandResponse represents the hand of a seat in a BoardResponse.
+type HandResponse struct {
+	// Seat is the code of the seat: N, E, S or W.
+	Seat string `json:"seat"`
+
+	// Cards are the cards of the hand, sorted by suit from spades and by rank from the ace.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// HCP is the number of high card points of the hand.
+	HCP int `json:"hcp"`
+
+	// Shape holds the lengths of the suits, from spades to clubs.
+	Shape []int `json:"shape"`
+}
+
+// BoardResponse represents a dealt board in a DealResponse.
+type BoardResponse struct {
+	// Board is the number of the board.
+	Board int `json:"board"`
+
+	// Dealer is the code of the seat of the dealer.
+	Dealer string `json:"dealer"`
+
+	// Vulnerable is the vulnerability of the board: "None", "NS", "EW" or "All".
+	Vulnerable string `json:"vulnerable"`
+
+	// Deal is the deal in Portable Bridge Notation.
+	Deal string `json:"deal"`
+
+	// Attempts is the number of shuffles until the constraints were met.
+	Attempts int `json:"attempts"`
+
+	// Hands holds the hands of the seats, from North to West.
+	Hands []HandResponse `json:"hands"`
+}
+
+// DealResponse represents the response of a Deal call.
+type DealResponse struct {
+	// Seed is the seed of the shuffles. Repeating the request with the same seed gives the same deals.
+	Seed int64 `json:"seed"`
+
+	// Boards holds the dealt boards.
+	Boards []BoardResponse `json:"boards"`
+
+	// PBN holds all boards as a Portable Bridge Notation file.
+	PBN string `json:"pbn"`
+}
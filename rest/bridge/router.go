@@ -0,0 +1,8 @@
+package bridge
+
+import "github.com/gin-gonic/gin"
+
+// SetupBridgeServiceRouting sets up the routing for BridgeService with gin router.
+func SetupBridgeServiceRouting(group *gin.RouterGroup, bridgeService *BridgeService) {
+	group.POST("/bridge/deals", bridgeService.Deal)
+}
@@ -0,0 +1,93 @@
+package cribbage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/cribbage"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// CribbageService represents the REST API service for scoring cribbage.
+type CribbageService struct{}
+
+// Score scores a cribbage hand with the starter card, the pegging play sequence, or both.
+// Accepts a ScoreRequest JSON body with the cards of the hand and the starter, or the cards played in the pegging.
+// Returns the itemized score of the hand and of every play in the pegging.
+// If neither the hand nor the pegging is given, or the cards are not valid, returns a 400 Bad Request error response.
+func (s *CribbageService) Score(ctx *gin.Context) {
+	request := &ScoreRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	if strings.TrimSpace(request.Hand) == "" && strings.TrimSpace(request.Pegging) == "" {
+		ctx.Error(errors.BadRequestError("either the hand or the pegging is required", nil))
+		return
+	}
+
+	response := &ScoreResponse{}
+
+	if strings.TrimSpace(request.Hand) != "" {
+		starter := deck_repo.AsCards(request.Starter)
+		if len(starter) != 1 {
+			ctx.Error(errors.BadRequestError("exactly one starter card is required with the hand", nil))
+			return
+		}
+		score, err := cribbage.ScoreHand(deck_repo.AsCards(request.Hand), starter[0], request.Crib)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		response.Hand = &HandScoreResponse{
+			Items: toItemResponses(score.Items),
+			Total: score.Total,
+		}
+	}
+
+	if strings.TrimSpace(request.Pegging) != "" {
+		pegging, err := cribbage.ScorePegging(deck_repo.AsCards(request.Pegging))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		response.Pegging = &PeggingScoreResponse{
+			Plays: []PlayResponse{},
+			Total: pegging.Total,
+		}
+		for _, play := range pegging.Plays {
+			response.Pegging.Plays = append(response.Pegging.Plays, PlayResponse{
+				Card:   deck_api.ToCardResponse(play.Card),
+				Count:  play.Count,
+				Items:  toItemResponses(play.Items),
+				Points: play.Total,
+			})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func toItemResponses(items []cribbage.Item) []ItemResponse {
+	respItems := []ItemResponse{}
+
+	for _, item := range items {
+		respItems = append(respItems, ItemResponse{
+			Kind:   item.Kind,
+			Cards:  deck_api.ToCardResponses(item.Cards),
+			Points: item.Points,
+		})
+	}
+
+	return respItems
+}
+
+// NewCribbageService creates a new pointer to a CribbageService.
+func NewCribbageService() *CribbageService {
+	return &CribbageService{}
+}
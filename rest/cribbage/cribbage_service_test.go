@@ -0,0 +1,86 @@
+package cribbage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+)
+
+func setupTest() *gin.Engine {
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupCribbageServiceRouting(router.Group("/v1"), NewCribbageService())
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, path, body string, expectedCode int) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s, but got %d instead: %s", expectedCode, path, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestScore(t *testing.T) {
+	router := setupTest()
+
+	w := call(t, router, "/v1/cribbage/score", `{
+		"hand": "5H,5C,5S,JD",
+		"starter": "5D",
+		"pegging": "7H,8C,8D,AC,GO"
+	}`, http.StatusOK)
+
+	resp := &ScoreResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the score, but got error: %s", err.Error())
+	}
+	if resp.Hand == nil || resp.Hand.Total != 29 {
+		t.Fatalf("Expected the hand to score 29, but got: %+v", resp.Hand)
+	}
+	last := resp.Hand.Items[len(resp.Hand.Items)-1]
+	if last.Kind != "NOBS" || last.Points != 1 || len(last.Cards) != 1 || last.Cards[0].Code != "JD" {
+		t.Errorf("Expected nobs for the jack of diamonds, but got: %+v", last)
+	}
+
+	if resp.Pegging == nil || resp.Pegging.Total != 5 || len(resp.Pegging.Plays) != 4 {
+		t.Fatalf("Expected the pegging to score 5 in 4 plays, but got: %+v", resp.Pegging)
+	}
+	if play := resp.Pegging.Plays[3]; play.Card.Code != "AC" || play.Count != 24 || play.Points != 1 || play.Items[0].Kind != "GO" {
+		t.Errorf("Expected a go for the ace of clubs, but got: %+v", play)
+	}
+}
+
+func TestScore_Crib(t *testing.T) {
+	router := setupTest()
+
+	w := call(t, router, "/v1/cribbage/score", `{"hand": "2H,4H,6H,8H", "starter": "KS", "crib": true}`, http.StatusOK)
+
+	resp := &ScoreResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the score, but got error: %s", err.Error())
+	}
+	if resp.Hand == nil || resp.Hand.Total != 0 || resp.Pegging != nil {
+		t.Errorf("Expected no flush in the crib and no pegging, but got: %s", w.Body.String())
+	}
+}
+
+func TestScore_Invalid(t *testing.T) {
+	router := setupTest()
+
+	call(t, router, "/v1/cribbage/score", `{}`, http.StatusBadRequest)
+	call(t, router, "/v1/cribbage/score", `{"hand": "5H,5C,5S,JD"}`, http.StatusBadRequest)
+	call(t, router, "/v1/cribbage/score", `{"hand": "5H,5C,5S", "starter": "5D"}`, http.StatusBadRequest)
+	call(t, router, "/v1/cribbage/score", `{"hand": "5H,5C,5S,5D", "starter": "5D"}`, http.StatusBadRequest)
+	call(t, router, "/v1/cribbage/score", `{"pegging": "10C,JD,QH,2S"}`, http.StatusBadRequest)
+}
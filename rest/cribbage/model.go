@@ -0,0 +1,74 @@
+package cribbage
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// ScoreRequest represents the request of a Score call. At least one of the hand and the pegging is required.
+type ScoreRequest struct {
+	// Hand is a comma-separated list of the four cards of the hand, like "5H,5C,5S,JD".
+	Hand string `json:"hand"`
+
+	// Starter is the code of the starter card. Required with the hand.
+	Starter string `json:"starter"`
+
+	// Crib is a flag whether the hand is the crib, which scores a flush only together with the starter.
+	Crib bool `json:"crib"`
+
+	// Pegging is a comma-separated list of the cards played in the pegging, in order, with "GO" where the count
+	// ended with a go, like "7H,8C,AC,GO,4C".
+	Pegging string `json:"pegging"`
+}
+
+// ItemResponse represents a scoring combination.
+type ItemResponse struct {
+	// Kind is the kind of the combination: FIFTEEN, PAIR, PAIR_ROYAL, DOUBLE_PAIR_ROYAL, RUN, FLUSH, NOBS,
+	// THIRTY_ONE or GO.
+	Kind string `json:"kind"`
+
+	// Cards are the cards making up the combination.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// Points is the number of points the combination scores.
+	Points int `json:"points"`
+}
+
+// HandScoreResponse represents the itemized score of a hand in a ScoreResponse.
+type HandScoreResponse struct {
+	// Items holds the scoring combinations, in the order of counting: fifteens, pairs, runs, flush and nobs.
+	Items []ItemResponse `json:"items"`
+
+	// Total is the total score of the hand.
+	Total int `json:"total"`
+}
+
+// PlayResponse represents a card played in the pegging, in a PeggingScoreResponse.
+type PlayResponse struct {
+	// Card is the played card.
+	Card deck_api.CardResponse `json:"card"`
+
+	// Count is the count after the card was played.
+	Count int `json:"count"`
+
+	// Items holds the scoring combinations of the play.
+	Items []ItemResponse `json:"items"`
+
+	// Points is the number of points scored by the play.
+	Points int `json:"points"`
+}
+
+// PeggingScoreResponse represents the itemized score of the pegging in a ScoreResponse.
+type PeggingScoreResponse struct {
+	// Plays holds the played cards in order, with their points.
+	Plays []PlayResponse `json:"plays"`
+
+	// Total is the total score of the plays.
+	Total int `json:"total"`
+}
+
+// ScoreResponse represents the response of a Score call.
+type ScoreResponse struct {
+	// Hand is the score of the hand, if one was given.
+	Hand *HandScoreResponse `json:"hand,omitempty"`
+
+	// Pegging is the score of the pegging, if one was given.
+	Pegging *PeggingScoreResponse `json:"pegging,omitempty"`
+}
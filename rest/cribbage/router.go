@@ -0,0 +1,8 @@
+package cribbage
+
+import "github.com/gin-gonic/gin"
+
+// SetupCribbageServiceRouting sets up the routing for CribbageService with gin router.
+func SetupCribbageServiceRouting(group *gin.RouterGroup, cribbageService *CribbageService) {
+	group.POST("/cribbage/score", cribbageService.Score)
+}
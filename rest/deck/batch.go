@@ -0,0 +1,190 @@
+package deck
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/events"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// MaxBatchOperations is the maximal number of operations in a single batch request.
+const MaxBatchOperations = 1000
+
+// Batch executes a list of create, draw and shuffle operations within a single database transaction.
+// Accepts a BatchRequest JSON body and returns the result of each operation, with a status code
+// as if the operation was executed on its own.
+// By default, each operation is executed in a nested transaction, so a failed operation is rolled back
+// without affecting the other operations.
+// In atomic mode, the first failed operation aborts the batch and rolls back all of the operations. The response
+// status code is then the status code of the failed operation, and all other operations are reported with
+// 424 Failed Dependency.
+// Every created deck gets a dealer, and its result holds the secret token of the dealer, shown only once.
+// Drawing from and shuffling a deck with players is allowed only to the dealer, as on their own.
+// The events of the draws and shuffles are logged with their operations and published once the batch is committed.
+// If the request is malformed or has no operations or too many operations, returns a 400 Bad Request error response.
+func (d *DeckService) Batch(ctx *gin.Context) {
+	request := &BatchRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid batch request: %s", err.Error()), err))
+		return
+	}
+
+	if len(request.Operations) == 0 || len(request.Operations) > MaxBatchOperations {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("a batch must have between 1 and %d operations", MaxBatchOperations), nil))
+		return
+	}
+
+	results := make([]BatchOperationResult, len(request.Operations))
+	failed := -1
+
+	err := d.change(func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error) {
+		changes := []*events.Event{}
+		for i, operation := range request.Operations {
+			var result *BatchOperationResult
+			var operationChanges []*events.Event
+			execute := func(repository deck_repo.DeckRepository) error {
+				var err error
+				if result, operationChanges, err = d.executeBatchOperation(ctx, repository, players, &operation); err != nil {
+					return err
+				}
+				return d.logEvents(repository, operationChanges)
+			}
+
+			var err error
+			if request.Atomic {
+				err = execute(repository)
+			} else {
+				err = repository.Transaction(execute)
+			}
+
+			if err != nil {
+				results[i] = BatchOperationResult{
+					Status: errors.StatusCode(err),
+					Error:  err.Error(),
+				}
+				if request.Atomic {
+					failed = i
+					return nil, err
+				}
+				continue
+			}
+
+			results[i] = *result
+			changes = append(changes, operationChanges...)
+		}
+		return changes, nil
+	})
+
+	if err != nil && failed < 0 {
+		ctx.Error(err)
+		return
+	}
+
+	if failed >= 0 {
+		for i := range results {
+			if i < failed {
+				results[i] = BatchOperationResult{
+					Status: http.StatusFailedDependency,
+					Error:  fmt.Sprintf("rolled back: operation %d failed", failed),
+				}
+			} else if i > failed {
+				results[i] = BatchOperationResult{
+					Status: http.StatusFailedDependency,
+					Error:  fmt.Sprintf("not executed: operation %d failed", failed),
+				}
+			}
+		}
+		ctx.JSON(results[failed].Status, &BatchResponse{
+			Committed: false,
+			Results:   results,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &BatchResponse{
+		Committed: true,
+		Results:   results,
+	})
+}
+
+// executeBatchOperation executes the operation with the repositories of the batch transaction. Returns the result and
+// the events of the operation.
+func (d *DeckService) executeBatchOperation(ctx *gin.Context, repository deck_repo.DeckRepository, players deck_repo.PlayerRepository, operation *BatchOperation) (*BatchOperationResult, []*events.Event, error) {
+	switch operation.Op {
+	case "create":
+		var cards []*deck_repo.Card
+		if operation.Cards != "" {
+			cards = deck_repo.AsCards(operation.Cards)
+		}
+
+		deck, err := repository.CreateDeck(&deck_repo.Deck{
+			Shuffled: operation.Shuffled,
+			Cards:    cards,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		dealerToken, err := d.createDealer(repository, deck.ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &BatchOperationResult{
+			Status:      http.StatusCreated,
+			DeckID:      deck.ID,
+			Shuffled:    deck.Shuffled,
+			Remaining:   &deck.Remaining,
+			DealerToken: dealerToken,
+		}, nil, nil
+	case "draw":
+		count := 1
+		if operation.Count != nil {
+			count = *operation.Count
+		}
+		if count < 1 {
+			return nil, nil, errors.BadRequestError("invalid cards count number", nil)
+		}
+		if err := d.checkDealerWith(players, RequestToken(ctx), operation.DeckID, "draw cards from"); err != nil {
+			return nil, nil, err
+		}
+
+		drawnCards, err := repository.DrawCards(operation.DeckID, count)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &BatchOperationResult{
+			Status: http.StatusOK,
+			DeckID: operation.DeckID,
+			Cards:  ToCardResponses(drawnCards),
+		}, []*events.Event{{
+			Type:   events.Drawn,
+			DeckID: operation.DeckID,
+			Count:  len(drawnCards),
+			Cards:  publicCards(players, operation.DeckID, cardCodes(drawnCards)),
+		}}, nil
+	case "shuffle":
+		if err := d.checkDealerWith(players, RequestToken(ctx), operation.DeckID, "shuffle"); err != nil {
+			return nil, nil, err
+		}
+		deck, err := repository.ShuffleDeck(operation.DeckID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &BatchOperationResult{
+			Status:    http.StatusOK,
+			DeckID:    deck.ID,
+			Shuffled:  deck.Shuffled,
+			Remaining: &deck.Remaining,
+		}, []*events.Event{{
+			Type:   events.Shuffled,
+			DeckID: deck.ID,
+		}}, nil
+	default:
+		return nil, nil, errors.BadRequestError(fmt.Sprintf("unsupported operation: %s", operation.Op), nil)
+	}
+}
@@ -0,0 +1,146 @@
+package deck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func executeBatch(t *testing.T, td TestData, body string) (int, *BatchResponse) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/deck/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	td.Router.ServeHTTP(w, req)
+
+	resp := &BatchResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the batch response, but got error: %s", err.Error())
+	}
+	return w.Code, resp
+}
+
+func TestBatch(t *testing.T) {
+	td := setupTest(t)
+
+	code, resp := executeBatch(t, td, fmt.Sprintf(`{
+		"operations": [
+			{"op": "create", "shuffled": true},
+			{"op": "create", "cards": "AS,KS"},
+			{"op": "draw", "deck_id": "%s", "count": 2},
+			{"op": "draw", "deck_id": "%s", "count": 10},
+			{"op": "shuffle", "deck_id": "%s"},
+			{"op": "shuffle", "deck_id": "00000000-0000-0000-0000-000000000000"}
+		]
+	}`, td.FullDeckID, td.PartialDeckID, td.FullDeckID))
+
+	if code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead.", code)
+	}
+	if !resp.Committed || len(resp.Results) != 6 {
+		t.Fatal("Expected the batch to be committed with 6 results.")
+	}
+
+	expectedStatuses := []int{201, 201, 200, 400, 200, 404}
+	for i, result := range resp.Results {
+		if result.Status != expectedStatuses[i] {
+			t.Errorf("Expected operation %d to have status %d, but got %d (%s).", i, expectedStatuses[i], result.Status, result.Error)
+		}
+	}
+
+	if resp.Results[0].DeckID == "" || !resp.Results[0].Shuffled || *resp.Results[0].Remaining != 52 {
+		t.Error("Expected a full shuffled deck to be created.")
+	}
+	if *resp.Results[1].Remaining != 2 {
+		t.Error("Expected a partial deck to be created.")
+	}
+	for _, result := range resp.Results[:2] {
+		v, err := td.DeckService.authorizeToken(result.DealerToken, result.DeckID)
+		if err != nil || !v.dealer {
+			t.Errorf("Expected the token of the dealer of the created deck, but got: %+v", result)
+		}
+	}
+	if !compare(resp.Results[2].Cards, "AC,2C") {
+		t.Error("Expected to draw the first two cards.")
+	}
+	if resp.Results[3].Error != "not enough cards in deck" {
+		t.Errorf("Expected the overdraw error, but got '%s' instead.", resp.Results[3].Error)
+	}
+	if *resp.Results[4].Remaining != 50 {
+		t.Error("Expected the shuffled deck to have 50 remaining cards.")
+	}
+
+	deck, err := td.DeckService.Repository.GetDeck(td.PartialDeckID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	if deck.Remaining != 3 {
+		t.Error("Expected the failed draw not to change the deck.")
+	}
+}
+
+func TestBatch_Atomic(t *testing.T) {
+	td := setupTest(t)
+
+	code, resp := executeBatch(t, td, fmt.Sprintf(`{
+		"atomic": true,
+		"operations": [
+			{"op": "draw", "deck_id": "%s", "count": 2},
+			{"op": "draw", "deck_id": "%s", "count": 2},
+			{"op": "create"}
+		]
+	}`, td.PartialDeckID, td.PartialDeckID))
+
+	if code != http.StatusBadRequest {
+		t.Fatalf("Expected response code 400 (Bad Request), but got %d instead.", code)
+	}
+	if resp.Committed {
+		t.Fatal("Expected the batch not to be committed.")
+	}
+
+	expectedStatuses := []int{424, 400, 424}
+	for i, result := range resp.Results {
+		if result.Status != expectedStatuses[i] {
+			t.Errorf("Expected operation %d to have status %d, but got %d (%s).", i, expectedStatuses[i], result.Status, result.Error)
+		}
+	}
+
+	deck, err := td.DeckService.Repository.GetDeck(td.PartialDeckID)
+	if err != nil {
+		t.Fatalf("Expected to get the deck, but got error: %s", err.Error())
+	}
+	if deck.Remaining != 3 {
+		t.Errorf("Expected all draws to be rolled back, but the deck has %d remaining cards.", deck.Remaining)
+	}
+
+	logged, err := td.DeckService.EventLog.ListEvents(td.PartialDeckID, 0, 10)
+	if err != nil {
+		t.Fatalf("Expected to list the events, but got error: %s", err.Error())
+	}
+	if len(logged) != 0 {
+		t.Errorf("Expected the events to be rolled back with the draws, but got: %+v", logged)
+	}
+}
+
+func TestBatch_InvalidRequest(t *testing.T) {
+	td := setupTest(t)
+
+	for _, body := range []string{`{"operations": []}`, `{"operations": "create"}`, `not-json`} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/deck/batch", strings.NewReader(body))
+
+		td.Router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected response code 400 (Bad Request) for '%s', but got %d instead.", body, w.Code)
+		}
+	}
+
+	_, resp := executeBatch(t, td, `{"operations": [{"op": "deal"}]}`)
+	if resp.Results[0].Status != http.StatusBadRequest || resp.Results[0].Error != "unsupported operation: deal" {
+		t.Error("Expected an unsupported operation to fail with 400 (Bad Request).")
+	}
+}
@@ -5,30 +5,75 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/events"
 	deck_repo "github.com/natemago/card-games-api/repositories/deck"
 )
 
 // DeckService represents the REST API service for the Deck resource.
-// Uses the DeckRepository to actually manage the deck of cards.
+// Uses the DeckRepository to actually manage the deck of cards, and the PlayerRepository to manage the dealers,
+// players and public piles of the decks.
 type DeckService struct {
 	Repository deck_repo.DeckRepository
+
+	// Players manages the dealers, players and public piles of the decks.
+	Players deck_repo.PlayerRepository
+
+	// Hub delivers the events of the decks to the subscribers, like the WebSocket clients. Defaults to a hub
+	// buffering events.DefaultBuffer events per subscriber; nil discards the events.
+	Hub *events.Hub
+
+	// EventLog keeps the events of the decks, so the clients of the event streams can resume where they left off.
+	// Optional: without it, the events are not kept.
+	EventLog deck_repo.EventRepository
+
+	// Heartbeat is the interval to send a heartbeat on the event streams. Zero disables the heartbeat.
+	Heartbeat time.Duration
+
+	// MaxStreams is the maximal number of open event streams. Zero means unlimited.
+	MaxStreams int
+
+	// Webhooks manages the webhooks subscribed to the events of the decks. Optional: without it, the webhook
+	// endpoints are not routed.
+	Webhooks deck_repo.WebhookRepository
+
+	// AllowPrivateWebhooks allows the webhooks on loopback, private and link-local addresses. By default, a webhook
+	// must resolve to public addresses only (see webhooks.CheckHost).
+	AllowPrivateWebhooks bool
+
+	// AdminToken is the secret token of the administrator, who acts as the dealer of every deck. Empty disables the
+	// administrator.
+	AdminToken string
+
+	// sequence publishes the events of every deck in the order of the changes of the deck.
+	sequence eventSequence
+
+	// streamsLock guards the number of open event streams.
+	streamsLock sync.Mutex
+	streams     int
 }
 
 // CreateDeck endpoint for creating new deck given.
-// Accepts two query parameters:
+// Accepts three query parameters:
 //  - shuffled - (optional) whether to create a shuffled deck or a deck with the cards in proper order.
 //  - cards - (optional) an optional list of cards given in a comma-separated string. When supplied, the
 //      deck will contain only the given cards (partial deck).
+//  - packs - (optional) the number of packs in the deck (a shoe). Every card may appear once per pack.
 // If none of the query parameters are supplied, then a full 52 deck of cards in proper order will be created.
 // If the cards list contain any invalid or duplicated values, returns a 400 Bad Request error response.
+// Returns the secret token of the dealer of the new deck, shown only once.
 func (d *DeckService) CreateDeck(ctx *gin.Context) {
 	cardsParam, _ := ctx.GetQuery("cards")
 	shuffledParam, _ := ctx.GetQuery("shuffled")
+	packsParam, _ := ctx.GetQuery("packs")
 
 	var cards []*deck_repo.Card
 	shuffled := false
+	packs := 1
 
 	if cardsParam != "" {
 		cards = deck_repo.AsCards(cardsParam)
@@ -38,9 +83,18 @@ func (d *DeckService) CreateDeck(ctx *gin.Context) {
 		shuffled, _ = strconv.ParseBool(strings.TrimSpace(shuffledParam))
 	}
 
-	deck, err := d.Repository.CreateDeck(&deck_repo.Deck{
+	if packsParam != "" {
+		var err error
+		if packs, err = strconv.Atoi(strings.TrimSpace(packsParam)); err != nil {
+			ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid number of packs: %s", packsParam), err))
+			return
+		}
+	}
+
+	deck, dealerToken, err := d.NewDeck(&deck_repo.Deck{
 		Shuffled: shuffled,
 		Cards:    cards,
+		Packs:    packs,
 	})
 
 	if err != nil {
@@ -49,16 +103,66 @@ func (d *DeckService) CreateDeck(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusCreated, &CreateDeckResponse{
-		DeckID:    deck.ID,
-		Shuffled:  deck.Shuffled,
-		Remaining: deck.Remaining,
+		DeckID:      deck.ID,
+		Shuffled:    deck.Shuffled,
+		Remaining:   deck.Remaining,
+		DealerToken: dealerToken,
+	})
+}
+
+// NewDeck creates the deck and the dealer of the deck within a single transaction, so there is no deck without a
+// dealer. Returns the created deck and the secret token of the dealer.
+func (d *DeckService) NewDeck(deck *deck_repo.Deck) (*deck_repo.Deck, string, error) {
+	return d.newDeck(deck, false)
+}
+
+// NewPrivateDeck creates a deck like NewDeck, kept private by its dealer: only the holder of the returned token sees
+// the cards of the deck, draws from it and shuffles it. Used for the decks owned by a game, like the deck of a table.
+func (d *DeckService) NewPrivateDeck(deck *deck_repo.Deck) (*deck_repo.Deck, string, error) {
+	return d.newDeck(deck, true)
+}
+
+func (d *DeckService) newDeck(deck *deck_repo.Deck, private bool) (*deck_repo.Deck, string, error) {
+	var dealerToken string
+	err := d.Repository.Transaction(func(repository deck_repo.DeckRepository) error {
+		var err error
+		if deck, err = repository.CreateDeck(deck); err != nil {
+			return err
+		}
+		dealerToken, err = d.createDealer(repository, deck.ID, private)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return deck, dealerToken, nil
+}
+
+// createDealer creates the dealer of a new deck within the transaction of the repository that creates the deck.
+// Returns the secret token of the dealer.
+func (d *DeckService) createDealer(repository deck_repo.DeckRepository, deckID string, private bool) (string, error) {
+	dealerToken, err := deck_repo.NewToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = d.Players.Bind(repository).CreateDealer(&deck_repo.Dealer{
+		DeckID:    deckID,
+		TokenHash: deck_repo.HashToken(dealerToken),
+		Private:   private,
 	})
+	if err != nil {
+		return "", err
+	}
+	return dealerToken, nil
 }
 
 // OpenDeck looks up a deck by its id, and returns the deck data.
 // Accepts one path parameter: deckId - the ID of the deck to look up.
 // If the deck does not exist, generates a 404 error response.
-// Returns the deck metadata and the list of cards remaining in the deck.
+// Returns the deck metadata and the list of cards remaining in the deck. Once the deck has players, or if the deck is
+// private, the cards in the deck and in the hands are hidden: a player sees only the own hand, and only the dealer
+// sees all of the cards. The players and the public piles are shown to everyone.
 func (d *DeckService) OpenDeck(ctx *gin.Context) {
 	deckID := ctx.Param("deckId")
 	if deckID == "" {
@@ -72,22 +176,44 @@ func (d *DeckService) OpenDeck(ctx *gin.Context) {
 		return
 	}
 
-	var cards []CardResponse
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	players, err := d.Players.ListPlayers(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	piles, err := d.Players.ListPiles(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
-	for _, card := range deck.Cards {
-		cards = append(cards, CardResponse{
-			Value: card.RankName(),
-			Suit:  card.SuitName(),
-			Code:  card.Value,
-		})
+	public, err := isPublic(d.Players, deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
-	ctx.JSON(http.StatusOK, &OpenDeckResponse{
+	response := &OpenDeckResponse{
 		DeckID:    deck.ID,
 		Shuffled:  deck.Shuffled,
 		Remaining: deck.Remaining,
-		Cards:     cards,
-	})
+	}
+	if v.dealer || public {
+		response.Cards = ToCardResponses(deck.Cards)
+	}
+	if len(players) > 0 {
+		response.Players = toPlayerResponses(players, v)
+	}
+	if len(piles) > 0 {
+		response.Piles = toPileResponses(piles)
+	}
+
+	ctx.JSON(http.StatusOK, response)
 }
 
 // DrawCards draws a number of cards from a given deck.
@@ -98,6 +224,7 @@ func (d *DeckService) OpenDeck(ctx *gin.Context) {
 // If there is no deck with the given id, then returns a 404 not found error response.
 // If the count paramters is not an integer or is greater then the number of remaining cards,
 // then returns a 400 Bad Request error response.
+// Once the deck has players, only the dealer may draw cards, otherwise returns a 403 Forbidden error response.
 func (d *DeckService) DrawCards(ctx *gin.Context) {
 	deckID := ctx.Param("deckId")
 	if deckID == "" {
@@ -124,30 +251,70 @@ func (d *DeckService) DrawCards(ctx *gin.Context) {
 		return
 	}
 
-	drawnCards, err := d.Repository.DrawCards(deckID, numCards)
+	drawnCards, err := d.Draw(RequestToken(ctx), deckID, numCards)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	var respCards []CardResponse
+	ctx.JSON(http.StatusOK, &DrawCardsResponse{
+		Cards: ToCardResponses(drawnCards),
+	})
+}
+
+// ShuffleDeck shuffles the remaining cards in a deck. The drawn cards are not put back into the deck.
+// Accepts one path parameter: deckId - the ID of the deck to shuffle.
+// If the deck does not exist, generates a 404 error response.
+// Once the deck has players, only the dealer may shuffle the deck, otherwise returns a 403 Forbidden error response.
+// Returns the deck metadata.
+func (d *DeckService) ShuffleDeck(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if deckID == "" {
+		ctx.Error(fmt.Errorf("not-found"))
+		return
+	}
 
-	for _, card := range drawnCards {
-		respCards = append(respCards, CardResponse{
-			Code:  card.Value,
-			Suit:  card.SuitName(),
-			Value: card.RankName(),
-		})
+	deck, err := d.Shuffle(RequestToken(ctx), deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
-	ctx.JSON(http.StatusOK, &DrawCardsResponse{
-		Cards: respCards,
+	ctx.JSON(http.StatusOK, &ShuffleDeckResponse{
+		DeckID:    deck.ID,
+		Shuffled:  deck.Shuffled,
+		Remaining: deck.Remaining,
 	})
 }
 
-// NewDeckService creates a new pointer to a DeckService using the given DeckRepository.
-func NewDeckService(deckRepository deck_repo.DeckRepository) *DeckService {
+// ToCardResponse converts a card to its CardResponse.
+func ToCardResponse(card *deck_repo.Card) CardResponse {
+	return CardResponse{
+		Code:  card.Value,
+		Suit:  card.SuitName(),
+		Value: card.RankName(),
+	}
+}
+
+// ToCardResponses converts the cards to their CardResponses, in the same order. Returns an empty list, not nil, if
+// there are no cards.
+func ToCardResponses(cards []*deck_repo.Card) []CardResponse {
+	respCards := []CardResponse{}
+
+	for _, card := range cards {
+		respCards = append(respCards, ToCardResponse(card))
+	}
+
+	return respCards
+}
+
+// NewDeckService creates a new pointer to a DeckService using the given DeckRepository and PlayerRepository,
+// publishing the events of the decks to a new Hub. The optional collaborators, like the EventLog, the Webhooks and
+// the AdminToken, are set on the returned DeckService.
+func NewDeckService(deckRepository deck_repo.DeckRepository, playerRepository deck_repo.PlayerRepository) *DeckService {
 	return &DeckService{
 		Repository: deckRepository,
+		Players:    playerRepository,
+		Hub:        events.NewHub(events.DefaultBuffer),
 	}
 }
@@ -26,6 +26,8 @@ var testConfig = &config.Config{
 	},
 }
 
+const testAdminToken = "admin-secret"
+
 type TestData struct {
 	Router         *gin.Engine
 	DeckService    *DeckService
@@ -44,7 +46,11 @@ func setupTest(t *testing.T) TestData {
 	}
 
 	deckRepo := deck_repo.NewDBDeckRepository(db)
-	deckService := NewDeckService(deckRepo)
+	deckService := NewDeckService(deckRepo, deck_repo.NewDBPlayerRepository(db))
+	deckService.EventLog = deck_repo.NewDBEventRepository(db)
+	deckService.AdminToken = testAdminToken
+	deckService.Webhooks = deck_repo.NewDBWebhookRepository(db)
+	deckService.AllowPrivateWebhooks = true
 
 	router := gin.Default()
 	router.Use(errors.ErrorHandler())
@@ -356,3 +362,62 @@ func compare(deck1 []CardResponse, deck2 string) bool {
 	}
 	return strings.Join(deck1Arr, ",") == deck2
 }
+
+func TestShuffleDeck(t *testing.T) {
+	td := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/deck/%s/shuffle", td.FullDeckID), nil)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead.", w.Code)
+	}
+
+	resp := &ShuffleDeckResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the deck, but got error: %s", err.Error())
+	}
+	if !resp.Shuffled || resp.Remaining != 52 {
+		t.Error("Expected the deck to be shuffled with 52 remaining cards.")
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/v1/deck/00000000-0000-0000-0000-000000000000/shuffle", nil)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected response code 404 (Not Found), but got %d instead.", w.Code)
+	}
+}
+
+func TestCreateDeck_Shoe(t *testing.T) {
+	td := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/deck?shuffled=true&packs=6", nil)
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected response code 201 (Created), but got %d instead.", w.Code)
+	}
+	resp := &CreateDeckResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the response, but got error: %s", err.Error())
+	}
+	if resp.Remaining != 6*52 {
+		t.Errorf("Expected a shoe of %d cards, but got %d.", 6*52, resp.Remaining)
+	}
+
+	for _, packs := range []string{"-1", "9", "six"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/deck?packs="+packs, nil)
+		td.Router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected response code 400 (Bad Request) for %s packs, but got %d instead.", packs, w.Code)
+		}
+	}
+}
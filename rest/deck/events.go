@@ -0,0 +1,154 @@
+package deck
+
+import (
+	"strings"
+
+	"github.com/natemago/card-games-api/events"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Draw draws the number of cards from the deck, if the holder of the token may draw from the deck (see checkDealer),
+// and publishes the Drawn event.
+func (d *DeckService) Draw(token, deckID string, count int) ([]*deck_repo.Card, error) {
+	if err := d.checkDealer(token, deckID, "draw cards from"); err != nil {
+		return nil, err
+	}
+
+	var drawn []*deck_repo.Card
+	err := d.change(func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error) {
+		var err error
+		if drawn, err = repository.DrawCards(deckID, count); err != nil {
+			return nil, err
+		}
+		changes := []*events.Event{{
+			Type:   events.Drawn,
+			DeckID: deckID,
+			Count:  len(drawn),
+			Cards:  publicCards(players, deckID, cardCodes(drawn)),
+		}}
+		return changes, d.logEvents(repository, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drawn, nil
+}
+
+// Shuffle shuffles the deck, if the holder of the token may shuffle the deck (see checkDealer), and publishes the
+// Shuffled event.
+func (d *DeckService) Shuffle(token, deckID string) (*deck_repo.Deck, error) {
+	if err := d.checkDealer(token, deckID, "shuffle"); err != nil {
+		return nil, err
+	}
+
+	var deck *deck_repo.Deck
+	err := d.change(func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error) {
+		var err error
+		if deck, err = repository.ShuffleDeck(deckID); err != nil {
+			return nil, err
+		}
+		changes := []*events.Event{{
+			Type:   events.Shuffled,
+			DeckID: deckID,
+		}}
+		return changes, d.logEvents(repository, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// change executes the change of the decks within a single transaction, with the repositories bound to the
+// transaction. The change logs its events within the transaction (see logEvents) and returns them, to be published to
+// the hub once the transaction is committed. The events of a deck are published in the order of the changes of the
+// deck, the same as in the event log (see eventSequence), while the changes of different decks run concurrently.
+func (d *DeckService) change(fn func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error)) error {
+	var changes []*events.Event
+	var tickets []ticket
+	err := d.Repository.Transaction(func(repository deck_repo.DeckRepository) error {
+		var err error
+		if changes, err = fn(repository, d.Players.Bind(repository)); err != nil {
+			return err
+		}
+		for _, event := range changes {
+			tickets = append(tickets, d.sequence.take(event.DeckID))
+		}
+		return nil
+	})
+
+	for i, t := range tickets {
+		if err != nil {
+			d.sequence.done(t, nil, d.Hub)
+		} else {
+			d.sequence.done(t, changes[i], d.Hub)
+		}
+	}
+	return err
+}
+
+// logEvents sets the number of remaining cards in the deck of each event, as changed by the transaction of the
+// repository, and appends the events to the EventLog within the transaction.
+func (d *DeckService) logEvents(repository deck_repo.DeckRepository, changes []*events.Event) error {
+	for _, event := range changes {
+		deck, err := repository.GetDeck(event.DeckID)
+		if err != nil {
+			return err
+		}
+		event.Remaining = deck.Remaining
+
+		if d.EventLog == nil {
+			continue
+		}
+		logged, err := d.EventLog.Bind(repository).AppendEvent(&deck_repo.Event{
+			DeckID:    event.DeckID,
+			Type:      event.Type,
+			Remaining: event.Remaining,
+			Count:     event.Count,
+			Cards:     strings.Join(event.Cards, ","),
+			PlayerID:  event.PlayerID,
+			Pile:      event.Pile,
+		})
+		if err != nil {
+			return err
+		}
+		event.ID = logged.ID
+		event.Time = logged.CreatedAt.UTC()
+	}
+	return nil
+}
+
+// toEvent converts a logged event to the published event.
+func toEvent(logged *deck_repo.Event) *events.Event {
+	event := &events.Event{
+		ID:        logged.ID,
+		Type:      logged.Type,
+		DeckID:    logged.DeckID,
+		Time:      logged.CreatedAt.UTC(),
+		Remaining: logged.Remaining,
+		Count:     logged.Count,
+		PlayerID:  logged.PlayerID,
+		Pile:      logged.Pile,
+	}
+	if logged.Cards != "" {
+		event.Cards = strings.Split(logged.Cards, ",")
+	}
+	return event
+}
+
+// publicCards returns the codes of the cards if they may be shown to everyone: when the deck is public (see
+// isPublic). Otherwise returns nil.
+func publicCards(players deck_repo.PlayerRepository, deckID string, codes []string) []string {
+	if public, err := isPublic(players, deckID); err != nil || !public {
+		return nil
+	}
+	return codes
+}
+
+func cardCodes(cards []*deck_repo.Card) []string {
+	codes := []string{}
+	for _, card := range cards {
+		codes = append(codes, card.Value)
+	}
+	return codes
+}
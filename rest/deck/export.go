@@ -0,0 +1,103 @@
+package deck
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// ExportDeck exports a deck, with all of its cards including the drawn ones, as a versioned JSON document
+// (see deck_repo.DeckExport).
+// Accepts one path parameter: deckId - the ID of the deck to export.
+// If the deck does not exist, generates a 404 error response.
+// Once the deck has players, only the dealer may export the deck, otherwise returns a 403 Forbidden error response.
+func (d *DeckService) ExportDeck(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if deckID == "" {
+		ctx.Error(fmt.Errorf("not-found"))
+		return
+	}
+
+	if err := d.checkDealer(RequestToken(ctx), deckID, "export"); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	deck, err := d.Repository.ExportDeck(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deck_repo.NewDeckExport(deck))
+}
+
+// ImportDecks imports the decks from an export document given as JSON body (see deck_repo.DeckExport).
+// Accepts one query parameter:
+//  - remap_ids - (optional) boolean. When set to true, every imported deck gets a new ID. Otherwise the deck IDs
+//      from the document are preserved.
+// All of the decks are imported within a single transaction - either all of them are imported or none. Every
+// imported deck gets a new dealer, created in the same transaction, and the secret token of the dealer is returned
+// with the deck, shown only once.
+// Only the administrator may import decks, otherwise returns a 401 Unauthorized error response.
+// If the document is invalid, has an unsupported version or any of the decks holds invalid cards, returns
+// a 400 Bad Request error response. Returns 400 Bad Request as well if a deck with the same ID already exists.
+func (d *DeckService) ImportDecks(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	remapIDs := false
+	if remapIDsParam, _ := ctx.GetQuery("remap_ids"); remapIDsParam != "" {
+		remapIDs, _ = strconv.ParseBool(strings.TrimSpace(remapIDsParam))
+	}
+
+	export := &deck_repo.DeckExport{}
+	if err := ctx.ShouldBindJSON(export); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid export document: %s", err.Error()), err))
+		return
+	}
+
+	decks, err := export.ToDecks(remapIDs)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	imported := []ImportedDeckResponse{}
+
+	err = d.Repository.Transaction(func(repository deck_repo.DeckRepository) error {
+		for i, deck := range decks {
+			deck, err := repository.ImportDeck(deck)
+			if err != nil {
+				return err
+			}
+			dealerToken, err := d.createDealer(repository, deck.ID, false)
+			if err != nil {
+				return err
+			}
+			imported = append(imported, ImportedDeckResponse{
+				DeckID:         deck.ID,
+				OriginalDeckID: export.Decks[i].ID,
+				Shuffled:       deck.Shuffled,
+				Remaining:      deck.Remaining,
+				DealerToken:    dealerToken,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &ImportDecksResponse{
+		Decks: imported,
+	})
+}
@@ -0,0 +1,119 @@
+package deck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func TestExportImportDeck(t *testing.T) {
+	td := setupTest(t)
+
+	if _, err := td.DeckService.Repository.DrawCards(td.PartialDeckID, 1); err != nil {
+		t.Fatalf("Expected to draw a card, but got error: %s", err.Error())
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/deck/%s/export", td.PartialDeckID), nil)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead.", w.Code)
+	}
+
+	export := &deck_repo.DeckExport{}
+	if err := json.Unmarshal(w.Body.Bytes(), export); err != nil {
+		t.Fatalf("Expected to deserialize the export document, but got error: %s", err.Error())
+	}
+	if export.Version != deck_repo.DeckExportVersion || len(export.Decks) != 1 {
+		t.Fatal("Expected a versioned document with one deck.")
+	}
+	if len(export.Decks[0].Cards) != 3 || !export.Decks[0].Cards[0].Drawn || export.Decks[0].Remaining != 2 {
+		t.Error("Expected all 3 cards to be exported, including the drawn card.")
+	}
+
+	body, _ := json.Marshal(export)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/v1/deck/import?remap_ids=true", bytes.NewReader(body))
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected importing without the token of the administrator to fail with 401 (Unauthorized), but got %d instead.", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/v1/deck/import", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected importing an existing deck to fail with 400 (Bad Request), but got %d instead.", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/v1/deck/import?remap_ids=true", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected response code 201 (Created), but got %d instead.", w.Code)
+	}
+
+	resp := &ImportDecksResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the import response, but got error: %s", err.Error())
+	}
+	if len(resp.Decks) != 1 || resp.Decks[0].OriginalDeckID != td.PartialDeckID || resp.Decks[0].DeckID == td.PartialDeckID {
+		t.Fatal("Expected the deck to be imported with a new id.")
+	}
+
+	deck, err := td.DeckService.Repository.GetDeck(resp.Decks[0].DeckID)
+	if err != nil {
+		t.Fatalf("Expected to get the imported deck, but got error: %s", err.Error())
+	}
+	if deck.Remaining != 2 || deck.Cards[0].Value != "2C" {
+		t.Error("Expected the imported deck to keep the drawn card out of the deck.")
+	}
+
+	v, err := td.DeckService.authorizeToken(resp.Decks[0].DealerToken, resp.Decks[0].DeckID)
+	if err != nil || !v.dealer {
+		t.Errorf("Expected the token of the dealer of the imported deck, but got: %+v", resp.Decks[0])
+	}
+}
+
+func TestImportDecks_Invalid(t *testing.T) {
+	td := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/deck/import", strings.NewReader(`{
+		"version": 1,
+		"decks": [{"id": "invalid", "remaining": 1, "cards": [{"value": "XX", "idx": 0}]}]
+	}`))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected response code 400 (Bad Request), but got %d instead.", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/v1/deck/00000000-0000-0000-0000-000000000000/export", nil)
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected response code 404 (Not Found), but got %d instead.", w.Code)
+	}
+}
@@ -1,5 +1,10 @@
 package deck
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // CardResponse represents a Card response object. Holds the data for a particular card in a deck.
 type CardResponse struct {
 	// Value is the card rank (number), like "ACE", "2", "10", "QUEEN" etc.
@@ -22,6 +27,9 @@ type CreateDeckResponse struct {
 
 	// Remaining is the number of remaining cards in the deck.
 	Remaining int `json:"remaining"`
+
+	// DealerToken is the secret token of the dealer of the deck. It is shown only once, when the deck is created.
+	DealerToken string `json:"dealer_token,omitempty"`
 }
 
 // OpenDeckResponse represents the response for an OpenDeck call (show all cards in deck).
@@ -33,8 +41,15 @@ type OpenDeckResponse struct {
 	// Remaining is the number of remaining cards in the deck.
 	Remaining int `json:"remaining"`
 
-	// Cards is the list of cards in the deck, in the order they were inserted/generated.
+	// Cards is the list of cards in the deck, in the order they were inserted/generated. Once the deck has players,
+	// only the dealer sees the cards in the deck.
 	Cards []CardResponse `json:"cards"`
+
+	// Players is the list of the players of the deck, if any.
+	Players []PlayerResponse `json:"players,omitempty"`
+
+	// Piles is the list of the public piles of the deck, if any.
+	Piles []PileResponse `json:"piles,omitempty"`
 }
 
 // DrawCardsResponse represents the response for a DrawCards call - draw one or more cards.
@@ -43,3 +58,254 @@ type DrawCardsResponse struct {
 	// Cards list of cards drawn from the deck.
 	Cards []CardResponse `json:"cards"`
 }
+
+// ShuffleDeckResponse represents the response for a ShuffleDeck call.
+type ShuffleDeckResponse struct {
+	// DeckID is the id of the deck.
+	DeckID string `json:"deck_id"`
+
+	// Shuffled flag whether the deck is shuffled or in proper order.
+	Shuffled bool `json:"shuffled"`
+
+	// Remaining is the number of remaining cards in the deck.
+	Remaining int `json:"remaining"`
+}
+
+// BatchOperation represents a single operation in a batch request.
+type BatchOperation struct {
+	// Op is the operation to execute: "create", "draw" or "shuffle".
+	Op string `json:"op"`
+
+	// DeckID is the id of the deck to draw cards from or to shuffle. Not used when creating a deck.
+	DeckID string `json:"deck_id"`
+
+	// Shuffled whether to create a shuffled deck. Used only when creating a deck.
+	Shuffled bool `json:"shuffled"`
+
+	// Cards is an optional comma-separated list of cards for a partial deck. Used only when creating a deck.
+	Cards string `json:"cards"`
+
+	// Count is the number of cards to draw. Defaults to 1. Used only when drawing cards.
+	Count *int `json:"count"`
+}
+
+// BatchRequest represents the request for a Batch call - a list of operations to execute in a single transaction.
+type BatchRequest struct {
+	// Atomic flag - if set, either all operations succeed or none of them is applied.
+	Atomic bool `json:"atomic"`
+
+	// Operations is the list of operations to execute, in order.
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperationResult represents the result of a single operation in a batch.
+type BatchOperationResult struct {
+	// Status is the HTTP status code of the operation, as if it was executed on its own.
+	Status int `json:"status"`
+
+	// Error is the error message, if the operation failed.
+	Error string `json:"error,omitempty"`
+
+	// DeckID is the id of the created, drawn from or shuffled deck.
+	DeckID string `json:"deck_id,omitempty"`
+
+	// Shuffled flag whether the deck is shuffled. Set for created and shuffled decks.
+	Shuffled bool `json:"shuffled,omitempty"`
+
+	// Remaining is the number of remaining cards in the deck. Set for created and shuffled decks.
+	Remaining *int `json:"remaining,omitempty"`
+
+	// Cards is the list of drawn cards. Set when drawing cards.
+	Cards []CardResponse `json:"cards,omitempty"`
+
+	// DealerToken is the secret token of the dealer of the created deck. Set for created decks, shown only once.
+	DealerToken string `json:"dealer_token,omitempty"`
+}
+
+// BatchResponse represents the response for a Batch call.
+type BatchResponse struct {
+	// Committed flag whether the changes made by the operations were committed.
+	Committed bool `json:"committed"`
+
+	// Results holds the result of each operation, in the same order as the operations in the request.
+	Results []BatchOperationResult `json:"results"`
+}
+
+// ImportedDeckResponse holds the data for a single imported deck.
+type ImportedDeckResponse struct {
+	// DeckID is the id of the imported deck.
+	DeckID string `json:"deck_id"`
+
+	// OriginalDeckID is the id of the deck in the export document. Differs from DeckID if the ids were remapped.
+	OriginalDeckID string `json:"original_deck_id"`
+
+	// Shuffled flag whether the deck is shuffled or in proper order.
+	Shuffled bool `json:"shuffled"`
+
+	// Remaining is the number of remaining cards in the deck.
+	Remaining int `json:"remaining"`
+
+	// DealerToken is the secret token of the dealer of the imported deck. It is shown only once, when the deck is
+	// imported.
+	DealerToken string `json:"dealer_token"`
+}
+
+// ImportDecksResponse represents the response for an ImportDecks call.
+type ImportDecksResponse struct {
+	// Decks is the list of imported decks, in the order they appear in the export document.
+	Decks []ImportedDeckResponse `json:"decks"`
+}
+
+// AddPlayerRequest represents the request for an AddPlayer call.
+type AddPlayerRequest struct {
+	// Name is the name of the player.
+	Name string `json:"name" binding:"required"`
+}
+
+// PlayerResponse holds the data of a player of a deck.
+type PlayerResponse struct {
+	// PlayerID is the id of the player.
+	PlayerID string `json:"player_id"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// Token is the secret token of the player. It is shown only once, when the player is added.
+	Token string `json:"token,omitempty"`
+
+	// CardsInHand is the number of cards in the hand of the player.
+	CardsInHand int `json:"cards_in_hand"`
+
+	// Cards is the list of cards in the hand of the player. Shown only to the player and to the dealer.
+	Cards []CardResponse `json:"cards,omitempty"`
+}
+
+// PlayersResponse represents the response for a ListPlayers call.
+type PlayersResponse struct {
+	// Players is the list of the players of the deck, in the order they joined the deck.
+	Players []PlayerResponse `json:"players"`
+}
+
+// PlayCardsRequest represents the request for a PlayCards call.
+type PlayCardsRequest struct {
+	// Pile is the name of the public pile to put the cards on.
+	Pile string `json:"pile" binding:"required"`
+
+	// Cards is the comma-separated list of the cards to play from the hand, put on the pile in the given order.
+	Cards string `json:"cards" binding:"required"`
+}
+
+// PileResponse holds the data of a public pile of a deck.
+type PileResponse struct {
+	// Name is the name of the pile.
+	Name string `json:"name"`
+
+	// Cards is the list of cards of the pile, with the top card last.
+	Cards []CardResponse `json:"cards"`
+}
+
+// PilesResponse represents the response for a ListPiles call.
+type PilesResponse struct {
+	// Piles is the list of the public piles of the deck, ordered by name.
+	Piles []PileResponse `json:"piles"`
+}
+
+// SocketCommand represents a command sent by a client over the WebSocket of a deck.
+type SocketCommand struct {
+	// Command is the command to execute: "draw" or "shuffle".
+	Command string `json:"command"`
+
+	// Count is the number of cards to draw. Defaults to 1. Used only when drawing cards.
+	Count int `json:"count"`
+}
+
+// SocketResult represents the result of a SocketCommand, sent only to the client that sent the command. The events
+// of the deck are sent to every client as events.Event messages.
+type SocketResult struct {
+	// Type is always "result", to tell the results from the events.
+	Type string `json:"type"`
+
+	// Command is the executed command.
+	Command string `json:"command"`
+
+	// Error is the error message, if the command failed.
+	Error string `json:"error,omitempty"`
+
+	// Status is the HTTP status code of the command, as if it was executed as a REST call.
+	Status int `json:"status"`
+
+	// Remaining is the number of remaining cards in the deck, after a shuffle.
+	Remaining *int `json:"remaining,omitempty"`
+
+	// Cards is the list of drawn cards.
+	Cards []CardResponse `json:"cards,omitempty"`
+}
+
+// CreateWebhookRequest represents the request for a CreateWebhook or a CreateGlobalWebhook call.
+type CreateWebhookRequest struct {
+	// URL is the http or https URL the events are posted to.
+	URL string `json:"url" binding:"required"`
+
+	// Events is the list of the event types to subscribe to, like "deck.created". Empty subscribes to every event type.
+	Events []string `json:"events"`
+}
+
+// WebhookResponse holds the data of a webhook.
+type WebhookResponse struct {
+	// WebhookID is the id of the webhook.
+	WebhookID string `json:"webhook_id"`
+
+	// DeckID is the id of the deck the webhook subscribes to. Empty for a global webhook.
+	DeckID string `json:"deck_id,omitempty"`
+
+	// URL is the URL the events are posted to.
+	URL string `json:"url"`
+
+	// Events is the list of the subscribed event types. Empty if the webhook subscribes to every event type.
+	Events []string `json:"events"`
+
+	// Secret is the secret key the payloads are signed with. It is shown only once, when the webhook is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhooksResponse represents the response for a ListWebhooks or a ListGlobalWebhooks call.
+type WebhooksResponse struct {
+	// Webhooks is the list of the webhooks, in the order they were created.
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// DeliveryResponse holds the data of the delivery of an event to a webhook.
+type DeliveryResponse struct {
+	// DeliveryID is the id of the delivery.
+	DeliveryID uint64 `json:"delivery_id"`
+
+	// WebhookID is the id of the webhook.
+	WebhookID string `json:"webhook_id"`
+
+	// DeckID is the id of the changed deck.
+	DeckID string `json:"deck_id"`
+
+	// Event is the type of the event.
+	Event string `json:"event"`
+
+	// CreatedAt is the time of the event.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Attempts is the number of delivery attempts made.
+	Attempts int `json:"attempts"`
+
+	// Dead is true if the delivery was given up on.
+	Dead bool `json:"dead"`
+
+	// LastError is the error of the last failed delivery attempt.
+	LastError string `json:"last_error,omitempty"`
+
+	// Payload is the payload posted to the webhook.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// DeliveriesResponse represents the response for a ListDeadDeliveries call.
+type DeliveriesResponse struct {
+	// Deliveries is the list of the deliveries, in the order of the events.
+	Deliveries []DeliveryResponse `json:"deliveries"`
+}
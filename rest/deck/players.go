@@ -0,0 +1,454 @@
+package deck
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/events"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// handUpdateRetries is the number of times the drawn cards are put into the hand of a player again, within the
+// transaction of the draw, if the player was changed concurrently.
+const handUpdateRetries = 3
+
+// NoSeat is the seat of a request without a token (see RequestSeat).
+const NoSeat = -1
+
+// viewer is the holder of the token of a request: the dealer, a player of the deck, or nobody.
+type viewer struct {
+	dealer bool
+	player *deck_repo.Player
+}
+
+// sees returns true if the viewer may see the hand of the player: the player and the dealer.
+func (v *viewer) sees(player *deck_repo.Player) bool {
+	return v.dealer || (v.player != nil && v.player.ID == player.ID)
+}
+
+// AddPlayer adds a new player to a deck. Only the dealer of the deck may add players.
+// Accepts one path parameter: deckId - the ID of the deck, and an AddPlayerRequest JSON body.
+// Returns the player with the secret token of the player, shown only once.
+// If the deck does not exist, generates a 404 error response. Without the token of the dealer, generates a 403 error
+// response.
+func (d *DeckService) AddPlayer(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	request := &AddPlayerRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid player: %s", err.Error()), err))
+		return
+	}
+
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if !v.dealer {
+		ctx.Error(errors.ForbiddenError(fmt.Sprintf("only the dealer may add players to the deck %s", deckID), nil))
+		return
+	}
+
+	token, err := deck_repo.NewToken()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	player, err := d.Players.CreatePlayer(&deck_repo.Player{
+		DeckID:    deckID,
+		Name:      request.Name,
+		TokenHash: deck_repo.HashToken(token),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := toPlayerResponse(player, v)
+	response.Token = token
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// ListPlayers lists the players of a deck, in the order they joined the deck. The cards in a hand are shown only to
+// the player and to the dealer.
+// Accepts one path parameter: deckId - the ID of the deck.
+// If the deck does not exist, generates a 404 error response. For an invalid token, generates a 401 error response.
+func (d *DeckService) ListPlayers(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	players, err := d.Players.ListPlayers(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &PlayersResponse{
+		Players: toPlayerResponses(players, v),
+	})
+}
+
+// GetPlayer looks up a player of a deck. The cards in the hand are shown only to the player and to the dealer.
+// Accepts two path parameters: deckId - the ID of the deck, and playerId - the ID of the player.
+// If the player does not exist, generates a 404 error response. For an invalid token, generates a 401 error response.
+func (d *DeckService) GetPlayer(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	player, err := d.Players.GetPlayer(deckID, ctx.Param("playerId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlayerResponse(player, v))
+}
+
+// DealCards draws a number of cards from a deck directly into the hand of a player. Only the dealer and the player
+// may draw cards into the hand.
+// Accepts two path parameters: deckId - the ID of the deck, and playerId - the ID of the player, and the count query
+// parameter - the number of cards to draw, 1 by default.
+// Returns the player with the cards in the hand.
+// If the player does not exist, generates a 404 error response. If the count is invalid or greater than the number
+// of remaining cards, generates a 400 error response. For a token of another player, generates a 403 error response.
+func (d *DeckService) DealCards(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	player, v, err := d.playerAccess(ctx, deckID, ctx.Param("playerId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	count := 1
+	if countParam := strings.TrimSpace(ctx.Query("count")); countParam != "" {
+		if count, err = strconv.Atoi(countParam); err != nil || count < 1 {
+			ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid cards count number: %s", countParam), err))
+			return
+		}
+	}
+
+	// The cards are drawn and put into the hand within a single transaction, so no drawn card is lost.
+	err = d.change(func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error) {
+		drawn, err := repository.DrawCards(deckID, count)
+		if err != nil {
+			return nil, err
+		}
+
+		for attempt := 0; ; attempt++ {
+			player.Hand = deck_repo.JoinCards(append(player.HandCards(), drawn...))
+			if _, err = players.UpdatePlayer(player); err == nil || !errors.IsBadRequestError(err) || attempt >= handUpdateRetries {
+				break
+			}
+			if player, err = players.GetPlayer(deckID, player.ID); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		changes := []*events.Event{{
+			Type:     events.Drawn,
+			DeckID:   deckID,
+			Count:    len(drawn),
+			PlayerID: player.ID,
+		}}
+		return changes, d.logEvents(repository, changes)
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlayerResponse(player, v))
+}
+
+// PlayCards plays cards from the hand of a player face up on a public pile of the deck. The pile is created with the
+// first cards played on it. Only the dealer and the player may play the cards of the hand.
+// Accepts two path parameters: deckId - the ID of the deck, and playerId - the ID of the player, and a
+// PlayCardsRequest JSON body.
+// Returns the pile with the played cards on top.
+// If the player does not exist, generates a 404 error response. If the player does not hold the cards, generates a
+// 400 error response. For a token of another player, generates a 403 error response.
+func (d *DeckService) PlayCards(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	request := &PlayCardsRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid play: %s", err.Error()), err))
+		return
+	}
+
+	player, _, err := d.playerAccess(ctx, deckID, ctx.Param("playerId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	hand := player.HandCards()
+	played := deck_repo.AsCards(strings.ToUpper(request.Cards))
+	if len(played) == 0 {
+		ctx.Error(errors.BadRequestError("no cards to play", nil))
+		return
+	}
+	for _, card := range played {
+		held := false
+		for i, handCard := range hand {
+			if handCard.Value == card.Value {
+				hand = append(hand[:i], hand[i+1:]...)
+				held = true
+				break
+			}
+		}
+		if !held {
+			ctx.Error(errors.BadRequestError(fmt.Sprintf("player %s does not hold the card %s", player.ID, card.Value), nil))
+			return
+		}
+	}
+
+	player.Hand = deck_repo.JoinCards(hand)
+	var pile *deck_repo.Pile
+	err = d.change(func(repository deck_repo.DeckRepository, players deck_repo.PlayerRepository) ([]*events.Event, error) {
+		var err error
+		if pile, err = players.MoveToPile(player, request.Pile, played); err != nil {
+			return nil, err
+		}
+
+		changes := []*events.Event{{
+			Type:     events.PileMoved,
+			DeckID:   deckID,
+			Count:    len(played),
+			Cards:    cardCodes(played),
+			PlayerID: player.ID,
+			Pile:     pile.Name,
+		}}
+		return changes, d.logEvents(repository, changes)
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPileResponse(pile))
+}
+
+// ListPiles lists the public piles of a deck, ordered by name. The piles are public, so no token is needed.
+// Accepts one path parameter: deckId - the ID of the deck.
+// If the deck does not exist, generates a 404 error response.
+func (d *DeckService) ListPiles(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	piles, err := d.Players.ListPiles(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &PilesResponse{
+		Piles: toPileResponses(piles),
+	})
+}
+
+// authorize returns the viewer holding the token given in the Authorization header as "Bearer <token>", or in the
+// token query parameter (see authorizeToken).
+func (d *DeckService) authorize(ctx *gin.Context, deckID string) (*viewer, error) {
+	return d.authorizeToken(RequestToken(ctx), deckID)
+}
+
+// authorizeToken returns the viewer holding the token: the administrator or the dealer of the deck, or one of its
+// players. Without a token, the viewer is nobody. Returns an UnauthorizedError if the token is neither of them.
+func (d *DeckService) authorizeToken(token, deckID string) (*viewer, error) {
+	return d.authorizeWith(d.Players, token, deckID)
+}
+
+// authorizeWith returns the viewer holding the token like authorizeToken, looking up the dealer and the players with
+// the given repository, so the token can be checked within a transaction.
+func (d *DeckService) authorizeWith(players deck_repo.PlayerRepository, token, deckID string) (*viewer, error) {
+	if token == "" {
+		return &viewer{}, nil
+	}
+	if d.isAdmin(token) {
+		return &viewer{dealer: true}, nil
+	}
+
+	hash := deck_repo.HashToken(token)
+	dealer, err := players.GetDealer(deckID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, err
+	}
+	if dealer != nil && subtle.ConstantTimeCompare([]byte(hash), []byte(dealer.TokenHash)) == 1 {
+		return &viewer{dealer: true}, nil
+	}
+
+	player, err := players.FindPlayer(deckID, hash)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.UnauthorizedError(fmt.Sprintf("invalid token for the deck %s", deckID), nil)
+		}
+		return nil, err
+	}
+	return &viewer{player: player}, nil
+}
+
+// SeesCards returns true if the holder of the token may see the cards in the deck: anyone, while the deck is public
+// (see isPublic), and only the dealer after. Returns an UnauthorizedError if the token is invalid for the deck.
+func (d *DeckService) SeesCards(token, deckID string) (bool, error) {
+	v, err := d.authorizeToken(token, deckID)
+	if err != nil {
+		return false, err
+	}
+	if v.dealer {
+		return true, nil
+	}
+	return isPublic(d.Players, deckID)
+}
+
+// RequestToken returns the token given in the Authorization header as "Bearer <token>", or in the token query
+// parameter. Empty if there is no token.
+func RequestToken(ctx *gin.Context) string {
+	token := strings.TrimSpace(strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer "))
+	if token == "" {
+		token = strings.TrimSpace(ctx.Query("token"))
+	}
+	return token
+}
+
+// RequestSeat returns the seat of the player holding the token of the request (see RequestToken), given the hashes of
+// the tokens of the players by seat (see deck_repo.HashToken). Returns NoSeat if there is no token, and an
+// UnauthorizedError if the token is not the token of any of the seats.
+func RequestSeat(ctx *gin.Context, tokenHashes []string) (int, error) {
+	token := RequestToken(ctx)
+	if token == "" {
+		return NoSeat, nil
+	}
+
+	hash := deck_repo.HashToken(token)
+	for seat, tokenHash := range tokenHashes {
+		if tokenHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(tokenHash)) == 1 {
+			return seat, nil
+		}
+	}
+	return NoSeat, errors.UnauthorizedError("invalid token for the seats", nil)
+}
+
+// isAdmin returns true if the token is the token of the administrator.
+func (d *DeckService) isAdmin(token string) bool {
+	return d.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(d.AdminToken)) == 1
+}
+
+// playerAccess looks up the player and checks that the request is made by the player or by the dealer.
+// Returns a ForbiddenError otherwise.
+func (d *DeckService) playerAccess(ctx *gin.Context, deckID, playerID string) (*deck_repo.Player, *viewer, error) {
+	player, err := d.Players.GetPlayer(deckID, playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !v.sees(player) {
+		return nil, nil, errors.ForbiddenError(fmt.Sprintf("only the player %s and the dealer may use the hand", playerID), nil)
+	}
+	return player, v, nil
+}
+
+// checkDealer checks that the token is the token of the dealer, if the deck is not public (see isPublic), so the
+// cards of the deck cannot be seen by the players. Returns a ForbiddenError otherwise.
+func (d *DeckService) checkDealer(token, deckID, action string) error {
+	return d.checkDealerWith(d.Players, token, deckID, action)
+}
+
+// checkDealerWith checks the token like checkDealer, looking up the dealer and the players with the given
+// repository, so the token can be checked within a transaction.
+func (d *DeckService) checkDealerWith(players deck_repo.PlayerRepository, token, deckID, action string) error {
+	public, err := isPublic(players, deckID)
+	if err != nil || public {
+		return err
+	}
+	v, err := d.authorizeWith(players, token, deckID)
+	if err != nil {
+		return err
+	}
+	if !v.dealer {
+		return errors.ForbiddenError(fmt.Sprintf("only the dealer may %s the deck %s", action, deckID), nil)
+	}
+	return nil
+}
+
+// isPublic returns true if anyone may see the cards in the deck, draw from it and shuffle it: until the deck has
+// players, unless its dealer keeps the deck private (see deck_repo.Dealer).
+func isPublic(players deck_repo.PlayerRepository, deckID string) (bool, error) {
+	dealer, err := players.GetDealer(deckID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return false, err
+	}
+	if dealer != nil && dealer.Private {
+		return false, nil
+	}
+
+	listed, err := players.ListPlayers(deckID)
+	if err != nil {
+		return false, err
+	}
+	return len(listed) == 0, nil
+}
+
+func toPlayerResponse(player *deck_repo.Player, v *viewer) PlayerResponse {
+	hand := player.HandCards()
+	response := PlayerResponse{
+		PlayerID:    player.ID,
+		Name:        player.Name,
+		CardsInHand: len(hand),
+	}
+	if v.sees(player) {
+		response.Cards = ToCardResponses(hand)
+	}
+	return response
+}
+
+func toPlayerResponses(players []*deck_repo.Player, v *viewer) []PlayerResponse {
+	responses := []PlayerResponse{}
+	for _, player := range players {
+		responses = append(responses, toPlayerResponse(player, v))
+	}
+	return responses
+}
+
+func toPileResponse(pile *deck_repo.Pile) PileResponse {
+	return PileResponse{
+		Name:  pile.Name,
+		Cards: ToCardResponses(pile.PileCards()),
+	}
+}
+
+func toPileResponses(piles []*deck_repo.Pile) []PileResponse {
+	responses := []PileResponse{}
+	for _, pile := range piles {
+		responses = append(responses, toPileResponse(pile))
+	}
+	return responses
+}
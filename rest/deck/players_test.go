@@ -0,0 +1,197 @@
+package deck
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// call makes a request with the token, if any, checks the response code and reads the response into resp, if any.
+func call(t *testing.T, td TestData, method, path, token, body string, code int, resp interface{}) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	td.Router.ServeHTTP(w, req)
+
+	if w.Code != code {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", code, method, path, w.Code, w.Body.String())
+	}
+	if resp != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatalf("Failed to deserialize the response: %s", err.Error())
+		}
+	}
+}
+
+// setupTable creates a deck with two players, alice and bob. Returns the deck and the players.
+func setupTable(t *testing.T, td TestData) (*CreateDeckResponse, *PlayerResponse, *PlayerResponse) {
+	deck := &CreateDeckResponse{}
+	call(t, td, "POST", "/v1/deck", "", "", http.StatusCreated, deck)
+	if deck.DealerToken == "" {
+		t.Fatal("Expected the dealer token of the new deck.")
+	}
+
+	path := "/v1/deck/" + deck.DeckID + "/players"
+	alice := &PlayerResponse{}
+	call(t, td, "POST", path, deck.DealerToken, `{"name": "alice"}`, http.StatusCreated, alice)
+	bob := &PlayerResponse{}
+	call(t, td, "POST", path, deck.DealerToken, `{"name": "bob"}`, http.StatusCreated, bob)
+	if alice.Token == "" || bob.Token == "" || alice.Token == bob.Token {
+		t.Fatalf("Expected a token for each player, but got: %+v, %+v", alice, bob)
+	}
+
+	return deck, alice, bob
+}
+
+func TestAddPlayer(t *testing.T) {
+	td := setupTest(t)
+	deck, alice, _ := setupTable(t, td)
+	path := "/v1/deck/" + deck.DeckID + "/players"
+
+	call(t, td, "POST", path, "", `{"name": "carol"}`, http.StatusForbidden, nil)
+	call(t, td, "POST", path, alice.Token, `{"name": "carol"}`, http.StatusForbidden, nil)
+	call(t, td, "POST", path, "no-such-token", `{"name": "carol"}`, http.StatusUnauthorized, nil)
+	call(t, td, "POST", path, deck.DealerToken, `{}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", "/v1/deck/no-such-deck/players", deck.DealerToken, `{"name": "carol"}`, http.StatusNotFound, nil)
+
+	// the administrator is the dealer of every deck, including the decks created without a dealer
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/players", testAdminToken, `{"name": "carol"}`, http.StatusCreated, nil)
+
+	players := &PlayersResponse{}
+	call(t, td, "GET", path, "", "", http.StatusOK, players)
+	if len(players.Players) != 2 || players.Players[0].Name != "alice" || players.Players[1].Token != "" {
+		t.Errorf("Expected alice and bob without the tokens, but got: %+v", players.Players)
+	}
+}
+
+func TestDealCards_HiddenHands(t *testing.T) {
+	td := setupTest(t)
+	deck, alice, bob := setupTable(t, td)
+	deckPath := "/v1/deck/" + deck.DeckID
+	alicePath := deckPath + "/players/" + alice.PlayerID
+
+	call(t, td, "POST", alicePath+"/draw?count=5", deck.DealerToken, "", http.StatusOK, alice)
+	if alice.CardsInHand != 5 || !compare(alice.Cards, "AC,2C,3C,4C,5C") {
+		t.Errorf("Expected the dealer to deal 5 cards to alice, but got: %+v", alice)
+	}
+	player := &PlayerResponse{}
+	call(t, td, "POST", deckPath+"/players/"+bob.PlayerID+"/draw?count=2", bob.Token, "", http.StatusOK, player)
+	if player.CardsInHand != 2 || len(player.Cards) != 2 {
+		t.Errorf("Expected bob to draw 2 cards, but got: %+v", player)
+	}
+
+	call(t, td, "POST", alicePath+"/draw", bob.Token, "", http.StatusForbidden, nil)
+	call(t, td, "POST", alicePath+"/draw", "", "", http.StatusForbidden, nil)
+	call(t, td, "POST", alicePath+"/draw?count=x", alice.Token, "", http.StatusBadRequest, nil)
+	call(t, td, "POST", alicePath+"/draw?count=50", alice.Token, "", http.StatusBadRequest, nil)
+
+	// bob sees only the own hand, and not the cards in the deck
+	player = &PlayerResponse{}
+	call(t, td, "GET", alicePath, bob.Token, "", http.StatusOK, player)
+	if player.CardsInHand != 5 || len(player.Cards) != 0 {
+		t.Errorf("Expected the hand of alice hidden from bob, but got: %+v", player)
+	}
+	opened := &OpenDeckResponse{}
+	call(t, td, "GET", deckPath, bob.Token, "", http.StatusOK, opened)
+	if opened.Remaining != 45 || len(opened.Cards) != 0 || len(opened.Players) != 2 {
+		t.Fatalf("Expected the cards in the deck hidden from bob, but got: %+v", opened)
+	}
+	if len(opened.Players[0].Cards) != 0 || len(opened.Players[1].Cards) != 2 {
+		t.Errorf("Expected bob to see only the own hand, but got: %+v", opened.Players)
+	}
+
+	opened = &OpenDeckResponse{}
+	call(t, td, "GET", deckPath, "", "", http.StatusOK, opened)
+	if len(opened.Cards) != 0 || len(opened.Players[0].Cards) != 0 || len(opened.Players[1].Cards) != 0 {
+		t.Errorf("Expected all of the cards hidden without a token, but got: %+v", opened)
+	}
+
+	opened = &OpenDeckResponse{}
+	call(t, td, "GET", deckPath, deck.DealerToken, "", http.StatusOK, opened)
+	if len(opened.Cards) != 45 || len(opened.Players[0].Cards) != 5 || len(opened.Players[1].Cards) != 2 {
+		t.Errorf("Expected the dealer to see all of the cards, but got: %+v", opened)
+	}
+
+	call(t, td, "GET", deckPath, "no-such-token", "", http.StatusUnauthorized, nil)
+
+	// only the dealer may draw from, shuffle or export a deck with players
+	call(t, td, "POST", deckPath+"/draw", alice.Token, "", http.StatusForbidden, nil)
+	call(t, td, "POST", deckPath+"/shuffle", "", "", http.StatusForbidden, nil)
+	call(t, td, "GET", deckPath+"/export", bob.Token, "", http.StatusForbidden, nil)
+	call(t, td, "POST", deckPath+"/draw", deck.DealerToken, "", http.StatusOK, nil)
+	call(t, td, "POST", deckPath+"/shuffle", testAdminToken, "", http.StatusOK, nil)
+
+	batch := &BatchResponse{}
+	call(t, td, "POST", "/v1/deck/batch", alice.Token, `{"operations": [{"op": "draw", "deck_id": "`+deck.DeckID+`"}]}`,
+		http.StatusOK, batch)
+	if batch.Results[0].Status != http.StatusForbidden {
+		t.Errorf("Expected a batch draw to be forbidden to alice, but got: %+v", batch.Results[0])
+	}
+}
+
+func TestPlayCards(t *testing.T) {
+	td := setupTest(t)
+	deck, alice, bob := setupTable(t, td)
+	deckPath := "/v1/deck/" + deck.DeckID
+	alicePath := deckPath + "/players/" + alice.PlayerID
+
+	call(t, td, "POST", alicePath+"/draw?count=3", deck.DealerToken, "", http.StatusOK, nil)
+
+	pile := &PileResponse{}
+	call(t, td, "POST", alicePath+"/play", alice.Token, `{"pile": "discard", "cards": "2c,AC"}`, http.StatusOK, pile)
+	if pile.Name != "discard" || !compare(pile.Cards, "2C,AC") {
+		t.Errorf("Expected the ace on top of the two of clubs, but got: %+v", pile)
+	}
+
+	call(t, td, "POST", alicePath+"/play", alice.Token, `{"pile": "discard", "cards": "AC"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", alicePath+"/play", alice.Token, `{"pile": "discard", "cards": "3C,3C"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", alicePath+"/play", alice.Token, `{"pile": "discard"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", alicePath+"/play", bob.Token, `{"pile": "discard", "cards": "3C"}`, http.StatusForbidden, nil)
+
+	// the piles are public
+	piles := &PilesResponse{}
+	call(t, td, "GET", deckPath+"/piles", "", "", http.StatusOK, piles)
+	if len(piles.Piles) != 1 || !compare(piles.Piles[0].Cards, "2C,AC") {
+		t.Errorf("Expected the discard pile, but got: %+v", piles)
+	}
+	opened := &OpenDeckResponse{}
+	call(t, td, "GET", deckPath, bob.Token, "", http.StatusOK, opened)
+	if len(opened.Piles) != 1 || opened.Players[0].CardsInHand != 1 {
+		t.Errorf("Expected bob to see the discard pile and the last card of alice, but got: %+v", opened)
+	}
+}
+
+func TestNewPrivateDeck(t *testing.T) {
+	td := setupTest(t)
+	deck, dealerToken, err := td.DeckService.NewPrivateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards("AS,KS,QS")})
+	if err != nil {
+		t.Fatalf("Failed to create a private deck: %s", err.Error())
+	}
+	path := "/v1/deck/" + deck.ID
+
+	// hidden from everyone but the dealer, even without players
+	opened := &OpenDeckResponse{}
+	call(t, td, "GET", path, "", "", http.StatusOK, opened)
+	if opened.Remaining != 3 || opened.Cards != nil {
+		t.Errorf("Expected the cards of the private deck to be hidden, but got: %+v", opened)
+	}
+	call(t, td, "GET", path, dealerToken, "", http.StatusOK, opened)
+	if len(opened.Cards) != 3 {
+		t.Errorf("Expected the dealer to see the cards, but got: %+v", opened)
+	}
+
+	call(t, td, "POST", path+"/draw?count=1", "", "", http.StatusForbidden, nil)
+	call(t, td, "POST", path+"/shuffle", "", "", http.StatusForbidden, nil)
+	drawn := &DrawCardsResponse{}
+	call(t, td, "POST", path+"/draw?count=1", dealerToken, "", http.StatusOK, drawn)
+	if len(drawn.Cards) != 1 || drawn.Cards[0].Code != "AS" {
+		t.Errorf("Expected the dealer to draw from the deck, but got: %+v", drawn)
+	}
+}
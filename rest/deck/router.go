@@ -2,9 +2,34 @@ package deck
 
 import "github.com/gin-gonic/gin"
 
-// SetupDeckServiceRouting sets up the routing for DeckService with gin router.
+// SetupDeckServiceRouting sets up the routing for DeckService with gin router. The webhook endpoints are routed only if
+// the DeckService has Webhooks.
 func SetupDeckServiceRouting(group *gin.RouterGroup, deckService *DeckService) {
 	group.POST("/deck", deckService.CreateDeck)
 	group.GET("/deck/:deckId", deckService.OpenDeck)
 	group.POST("/deck/:deckId/draw", deckService.DrawCards)
+	group.POST("/deck/:deckId/shuffle", deckService.ShuffleDeck)
+	group.POST("/deck/batch", deckService.Batch)
+	group.GET("/deck/:deckId/export", deckService.ExportDeck)
+	group.POST("/deck/import", deckService.ImportDecks)
+	group.POST("/deck/:deckId/players", deckService.AddPlayer)
+	group.GET("/deck/:deckId/players", deckService.ListPlayers)
+	group.GET("/deck/:deckId/players/:playerId", deckService.GetPlayer)
+	group.POST("/deck/:deckId/players/:playerId/draw", deckService.DealCards)
+	group.POST("/deck/:deckId/players/:playerId/play", deckService.PlayCards)
+	group.GET("/deck/:deckId/piles", deckService.ListPiles)
+	group.GET("/deck/:deckId/ws", deckService.Watch)
+	group.GET("/deck/:deckId/events", deckService.Stream)
+
+	if deckService.Webhooks == nil {
+		return
+	}
+	group.POST("/deck/:deckId/webhooks", deckService.CreateWebhook)
+	group.GET("/deck/:deckId/webhooks", deckService.ListWebhooks)
+	group.DELETE("/deck/:deckId/webhooks/:webhookId", deckService.DeleteWebhook)
+	group.POST("/webhooks", deckService.CreateGlobalWebhook)
+	group.GET("/webhooks", deckService.ListGlobalWebhooks)
+	group.DELETE("/webhooks/:webhookId", deckService.DeleteGlobalWebhook)
+	group.GET("/webhooks/dead-letters", deckService.ListDeadDeliveries)
+	group.POST("/webhooks/dead-letters/:deliveryId/retry", deckService.RetryDelivery)
 }
@@ -0,0 +1,81 @@
+package deck
+
+import (
+	"sync"
+
+	"github.com/natemago/card-games-api/events"
+)
+
+// eventSequence publishes the events of every deck in the order of the changes of the deck, without holding a lock
+// across the transactions of the changes. The changes of different decks are not ordered against each other.
+//
+// Every event takes a ticket of its deck within the transaction of its change, once the change is made. A change of a
+// deck holds the rows it changed until the commit, so a later change of the same rows takes its ticket only after the
+// earlier change is committed: the tickets of a deck follow the order of the commits, as do the IDs of the events in
+// the event log. Once the transaction ends, the events are published in the order of their tickets; the tickets of a
+// rolled back change are skipped.
+type eventSequence struct {
+	mu    sync.Mutex
+	decks map[string]*deckSequence
+}
+
+// deckSequence holds the tickets of a deck that are taken, but not published yet.
+type deckSequence struct {
+	// taken is the last ticket taken.
+	taken uint64
+
+	// published is the last ticket published or skipped.
+	published uint64
+
+	// done holds the events of the tickets whose transaction has ended, but that wait for an earlier ticket. A nil
+	// event skips the ticket.
+	done map[uint64]*events.Event
+}
+
+// ticket is the place of an event in the sequence of its deck.
+type ticket struct {
+	deckID string
+	number uint64
+}
+
+// take takes the next ticket of the deck.
+func (s *eventSequence) take(deckID string) ticket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.decks == nil {
+		s.decks = map[string]*deckSequence{}
+	}
+	deck := s.decks[deckID]
+	if deck == nil {
+		deck = &deckSequence{done: map[uint64]*events.Event{}}
+		s.decks[deckID] = deck
+	}
+	deck.taken++
+	return ticket{deckID: deckID, number: deck.taken}
+}
+
+// done ends the ticket with its event, or with nil if the change was rolled back, and publishes to the hub the events
+// of the deck that are no longer waiting for an earlier ticket. Publishing to the hub never blocks.
+func (s *eventSequence) done(t ticket, event *events.Event, hub *events.Hub) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deck := s.decks[t.deckID]
+	deck.done[t.number] = event
+	for {
+		next, ok := deck.done[deck.published+1]
+		if !ok {
+			break
+		}
+		delete(deck.done, deck.published+1)
+		deck.published++
+		if next != nil {
+			hub.Publish(next)
+		}
+	}
+
+	if deck.published == deck.taken {
+		delete(s.decks, t.deckID)
+	}
+}
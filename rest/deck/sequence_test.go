@@ -0,0 +1,40 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/events"
+)
+
+func TestEventSequence(t *testing.T) {
+	hub := events.NewHub(events.DefaultBuffer)
+	subscription := hub.Subscribe("deck-1")
+	defer subscription.Close()
+
+	sequence := &eventSequence{}
+	first := sequence.take("deck-1")
+	second := sequence.take("deck-1")
+	rolledBack := sequence.take("deck-1")
+	fourth := sequence.take("deck-1")
+	other := sequence.take("deck-2")
+
+	// The transactions end out of order: the events wait for the earlier tickets of their deck only.
+	sequence.done(fourth, &events.Event{ID: 4, DeckID: "deck-1"}, hub)
+	sequence.done(second, &events.Event{ID: 2, DeckID: "deck-1"}, hub)
+	sequence.done(other, &events.Event{ID: 5, DeckID: "deck-2"}, hub)
+	if len(subscription.Events) != 0 {
+		t.Fatal("Expected the events to wait for the first change of the deck.")
+	}
+
+	sequence.done(first, &events.Event{ID: 1, DeckID: "deck-1"}, hub)
+	sequence.done(rolledBack, nil, hub)
+
+	for _, id := range []uint64{1, 2, 4} {
+		if event := <-subscription.Events; event.ID != id {
+			t.Errorf("Expected the event %d, but got: %+v", id, event)
+		}
+	}
+	if len(sequence.decks) != 0 {
+		t.Errorf("Expected no tickets left, but got: %+v", sequence.decks)
+	}
+}
@@ -0,0 +1,145 @@
+package deck
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/events"
+)
+
+// streamReplayPage is the number of logged events read at once, when a client resumes an event stream.
+const streamReplayPage = 100
+
+// Stream streams the events of a deck (see events.Event) as Server-Sent Events, for the clients that cannot use the
+// WebSocket (see Watch). Every event is sent with its ID in the event log of the deck. A client that reconnects with
+// the Last-Event-ID header gets the events it missed first.
+// Accepts one path parameter: deckId - the ID of the deck. The token may be given in the token query parameter, for
+// clients that cannot set the Authorization header.
+// If the deck does not exist, generates a 404 error response. For an invalid token, generates a 401 error response.
+// If the Last-Event-ID is not a valid ID, generates a 400 error response. If there are too many open event streams,
+// generates a 503 error response.
+func (d *DeckService) Stream(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	if _, err := d.authorize(ctx, deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var lastEventID uint64
+	if lastEventIDHeader := strings.TrimSpace(ctx.GetHeader("Last-Event-ID")); lastEventIDHeader != "" {
+		var err error
+		if lastEventID, err = strconv.ParseUint(lastEventIDHeader, 10, 64); err != nil {
+			ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid Last-Event-ID: %s", lastEventIDHeader), err))
+			return
+		}
+	}
+
+	if !d.openStream() {
+		ctx.Error(errors.UnavailableError("too many open event streams, please retry later", nil))
+		return
+	}
+
+	// subscribe before reading the event log, so no event is missed in between
+	subscription := d.Hub.Subscribe(deckID)
+	defer subscription.Close()
+	defer d.closeStream()
+
+	ctx.Header("Content-Type", sse.ContentType)
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	if lastEventID > 0 && d.EventLog != nil {
+		for {
+			logged, err := d.EventLog.ListEvents(deckID, lastEventID, streamReplayPage)
+			if err != nil {
+				// the stream has started, the client may resume later
+				log.Printf("Failed to read the event log of the deck %s: %s", deckID, err.Error())
+				return
+			}
+			for _, event := range logged {
+				if err := writeStreamEvent(ctx, toEvent(event)); err != nil {
+					return
+				}
+				lastEventID = event.ID
+			}
+			if len(logged) < streamReplayPage {
+				break
+			}
+		}
+	}
+	ctx.Writer.Flush()
+
+	var heartbeat <-chan time.Time
+	if d.Heartbeat > 0 {
+		ticker := time.NewTicker(d.Heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// too slow to receive the events, the client may resume from the last event it got
+				return
+			}
+			if event.ID != 0 && event.ID <= lastEventID {
+				// already sent from the event log
+				continue
+			}
+			if err := writeStreamEvent(ctx, event); err != nil {
+				return
+			}
+		case <-heartbeat:
+			if _, err := fmt.Fprint(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-ctx.Request.Context().Done():
+			return
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// openStream counts a new open event stream. Returns false if there are too many open event streams.
+func (d *DeckService) openStream() bool {
+	d.streamsLock.Lock()
+	defer d.streamsLock.Unlock()
+	if d.MaxStreams > 0 && d.streams >= d.MaxStreams {
+		return false
+	}
+	d.streams++
+	return true
+}
+
+// closeStream counts a closed event stream.
+func (d *DeckService) closeStream() {
+	d.streamsLock.Lock()
+	defer d.streamsLock.Unlock()
+	d.streams--
+}
+
+// writeStreamEvent writes the event as a Server-Sent Event, named after the type of the event.
+func writeStreamEvent(ctx *gin.Context, event *events.Event) error {
+	streamEvent := sse.Event{
+		Event: event.Type,
+		Data:  event,
+	}
+	if event.ID != 0 {
+		streamEvent.Id = strconv.FormatUint(event.ID, 10)
+	}
+	return sse.Encode(ctx.Writer, streamEvent)
+}
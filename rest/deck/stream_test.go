@@ -0,0 +1,199 @@
+package deck
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/natemago/card-games-api/events"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// streamEvent is a Server-Sent Event, or a heartbeat comment if the name is empty.
+type streamEvent struct {
+	ID    string
+	Name  string
+	Event events.Event
+}
+
+type eventStream struct {
+	resp    *http.Response
+	cancel  context.CancelFunc
+	scanner *bufio.Scanner
+}
+
+func openStream(t *testing.T, server *httptest.Server, deckID, lastEventID string, code int) *eventStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/v1/deck/"+deckID+"/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("Failed to open the event stream: %s", err.Error())
+	}
+	if resp.StatusCode != code {
+		cancel()
+		resp.Body.Close()
+		t.Fatalf("Expected status %d, but got %d.", code, resp.StatusCode)
+	}
+	return &eventStream{resp: resp, cancel: cancel, scanner: bufio.NewScanner(resp.Body)}
+}
+
+func (s *eventStream) Close() {
+	s.cancel()
+	s.resp.Body.Close()
+}
+
+// next reads the next event or heartbeat from the stream, failing the test if none comes in time.
+func (s *eventStream) next(t *testing.T) *streamEvent {
+	result := make(chan *streamEvent, 1)
+	go func() {
+		event := &streamEvent{}
+		for s.scanner.Scan() {
+			line := s.scanner.Text()
+			switch {
+			case line == "":
+				result <- event
+				return
+			case strings.HasPrefix(line, ":"):
+				continue
+			case strings.HasPrefix(line, "id:"):
+				event.ID = strings.TrimPrefix(line, "id:")
+			case strings.HasPrefix(line, "event:"):
+				event.Name = strings.TrimPrefix(line, "event:")
+			case strings.HasPrefix(line, "data:"):
+				json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &event.Event)
+			}
+		}
+		close(result)
+	}()
+
+	select {
+	case event, ok := <-result:
+		if !ok {
+			t.Fatal("The event stream was closed.")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for an event.")
+	}
+	return nil
+}
+
+func TestStream(t *testing.T) {
+	td := setupTest(t)
+	server := httptest.NewServer(td.Router)
+	defer server.Close()
+
+	stream := openStream(t, server, td.FullDeckID, "", http.StatusOK)
+	if ct := stream.resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected an event stream, but got: %s", ct)
+	}
+	waitForSubscribers(t, td.DeckService.Hub, td.FullDeckID, 1)
+
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/draw?count=2", "", "", http.StatusOK, nil)
+	drawn := stream.next(t)
+	if drawn.Name != events.Drawn || drawn.ID == "" || drawn.Event.Remaining != 50 || strings.Join(drawn.Event.Cards, ",") != "AC,2C" {
+		t.Errorf("Expected the drawn event, but got: %+v", drawn)
+	}
+	stream.Close()
+	waitForSubscribers(t, td.DeckService.Hub, td.FullDeckID, 0)
+
+	// the changes made while disconnected are sent on resume, then the new ones
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/shuffle", "", "", http.StatusOK, nil)
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/draw", "", "", http.StatusOK, nil)
+
+	stream = openStream(t, server, td.FullDeckID, drawn.ID, http.StatusOK)
+	defer stream.Close()
+	waitForSubscribers(t, td.DeckService.Hub, td.FullDeckID, 1)
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/draw", "", "", http.StatusOK, nil)
+
+	names := []string{}
+	remaining := []int{}
+	for i := 0; i < 3; i++ {
+		event := stream.next(t)
+		names = append(names, event.Name)
+		remaining = append(remaining, event.Event.Remaining)
+	}
+	if strings.Join(names, ",") != "shuffled,drawn,drawn" || remaining[1] != 49 || remaining[2] != 48 {
+		t.Errorf("Expected the missed events and then the new one, but got: %v %v", names, remaining)
+	}
+
+	openStream(t, server, td.FullDeckID, "abc", http.StatusBadRequest).Close()
+	openStream(t, server, "no-such-deck", "", http.StatusNotFound).Close()
+}
+
+func TestStream_Limits(t *testing.T) {
+	td := setupTest(t)
+	td.DeckService.Heartbeat = 10 * time.Millisecond
+	td.DeckService.MaxStreams = 1
+	server := httptest.NewServer(td.Router)
+	defer server.Close()
+
+	stream := openStream(t, server, td.PartialDeckID, "", http.StatusOK)
+	if heartbeat := stream.next(t); heartbeat.Name != "" {
+		t.Errorf("Expected a heartbeat, but got: %+v", heartbeat)
+	}
+
+	openStream(t, server, td.FullDeckID, "", http.StatusServiceUnavailable).Close()
+
+	stream.Close()
+	waitForSubscribers(t, td.DeckService.Hub, td.PartialDeckID, 0)
+	openStream(t, server, td.FullDeckID, "", http.StatusOK).Close()
+}
+
+func TestEventLog_ConcurrentDraws(t *testing.T) {
+	td := setupTest(t)
+
+	// SQLite locks a whole table on write, failing the concurrent writers instead of waiting like a row lock of
+	// PostgreSQL, so the transactions are made to wait for the single connection.
+	dbConfig := testConfig.DBConfig
+	dbConfig.MaxOpenConns = 1
+	db, err := repositories.OpenDatabase(&dbConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	service := NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	service.EventLog = deck_repo.NewDBEventRepository(db)
+
+	subscription := service.Hub.Subscribe(td.FullDeckID)
+	defer subscription.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.Draw("", td.FullDeckID, 1); err != nil {
+				t.Errorf("Failed to draw a card: %s", err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	logged, err := service.EventLog.ListEvents(td.FullDeckID, 0, 20)
+	if err != nil {
+		t.Fatalf("Failed to list the events: %s", err.Error())
+	}
+	if len(logged) != 10 {
+		t.Fatalf("Expected 10 logged events, but got %d.", len(logged))
+	}
+	for i, event := range logged {
+		if event.Remaining != 51-i {
+			t.Errorf("Expected event %d to have %d remaining cards, but got %d.", i, 51-i, event.Remaining)
+		}
+		if published := <-subscription.Events; published.ID != event.ID {
+			t.Errorf("Expected the events to be published in the order of the event log, but got %d for %d.",
+				published.ID, event.ID)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package deck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/natemago/card-games-api/webhooks"
+)
+
+// CreateWebhook subscribes a webhook to the events of a deck. Only the dealer of the deck may create webhooks.
+// Accepts one path parameter: deckId - the ID of the deck, and a CreateWebhookRequest JSON body.
+// Returns the webhook with the secret key the payloads are signed with, shown only once.
+// If the deck does not exist, generates a 404 error response. Without the token of the dealer, generates a 403 error
+// response. If the URL or the event types are not valid, or the URL is not on a public address, generates a 400 error
+// response.
+func (d *DeckService) CreateWebhook(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if err := d.checkWebhookDealer(ctx, deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.createWebhook(ctx, deckID)
+}
+
+// ListWebhooks lists the webhooks of a deck, in the order they were created. Only the dealer of the deck may list
+// the webhooks.
+// Accepts one path parameter: deckId - the ID of the deck.
+// If the deck does not exist, generates a 404 error response. Without the token of the dealer, generates a 403 error
+// response.
+func (d *DeckService) ListWebhooks(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if err := d.checkWebhookDealer(ctx, deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.listWebhooks(ctx, deckID)
+}
+
+// DeleteWebhook deletes a webhook of a deck, with its events that were not delivered yet. Only the dealer of the
+// deck may delete the webhooks.
+// Accepts two path parameters: deckId - the ID of the deck, and webhookId - the ID of the webhook.
+// If the deck or the webhook of the deck does not exist, generates a 404 error response. Without the token of the
+// dealer, generates a 403 error response.
+func (d *DeckService) DeleteWebhook(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if err := d.checkWebhookDealer(ctx, deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.deleteWebhook(ctx, deckID)
+}
+
+// CreateGlobalWebhook subscribes a webhook to the events of every deck. Only the administrator may create global
+// webhooks.
+// Accepts a CreateWebhookRequest JSON body.
+// Returns the webhook with the secret key the payloads are signed with, shown only once.
+// Without the token of the administrator, generates a 401 error response. If the URL or the event types are not valid,
+// or the URL is not on a public address, generates a 400 error response.
+func (d *DeckService) CreateGlobalWebhook(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.createWebhook(ctx, "")
+}
+
+// ListGlobalWebhooks lists the global webhooks, in the order they were created. Only the administrator may list the
+// global webhooks.
+// Without the token of the administrator, generates a 401 error response.
+func (d *DeckService) ListGlobalWebhooks(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.listWebhooks(ctx, "")
+}
+
+// DeleteGlobalWebhook deletes a global webhook, with its events that were not delivered yet. Only the administrator
+// may delete the global webhooks.
+// Accepts one path parameter: webhookId - the ID of the webhook.
+// If the global webhook does not exist, generates a 404 error response. Without the token of the administrator,
+// generates a 401 error response.
+func (d *DeckService) DeleteGlobalWebhook(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+	d.deleteWebhook(ctx, "")
+}
+
+// ListDeadDeliveries lists the deliveries of the events to the webhooks that were given up on after too many failed
+// attempts (the dead letters), in the order of the events. Only the administrator may list the dead letters.
+// Without the token of the administrator, generates a 401 error response.
+func (d *DeckService) ListDeadDeliveries(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	deliveries, err := d.Webhooks.ListDeadDeliveries()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &DeliveriesResponse{
+		Deliveries: []DeliveryResponse{},
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, toDeliveryResponse(delivery))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RetryDelivery schedules a delivery that was given up on for a new round of attempts. Only the administrator may
+// retry the dead letters.
+// Accepts one path parameter: deliveryId - the ID of the delivery.
+// If the delivery does not exist, generates a 404 error response. If the delivery was not given up on, generates a
+// 400 error response. Without the token of the administrator, generates a 401 error response.
+func (d *DeckService) RetryDelivery(ctx *gin.Context) {
+	if err := d.checkAdmin(ctx); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	deliveryID, err := strconv.ParseUint(ctx.Param("deliveryId"), 10, 64)
+	if err != nil {
+		ctx.Error(errors.NotFoundError("no such delivery", err))
+		return
+	}
+	delivery, err := d.Webhooks.RetryDelivery(deliveryID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toDeliveryResponse(delivery))
+}
+
+func (d *DeckService) createWebhook(ctx *gin.Context, deckID string) {
+	request := &CreateWebhookRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid webhook: %s", err.Error()), err))
+		return
+	}
+	if err := validateWebhook(request, d.AllowPrivateWebhooks); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	secret, err := deck_repo.NewToken()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	webhook, err := d.Webhooks.CreateWebhook(&deck_repo.Webhook{
+		DeckID: deckID,
+		URL:    request.URL,
+		Secret: secret,
+		Events: strings.Join(request.Events, ","),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := toWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	ctx.JSON(http.StatusCreated, response)
+}
+
+func (d *DeckService) listWebhooks(ctx *gin.Context, deckID string) {
+	webhooks, err := d.Webhooks.ListWebhooks(deckID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &WebhooksResponse{
+		Webhooks: []WebhookResponse{},
+	}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, toWebhookResponse(webhook))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// deleteWebhook deletes the webhook, if it subscribes to the deck (or is global, for an empty deckID).
+func (d *DeckService) deleteWebhook(ctx *gin.Context, deckID string) {
+	webhook, err := d.Webhooks.GetWebhook(ctx.Param("webhookId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if webhook.DeckID != deckID {
+		ctx.Error(errors.NotFoundError("no such webhook", nil))
+		return
+	}
+
+	if err := d.Webhooks.DeleteWebhook(webhook.ID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkWebhookDealer checks that the deck exists and that the request is made by the dealer of the deck.
+func (d *DeckService) checkWebhookDealer(ctx *gin.Context, deckID string) error {
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		return err
+	}
+	v, err := d.authorize(ctx, deckID)
+	if err != nil {
+		return err
+	}
+	if !v.dealer {
+		return errors.ForbiddenError(fmt.Sprintf("only the dealer may manage the webhooks of the deck %s", deckID), nil)
+	}
+	return nil
+}
+
+// checkAdmin checks that the request is made by the administrator. Returns an UnauthorizedError otherwise.
+func (d *DeckService) checkAdmin(ctx *gin.Context) error {
+	if !d.isAdmin(RequestToken(ctx)) {
+		return errors.UnauthorizedError("the token of the administrator is required", nil)
+	}
+	return nil
+}
+
+// validateWebhook validates the URL and the events of the webhook. Unless allowPrivate is set, the host of the URL
+// must resolve to public addresses only.
+func validateWebhook(request *CreateWebhookRequest, allowPrivate bool) error {
+	webhookURL, err := url.Parse(request.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Hostname() == "" {
+		return errors.ValidationError(fmt.Sprintf("invalid webhook url: %s", request.URL), err)
+	}
+	if !allowPrivate {
+		if err := webhooks.CheckHost(webhookURL.Hostname()); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range request.Events {
+		supported := false
+		for _, webhookEvent := range deck_repo.WebhookEvents {
+			supported = supported || event == webhookEvent
+		}
+		if !supported {
+			return errors.ValidationError(fmt.Sprintf("unsupported webhook event: %s", event), nil)
+		}
+	}
+	return nil
+}
+
+func toWebhookResponse(webhook *deck_repo.Webhook) WebhookResponse {
+	events := []string{}
+	if webhook.Events != "" {
+		events = strings.Split(webhook.Events, ",")
+	}
+	return WebhookResponse{
+		WebhookID: webhook.ID,
+		DeckID:    webhook.DeckID,
+		URL:       webhook.URL,
+		Events:    events,
+	}
+}
+
+func toDeliveryResponse(delivery *deck_repo.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		DeckID:     delivery.DeckID,
+		Event:      delivery.Event,
+		CreatedAt:  delivery.CreatedAt,
+		Attempts:   delivery.Attempts,
+		Dead:       delivery.Dead,
+		LastError:  delivery.LastError,
+		Payload:    json.RawMessage(delivery.Payload),
+	}
+}
@@ -0,0 +1,156 @@
+package deck
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/natemago/card-games-api/config"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"github.com/natemago/card-games-api/webhooks"
+)
+
+func TestWebhooks(t *testing.T) {
+	td := setupTest(t)
+	deck := &CreateDeckResponse{}
+	call(t, td, "POST", "/v1/deck", "", "", http.StatusCreated, deck)
+	path := "/v1/deck/" + deck.DeckID + "/webhooks"
+
+	webhook := &WebhookResponse{}
+	call(t, td, "POST", path, deck.DealerToken, `{"url": "http://localhost/hook", "events": ["deck.emptied"]}`,
+		http.StatusCreated, webhook)
+	if webhook.WebhookID == "" || webhook.Secret == "" || webhook.DeckID != deck.DeckID || len(webhook.Events) != 1 {
+		t.Errorf("Expected the webhook of the deck with its secret, but got: %+v", webhook)
+	}
+
+	call(t, td, "POST", path, "", `{"url": "http://localhost/hook"}`, http.StatusForbidden, nil)
+	call(t, td, "POST", path, deck.DealerToken, `{"url": "ftp://localhost/hook"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", path, deck.DealerToken, `{"url": "http://localhost/hook", "events": ["deck.burned"]}`,
+		http.StatusBadRequest, nil)
+	call(t, td, "POST", "/v1/deck/no-such-deck/webhooks", testAdminToken, `{"url": "http://localhost/hook"}`,
+		http.StatusNotFound, nil)
+
+	// only public addresses, unless the private addresses are allowed
+	td.DeckService.AllowPrivateWebhooks = false
+	call(t, td, "POST", path, deck.DealerToken, `{"url": "http://127.0.0.1/hook"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", path, deck.DealerToken, `{"url": "http://169.254.169.254/latest"}`, http.StatusBadRequest, nil)
+	call(t, td, "POST", "/v1/webhooks", testAdminToken, `{"url": "http://10.0.0.1/global"}`, http.StatusBadRequest, nil)
+	td.DeckService.AllowPrivateWebhooks = true
+
+	webhooks := &WebhooksResponse{}
+	call(t, td, "GET", path, deck.DealerToken, "", http.StatusOK, webhooks)
+	if len(webhooks.Webhooks) != 1 || webhooks.Webhooks[0].WebhookID != webhook.WebhookID || webhooks.Webhooks[0].Secret != "" {
+		t.Errorf("Expected the webhook without its secret, but got: %+v", webhooks.Webhooks)
+	}
+
+	// a deck webhook is not a global one, and the other way around
+	call(t, td, "DELETE", "/v1/webhooks/"+webhook.WebhookID, testAdminToken, "", http.StatusNotFound, nil)
+	global := &WebhookResponse{}
+	call(t, td, "POST", "/v1/webhooks", "", `{"url": "http://localhost/global"}`, http.StatusUnauthorized, nil)
+	call(t, td, "POST", "/v1/webhooks", deck.DealerToken, `{"url": "http://localhost/global"}`, http.StatusUnauthorized, nil)
+	call(t, td, "POST", "/v1/webhooks", testAdminToken, `{"url": "http://localhost/global"}`, http.StatusCreated, global)
+	call(t, td, "DELETE", path+"/"+global.WebhookID, deck.DealerToken, "", http.StatusNotFound, nil)
+
+	call(t, td, "GET", "/v1/webhooks", testAdminToken, "", http.StatusOK, webhooks)
+	if len(webhooks.Webhooks) != 1 || webhooks.Webhooks[0].WebhookID != global.WebhookID || len(webhooks.Webhooks[0].Events) != 0 {
+		t.Errorf("Expected the global webhook, subscribed to every event, but got: %+v", webhooks.Webhooks)
+	}
+
+	call(t, td, "DELETE", "/v1/webhooks/"+global.WebhookID, testAdminToken, "", http.StatusNoContent, nil)
+	call(t, td, "DELETE", path+"/"+webhook.WebhookID, deck.DealerToken, "", http.StatusNoContent, nil)
+	call(t, td, "GET", path, deck.DealerToken, "", http.StatusOK, webhooks)
+	if len(webhooks.Webhooks) != 0 {
+		t.Errorf("Expected no webhooks left, but got: %+v", webhooks.Webhooks)
+	}
+}
+
+// webhookReceiver is a webhook that records the signed payloads it receives.
+type webhookReceiver struct {
+	mutex    sync.Mutex
+	secret   string
+	payloads []string
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	if req.Header.Get(webhooks.SignatureHeader) != "sha256="+deck_repo.SignPayload(r.secret, body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.payloads = append(r.payloads, string(body))
+}
+
+func TestWebhooks_Delivery(t *testing.T) {
+	td := setupTest(t)
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	worker := webhooks.NewWorker(td.DeckService.Webhooks, &config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 1, AllowPrivateAddresses: true})
+
+	deck := &CreateDeckResponse{}
+	call(t, td, "POST", "/v1/deck?cards=AS,KS", "", "", http.StatusCreated, deck)
+	webhook := &WebhookResponse{}
+	call(t, td, "POST", "/v1/deck/"+deck.DeckID+"/webhooks", deck.DealerToken, `{"url": "`+server.URL+`"}`,
+		http.StatusCreated, webhook)
+	receiver.secret = webhook.Secret
+
+	call(t, td, "POST", "/v1/deck/"+deck.DeckID+"/shuffle", deck.DealerToken, "", http.StatusOK, nil)
+	call(t, td, "POST", "/v1/deck/"+deck.DeckID+"/draw?count=2", deck.DealerToken, "", http.StatusOK, nil)
+	if _, err := worker.DeliverPending(); err != nil {
+		t.Fatalf("Failed to deliver the events: %s", err.Error())
+	}
+
+	if len(receiver.payloads) != 2 || !strings.Contains(receiver.payloads[0], `"event":"deck.shuffled"`) ||
+		!strings.Contains(receiver.payloads[1], `"event":"deck.emptied"`) {
+		t.Errorf("Expected the shuffled and emptied events, but got: %v", receiver.payloads)
+	}
+}
+
+func TestWebhooks_DeadLetters(t *testing.T) {
+	td := setupTest(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	worker := webhooks.NewWorker(td.DeckService.Webhooks, &config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 1, AllowPrivateAddresses: true})
+
+	webhook := &WebhookResponse{}
+	call(t, td, "POST", "/v1/deck/"+td.PartialDeckID+"/webhooks", testAdminToken, `{"url": "`+server.URL+`"}`,
+		http.StatusCreated, webhook)
+	call(t, td, "POST", "/v1/deck/"+td.PartialDeckID+"/shuffle", "", "", http.StatusOK, nil)
+	worker.DeliverPending()
+
+	call(t, td, "GET", "/v1/webhooks/dead-letters", "", "", http.StatusUnauthorized, nil)
+	deliveries := &DeliveriesResponse{}
+	call(t, td, "GET", "/v1/webhooks/dead-letters", testAdminToken, "", http.StatusOK, deliveries)
+	var dead *DeliveryResponse
+	for i, delivery := range deliveries.Deliveries {
+		if delivery.WebhookID == webhook.WebhookID {
+			dead = &deliveries.Deliveries[i]
+		}
+	}
+	if dead == nil || !dead.Dead || dead.Attempts != 1 || dead.Event != deck_repo.WebhookDeckShuffled ||
+		!strings.Contains(dead.LastError, "503") || !strings.Contains(string(dead.Payload), td.PartialDeckID) {
+		t.Fatalf("Expected the dead delivery of the shuffled event, but got: %+v", dead)
+	}
+
+	retried := &DeliveryResponse{}
+	path := "/v1/webhooks/dead-letters/" + strconv.FormatUint(dead.DeliveryID, 10) + "/retry"
+	call(t, td, "POST", path, testAdminToken, "", http.StatusOK, retried)
+	if retried.Dead || retried.Attempts != 0 {
+		t.Errorf("Expected the delivery to be pending again, but got: %+v", retried)
+	}
+	call(t, td, "POST", path, testAdminToken, "", http.StatusBadRequest, nil)
+	call(t, td, "POST", "/v1/webhooks/dead-letters/abc/retry", testAdminToken, "", http.StatusNotFound, nil)
+
+	call(t, td, "DELETE", "/v1/deck/"+td.PartialDeckID+"/webhooks/"+webhook.WebhookID, testAdminToken, "",
+		http.StatusNoContent, nil)
+}
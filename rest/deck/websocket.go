@@ -0,0 +1,184 @@
+package deck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/natemago/card-games-api/errors"
+)
+
+const (
+	// socketPongWait is the time to wait for a message or a pong from a WebSocket client before the connection is
+	// considered dead.
+	socketPongWait = 60 * time.Second
+
+	// socketPingInterval is the interval to ping a WebSocket client. Must be less than socketPongWait.
+	socketPingInterval = socketPongWait * 9 / 10
+
+	// socketWriteWait is the time allowed to write a message to a WebSocket client.
+	socketWriteWait = 10 * time.Second
+
+	// socketMaxMessageSize is the maximal size of a message from a WebSocket client, in bytes.
+	socketMaxMessageSize = 1024
+)
+
+// upgrader upgrades the HTTP connections to WebSockets. The API does not use cookies, so connections from any origin
+// are accepted.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// Watch upgrades the connection to a WebSocket that streams the events of a deck (see events.Event) as JSON messages,
+// as soon as the changes are committed. The client may send commands over the same socket (see SocketCommand), to
+// draw cards from or to shuffle the deck, with the same permissions as the REST calls. The result of a command is sent
+// only to the client that sent it (see SocketResult).
+// Accepts one path parameter: deckId - the ID of the deck. The token may be given in the token query parameter, for
+// clients that cannot set the Authorization header.
+// If the deck does not exist, generates a 404 error response. For an invalid token, generates a 401 error response.
+func (d *DeckService) Watch(ctx *gin.Context) {
+	deckID := ctx.Param("deckId")
+	if _, err := d.Repository.GetDeck(deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+	if _, err := d.authorize(ctx, deckID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// the upgrader has already responded with the error
+		return
+	}
+
+	subscription := d.Hub.Subscribe(deckID)
+	defer subscription.Close()
+
+	results := make(chan *SocketResult)
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.readCommands(ctx, conn, deckID, results, quit)
+	}()
+	defer func() {
+		close(quit)
+		conn.Close()
+		<-done
+	}()
+
+	ticker := time.NewTicker(socketPingInterval)
+	defer ticker.Stop()
+	for {
+		var message interface{}
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "too slow to receive the events"),
+					time.Now().Add(socketWriteWait))
+				return
+			}
+			message = event
+		case result := <-results:
+			message = result
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+			continue
+		case <-done:
+			return
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+		if err := conn.WriteJSON(message); err != nil {
+			return
+		}
+	}
+}
+
+// readCommands reads the commands from the WebSocket client, executes them and sends the results to be written to
+// the client. Returns once the connection is closed or quit is closed.
+func (d *DeckService) readCommands(ctx *gin.Context, conn *websocket.Conn, deckID string, results chan<- *SocketResult, quit <-chan struct{}) {
+	conn.SetReadLimit(socketMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(socketPongWait))
+
+		command := &SocketCommand{}
+		var result *SocketResult
+		if err := json.Unmarshal(data, command); err != nil {
+			result = socketResult(command, errors.BadRequestError(fmt.Sprintf("invalid command: %s", err.Error()), err))
+		} else {
+			result = d.executeCommand(ctx, deckID, command)
+		}
+
+		select {
+		case results <- result:
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (d *DeckService) executeCommand(ctx *gin.Context, deckID string, command *SocketCommand) *SocketResult {
+	switch command.Command {
+	case "draw":
+		count := command.Count
+		if count == 0 {
+			count = 1
+		}
+		if count < 1 {
+			return socketResult(command, errors.BadRequestError("invalid cards count number", nil))
+		}
+		drawn, err := d.Draw(RequestToken(ctx), deckID, count)
+		if err != nil {
+			return socketResult(command, err)
+		}
+		result := socketResult(command, nil)
+		result.Cards = ToCardResponses(drawn)
+		return result
+	case "shuffle":
+		deck, err := d.Shuffle(RequestToken(ctx), deckID)
+		if err != nil {
+			return socketResult(command, err)
+		}
+		result := socketResult(command, nil)
+		result.Remaining = &deck.Remaining
+		return result
+	default:
+		return socketResult(command, errors.BadRequestError(fmt.Sprintf("unsupported command: %s", command.Command), nil))
+	}
+}
+
+func socketResult(command *SocketCommand, err error) *SocketResult {
+	result := &SocketResult{
+		Type:    "result",
+		Command: command.Command,
+		Status:  http.StatusOK,
+	}
+	if err != nil {
+		result.Status = errors.StatusCode(err)
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package deck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/natemago/card-games-api/events"
+)
+
+// socketMessage holds the fields of both the events and the results sent over the WebSocket.
+type socketMessage struct {
+	events.Event
+	Command string `json:"command"`
+	Error   string `json:"error"`
+	Status  int    `json:"status"`
+}
+
+func dial(t *testing.T, server *httptest.Server, deckID, token string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/v1/deck/" + deckID + "/ws"
+	if token != "" {
+		url += "?token=" + token
+	}
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect to the WebSocket: %s (%v)", err.Error(), resp)
+	}
+	return conn
+}
+
+// receive reads the next message, failing the test if none comes in time.
+func receive(t *testing.T, conn *websocket.Conn) *socketMessage {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	message := &socketMessage{}
+	if err := conn.ReadJSON(message); err != nil {
+		t.Fatalf("Failed to receive a message: %s", err.Error())
+	}
+	return message
+}
+
+// waitForSubscribers waits until the deck has the number of subscribers, as the subscription is made after the upgrade.
+func waitForSubscribers(t *testing.T, hub *events.Hub, deckID string, subscribers int) {
+	for i := 0; i < 100 && hub.Subscribers(deckID) != subscribers; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if hub.Subscribers(deckID) != subscribers {
+		t.Fatalf("Expected %d subscribers, but got %d.", subscribers, hub.Subscribers(deckID))
+	}
+}
+
+func TestWatch(t *testing.T) {
+	td := setupTest(t)
+	server := httptest.NewServer(td.Router)
+	defer server.Close()
+
+	watcher := dial(t, server, td.FullDeckID, "")
+	defer watcher.Close()
+	other := dial(t, server, td.PartialDeckID, "")
+	defer other.Close()
+	waitForSubscribers(t, td.DeckService.Hub, td.FullDeckID, 1)
+
+	call(t, td, "POST", "/v1/deck/"+td.FullDeckID+"/draw?count=2", "", "", http.StatusOK, nil)
+	event := receive(t, watcher)
+	if event.Type != events.Drawn || event.Count != 2 || event.Remaining != 50 || strings.Join(event.Cards, ",") != "AC,2C" {
+		t.Errorf("Expected the drawn event, but got: %+v", event)
+	}
+
+	// a command gets a result for the sender, and an event for every watcher
+	if err := watcher.WriteJSON(&SocketCommand{Command: "shuffle"}); err != nil {
+		t.Fatalf("Failed to send the command: %s", err.Error())
+	}
+	types := map[string]*socketMessage{}
+	for i := 0; i < 2; i++ {
+		message := receive(t, watcher)
+		types[message.Type] = message
+	}
+	if result := types["result"]; result == nil || result.Command != "shuffle" || result.Status != http.StatusOK {
+		t.Errorf("Expected the result of the shuffle, but got: %+v", types)
+	}
+	if event := types[events.Shuffled]; event == nil || event.Remaining != 50 {
+		t.Errorf("Expected the shuffled event, but got: %+v", types)
+	}
+
+	for _, command := range []string{`{"command": "draw", "count": 100}`, `{"command": "fold"}`, `not json`} {
+		if err := watcher.WriteMessage(websocket.TextMessage, []byte(command)); err != nil {
+			t.Fatalf("Failed to send the command: %s", err.Error())
+		}
+		if result := receive(t, watcher); result.Type != "result" || result.Status != http.StatusBadRequest || result.Error == "" {
+			t.Errorf("Expected a failed result for %s, but got: %+v", command, result)
+		}
+	}
+
+	// the other deck had no events
+	other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, err := other.ReadMessage(); err == nil {
+		t.Error("Expected no events for the other deck.")
+	}
+
+	watcher.Close()
+	waitForSubscribers(t, td.DeckService.Hub, td.FullDeckID, 0)
+}
+
+func TestWatch_HiddenHands(t *testing.T) {
+	td := setupTest(t)
+	server := httptest.NewServer(td.Router)
+	defer server.Close()
+	deck, alice, bob := setupTable(t, td)
+
+	watcher := dial(t, server, deck.DeckID, bob.Token)
+	defer watcher.Close()
+	waitForSubscribers(t, td.DeckService.Hub, deck.DeckID, 1)
+
+	call(t, td, "POST", "/v1/deck/"+deck.DeckID+"/players/"+alice.PlayerID+"/draw?count=3", deck.DealerToken, "",
+		http.StatusOK, nil)
+	event := receive(t, watcher)
+	if event.Type != events.Drawn || event.PlayerID != alice.PlayerID || event.Count != 3 || len(event.Cards) != 0 {
+		t.Errorf("Expected the cards drawn by alice hidden, but got: %+v", event)
+	}
+
+	call(t, td, "POST", "/v1/deck/"+deck.DeckID+"/players/"+alice.PlayerID+"/play", alice.Token,
+		`{"pile": "discard", "cards": "AC"}`, http.StatusOK, nil)
+	event = receive(t, watcher)
+	if event.Type != events.PileMoved || event.Pile != "discard" || strings.Join(event.Cards, ",") != "AC" {
+		t.Errorf("Expected the played card shown, but got: %+v", event)
+	}
+
+	// bob may not draw from the deck over the socket either
+	if err := watcher.WriteJSON(&SocketCommand{Command: "draw"}); err != nil {
+		t.Fatalf("Failed to send the command: %s", err.Error())
+	}
+	if result := receive(t, watcher); result.Status != http.StatusForbidden {
+		t.Errorf("Expected the draw to be forbidden, but got: %+v", result)
+	}
+
+	if _, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/v1/deck/"+deck.DeckID+"/ws?token=x", nil); err == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected an invalid token to be rejected, but got: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package games
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// TypesResponse represents the response of a ListTypes call.
+type TypesResponse struct {
+	// Types holds the types of the supported games, sorted.
+	Types []string `json:"types"`
+}
+
+// CreateSessionRequest represents the request of a CreateSession call.
+type CreateSessionRequest struct {
+	// Players holds the names of the players, in the order of their seats.
+	Players []string `json:"players"`
+
+	// Options holds the options of the game, specific to the type of the game.
+	Options map[string]string `json:"options"`
+}
+
+// ActionRequest represents the request of an Act call. The action is made by the seat of the token of the request,
+// or is not made by a player without a token.
+type ActionRequest struct {
+	// Type is the type of the action, specific to the type of the game.
+	Type string `json:"type"`
+
+	// Cards is the comma-separated list of the codes of the cards of the action, if any.
+	Cards string `json:"cards,omitempty"`
+
+	// Params holds the other parameters of the action, by name.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// ActionResponse represents a legal action in a session.
+type ActionResponse struct {
+	// Seat is the seat of the acting player, or -1 for the actions that are not made by a player.
+	Seat int `json:"seat"`
+
+	// Type is the type of the action, specific to the type of the game.
+	Type string `json:"type"`
+
+	// Cards is the comma-separated list of the codes of the cards of the action, if any.
+	Cards string `json:"cards,omitempty"`
+
+	// Params holds the other parameters of the action, by name.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// PlayerResponse represents a player in a SessionResponse.
+type PlayerResponse struct {
+	// Seat is the position of the player.
+	Seat int `json:"seat"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// CardsInHand is the number of cards in the hand of the player.
+	CardsInHand int `json:"cards_in_hand"`
+
+	// Cards holds the cards in the hand of the player. Only the hand of the seat the session is viewed from is shown.
+	Cards []deck_api.CardResponse `json:"cards,omitempty"`
+
+	// Score is the score of the player.
+	Score int `json:"score"`
+
+	// Token is the secret token of the player, to view the session from the seat of the player and to act. It is
+	// shown only once, when the session is created.
+	Token string `json:"token,omitempty"`
+}
+
+// DeckResponse represents a deck owned by a session.
+type DeckResponse struct {
+	// Name is the name of the deck in the session, like "stock".
+	Name string `json:"name"`
+
+	// Remaining is the number of cards left in the deck.
+	Remaining int `json:"remaining"`
+}
+
+// SessionResponse represents the state of a session of a game, viewed from a seat.
+type SessionResponse struct {
+	// SessionID is the ID of the session.
+	SessionID string `json:"session_id"`
+
+	// Type is the type of the game.
+	Type string `json:"type"`
+
+	// Turn is the seat of the player to act, if any.
+	Turn *int `json:"turn,omitempty"`
+
+	// Actions is the number of actions applied in the session.
+	Actions int `json:"actions"`
+
+	// Finished is set once the game is over.
+	Finished bool `json:"finished"`
+
+	// Players holds the players, ordered by seat.
+	Players []PlayerResponse `json:"players"`
+
+	// Decks holds the decks owned by the session, ordered by name.
+	Decks []DeckResponse `json:"decks"`
+
+	// State is the state of the game visible from the seat, specific to the type of the game.
+	State interface{} `json:"state,omitempty"`
+
+	// LegalActions holds the actions the seat may make.
+	LegalActions []ActionResponse `json:"legal_actions"`
+}
@@ -0,0 +1,12 @@
+package games
+
+import "github.com/gin-gonic/gin"
+
+// SetupSessionServiceRouting sets up the routing for SessionService with gin router.
+func SetupSessionServiceRouting(group *gin.RouterGroup, sessionService *SessionService) {
+	group.GET("/games", sessionService.ListTypes)
+	group.POST("/games/:type/sessions", sessionService.CreateSession)
+	group.GET("/games/:type/sessions/:sessionId", sessionService.GetSession)
+	group.GET("/games/:type/sessions/:sessionId/actions", sessionService.GetActions)
+	group.POST("/games/:type/sessions/:sessionId/actions", sessionService.Act)
+}
@@ -0,0 +1,225 @@
+package games
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/games"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// SessionService represents the REST API service for the sessions of the turn-based games. The requests are
+// dispatched to the rule engine registered for the type of the game in the path (see games.Register).
+// Uses the SessionRepository to store the sessions, and the DeckRepository for the decks owned by the sessions.
+type SessionService struct {
+	Sessions games_repo.SessionRepository
+	Decks    deck_repo.DeckRepository
+}
+
+// ListTypes returns the types of the supported games.
+func (s *SessionService) ListTypes(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &TypesResponse{
+		Types: games.Types(),
+	})
+}
+
+// CreateSession creates a new session of the game in the path, for the players given with a CreateSessionRequest
+// JSON body. Every player gets a secret token, shown only once, to view the session from the seat of the player and
+// to act. The session is returned as viewed by no player.
+// If the game is not supported, returns a 404 Not Found error response. If the number of players does not match
+// the game or an option is not valid, returns a 400 Bad Request error response.
+func (s *SessionService) CreateSession(ctx *gin.Context) {
+	request := &CreateSessionRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	session, err := games.NewSession(strings.ToLower(ctx.Param("type")), request.Players, request.Options, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tokens := []string{}
+	for _, player := range session.Players {
+		token, err := deck_repo.NewToken()
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		player.TokenHash = deck_repo.HashToken(token)
+		tokens = append(tokens, token)
+	}
+
+	session, err = s.Sessions.CreateSession(session)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	s.respond(ctx, http.StatusCreated, session, games.Dealer, tokens)
+}
+
+// GetSession looks up a session by its ID and returns its state, viewed from the seat of the token of the request:
+// only the hand of that seat is shown. Without a token, the session is viewed by no player.
+// If the session does not exist, returns a 404 Not Found error response. If the token is not the token of a player
+// of the session, returns a 401 Unauthorized error response.
+func (s *SessionService) GetSession(ctx *gin.Context) {
+	session, seat, err := s.getSession(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	s.respond(ctx, http.StatusOK, session, seat, nil)
+}
+
+// GetActions returns the legal actions of the seat of the token of the request, or the actions not made by a
+// player without a token.
+// If the session does not exist, returns a 404 Not Found error response. If the token is not the token of a player
+// of the session, returns a 401 Unauthorized error response.
+func (s *SessionService) GetActions(ctx *gin.Context) {
+	session, seat, err := s.getSession(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toActionResponses(games.LegalActions(session, seat)))
+}
+
+// Act applies the action given as an ActionRequest JSON body, made by the seat of the token of the request, or not
+// made by a player without a token. The session is returned as viewed from the acting seat.
+// If the session does not exist, returns a 404 Not Found error response. If the token is not the token of a player
+// of the session, returns a 401 Unauthorized error response. If the game is over or the action is not legal,
+// returns a 400 Bad Request error response.
+func (s *SessionService) Act(ctx *gin.Context) {
+	request := &ActionRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	session, seat, err := s.getSession(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = games.Act(session, &games.Action{
+		Seat:   seat,
+		Type:   strings.ToLower(request.Type),
+		Cards:  strings.ToUpper(request.Cards),
+		Params: request.Params,
+	}, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	session, err = s.Sessions.UpdateSession(session)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	s.respond(ctx, http.StatusOK, session, seat, nil)
+}
+
+// getSession looks up the session from the path, and the seat of the token of the request (see
+// deck_api.RequestSeat), or games.Dealer without a token. A session of another game than the one in the path is not
+// found.
+func (s *SessionService) getSession(ctx *gin.Context) (*games_repo.Session, int, error) {
+	session, err := s.Sessions.GetSession(ctx.Param("sessionId"))
+	if err != nil {
+		return nil, games.Dealer, err
+	}
+	if session.Type != strings.ToLower(ctx.Param("type")) {
+		return nil, games.Dealer, errors.NotFoundError("no such session", nil)
+	}
+
+	seat, err := deck_api.RequestSeat(ctx, session.TokenHashes())
+	if err != nil {
+		return nil, games.Dealer, err
+	}
+	if seat == deck_api.NoSeat {
+		seat = games.Dealer
+	}
+	return session, seat, nil
+}
+
+// respond writes the session viewed from the seat, with the remaining cards in its decks, and the tokens of the
+// players by seat, if given.
+func (s *SessionService) respond(ctx *gin.Context, code int, session *games_repo.Session, seat int, tokens []string) {
+	response := &SessionResponse{
+		SessionID:    session.ID,
+		Type:         session.Type,
+		Actions:      session.Actions,
+		Finished:     session.Finished,
+		Players:      []PlayerResponse{},
+		Decks:        []DeckResponse{},
+		State:        games.View(session, seat),
+		LegalActions: toActionResponses(games.LegalActions(session, seat)),
+	}
+	if session.Turn != games_repo.NoTurn {
+		turn := session.Turn
+		response.Turn = &turn
+	}
+
+	for _, player := range session.Players {
+		playerResponse := PlayerResponse{
+			Seat:        player.Seat,
+			Name:        player.Name,
+			CardsInHand: len(player.HandCards()),
+			Score:       player.Score,
+		}
+		if player.Seat == seat {
+			playerResponse.Cards = deck_api.ToCardResponses(player.HandCards())
+		}
+		if player.Seat < len(tokens) {
+			playerResponse.Token = tokens[player.Seat]
+		}
+		response.Players = append(response.Players, playerResponse)
+	}
+
+	for _, sessionDeck := range session.Decks {
+		deck, err := s.Decks.GetDeck(sessionDeck.DeckID)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		response.Decks = append(response.Decks, DeckResponse{
+			Name:      sessionDeck.Name,
+			Remaining: deck.Remaining,
+		})
+	}
+
+	ctx.JSON(code, response)
+}
+
+func toActionResponses(actions []*games.Action) []ActionResponse {
+	response := []ActionResponse{}
+	for _, action := range actions {
+		response = append(response, ActionResponse{
+			Seat:   action.Seat,
+			Type:   action.Type,
+			Cards:  action.Cards,
+			Params: action.Params,
+		})
+	}
+	return response
+}
+
+// NewSessionService creates a new pointer to a SessionService using the given repositories.
+func NewSessionService(sessionRepository games_repo.SessionRepository, deckRepository deck_repo.DeckRepository) *SessionService {
+	return &SessionService{
+		Sessions: sessionRepository,
+		Decks:    deckRepository,
+	}
+}
@@ -0,0 +1,253 @@
+package games
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/games"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	games_repo "github.com/natemago/card-games-api/repositories/games"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+// drawTwo is a test game: the players draw two cards each in turn from a new shuffled deck, then the game is over.
+type drawTwo struct{}
+
+func (e *drawTwo) Type() string {
+	return "draw-two"
+}
+
+func (e *drawTwo) Players() (int, int) {
+	return 2, 2
+}
+
+func (e *drawTwo) Setup(session *games_repo.Session, options map[string]string, decks deck_repo.DeckRepository) error {
+	_, err := games.CreateDeck(session, "stock", &deck_repo.Deck{Shuffled: true}, decks)
+	session.Turn = 0
+	return err
+}
+
+func (e *drawTwo) LegalActions(session *games_repo.Session, seat int) []*games.Action {
+	if seat != session.Turn {
+		return []*games.Action{}
+	}
+	return []*games.Action{{Seat: seat, Type: "draw"}}
+}
+
+func (e *drawTwo) Apply(session *games_repo.Session, action *games.Action, decks deck_repo.DeckRepository) error {
+	cards, err := decks.DrawCards(session.DeckID("stock"), 2)
+	if err != nil {
+		return err
+	}
+	session.Player(action.Seat).SetHand(cards)
+	session.Turn = (session.Turn + 1) % len(session.Players)
+	return nil
+}
+
+func (e *drawTwo) IsFinished(session *games_repo.Session) bool {
+	return session.Actions == len(session.Players)
+}
+
+func (e *drawTwo) Scores(session *games_repo.Session) []int {
+	scores := []int{}
+	for _, player := range session.Players {
+		scores = append(scores, len(player.HandCards()))
+	}
+	return scores
+}
+
+func (e *drawTwo) View(session *games_repo.Session, seat int) interface{} {
+	return map[string]int{"viewer": seat}
+}
+
+func init() {
+	games.Register(&drawTwo{})
+}
+
+func setupTest(t *testing.T) *gin.Engine {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupSessionServiceRouting(router.Group("/v1"),
+		NewSessionService(games_repo.NewDBSessionRepository(db), deck_repo.NewDBDeckRepository(db)))
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, token, body string, expectedCode int, resp interface{}) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if resp == nil {
+		return
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the response, but got error: %s", err.Error())
+	}
+}
+
+func TestListTypes(t *testing.T) {
+	router := setupTest(t)
+
+	resp := &TypesResponse{}
+	call(t, router, "GET", "/v1/games", "", "", http.StatusOK, resp)
+	found := false
+	for _, gameType := range resp.Types {
+		found = found || gameType == "draw-two"
+	}
+	if !found {
+		t.Errorf("Expected the registered game in the types, but got: %v", resp.Types)
+	}
+}
+
+func TestSession(t *testing.T) {
+	router := setupTest(t)
+
+	session := &SessionResponse{}
+	call(t, router, "POST", "/v1/games/draw-two/sessions", "", `{"players": ["alice", "bob"]}`, http.StatusCreated, session)
+	if session.SessionID == "" || session.Type != "draw-two" || session.Turn == nil || *session.Turn != 0 {
+		t.Fatalf("Expected a new session with the first seat to act, but got: %+v", session)
+	}
+	if len(session.Decks) != 1 || session.Decks[0].Name != "stock" || session.Decks[0].Remaining != 52 {
+		t.Errorf("Expected a full stock, but got: %+v", session.Decks)
+	}
+	alice, bob := session.Players[0].Token, session.Players[1].Token
+	if alice == "" || bob == "" || alice == bob {
+		t.Fatalf("Expected a token for every player, but got: %+v", session.Players)
+	}
+	path := "/v1/games/draw-two/sessions/" + session.SessionID
+
+	actions := []ActionResponse{}
+	call(t, router, "GET", path+"/actions", alice, "", http.StatusOK, &actions)
+	if len(actions) != 1 || actions[0].Type != "draw" || actions[0].Seat != 0 {
+		t.Errorf("Expected seat 0 to draw, but got: %+v", actions)
+	}
+	call(t, router, "GET", path+"/actions", bob, "", http.StatusOK, &actions)
+	if len(actions) != 0 {
+		t.Errorf("Expected no actions out of turn, but got: %+v", actions)
+	}
+
+	// bob may not draw for alice by giving the seat 0 in the body
+	call(t, router, "POST", path+"/actions", bob, `{"seat": 0, "type": "draw"}`, http.StatusBadRequest, nil)
+	call(t, router, "POST", path+"/actions", "", `{"type": "draw"}`, http.StatusBadRequest, nil)
+	session = &SessionResponse{}
+	call(t, router, "POST", path+"/actions", alice, `{"type": "draw"}`, http.StatusOK, session)
+	if len(session.Players[0].Cards) != 2 || session.Players[1].Cards != nil || session.Decks[0].Remaining != 50 {
+		t.Errorf("Expected only the hand of the acting seat, but got: %+v", session.Players)
+	}
+	if session.Players[0].Token != "" || session.Players[1].Token != "" {
+		t.Errorf("Expected the tokens to be shown only once, but got: %+v", session.Players)
+	}
+
+	session = &SessionResponse{}
+	call(t, router, "GET", path+"?seat=0", bob, "", http.StatusOK, session)
+	if session.Players[0].Cards != nil || session.Players[0].CardsInHand != 2 || len(session.LegalActions) != 1 {
+		t.Errorf("Expected the session viewed from seat 1, but got: %+v", session)
+	}
+	if state, ok := session.State.(map[string]interface{}); !ok || state["viewer"] != 1.0 {
+		t.Errorf("Expected the state viewed from seat 1, but got: %+v", session.State)
+	}
+
+	session = &SessionResponse{}
+	call(t, router, "POST", path+"/actions", bob, `{"type": "draw"}`, http.StatusOK, session)
+	if !session.Finished || session.Turn != nil || session.Players[0].Score != 2 || session.Players[1].Score != 2 {
+		t.Errorf("Expected the session to be finished and scored, but got: %+v", session)
+	}
+	call(t, router, "POST", path+"/actions", alice, `{"type": "draw"}`, http.StatusBadRequest, nil)
+}
+
+func TestSession_Invalid(t *testing.T) {
+	router := setupTest(t)
+
+	call(t, router, "POST", "/v1/games/missing/sessions", "", `{"players": ["alice", "bob"]}`, http.StatusNotFound, nil)
+	call(t, router, "POST", "/v1/games/draw-two/sessions", "", `{"players": ["alice"]}`, http.StatusBadRequest, nil)
+	call(t, router, "GET", "/v1/games/draw-two/sessions/missing", "", "", http.StatusNotFound, nil)
+
+	session := &SessionResponse{}
+	call(t, router, "POST", "/v1/games/draw-two/sessions", "", `{"players": ["alice", "bob"]}`, http.StatusCreated, session)
+	call(t, router, "GET", "/v1/games/other/sessions/"+session.SessionID, "", "", http.StatusNotFound, nil)
+	call(t, router, "GET", "/v1/games/draw-two/sessions/"+session.SessionID, "invalid", "", http.StatusUnauthorized, nil)
+	call(t, router, "POST", "/v1/games/draw-two/sessions/"+session.SessionID+"/actions", "invalid", `{"type": "draw"}`,
+		http.StatusUnauthorized, nil)
+
+	// the token of a player of another session is not valid
+	other := &SessionResponse{}
+	call(t, router, "POST", "/v1/games/draw-two/sessions", "", `{"players": ["carol", "dave"]}`, http.StatusCreated, other)
+	call(t, router, "GET", "/v1/games/draw-two/sessions/"+session.SessionID, other.Players[0].Token, "",
+		http.StatusUnauthorized, nil)
+}
+
+func TestWar(t *testing.T) {
+	router := setupTest(t)
+
+	session := &SessionResponse{}
+	call(t, router, "POST", "/v1/games/war/sessions", "", `{"players": ["alice", "bob"], "options": {"seed": "42"}}`,
+		http.StatusCreated, session)
+	if session.Turn != nil || len(session.LegalActions) != 2 || session.Players[0].CardsInHand != 26 {
+		t.Fatalf("Expected a new game of War, but got: %+v", session)
+	}
+	path := "/v1/games/war/sessions/" + session.SessionID
+
+	call(t, router, "POST", path+"/actions", "", `{"type": "battle"}`, http.StatusOK, session)
+	if session.Actions != 1 || session.Players[0].Score+session.Players[1].Score != 52 {
+		t.Errorf("Expected a battle, but got: %+v", session)
+	}
+
+	session = &SessionResponse{}
+	call(t, router, "POST", path+"/actions", "", `{"type": "run"}`, http.StatusOK, session)
+	state, ok := session.State.(map[string]interface{})
+	if !session.Finished || !ok || state["seed"] != 42.0 || len(state["battles"].([]interface{})) == 0 {
+		t.Errorf("Expected the game to be over with the transcript, but got: %+v", session)
+	}
+}
+
+func TestGoFish(t *testing.T) {
+	router := setupTest(t)
+
+	session := &SessionResponse{}
+	call(t, router, "POST", "/v1/games/go-fish/sessions", "", `{"players": ["alice", "bob", "carol"]}`,
+		http.StatusCreated, session)
+	path := "/v1/games/go-fish/sessions/" + session.SessionID
+	bob := session.Players[1].Token
+
+	session = &SessionResponse{}
+	call(t, router, "GET", path, bob, "", http.StatusOK, session)
+	for _, player := range session.Players {
+		if (player.Seat == 1) != (len(player.Cards) > 0) || player.CardsInHand == 0 {
+			t.Errorf("Expected only the hand of bob to be shown, but got: %+v", player)
+		}
+	}
+	if len(session.LegalActions) != 0 {
+		t.Errorf("Expected no legal actions for bob, but got: %+v", session.LegalActions)
+	}
+
+	call(t, router, "POST", path+"/actions", bob, `{"type": "ask", "params": {"target": "0", "rank": "2"}}`,
+		http.StatusBadRequest, nil)
+}
@@ -0,0 +1,296 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_svcs "github.com/natemago/card-games-api/rest/deck"
+)
+
+// GraphQLService serves the decks over GraphQL, on top of the Deck Service of the REST API, so both APIs share the
+// same permissions and the events of the decks.
+type GraphQLService struct {
+	// Decks is the Deck Service of the REST API.
+	Decks *deck_svcs.DeckService
+
+	// MaxDepth is the maximal depth of the selections of a query. Zero means unlimited.
+	MaxDepth int
+
+	// MaxComplexity is the maximal number of fields selected by a query, with the fragments expanded. Zero means
+	// unlimited.
+	MaxComplexity int
+
+	schema gql.Schema
+}
+
+// tokenKey is the key of the token of the request in the context of the resolvers.
+type tokenKey struct{}
+
+// Query executes a GraphQL query or mutation. Accepts a GraphQLRequest JSON body. The token of the dealer, a player or
+// the administrator is given in the Authorization header as "Bearer <token>", as for the REST API.
+// If the query cannot be parsed, is invalid, or is deeper or more complex than allowed, generates a 400 error response
+// with the GraphQL errors. Otherwise responds with the data and the errors of the resolvers, with the code of each
+// error in the "code" extension, like NOT_FOUND (see errorCode).
+func (g *GraphQLService) Query(ctx *gin.Context) {
+	request := &GraphQLRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid GraphQL request: %s", err.Error()), err))
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(request.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, &gql.Result{
+			Errors: gqlerrors.FormatErrors(err),
+		})
+		return
+	}
+
+	validation := gql.ValidateDocument(&g.schema, document, nil)
+	if !validation.IsValid {
+		ctx.JSON(http.StatusBadRequest, &gql.Result{
+			Errors: validation.Errors,
+		})
+		return
+	}
+
+	if err := g.checkLimits(document, request.OperationName); err != nil {
+		ctx.JSON(http.StatusBadRequest, &gql.Result{
+			Errors: gqlerrors.FormatErrors(err),
+		})
+		return
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        g.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(ctx.Request.Context(), tokenKey{}, headerToken(ctx)),
+	})
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Limits of the introspection fields, like __schema. The introspection types are cyclic, so the introspection fields
+// could be nested without bound. They are limited apart from the rest of the query, to allow the introspection query
+// of the usual GraphQL tools (13 levels and about 200 fields) whatever the limits of the API are.
+const (
+	// introspectionMaxDepth is the maximal depth of the selections under an introspection field.
+	introspectionMaxDepth = 15
+
+	// introspectionMaxComplexity is the maximal number of introspection fields selected by a query.
+	introspectionMaxComplexity = 500
+)
+
+// checkLimits checks the depth and the complexity of the operation to execute against MaxDepth and MaxComplexity. The
+// introspection fields, like __schema, and all of the fields under them are checked against introspectionMaxDepth and
+// introspectionMaxComplexity instead.
+func (g *GraphQLService) checkLimits(document *ast.Document, operationName string) error {
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if operation == nil {
+		// Left for the executor to report.
+		return nil
+	}
+
+	m := &measure{
+		fragments: fragments,
+		measured:  map[fragmentKey]*cost{},
+	}
+	c := m.selections(operation.SelectionSet, false)
+
+	if g.MaxDepth > 0 && c.depth > g.MaxDepth {
+		return fmt.Errorf("the query is too deep: %d levels, at most %d are allowed", c.depth, g.MaxDepth)
+	}
+	if g.MaxComplexity > 0 && c.complexity > g.MaxComplexity {
+		return fmt.Errorf("the query is too complex: %d fields, at most %d are allowed", c.complexity, g.MaxComplexity)
+	}
+	if c.introspectionDepth > introspectionMaxDepth {
+		return fmt.Errorf("the introspection is too deep: %d levels, at most %d are allowed", c.introspectionDepth, introspectionMaxDepth)
+	}
+	if c.introspectionComplexity > introspectionMaxComplexity {
+		return fmt.Errorf("the introspection is too complex: %d fields, at most %d are allowed", c.introspectionComplexity, introspectionMaxComplexity)
+	}
+	return nil
+}
+
+// maxCost caps the complexity, so fragments spread many times over cannot overflow it.
+const maxCost = 1 << 30
+
+// cost is the depth and the complexity of a selection set, apart for the fields under an introspection field. The
+// depth of the introspection is counted from the introspection field.
+type cost struct {
+	depth      int
+	complexity int
+
+	introspectionDepth      int
+	introspectionComplexity int
+}
+
+// add adds the cost of a selection to the cost of its selection set.
+func (c *cost) add(selection *cost) {
+	if selection.depth > c.depth {
+		c.depth = selection.depth
+	}
+	if selection.introspectionDepth > c.introspectionDepth {
+		c.introspectionDepth = selection.introspectionDepth
+	}
+	c.complexity += selection.complexity
+	if c.complexity > maxCost {
+		c.complexity = maxCost
+	}
+	c.introspectionComplexity += selection.introspectionComplexity
+	if c.introspectionComplexity > maxCost {
+		c.introspectionComplexity = maxCost
+	}
+}
+
+// fragmentKey identifies a measured fragment: the same fragment costs differently under an introspection field.
+type fragmentKey struct {
+	name          string
+	introspection bool
+}
+
+// measure measures the cost of the selection sets of a query. The cost of every fragment is measured once, as it is
+// the same wherever the fragment is spread. The query must be valid, so the fragments have no cycles.
+type measure struct {
+	fragments map[string]*ast.FragmentDefinition
+	measured  map[fragmentKey]*cost
+}
+
+// selections measures the selection set. Under an introspection field, every field is counted as an introspection field.
+func (m *measure) selections(set *ast.SelectionSet, introspection bool) *cost {
+	total := &cost{}
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if introspection || strings.HasPrefix(selection.Name.Value, "__") {
+				c := m.selections(selection.SelectionSet, true)
+				c.introspectionDepth++
+				c.introspectionComplexity++
+				total.add(c)
+			} else {
+				c := m.selections(selection.SelectionSet, false)
+				c.depth++
+				c.complexity++
+				total.add(c)
+			}
+		case *ast.InlineFragment:
+			total.add(m.selections(selection.SelectionSet, introspection))
+		case *ast.FragmentSpread:
+			total.add(m.fragment(selection.Name.Value, introspection))
+		}
+	}
+	return total
+}
+
+func (m *measure) fragment(name string, introspection bool) *cost {
+	key := fragmentKey{name: name, introspection: introspection}
+	if c, ok := m.measured[key]; ok {
+		return c
+	}
+	c := &cost{}
+	if fragment, ok := m.fragments[name]; ok {
+		c = m.selections(fragment.SelectionSet, introspection)
+	}
+	m.measured[key] = c
+	return c
+}
+
+// errorCode returns the code of the given error for the "code" extension of a GraphQL error, the counterpart of
+// errors.StatusCode: BAD_USER_INPUT for BadRequestError and ValidationError, UNAUTHENTICATED for UnauthorizedError,
+// FORBIDDEN for ForbiddenError, NOT_FOUND for NotFoundError, UNAVAILABLE for UnavailableError and
+// INTERNAL_SERVER_ERROR for any other error.
+func errorCode(err error) string {
+	if errors.IsBadRequestError(err) || errors.IsValidationError(err) {
+		return "BAD_USER_INPUT"
+	}
+	if errors.IsUnauthorizedError(err) {
+		return "UNAUTHENTICATED"
+	}
+	if errors.IsForbiddenError(err) {
+		return "FORBIDDEN"
+	}
+	if errors.IsNotFoundError(err) {
+		return "NOT_FOUND"
+	}
+	if errors.IsUnavailableError(err) {
+		return "UNAVAILABLE"
+	}
+	return "INTERNAL_SERVER_ERROR"
+}
+
+// extendedError is an error of a resolver, with the code of the error in the "code" extension.
+type extendedError struct {
+	error
+}
+
+// Extensions returns the extensions of the error, shown in the GraphQL response.
+func (e *extendedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code": errorCode(e.error),
+	}
+}
+
+func resolverError(err error) error {
+	return &extendedError{err}
+}
+
+// headerToken returns the token given in the Authorization header as "Bearer <token>". Empty if there is no token.
+func headerToken(ctx *gin.Context) string {
+	return strings.TrimSpace(strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer "))
+}
+
+// requestToken returns the token of the request from the context of a resolver (see headerToken).
+func requestToken(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+// NewGraphQLService creates a new GraphQLService on top of the given Deck Service, with the given limits of the
+// depth and the complexity of the queries. Returns an error if the schema cannot be built.
+func NewGraphQLService(decks *deck_svcs.DeckService, maxDepth, maxComplexity int) (*GraphQLService, error) {
+	service := &GraphQLService{
+		Decks:         decks,
+		MaxDepth:      maxDepth,
+		MaxComplexity: maxComplexity,
+	}
+
+	schema, err := service.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	service.schema = schema
+
+	return service, nil
+}
@@ -0,0 +1,356 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_svcs "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+const testAdminToken = "admin-secret"
+
+type TestData struct {
+	Router         *gin.Engine
+	GraphQLService *GraphQLService
+}
+
+type testResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func setupTest(t *testing.T) TestData {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	decks := deck_svcs.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	decks.EventLog = deck_repo.NewDBEventRepository(db)
+	decks.AdminToken = testAdminToken
+	graphQLService, err := NewGraphQLService(decks, 5, 20)
+	if err != nil {
+		t.Fatalf("Failed to build the GraphQL service: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupGraphQLServiceRouting(router.Group("/v1"), graphQLService)
+
+	return TestData{
+		Router:         router,
+		GraphQLService: graphQLService,
+	}
+}
+
+func query(t *testing.T, td TestData, token, document string, variables map[string]interface{}) (int, *testResponse) {
+	body, _ := json.Marshal(&GraphQLRequest{
+		Query:     document,
+		Variables: variables,
+	})
+	req, _ := http.NewRequest("POST", "/v1/graphql", strings.NewReader(string(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	td.Router.ServeHTTP(w, req)
+
+	response := &testResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), response); err != nil {
+		t.Fatalf("Failed to parse the response %s: %s", w.Body.String(), err.Error())
+	}
+	return w.Code, response
+}
+
+func createDeck(t *testing.T, td TestData, cards []string) (string, string) {
+	status, response := query(t, td, "", `mutation ($cards: [String!]) {
+		createDeck(cards: $cards) { deck { id } dealerToken }
+	}`, map[string]interface{}{"cards": cards})
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Failed to create the deck: %d %v", status, response.Errors)
+	}
+	created := response.Data["createDeck"].(map[string]interface{})
+	return created["deck"].(map[string]interface{})["id"].(string), created["dealerToken"].(string)
+}
+
+func responseCode(response *testResponse) string {
+	if len(response.Errors) == 0 {
+		return ""
+	}
+	code, _ := response.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+func TestGraphQLService_Decks(t *testing.T) {
+	td := setupTest(t)
+
+	status, response := query(t, td, "", `mutation {
+		createDeck(shuffled: true, packs: 2) { deck { id shuffled remaining cardsHidden } dealerToken }
+	}`, nil)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Failed to create the deck: %d %v", status, response.Errors)
+	}
+	created := response.Data["createDeck"].(map[string]interface{})
+	deck := created["deck"].(map[string]interface{})
+	if deck["shuffled"] != true || deck["remaining"] != 104.0 || deck["cardsHidden"] != false || created["dealerToken"] == "" {
+		t.Errorf("Unexpected created deck: %v", created)
+	}
+
+	deckID, _ := createDeck(t, td, []string{"AS", "KD", "AC"})
+
+	_, response = query(t, td, "", `query ($id: ID!) { deck(id: $id) { remaining cards { code } } }`,
+		map[string]interface{}{"id": deckID})
+	deck = response.Data["deck"].(map[string]interface{})
+	if deck["remaining"] != 3.0 || len(deck["cards"].([]interface{})) != 3 {
+		t.Errorf("Unexpected deck: %v", deck)
+	}
+	if _, ok := deck["cards"].([]interface{})[0].(map[string]interface{})["suit"]; ok {
+		t.Error("Expected only the selected fields of the cards.")
+	}
+
+	_, response = query(t, td, "", `mutation ($id: ID!) { drawCards(deckId: $id, count: 2) { value suit code } }`,
+		map[string]interface{}{"id": deckID})
+	drawn := response.Data["drawCards"].([]interface{})
+	if len(drawn) != 2 {
+		t.Fatalf("Expected 2 drawn cards, got: %v", response)
+	}
+	if card := drawn[0].(map[string]interface{}); card["code"] != "AS" || card["value"] != "ACE" || card["suit"] != "SPADES" {
+		t.Errorf("Unexpected first drawn card: %v", card)
+	}
+
+	_, response = query(t, td, "", `mutation ($id: ID!) { shuffleDeck(deckId: $id) { shuffled remaining } }`,
+		map[string]interface{}{"id": deckID})
+	shuffled := response.Data["shuffleDeck"].(map[string]interface{})
+	if shuffled["shuffled"] != true || shuffled["remaining"] != 1.0 {
+		t.Errorf("Unexpected shuffled deck: %v", shuffled)
+	}
+}
+
+func TestGraphQLService_Errors(t *testing.T) {
+	td := setupTest(t)
+	deckID, _ := createDeck(t, td, []string{"AS"})
+
+	status, response := query(t, td, "", `{ deck(id: "no-such-deck") { id } }`, nil)
+	if status != http.StatusOK || response.Data["deck"] != nil || responseCode(response) != "NOT_FOUND" {
+		t.Errorf("Expected NOT_FOUND, got: %d %v", status, response)
+	}
+
+	_, response = query(t, td, "", `mutation { createDeck(cards: ["AS", "AS"]) { dealerToken } }`, nil)
+	if responseCode(response) != "BAD_USER_INPUT" {
+		t.Errorf("Expected BAD_USER_INPUT for duplicated cards, got: %v", response)
+	}
+
+	_, response = query(t, td, "", `mutation ($id: ID!) { drawCards(deckId: $id, count: 2) { code } }`,
+		map[string]interface{}{"id": deckID})
+	if responseCode(response) != "BAD_USER_INPUT" {
+		t.Errorf("Expected BAD_USER_INPUT for too many cards, got: %v", response)
+	}
+
+	_, response = query(t, td, "invalid", `query ($id: ID!) { deck(id: $id) { id } }`,
+		map[string]interface{}{"id": deckID})
+	if responseCode(response) != "UNAUTHENTICATED" {
+		t.Errorf("Expected UNAUTHENTICATED for an invalid token, got: %v", response)
+	}
+
+	status, _ = query(t, td, "", `{ deck(id: "x") { id `, nil)
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a query that cannot be parsed, got: %d", status)
+	}
+
+	status, response = query(t, td, "", `{ deck(id: "x") { size } }`, nil)
+	if status != http.StatusBadRequest || len(response.Errors) == 0 {
+		t.Errorf("Expected 400 for an invalid query, got: %d %v", status, response)
+	}
+}
+
+func TestGraphQLService_HiddenCardsAndPiles(t *testing.T) {
+	td := setupTest(t)
+	deckID, dealerToken := createDeck(t, td, []string{"AS", "KD", "AC", "2H"})
+	players := td.GraphQLService.Decks.Players
+
+	playerToken, err := deck_repo.NewToken()
+	if err != nil {
+		t.Fatalf("Failed to generate the token of the player: %s", err.Error())
+	}
+	player, err := players.CreatePlayer(&deck_repo.Player{
+		DeckID:    deckID,
+		Name:      "Sam",
+		TokenHash: deck_repo.HashToken(playerToken),
+	})
+	if err != nil {
+		t.Fatalf("Failed to add a player: %s", err.Error())
+	}
+	if _, err := players.MoveToPile(player, "discard", deck_repo.AsCards("QS,JH")); err != nil {
+		t.Fatalf("Failed to move the cards onto the pile: %s", err.Error())
+	}
+
+	document := `query ($id: ID!) { deck(id: $id) { cardsHidden cards { code } piles { name cards { code } } } }`
+	variables := map[string]interface{}{"id": deckID}
+
+	_, response := query(t, td, playerToken, document, variables)
+	deck := response.Data["deck"].(map[string]interface{})
+	if deck["cardsHidden"] != true || deck["cards"] != nil {
+		t.Errorf("Expected the cards to be hidden from the player, got: %v", deck)
+	}
+	piles := deck["piles"].([]interface{})
+	if len(piles) != 1 {
+		t.Fatalf("Expected one pile, got: %v", piles)
+	}
+	pile := piles[0].(map[string]interface{})
+	if pile["name"] != "discard" || len(pile["cards"].([]interface{})) != 2 {
+		t.Errorf("Unexpected pile: %v", pile)
+	}
+
+	for _, token := range []string{dealerToken, testAdminToken} {
+		_, response = query(t, td, token, document, variables)
+		deck = response.Data["deck"].(map[string]interface{})
+		if deck["cardsHidden"] != false || len(deck["cards"].([]interface{})) != 4 {
+			t.Errorf("Expected the cards to be shown to the dealer, got: %v", deck)
+		}
+	}
+
+	_, response = query(t, td, playerToken, `mutation ($id: ID!) { drawCards(deckId: $id) { code } }`, variables)
+	if responseCode(response) != "FORBIDDEN" {
+		t.Errorf("Expected FORBIDDEN for a draw by a player, got: %v", response)
+	}
+}
+
+func TestGraphQLService_Limits(t *testing.T) {
+	td := setupTest(t)
+	deckID, _ := createDeck(t, td, []string{"AS"})
+	variables := map[string]interface{}{"id": deckID}
+
+	// 4 levels and 7 fields
+	status, response := query(t, td, "", `query ($id: ID!) {
+		deck(id: $id) { ...pileCards cards { ...card } }
+	}
+	fragment pileCards on Deck { piles { cards { code } } }
+	fragment card on Card { code }`, variables)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Errorf("Expected the query to be within the limits, got: %d %v", status, response.Errors)
+	}
+
+	status, response = query(t, td, "", `mutation {
+		createDeck { deck { piles { cards { code } } } }
+	}`, nil)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Errorf("Expected 5 levels to be within the limits, got: %d %v", status, response.Errors)
+	}
+
+	status, response = query(t, td, "", `mutation {
+		createDeck { deck { ...piles } }
+	}
+	fragment piles on Deck { piles { cards { ... on Card { ...code } } } }
+	fragment code on Card { code }`, nil)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Errorf("Expected fragments not to add levels, got: %d %v", status, response.Errors)
+	}
+
+	status, response = query(t, td, "", `query ($id: ID!) {
+		a: deck(id: $id) { id } b: deck(id: $id) { id } c: deck(id: $id) { id } d: deck(id: $id) { id }
+		e: deck(id: $id) { id } f: deck(id: $id) { id } g: deck(id: $id) { id } h: deck(id: $id) { id }
+		i: deck(id: $id) { id } j: deck(id: $id) { id } k: deck(id: $id) { id }
+	}`, variables)
+	if status != http.StatusBadRequest || len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "too complex") {
+		t.Errorf("Expected 400 for too many aliases, got: %d %v", status, response)
+	}
+
+	td.GraphQLService.MaxDepth = 4
+	status, response = query(t, td, "", `mutation {
+		createDeck { deck { piles { cards { code } } } }
+	}`, nil)
+	if status != http.StatusBadRequest || len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "too deep") {
+		t.Errorf("Expected 400 for a query that is too deep, got: %d %v", status, response)
+	}
+
+	// the introspection is limited apart from the query
+	status, response = query(t, td, "", testutil.IntrospectionQuery, nil)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Errorf("Expected the introspection query of the tools to be allowed, got: %d %v", status, response.Errors)
+	}
+
+	nested := "name"
+	for i := 0; i < 8; i++ {
+		nested = "name fields { type { " + nested + " } }"
+	}
+	status, response = query(t, td, "", "{ __schema { types { "+nested+" } } }", nil)
+	if status != http.StatusBadRequest || len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "introspection is too deep") {
+		t.Errorf("Expected 400 for a nested introspection, got: %d %v", status, response)
+	}
+
+	aliases := ""
+	for i := 0; i < 100; i++ {
+		aliases += fmt.Sprintf("t%d: __type(name: \"Deck\") { name kind description fields { name } } ", i)
+	}
+	status, response = query(t, td, "", "{ "+aliases+"}", nil)
+	if status != http.StatusBadRequest || len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "introspection is too complex") {
+		t.Errorf("Expected 400 for too many introspection fields, got: %d %v", status, response)
+	}
+
+	status, response = query(t, td, "", `query ($id: ID!) { deck(id: $id) { __typename id } }`, variables)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Errorf("Expected __typename to be allowed, got: %d %v", status, response.Errors)
+	}
+}
+
+func TestGraphQLService_FragmentLimits(t *testing.T) {
+	td := setupTest(t)
+
+	// every fragment doubles the cost of the next one: 2^40 fields, measured without expanding the fragments
+	document := "query ($id: ID!) { deck(id: $id) { ...f0 } }\n"
+	for i := 0; i < 40; i++ {
+		document += fmt.Sprintf("fragment f%d on Deck { ...f%d ... on Deck { ...f%d } }\n", i, i+1, i+1)
+	}
+	document += "fragment f40 on Deck { id }\n"
+
+	status, response := query(t, td, "", document, map[string]interface{}{"id": "x"})
+	if status != http.StatusBadRequest || len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "too complex") {
+		t.Errorf("Expected 400 for the fragments that expand to too many fields, got: %d %v", status, response)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	if errorCode(errors.BadRequestError("bad parameter", nil)) != "BAD_USER_INPUT" {
+		t.Error("Expected BAD_USER_INPUT for a 'bad-request-error'.")
+	}
+	if errorCode(errors.ValidationError("invalid value", nil)) != "BAD_USER_INPUT" {
+		t.Error("Expected BAD_USER_INPUT for a 'validation-error'.")
+	}
+	if errorCode(errors.UnauthorizedError("invalid token", nil)) != "UNAUTHENTICATED" {
+		t.Error("Expected UNAUTHENTICATED for an 'unauthorized-error'.")
+	}
+	if errorCode(errors.ForbiddenError("not allowed", nil)) != "FORBIDDEN" {
+		t.Error("Expected FORBIDDEN for a 'forbidden-error'.")
+	}
+	if errorCode(errors.NotFoundError("record not found", nil)) != "NOT_FOUND" {
+		t.Error("Expected NOT_FOUND for a 'not-found-error'.")
+	}
+	if errorCode(errors.UnavailableError("try again later", nil)) != "UNAVAILABLE" {
+		t.Error("Expected UNAVAILABLE for an 'unavailable-error'.")
+	}
+	if errorCode(fmt.Errorf("generic-error")) != "INTERNAL_SERVER_ERROR" {
+		t.Error("Expected INTERNAL_SERVER_ERROR for a generic error.")
+	}
+}
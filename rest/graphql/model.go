@@ -0,0 +1,13 @@
+package graphql
+
+// GraphQLRequest represents the request for a Query call.
+type GraphQLRequest struct {
+	// Query is the GraphQL document with the query or the mutation to execute.
+	Query string `json:"query" binding:"required"`
+
+	// OperationName is the name of the operation to execute, if the document holds more than one operation.
+	OperationName string `json:"operationName"`
+
+	// Variables holds the values of the variables of the operation.
+	Variables map[string]interface{} `json:"variables"`
+}
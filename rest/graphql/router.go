@@ -0,0 +1,8 @@
+package graphql
+
+import "github.com/gin-gonic/gin"
+
+// SetupGraphQLServiceRouting sets up the routing for the GraphQL endpoint.
+func SetupGraphQLServiceRouting(group *gin.RouterGroup, graphQLService *GraphQLService) {
+	group.POST("/graphql", graphQLService.Query)
+}
@@ -0,0 +1,249 @@
+package graphql
+
+import (
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// deckView is a deck as seen by the holder of the token of the request.
+type deckView struct {
+	deck *deck_repo.Deck
+
+	// seesCards is false if the deck has players and the request is not made by the dealer.
+	seesCards bool
+}
+
+// createdDeck is the result of the createDeck mutation.
+type createdDeck struct {
+	deck        *deckView
+	dealerToken string
+}
+
+var cardType = gql.NewObject(gql.ObjectConfig{
+	Name:        "Card",
+	Description: "A card in a deck or in a pile.",
+	Fields: gql.Fields{
+		"value": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: `The card rank, like "ACE", "2", "10" or "QUEEN".`,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(*deck_repo.Card).RankName(), nil
+			},
+		},
+		"suit": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: `The card suit name, like "HEARTS".`,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(*deck_repo.Card).SuitName(), nil
+			},
+		},
+		"code": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: `The card code, like "AC" (Ace of Clubs).`,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(*deck_repo.Card).Value, nil
+			},
+		},
+	},
+})
+
+var pileType = gql.NewObject(gql.ObjectConfig{
+	Name:        "Pile",
+	Description: "A public pile of a deck.",
+	Fields: gql.Fields{
+		"name": &gql.Field{
+			Type: gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(*deck_repo.Pile).Name, nil
+			},
+		},
+		"cards": &gql.Field{
+			Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(cardType))),
+			Description: "The cards of the pile, with the top card last.",
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(*deck_repo.Pile).PileCards(), nil
+			},
+		},
+	},
+})
+
+// buildSchema builds the GraphQL schema of the decks, resolved with the Deck Service of the service.
+func (g *GraphQLService) buildSchema() (gql.Schema, error) {
+	deckType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Deck",
+		Description: "A deck of cards.",
+		Fields: gql.Fields{
+			"id": &gql.Field{
+				Type: gql.NewNonNull(gql.ID),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return p.Source.(*deckView).deck.ID, nil
+				},
+			},
+			"shuffled": &gql.Field{
+				Type: gql.NewNonNull(gql.Boolean),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return p.Source.(*deckView).deck.Shuffled, nil
+				},
+			},
+			"remaining": &gql.Field{
+				Type:        gql.NewNonNull(gql.Int),
+				Description: "The number of remaining cards in the deck.",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return p.Source.(*deckView).deck.Remaining, nil
+				},
+			},
+			"cardsHidden": &gql.Field{
+				Type:        gql.NewNonNull(gql.Boolean),
+				Description: "True if the deck has players and the request is not made by the dealer.",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return !p.Source.(*deckView).seesCards, nil
+				},
+			},
+			"cards": &gql.Field{
+				Type:        gql.NewList(gql.NewNonNull(cardType)),
+				Description: "The remaining cards in the deck, in order. Null if the cards are hidden.",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					view := p.Source.(*deckView)
+					if !view.seesCards {
+						return nil, nil
+					}
+					return view.deck.Cards, nil
+				},
+			},
+			"piles": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(pileType))),
+				Description: "The public piles of the deck, ordered by name.",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					piles, err := g.Decks.Players.ListPiles(p.Source.(*deckView).deck.ID)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return piles, nil
+				},
+			},
+		},
+	})
+
+	createdDeckType := gql.NewObject(gql.ObjectConfig{
+		Name: "CreatedDeck",
+		Fields: gql.Fields{
+			"deck": &gql.Field{
+				Type: gql.NewNonNull(deckType),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return p.Source.(*createdDeck).deck, nil
+				},
+			},
+			"dealerToken": &gql.Field{
+				Type:        gql.NewNonNull(gql.String),
+				Description: "The secret token of the dealer of the deck, shown only once.",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return p.Source.(*createdDeck).dealerToken, nil
+				},
+			},
+		},
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"deck": &gql.Field{
+				Type:        deckType,
+				Description: "Looks up a deck by its id.",
+				Args: gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return g.viewDeck(p, p.Args["id"].(string))
+				},
+			},
+		},
+	})
+
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createDeck": &gql.Field{
+				Type:        gql.NewNonNull(createdDeckType),
+				Description: "Creates a new deck of cards. Without cards, a full deck is created.",
+				Args: gql.FieldConfigArgument{
+					"shuffled": &gql.ArgumentConfig{Type: gql.Boolean, DefaultValue: false},
+					"cards":    &gql.ArgumentConfig{Type: gql.NewList(gql.NewNonNull(gql.String))},
+					"packs":    &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 1},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					var cards []*deck_repo.Card
+					if codes, ok := p.Args["cards"].([]interface{}); ok && len(codes) > 0 {
+						values := []string{}
+						for _, code := range codes {
+							values = append(values, code.(string))
+						}
+						cards = deck_repo.AsCards(strings.Join(values, ","))
+					}
+
+					deck, dealerToken, err := g.Decks.NewDeck(&deck_repo.Deck{
+						Shuffled: p.Args["shuffled"].(bool),
+						Cards:    cards,
+						Packs:    p.Args["packs"].(int),
+					})
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return &createdDeck{
+						deck:        &deckView{deck: deck, seesCards: true},
+						dealerToken: dealerToken,
+					}, nil
+				},
+			},
+			"drawCards": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(cardType))),
+				Description: "Draws cards from the deck. Once the deck has players, only the dealer may draw cards.",
+				Args: gql.FieldConfigArgument{
+					"deckId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"count":  &gql.ArgumentConfig{Type: gql.Int, DefaultValue: 1},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					drawn, err := g.Decks.Draw(requestToken(p.Context), p.Args["deckId"].(string), p.Args["count"].(int))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return drawn, nil
+				},
+			},
+			"shuffleDeck": &gql.Field{
+				Type:        gql.NewNonNull(deckType),
+				Description: "Shuffles the remaining cards in the deck. Once the deck has players, only the dealer may shuffle.",
+				Args: gql.FieldConfigArgument{
+					"deckId": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					deckID := p.Args["deckId"].(string)
+					if _, err := g.Decks.Shuffle(requestToken(p.Context), deckID); err != nil {
+						return nil, resolverError(err)
+					}
+					return g.viewDeck(p, deckID)
+				},
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// viewDeck looks up the deck as seen by the holder of the token of the request.
+func (g *GraphQLService) viewDeck(p gql.ResolveParams, deckID string) (*deckView, error) {
+	deck, err := g.Decks.Repository.GetDeck(deckID)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	seesCards, err := g.Decks.SeesCards(requestToken(p.Context), deckID)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return &deckView{deck: deck, seesCards: seesCards}, nil
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// HealthChecker reports the result of the last database health check.
+type HealthChecker interface {
+	// Status returns whether the database was reachable, when it was checked and the error, if the check failed.
+	Status() (healthy bool, lastCheck time.Time, err error)
+}
+
+// CacheStatsProvider reports the counters of the deck cache.
+type CacheStatsProvider interface {
+	// Stats returns the cache hit and miss counters and the current number of cached decks.
+	Stats() deck_repo.CacheStats
+}
+
+// HealthService represents the REST API service that reports the health of the API.
+type HealthService struct {
+	HealthChecker HealthChecker
+
+	// Cache is the deck cache to report the counters for. Nil if the cache is disabled.
+	Cache CacheStatsProvider
+}
+
+// Health reports the health status of the API.
+// Returns 200 OK if the database is reachable, otherwise returns 503 Service Unavailable.
+func (h *HealthService) Health(ctx *gin.Context) {
+	healthy, lastCheck, err := h.HealthChecker.Status()
+
+	var cache *CacheStatsResponse
+	if h.Cache != nil {
+		stats := h.Cache.Stats()
+		cache = &CacheStatsResponse{
+			Hits:   stats.Hits,
+			Misses: stats.Misses,
+			Size:   stats.Size,
+		}
+	}
+
+	if !healthy {
+		database := "unreachable"
+		if err != nil {
+			database = err.Error()
+		}
+		ctx.JSON(http.StatusServiceUnavailable, &HealthResponse{
+			Status:    "unhealthy",
+			Database:  database,
+			CheckedAt: lastCheck,
+			Cache:     cache,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &HealthResponse{
+		Status:    "ok",
+		Database:  "ok",
+		CheckedAt: lastCheck,
+		Cache:     cache,
+	})
+}
+
+// NewHealthService creates a new pointer to a HealthService using the given HealthChecker.
+func NewHealthService(healthChecker HealthChecker) *HealthService {
+	return &HealthService{
+		HealthChecker: healthChecker,
+	}
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+type staticHealthChecker struct {
+	healthy bool
+	err     error
+}
+
+func (s *staticHealthChecker) Status() (bool, time.Time, error) {
+	return s.healthy, time.Now(), s.err
+}
+
+type staticCacheStats deck_repo.CacheStats
+
+func (s staticCacheStats) Stats() deck_repo.CacheStats {
+	return deck_repo.CacheStats(s)
+}
+
+func setupTest(healthChecker HealthChecker) (*gin.Engine, *HealthService) {
+	router := gin.Default()
+	healthService := NewHealthService(healthChecker)
+	SetupHealthServiceRouting(router, healthService)
+	return router, healthService
+}
+
+func TestHealth(t *testing.T) {
+	router, _ := setupTest(&staticHealthChecker{healthy: true})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead.", w.Code)
+	}
+
+	resp := &HealthResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the health status, but got error: %s", err.Error())
+	}
+	if resp.Status != "ok" || resp.Database != "ok" {
+		t.Errorf("Expected the API to be healthy, but got status '%s' and database '%s'.", resp.Status, resp.Database)
+	}
+}
+
+func TestHealth_Unhealthy(t *testing.T) {
+	router, _ := setupTest(&staticHealthChecker{healthy: false, err: fmt.Errorf("connection refused")})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected response code 503 (Service Unavailable), but got %d instead.", w.Code)
+	}
+
+	resp := &HealthResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the health status, but got error: %s", err.Error())
+	}
+	if resp.Status != "unhealthy" || resp.Database != "connection refused" {
+		t.Errorf("Expected the API to be unhealthy, but got status '%s' and database '%s'.", resp.Status, resp.Database)
+	}
+}
+
+func TestHealth_CacheStats(t *testing.T) {
+	router, healthService := setupTest(&staticHealthChecker{healthy: true})
+	healthService.Cache = staticCacheStats{Hits: 5, Misses: 2, Size: 1}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+
+	router.ServeHTTP(w, req)
+
+	resp := &HealthResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the health status, but got error: %s", err.Error())
+	}
+	if resp.Cache == nil || resp.Cache.Hits != 5 || resp.Cache.Misses != 2 || resp.Cache.Size != 1 {
+		t.Errorf("Expected the cache counters to be reported, but got: %+v", resp.Cache)
+	}
+}
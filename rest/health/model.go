@@ -0,0 +1,30 @@
+package health
+
+import "time"
+
+// HealthResponse represents the response of a health check call.
+type HealthResponse struct {
+	// Status is the overall status of the API: "ok" or "unhealthy".
+	Status string `json:"status"`
+
+	// Database is the status of the database connection: "ok" or the reason why the database is unreachable.
+	Database string `json:"database"`
+
+	// CheckedAt is the time when the database was last checked.
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Cache holds the deck cache counters. Omitted if the cache is disabled.
+	Cache *CacheStatsResponse `json:"cache,omitempty"`
+}
+
+// CacheStatsResponse holds the counters of the deck cache.
+type CacheStatsResponse struct {
+	// Hits is the number of deck lookups served from the cache.
+	Hits uint64 `json:"hits"`
+
+	// Misses is the number of deck lookups that were not served from the cache.
+	Misses uint64 `json:"misses"`
+
+	// Size is the number of decks currently in the cache.
+	Size int `json:"size"`
+}
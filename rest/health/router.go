@@ -0,0 +1,8 @@
+package health
+
+import "github.com/gin-gonic/gin"
+
+// SetupHealthServiceRouting sets up the routing for HealthService with gin router.
+func SetupHealthServiceRouting(router gin.IRouter, healthService *HealthService) {
+	router.GET("/health", healthService.Health)
+}
@@ -0,0 +1,128 @@
+package holdem
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// NewPlayerRequest represents a player in a CreateTable request.
+type NewPlayerRequest struct {
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// Stack is the amount of chips the player buys in with.
+	Stack int64 `json:"stack"`
+}
+
+// CreateTableRequest represents the request of a CreateTable call.
+type CreateTableRequest struct {
+	// SmallBlind is the amount of the small blind.
+	SmallBlind int64 `json:"small_blind"`
+
+	// BigBlind is the amount of the big blind.
+	BigBlind int64 `json:"big_blind"`
+
+	// Players holds the players, in the order of their seats.
+	Players []NewPlayerRequest `json:"players"`
+}
+
+// ActionRequest represents the request of an Act call. The action is made by the seat of the token of the request.
+type ActionRequest struct {
+	// Action is the action: FOLD, CHECK, CALL, RAISE or ALL_IN.
+	Action string `json:"action"`
+
+	// Amount is the total bet of the player after a RAISE.
+	Amount int64 `json:"amount"`
+}
+
+// PlayerResponse represents a player in a TableResponse.
+type PlayerResponse struct {
+	// Seat is the position of the player at the table.
+	Seat int `json:"seat"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// Stack is the amount of chips the player has behind.
+	Stack int64 `json:"stack"`
+
+	// Bet is the amount the player has bet in the current betting round.
+	Bet int64 `json:"bet"`
+
+	// Committed is the total amount the player has put in the pot in the current hand.
+	Committed int64 `json:"committed"`
+
+	// InHand is true if the player was dealt in the current hand.
+	InHand bool `json:"in_hand"`
+
+	// Folded is true if the player has folded in the current hand.
+	Folded bool `json:"folded"`
+
+	// AllIn is true if the player has no chips left to bet in the current hand.
+	AllIn bool `json:"all_in"`
+
+	// LastAction is the last action of the player in the current hand.
+	LastAction string `json:"last_action,omitempty"`
+
+	// Cards are the hole cards of the player. Only the hole cards of the seat the table is viewed from are shown, and
+	// the hole cards of the players that went to the showdown once the hand is over.
+	Cards []deck_api.CardResponse `json:"cards,omitempty"`
+
+	// HandCategory is the category of the best hand of the player at the showdown.
+	HandCategory string `json:"hand_category,omitempty"`
+
+	// Won is the amount the player won at the showdown.
+	Won int64 `json:"won"`
+
+	// Token is the secret token of the player, to see the own hole cards and to act. It is shown only once, when the
+	// table is created.
+	Token string `json:"token,omitempty"`
+}
+
+// PotResponse represents a pot in a TableResponse.
+type PotResponse struct {
+	// Amount is the amount of chips in the pot.
+	Amount int64 `json:"amount"`
+
+	// Seats holds the seats of the players that can win the pot.
+	Seats []int `json:"seats"`
+}
+
+// TableResponse represents the state of a table of Texas Hold'em.
+type TableResponse struct {
+	// TableID is the ID of the table.
+	TableID string `json:"table_id"`
+
+	// SmallBlind is the amount of the small blind.
+	SmallBlind int64 `json:"small_blind"`
+
+	// BigBlind is the amount of the big blind.
+	BigBlind int64 `json:"big_blind"`
+
+	// HandNumber is the number of hands started at the table.
+	HandNumber int `json:"hand_number"`
+
+	// Stage is the stage of the current hand: WAITING, PREFLOP, FLOP, TURN, RIVER or SHOWDOWN.
+	Stage string `json:"stage"`
+
+	// ButtonSeat is the seat of the dealer button, or -1 before the first hand.
+	ButtonSeat int `json:"button_seat"`
+
+	// ActingSeat is the seat of the player to act, or -1 if nobody can act.
+	ActingSeat int `json:"acting_seat"`
+
+	// CurrentBet is the highest bet in the current betting round.
+	CurrentBet int64 `json:"current_bet"`
+
+	// MinRaise is the minimal amount by which the current bet can be raised.
+	MinRaise int64 `json:"min_raise"`
+
+	// Board holds the community cards.
+	Board []deck_api.CardResponse `json:"board"`
+
+	// Burned holds the burned cards.
+	Burned []deck_api.CardResponse `json:"burned"`
+
+	// Pots holds the main pot and the side pots of the current hand.
+	Pots []PotResponse `json:"pots"`
+
+	// Players holds the players at the table, ordered by seat.
+	Players []PlayerResponse `json:"players"`
+}
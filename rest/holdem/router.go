@@ -0,0 +1,12 @@
+package holdem
+
+import "github.com/gin-gonic/gin"
+
+// SetupTableServiceRouting sets up the routing for TableService with gin router.
+func SetupTableServiceRouting(group *gin.RouterGroup, tableService *TableService) {
+	group.POST("/holdem/tables", tableService.CreateTable)
+	group.GET("/holdem/tables/:tableId", tableService.GetTable)
+	group.POST("/holdem/tables/:tableId/hands", tableService.StartHand)
+	group.POST("/holdem/tables/:tableId/deal", tableService.Deal)
+	group.POST("/holdem/tables/:tableId/actions", tableService.Act)
+}
@@ -0,0 +1,213 @@
+package holdem
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	holdem_repo "github.com/natemago/card-games-api/repositories/holdem"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// TableService represents the REST API service for the tables of Texas Hold'em.
+// Uses the TableRepository to store the tables, and the DeckService to deal the cards from decks kept private by the
+// tables.
+type TableService struct {
+	Tables holdem_repo.TableRepository
+	Decks  *deck_api.DeckService
+}
+
+// CreateTable creates a new table bound to a new shuffled deck, kept private by the table.
+// Accepts a CreateTableRequest JSON body with the blinds and the players. Every player gets a secret token, shown
+// only once, to see the own hole cards and to act. The table is returned as viewed by no player.
+// If the blinds or the players are not valid, returns a 400 Bad Request error response.
+func (s *TableService) CreateTable(ctx *gin.Context) {
+	request := &CreateTableRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	players := []*holdem_repo.Player{}
+	tokens := []string{}
+	for _, player := range request.Players {
+		token, err := deck_repo.NewToken()
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		players = append(players, &holdem_repo.Player{
+			Name:      player.Name,
+			TokenHash: deck_repo.HashToken(token),
+			Stack:     player.Stack,
+		})
+		tokens = append(tokens, token)
+	}
+
+	table, err := holdem_repo.NewTable(request.SmallBlind, request.BigBlind, players, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	table, err = s.Tables.CreateTable(table)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := toTableResponse(table, deck_api.NoSeat)
+	for seat, token := range tokens {
+		response.Players[seat].Token = token
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetTable looks up a table by its ID and returns its state, viewed from the seat of the token of the request: only
+// the hole cards of that seat are shown, until the showdown. Without a token, all hole cards are hidden.
+// If the table does not exist, returns a 404 Not Found error response. If the token is not the token of a player at
+// the table, returns a 401 Unauthorized error response.
+func (s *TableService) GetTable(ctx *gin.Context) {
+	table, seat, err := s.getTable(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTableResponse(table, seat))
+}
+
+// StartHand starts a new hand: moves the button, posts the blinds and deals the hole cards. Any player at the table
+// may start the hand, and the table is returned as viewed from the seat of the token of the request.
+// If there is no token, or it is not the token of a player at the table, returns a 401 Unauthorized error response.
+// If a hand is in progress, or less than two players have chips, returns a 400 Bad Request error response.
+func (s *TableService) StartHand(ctx *gin.Context) {
+	s.updateTable(ctx, func(table *holdem_repo.Table, seat int) error {
+		return holdem_repo.StartHand(table, s.Decks)
+	})
+}
+
+// Deal burns a card and deals the next street: the flop, the turn or the river. Any player at the table may deal,
+// and the table is returned as viewed from the seat of the token of the request.
+// If there is no token, or it is not the token of a player at the table, returns a 401 Unauthorized error response.
+// If the betting round is not complete or the river is already dealt, returns a 400 Bad Request error response.
+func (s *TableService) Deal(ctx *gin.Context) {
+	s.updateTable(ctx, func(table *holdem_repo.Table, seat int) error {
+		return holdem_repo.DealNext(table, s.Decks)
+	})
+}
+
+// Act applies the action of the player at the seat of the token of the request, given as an ActionRequest JSON body.
+// If there is no token, or it is not the token of a player at the table, returns a 401 Unauthorized error response.
+// If it is not the turn of the player or the action is not allowed, returns a 400 Bad Request error response.
+func (s *TableService) Act(ctx *gin.Context) {
+	request := &ActionRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateTable(ctx, func(table *holdem_repo.Table, seat int) error {
+		return holdem_repo.Act(table, seat, request.Action, request.Amount)
+	})
+}
+
+// getTable looks up the table from the path, and the seat of the token of the request (see deck_api.RequestSeat).
+func (s *TableService) getTable(ctx *gin.Context) (*holdem_repo.Table, int, error) {
+	table, err := s.Tables.GetTable(ctx.Param("tableId"))
+	if err != nil {
+		return nil, deck_api.NoSeat, err
+	}
+
+	seat, err := deck_api.RequestSeat(ctx, table.TokenHashes())
+	if err != nil {
+		return nil, deck_api.NoSeat, err
+	}
+	return table, seat, nil
+}
+
+// updateTable looks up the table from the path, applies the change as the seat of the token of the request and
+// stores the table. The change must be made by a player at the table, otherwise an UnauthorizedError is returned.
+func (s *TableService) updateTable(ctx *gin.Context, change func(table *holdem_repo.Table, seat int) error) {
+	table, seat, err := s.getTable(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if seat == deck_api.NoSeat {
+		ctx.Error(errors.UnauthorizedError("the token of a player is required", nil))
+		return
+	}
+
+	if err := change(table, seat); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	table, err = s.Tables.UpdateTable(table)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTableResponse(table, seat))
+}
+
+// toTableResponse converts the table viewed from the seat: the hole cards of the seat are shown, and the hole cards
+// of the players that went to the showdown once the hand is over.
+func toTableResponse(table *holdem_repo.Table, seat int) *TableResponse {
+	response := &TableResponse{
+		TableID:    table.ID,
+		SmallBlind: table.SmallBlind,
+		BigBlind:   table.BigBlind,
+		HandNumber: table.HandNumber,
+		Stage:      table.Stage,
+		ButtonSeat: table.ButtonSeat,
+		ActingSeat: table.ActingSeat,
+		CurrentBet: table.CurrentBet,
+		MinRaise:   table.MinRaise,
+		Board:      deck_api.ToCardResponses(table.BoardCards()),
+		Burned:     deck_api.ToCardResponses(table.BurnedCards()),
+		Pots:       []PotResponse{},
+		Players:    []PlayerResponse{},
+	}
+
+	for _, pot := range holdem_repo.Pots(table) {
+		response.Pots = append(response.Pots, PotResponse{
+			Amount: pot.Amount,
+			Seats:  pot.Seats,
+		})
+	}
+
+	for _, player := range table.Players {
+		playerResponse := PlayerResponse{
+			Seat:         player.Seat,
+			Name:         player.Name,
+			Stack:        player.Stack,
+			Bet:          player.Bet,
+			Committed:    player.Committed,
+			InHand:       player.InHand,
+			Folded:       player.Folded,
+			AllIn:        player.AllIn,
+			LastAction:   player.LastAction,
+			HandCategory: player.HandCategory,
+			Won:          player.Won,
+		}
+		if player.Seat == seat || (table.Stage == holdem_repo.ShowdownStage && player.HandCategory != "") {
+			playerResponse.Cards = deck_api.ToCardResponses(player.Cards())
+		}
+		response.Players = append(response.Players, playerResponse)
+	}
+
+	return response
+}
+
+// NewTableService creates a new pointer to a TableService using the given TableRepository and DeckService.
+func NewTableService(tableRepository holdem_repo.TableRepository, decks *deck_api.DeckService) *TableService {
+	return &TableService{
+		Tables: tableRepository,
+		Decks:  decks,
+	}
+}
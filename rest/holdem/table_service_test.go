@@ -0,0 +1,193 @@
+package holdem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	holdem_repo "github.com/natemago/card-games-api/repositories/holdem"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) (*gin.Engine, *TableService) {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	decks := deck_api.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	tableService := NewTableService(holdem_repo.NewDBTableRepository(db), decks)
+	SetupTableServiceRouting(router.Group("/v1"), tableService)
+
+	return router, tableService
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, token, body string, expectedCode int) *TableResponse {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if expectedCode >= 400 {
+		return nil
+	}
+
+	resp := &TableResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the table, but got error: %s", err.Error())
+	}
+	return resp
+}
+
+func TestTableFlow(t *testing.T) {
+	router, _ := setupTest(t)
+
+	table := call(t, router, "POST", "/v1/holdem/tables", "", `{
+		"small_blind": 5,
+		"big_blind": 10,
+		"players": [{"name": "alice", "stack": 1000}, {"name": "bob", "stack": 1000}]
+	}`, http.StatusCreated)
+	if table.Stage != "WAITING" || len(table.Players) != 2 || table.Players[1].Name != "bob" {
+		t.Fatalf("Expected a new table, but got: %+v", table)
+	}
+	alice, bob := table.Players[0].Token, table.Players[1].Token
+	if alice == "" || bob == "" || alice == bob {
+		t.Fatalf("Expected a token for every player, but got: %+v", table.Players)
+	}
+	path := "/v1/holdem/tables/" + table.TableID
+
+	call(t, router, "POST", path+"/hands", "", "", http.StatusUnauthorized)
+	table = call(t, router, "POST", path+"/hands", bob, "", http.StatusOK)
+	if table.Stage != "PREFLOP" || !table.Players[0].InHand || table.ActingSeat != 0 {
+		t.Fatalf("Expected the hole cards to be dealt, but got: %+v", table)
+	}
+	if table.Players[0].Cards != nil || len(table.Players[1].Cards) != 2 || table.Players[0].Token != "" {
+		t.Errorf("Expected only the hole cards of bob, but got: %+v", table.Players)
+	}
+	if len(table.Pots) != 1 || table.Pots[0].Amount != 15 {
+		t.Errorf("Expected the blinds in the pot, but got: %+v", table.Pots)
+	}
+
+	table = call(t, router, "GET", path, "", "", http.StatusOK)
+	if table.Players[0].Cards != nil || table.Players[1].Cards != nil {
+		t.Errorf("Expected the hole cards to be hidden without a token, but got: %+v", table.Players)
+	}
+
+	table = call(t, router, "GET", path, alice, "", http.StatusOK)
+	if len(table.Players[0].Cards) != 2 || table.Players[1].Cards != nil {
+		t.Errorf("Expected only the hole cards of alice, but got: %+v", table.Players)
+	}
+
+	// bob may not act in the seat of alice by naming it in the body
+	call(t, router, "POST", path+"/actions", bob, `{"seat": 0, "action": "CALL"}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/actions", "", `{"action": "CALL"}`, http.StatusUnauthorized)
+	table = call(t, router, "POST", path+"/actions", alice, `{"action": "CALL"}`, http.StatusOK)
+	if len(table.Players[0].Cards) != 2 || table.Players[1].Cards != nil {
+		t.Errorf("Expected the table viewed by alice, but got: %+v", table.Players)
+	}
+	call(t, router, "POST", path+"/actions", bob, `{"action": "CHECK"}`, http.StatusOK)
+
+	for _, expected := range []struct {
+		stage string
+		board int
+	}{{"FLOP", 3}, {"TURN", 4}, {"RIVER", 5}} {
+		call(t, router, "POST", path+"/deal", "", "", http.StatusUnauthorized)
+		table = call(t, router, "POST", path+"/deal", alice, "", http.StatusOK)
+		if table.Stage != expected.stage || len(table.Board) != expected.board {
+			t.Fatalf("Expected the %s with %d board cards, but got %s with %d.", expected.stage, expected.board, table.Stage, len(table.Board))
+		}
+		call(t, router, "POST", path+"/actions", bob, `{"action": "CHECK"}`, http.StatusOK)
+		table = call(t, router, "POST", path+"/actions", alice, `{"action": "CHECK"}`, http.StatusOK)
+	}
+
+	if table.Stage != "SHOWDOWN" || len(table.Burned) != 3 {
+		t.Fatalf("Expected the showdown after three burned cards, but got: %+v", table)
+	}
+	if table.Players[0].Won+table.Players[1].Won != 20 || table.Players[0].Stack+table.Players[1].Stack != 2000 {
+		t.Errorf("Expected the pot of 20 to be awarded, but got: %+v", table.Players)
+	}
+
+	stored := call(t, router, "GET", path, "", "", http.StatusOK)
+	if stored.Stage != "SHOWDOWN" || stored.Players[0].Stack != table.Players[0].Stack {
+		t.Errorf("Expected the table to be stored, but got: %+v", stored)
+	}
+	if len(stored.Players[0].Cards) != 2 || len(stored.Players[1].Cards) != 2 {
+		t.Errorf("Expected the hole cards to be shown at the showdown, but got: %+v", stored.Players)
+	}
+}
+
+func TestTable_PrivateDeck(t *testing.T) {
+	router, service := setupTest(t)
+
+	created := call(t, router, "POST", "/v1/holdem/tables", "", `{
+		"small_blind": 5,
+		"big_blind": 10,
+		"players": [{"stack": 100}, {"stack": 100}]
+	}`, http.StatusCreated)
+	call(t, router, "POST", "/v1/holdem/tables/"+created.TableID+"/hands", created.Players[0].Token, "", http.StatusOK)
+
+	table, err := service.Tables.GetTable(created.TableID)
+	if err != nil {
+		t.Fatalf("Failed to read the table: %s", err.Error())
+	}
+	if sees, err := service.Decks.SeesCards("", table.DeckID); sees || err != nil {
+		t.Errorf("Expected the cards of the deck to be hidden, but got: %v %v", sees, err)
+	}
+	if sees, err := service.Decks.SeesCards(created.Players[0].Token, table.DeckID); sees || err == nil {
+		t.Errorf("Expected the token of a player to be invalid for the deck, but got: %v %v", sees, err)
+	}
+	if _, err := service.Decks.Draw("", table.DeckID, 1); !errors.IsForbiddenError(err) {
+		t.Errorf("Expected only the table to draw from the deck, but got: %v", err)
+	}
+	if _, err := service.Decks.Shuffle("", table.DeckID); !errors.IsForbiddenError(err) {
+		t.Errorf("Expected only the table to shuffle the deck, but got: %v", err)
+	}
+}
+
+func TestTable_Invalid(t *testing.T) {
+	router, _ := setupTest(t)
+
+	call(t, router, "POST", "/v1/holdem/tables", "", `{"small_blind": 5, "big_blind": 10, "players": [{"stack": 100}]}`, http.StatusBadRequest)
+	call(t, router, "GET", "/v1/holdem/tables/missing", "", "", http.StatusNotFound)
+
+	table := call(t, router, "POST", "/v1/holdem/tables", "", `{
+		"small_blind": 5,
+		"big_blind": 10,
+		"players": [{"stack": 100}, {"stack": 100}]
+	}`, http.StatusCreated)
+	path := "/v1/holdem/tables/" + table.TableID
+	first, second := table.Players[0].Token, table.Players[1].Token
+
+	call(t, router, "POST", path+"/deal", first, "", http.StatusBadRequest)
+	call(t, router, "POST", path+"/hands", "invalid", "", http.StatusUnauthorized)
+	call(t, router, "POST", path+"/hands", second, "", http.StatusOK)
+	call(t, router, "POST", path+"/actions", second, `{"action": "CHECK"}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/actions", first, `{"action": "CHECK"}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/actions", first, `{"action": "RAISE", "amount": 15}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/actions", "invalid", `{"action": "CALL"}`, http.StatusUnauthorized)
+	call(t, router, "GET", path, "invalid", "", http.StatusUnauthorized)
+}
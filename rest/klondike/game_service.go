@@ -0,0 +1,146 @@
+package klondike
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	klondike_repo "github.com/natemago/card-games-api/repositories/klondike"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// GameService represents the REST API service for the games of Klondike solitaire.
+// Uses the GameRepository to store the games, and the DeckService to deal the cards from decks kept private by the
+// games.
+type GameService struct {
+	Games klondike_repo.GameRepository
+	Decks *deck_api.DeckService
+}
+
+// CreateGame deals a new game from a new shuffled deck, kept private by the game.
+// Accepts an optional CreateGameRequest JSON body with the draw count.
+// If the draw count is not valid, returns a 400 Bad Request error response.
+func (s *GameService) CreateGame(ctx *gin.Context) {
+	request := &CreateGameRequest{}
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(request); err != nil {
+			ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+			return
+		}
+	}
+	if request.DrawCount == 0 {
+		request.DrawCount = 1
+	}
+
+	game, err := klondike_repo.NewGame(request.DrawCount, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	game, err = s.Games.CreateGame(game)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toGameResponse(game))
+}
+
+// GetGame looks up a game by its ID and returns its state.
+// If the game does not exist, returns a 404 Not Found error response.
+func (s *GameService) GetGame(ctx *gin.Context) {
+	game, err := s.Games.GetGame(ctx.Param("gameId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toGameResponse(game))
+}
+
+// Move applies the move given as a MoveRequest JSON body. A move from the stock to the waste turns the next cards
+// of the stock.
+// If the move is not allowed by the rules, returns a 400 Bad Request error response.
+func (s *GameService) Move(ctx *gin.Context) {
+	request := &MoveRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateGame(ctx, func(game *klondike_repo.Game) error {
+		return klondike_repo.Move(game, request.From, request.To, request.Count)
+	})
+}
+
+// AutoComplete moves all remaining cards to the foundations.
+// If the game cannot be auto-completed yet, returns a 400 Bad Request error response.
+func (s *GameService) AutoComplete(ctx *gin.Context) {
+	s.updateGame(ctx, klondike_repo.AutoComplete)
+}
+
+// updateGame looks up the game from the path, applies the change and stores the game.
+func (s *GameService) updateGame(ctx *gin.Context, change func(game *klondike_repo.Game) error) {
+	game, err := s.Games.GetGame(ctx.Param("gameId"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := change(game); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	game, err = s.Games.UpdateGame(game)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toGameResponse(game))
+}
+
+func toGameResponse(game *klondike_repo.Game) *GameResponse {
+	response := &GameResponse{
+		GameID:       game.ID,
+		DrawCount:    game.DrawCount,
+		Status:       game.Status,
+		Moves:        game.Moves,
+		Passes:       game.Passes,
+		AutoComplete: klondike_repo.CanAutoComplete(game),
+		Stock:        toPileResponse(game.Pile(klondike_repo.StockPile)),
+		Waste:        toPileResponse(game.Pile(klondike_repo.WastePile)),
+		Foundations:  []PileResponse{},
+		Tableau:      []PileResponse{},
+	}
+
+	for i := 0; i < klondike_repo.NumFoundations; i++ {
+		response.Foundations = append(response.Foundations, toPileResponse(game.Pile(klondike_repo.FoundationPile(i))))
+	}
+	for i := 0; i < klondike_repo.NumTableauPiles; i++ {
+		response.Tableau = append(response.Tableau, toPileResponse(game.Pile(klondike_repo.TableauPile(i))))
+	}
+
+	return response
+}
+
+// toPileResponse converts the pile, leaving out the face-down cards.
+func toPileResponse(pile *klondike_repo.Pile) PileResponse {
+	return PileResponse{
+		Name:     pile.Name,
+		Count:    len(pile.PileCards()),
+		FaceDown: pile.FaceDown,
+		Cards:    deck_api.ToCardResponses(pile.FaceUpCards()),
+	}
+}
+
+// NewGameService creates a new pointer to a GameService using the given GameRepository and DeckService.
+func NewGameService(gameRepository klondike_repo.GameRepository, decks *deck_api.DeckService) *GameService {
+	return &GameService{
+		Games: gameRepository,
+		Decks: decks,
+	}
+}
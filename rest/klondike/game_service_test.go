@@ -0,0 +1,136 @@
+package klondike
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	klondike_repo "github.com/natemago/card-games-api/repositories/klondike"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) (*gin.Engine, *GameService) {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	decks := deck_api.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	service := NewGameService(klondike_repo.NewDBGameRepository(db), decks)
+	SetupGameServiceRouting(router.Group("/v1"), service)
+
+	return router, service
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, body string, expectedCode int) *GameResponse {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if expectedCode >= 400 {
+		return nil
+	}
+
+	resp := &GameResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the game, but got error: %s", err.Error())
+	}
+	return resp
+}
+
+func TestGameFlow(t *testing.T) {
+	router, service := setupTest(t)
+
+	game := call(t, router, "POST", "/v1/klondike/games", "", http.StatusCreated)
+	if game.Status != "PLAYING" || game.DrawCount != 1 || game.AutoComplete {
+		t.Fatalf("Expected a new game, but got: %+v", game)
+	}
+	stored, err := service.Games.GetGame(game.GameID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %s", err.Error())
+	}
+	if dealer, err := service.Decks.Players.GetDealer(stored.DeckID); err != nil || !dealer.Private {
+		t.Errorf("Expected the deck of the game to be kept private, but got: %+v (%v)", dealer, err)
+	}
+	if game.Stock.Count != 24 || len(game.Stock.Cards) != 0 || len(game.Foundations) != 4 || len(game.Tableau) != 7 {
+		t.Fatalf("Expected the stock to be face down, but got: %+v", game)
+	}
+	for i, pile := range game.Tableau {
+		if pile.Name != klondike_repo.TableauPile(i) || pile.Count != i+1 || pile.FaceDown != i || len(pile.Cards) != 1 {
+			t.Errorf("Expected only the top card of the tableau pile to be visible, but got: %+v", pile)
+		}
+	}
+	path := "/v1/klondike/games/" + game.GameID
+
+	game = call(t, router, "POST", path+"/moves", `{"from": "stock", "to": "waste"}`, http.StatusOK)
+	if game.Stock.Count != 23 || game.Waste.Count != 1 || len(game.Waste.Cards) != 1 || game.Waste.Cards[0].Code == "" {
+		t.Fatalf("Expected a card turned to the waste, but got: %+v", game)
+	}
+
+	call(t, router, "POST", path+"/moves", `{"from": "waste", "to": "stock"}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/moves", `{"from": "tableau-6", "to": "tableau-5", "count": 2}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/auto-complete", "", http.StatusBadRequest)
+
+	game = call(t, router, "GET", path, "", http.StatusOK)
+	if game.Moves != 1 {
+		t.Errorf("Expected the game to be stored, but got: %+v", game)
+	}
+}
+
+func TestAutoComplete(t *testing.T) {
+	router, service := setupTest(t)
+
+	game := klondike_repo.Deal(1, deck_repo.AsCards(""))
+	game.Pile(klondike_repo.FoundationPile(0)).Cards = "AC,2C,3C,4C,5C,6C,7C,8C,9C,10C,JC,QC,KC"
+	game.Pile(klondike_repo.FoundationPile(1)).Cards = "AD,2D,3D,4D,5D,6D,7D,8D,9D,10D,JD,QD,KD"
+	game.Pile(klondike_repo.FoundationPile(2)).Cards = "AH,2H,3H,4H,5H,6H,7H,8H,9H,10H,JH,QH,KH"
+	game.Pile(klondike_repo.FoundationPile(3)).Cards = "AS,2S,3S,4S,5S,6S,7S,8S,9S,10S"
+	game.Pile(klondike_repo.TableauPile(0)).Cards = "KS,QS"
+	game.Pile(klondike_repo.TableauPile(3)).Cards = "JS"
+	if _, err := service.Games.CreateGame(game); err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	path := "/v1/klondike/games/" + game.ID
+
+	resp := call(t, router, "GET", path, "", http.StatusOK)
+	if !resp.AutoComplete {
+		t.Fatalf("Expected the game to be auto-completed, but got: %+v", resp)
+	}
+
+	resp = call(t, router, "POST", path+"/auto-complete", "", http.StatusOK)
+	if resp.Status != "WON" || resp.AutoComplete || resp.Foundations[3].Count != 13 || resp.Tableau[0].Count != 0 {
+		t.Errorf("Expected the game to be won, but got: %+v", resp)
+	}
+
+	call(t, router, "POST", path+"/moves", `{"from": "stock", "to": "waste"}`, http.StatusBadRequest)
+}
+
+func TestCreateGame_Invalid(t *testing.T) {
+	router, _ := setupTest(t)
+
+	call(t, router, "POST", "/v1/klondike/games", `{"draw_count": 2}`, http.StatusBadRequest)
+	call(t, router, "POST", "/v1/klondike/games", `{"draw_count": "three"}`, http.StatusBadRequest)
+	call(t, router, "GET", "/v1/klondike/games/missing", "", http.StatusNotFound)
+}
@@ -0,0 +1,69 @@
+package klondike
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// CreateGameRequest represents the request of a CreateGame call.
+type CreateGameRequest struct {
+	// DrawCount is the number of cards turned from the stock at once: 1 (the default) or 3.
+	DrawCount int `json:"draw_count"`
+}
+
+// MoveRequest represents the request of a Move call.
+type MoveRequest struct {
+	// From is the name of the pile the cards are moved from, like "waste" or "tableau-3".
+	From string `json:"from"`
+
+	// To is the name of the pile the cards are moved to, like "foundation-0" or "tableau-5".
+	To string `json:"to"`
+
+	// Count is the number of cards moved from the top of the pile. Default is 1.
+	Count int `json:"count"`
+}
+
+// PileResponse represents a pile of cards in a GameResponse.
+type PileResponse struct {
+	// Name is the name of the pile.
+	Name string `json:"name"`
+
+	// Count is the number of cards in the pile.
+	Count int `json:"count"`
+
+	// FaceDown is the number of face-down cards at the bottom of the pile.
+	FaceDown int `json:"face_down"`
+
+	// Cards holds the face-up cards, from the bottom to the top of the pile.
+	Cards []deck_api.CardResponse `json:"cards"`
+}
+
+// GameResponse represents the state of a game of Klondike solitaire.
+type GameResponse struct {
+	// GameID is the ID of the game.
+	GameID string `json:"game_id"`
+
+	// DrawCount is the number of cards turned from the stock at once.
+	DrawCount int `json:"draw_count"`
+
+	// Status is the status of the game: PLAYING or WON.
+	Status string `json:"status"`
+
+	// Moves is the number of moves made in the game.
+	Moves int `json:"moves"`
+
+	// Passes is the number of times the waste was turned over into the stock.
+	Passes int `json:"passes"`
+
+	// AutoComplete is true if the game can be finished with an AutoComplete call.
+	AutoComplete bool `json:"auto_complete"`
+
+	// Stock is the stock. Its cards are face down.
+	Stock PileResponse `json:"stock"`
+
+	// Waste is the waste, with the top card playable.
+	Waste PileResponse `json:"waste"`
+
+	// Foundations holds the four foundations.
+	Foundations []PileResponse `json:"foundations"`
+
+	// Tableau holds the seven tableau piles.
+	Tableau []PileResponse `json:"tableau"`
+}
@@ -0,0 +1,11 @@
+package klondike
+
+import "github.com/gin-gonic/gin"
+
+// SetupGameServiceRouting sets up the routing for GameService with gin router.
+func SetupGameServiceRouting(group *gin.RouterGroup, gameService *GameService) {
+	group.POST("/klondike/games", gameService.CreateGame)
+	group.GET("/klondike/games/:gameId", gameService.GetGame)
+	group.POST("/klondike/games/:gameId/moves", gameService.Move)
+	group.POST("/klondike/games/:gameId/auto-complete", gameService.AutoComplete)
+}
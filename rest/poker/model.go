@@ -0,0 +1,170 @@
+package poker
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// EvaluateRequest represents the request of an Evaluate call.
+type EvaluateRequest struct {
+	// Cards is the list of card codes in the hand, like "AS" or "10H". Five to seven cards.
+	Cards []string `json:"cards"`
+}
+
+// EvaluateResponse represents the response of an Evaluate call.
+type EvaluateResponse struct {
+	// Category is the category of the hand, like "FLUSH" or "TWO_PAIR".
+	Category string `json:"category"`
+
+	// Score is a comparable value of the hand. A hand with a higher score beats a hand with a lower score,
+	// and hands with equal scores tie.
+	Score uint32 `json:"score"`
+
+	// Best is the best five-card combination in the hand.
+	Best []deck_api.CardResponse `json:"best"`
+}
+
+// ShowdownPlayer represents a player in a Showdown request.
+type ShowdownPlayer struct {
+	// Name is an optional name of the player.
+	Name string `json:"name"`
+
+	// Cards is the list of the hole cards of the player. Must be empty when the cards are taken from a deck.
+	Cards []string `json:"cards"`
+
+	// Pile is the name of the public pile of the deck holding the hole cards of the player, when the cards are taken
+	// from a deck.
+	Pile string `json:"pile"`
+}
+
+// ShowdownRequest represents the request of a Showdown call.
+// The cards are either given explicitly, in Players and Board, or taken from the public piles of the deck with DeckID.
+type ShowdownRequest struct {
+	// Game is the poker game: "holdem" (default) or "omaha".
+	Game string `json:"game"`
+
+	// Pot is the amount to be split between the winners.
+	Pot int64 `json:"pot"`
+
+	// Players holds the players in the showdown, in their order at the table.
+	Players []ShowdownPlayer `json:"players"`
+
+	// Board is the list of community cards.
+	Board []string `json:"board"`
+
+	// DeckID is the ID of the deck with the piles of the hole cards and the board.
+	DeckID string `json:"deck_id"`
+
+	// BoardPile is the name of the public pile of the deck holding the community cards.
+	BoardPile string `json:"board_pile"`
+}
+
+// ShowdownPlayerResponse represents the result of a single player in a ShowdownResponse.
+type ShowdownPlayerResponse struct {
+	// Player is the index of the player in the request.
+	Player int `json:"player"`
+
+	// Name is the name of the player, as given in the request.
+	Name string `json:"name,omitempty"`
+
+	// Cards are the hole cards of the player.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// Category is the category of the best hand of the player.
+	Category string `json:"category"`
+
+	// Score is a comparable value of the best hand of the player.
+	Score uint32 `json:"score"`
+
+	// Best is the best five-card combination of the player.
+	Best []deck_api.CardResponse `json:"best"`
+
+	// Rank is the place of the player in the showdown, starting at 1. Tied players share the rank.
+	Rank int `json:"rank"`
+
+	// Share is the part of the pot won by the player.
+	Share int64 `json:"share"`
+}
+
+// ShowdownResponse represents the response of a Showdown call.
+type ShowdownResponse struct {
+	// Game is the poker game.
+	Game string `json:"game"`
+
+	// Board is the list of community cards.
+	Board []deck_api.CardResponse `json:"board"`
+
+	// Players holds the result of each player, in the order of the players in the request.
+	Players []ShowdownPlayerResponse `json:"players"`
+
+	// Ranking holds the indexes of the players ordered from the best to the worst hand.
+	Ranking []int `json:"ranking"`
+
+	// Winners holds the indexes of the players with the best hand.
+	Winners []int `json:"winners"`
+
+	// Split is true when the pot is split between more than one winner.
+	Split bool `json:"split"`
+}
+
+// EquityRequest represents the request of an Equity call.
+type EquityRequest struct {
+	// Game is the poker game: "holdem" (default) or "omaha".
+	Game string `json:"game"`
+
+	// Hands holds the hole cards of every player.
+	Hands [][]string `json:"hands"`
+
+	// Board is the known part of the board: no cards, or three to five cards.
+	Board []string `json:"board"`
+
+	// Dead holds the cards known to be out of play.
+	Dead []string `json:"dead"`
+
+	// Iterations is the number of random boards dealt when the equity is estimated.
+	Iterations int `json:"iterations"`
+
+	// TimeBudgetMs is the maximal time, in milliseconds, spent on estimating the equity.
+	TimeBudgetMs int `json:"time_budget_ms"`
+
+	// Seed seeds the random boards. Zero means a random seed.
+	Seed int64 `json:"seed"`
+}
+
+// PlayerEquityResponse represents the equity of a single player in an EquityResponse.
+type PlayerEquityResponse struct {
+	// Cards are the hole cards of the player.
+	Cards []deck_api.CardResponse `json:"cards"`
+
+	// Win is the probability to win alone.
+	Win float64 `json:"win"`
+
+	// Tie is the probability to share the pot.
+	Tie float64 `json:"tie"`
+
+	// Equity is the expected share of the pot.
+	Equity float64 `json:"equity"`
+
+	// Wins is the number of boards won alone.
+	Wins uint64 `json:"wins"`
+
+	// Ties is the number of boards with a shared pot.
+	Ties uint64 `json:"ties"`
+}
+
+// EquityResponse represents the response of an Equity call.
+type EquityResponse struct {
+	// Exact is true if every possible board was evaluated, and false for a Monte Carlo estimate.
+	Exact bool `json:"exact"`
+
+	// Boards is the number of evaluated boards.
+	Boards uint64 `json:"boards"`
+
+	// Seed is the seed of the random boards. Repeating the request with the same seed and iterations gives the same
+	// estimate, unless the estimate is truncated.
+	Seed int64 `json:"seed,omitempty"`
+
+	// Truncated is true if the time budget ran out before all of the iterations. A truncated estimate depends on the
+	// speed of the server, so it cannot be repeated with the seed.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Players holds the equity of each player, in the order of the hands in the request.
+	Players []PlayerEquityResponse `json:"players"`
+}
@@ -0,0 +1,312 @@
+package poker
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/poker"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+// Limits of the equity calculation, so that a single request cannot keep the server busy.
+const (
+	// MaxEquityIterations is the maximal number of random boards of an equity estimate.
+	MaxEquityIterations = 10000000
+
+	// MaxEquityTimeBudget is the maximal (and default) time spent on an equity estimate.
+	MaxEquityTimeBudget = 5 * time.Second
+)
+
+// PokerService represents the REST API service for evaluating poker hands.
+type PokerService struct {
+	// Decks is the Deck Service with the public piles of the decks, to take the cards of a showdown from.
+	Decks *deck_api.DeckService
+}
+
+// Evaluate evaluates a poker hand of five to seven cards.
+// Accepts an EvaluateRequest JSON body with the card codes.
+// Returns the hand category, the best five cards and a comparable score of the hand.
+// If any of the cards is invalid or duplicated, or there are less than five or more than seven cards,
+// returns a 400 Bad Request error response.
+func (p *PokerService) Evaluate(ctx *gin.Context) {
+	request := &EvaluateRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	cards, err := poker.ParseCards(request.Cards...)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	value, err := poker.Evaluate(cards)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &EvaluateResponse{
+		Category: value.Category.String(),
+		Score:    uint32(value.Score),
+		Best:     toCardResponses(value.Best),
+	})
+}
+
+// Showdown compares the hands of multiple players and splits the pot between the winners.
+// Accepts a ShowdownRequest JSON body with either the hole cards of the players and the board, or a deck ID.
+// When a deck ID is given, the hole cards of every player and the board are the cards of the named public piles of
+// the deck. The piles are public, so no token is needed, and the deck is not changed.
+// Returns the result of every player, the ranking, the winners and whether the pot is split.
+// If any of the cards is invalid or dealt more than once, or a player does not have a valid hand for the game,
+// returns a 400 Bad Request error response. If the deck or a pile does not exist, returns a 404 Not Found error
+// response.
+func (p *PokerService) Showdown(ctx *gin.Context) {
+	request := &ShowdownRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+	if request.Game == "" {
+		request.Game = poker.Holdem
+	}
+	if request.Pot < 0 {
+		ctx.Error(errors.BadRequestError("the pot must not be negative", nil))
+		return
+	}
+
+	var hands [][]poker.Card
+	var board []poker.Card
+	var err error
+	if request.DeckID != "" {
+		hands, board, err = p.pileCards(request)
+	} else {
+		hands, board, err = parseShowdownCards(request)
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	result, err := poker.Showdown(request.Game, hands, board, request.Pot)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &ShowdownResponse{
+		Game:    request.Game,
+		Board:   toCardResponses(board),
+		Ranking: result.Ranking,
+		Winners: result.Winners,
+		Split:   len(result.Winners) > 1,
+	}
+	for i, player := range result.Players {
+		response.Players = append(response.Players, ShowdownPlayerResponse{
+			Player:   player.Player,
+			Name:     request.Players[i].Name,
+			Cards:    toCardResponses(hands[i]),
+			Category: player.Hand.Category.String(),
+			Score:    uint32(player.Hand.Score),
+			Best:     toCardResponses(player.Hand.Best),
+			Rank:     player.Rank,
+			Share:    player.Share,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseShowdownCards parses the hole cards and the board of a showdown request. All cards are validated together,
+// so a card given to more than one player, or to a player and the board, is rejected.
+func parseShowdownCards(request *ShowdownRequest) ([][]poker.Card, []poker.Card, error) {
+	codes := append([]string{}, request.Board...)
+	for _, player := range request.Players {
+		codes = append(codes, player.Cards...)
+	}
+
+	cards, err := poker.ParseCards(codes...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	board := cards[:len(request.Board)]
+	cards = cards[len(request.Board):]
+
+	hands := make([][]poker.Card, 0, len(request.Players))
+	for _, player := range request.Players {
+		hands = append(hands, cards[:len(player.Cards)])
+		cards = cards[len(player.Cards):]
+	}
+
+	return hands, board, nil
+}
+
+// pileCards takes the hole cards of the players and the board from the named public piles of the deck of the showdown
+// request, without changing the deck. All cards are validated together, as in parseShowdownCards.
+func (p *PokerService) pileCards(request *ShowdownRequest) ([][]poker.Card, []poker.Card, error) {
+	if len(request.Board) > 0 {
+		return nil, nil, errors.BadRequestError("the board must not be given with a deck, only the board pile", nil)
+	}
+	if request.BoardPile == "" {
+		return nil, nil, errors.BadRequestError("the board pile must be given with a deck", nil)
+	}
+	for _, player := range request.Players {
+		if len(player.Cards) > 0 {
+			return nil, nil, errors.BadRequestError("the hole cards must not be given with a deck, only the pile of the player", nil)
+		}
+		if player.Pile == "" {
+			return nil, nil, errors.BadRequestError("the pile of every player must be given with a deck", nil)
+		}
+	}
+
+	if _, err := p.Decks.Repository.GetDeck(request.DeckID); err != nil {
+		return nil, nil, err
+	}
+	piles, err := p.Decks.Players.ListPiles(request.DeckID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pileCards := map[string][]*deck_repo.Card{}
+	for _, pile := range piles {
+		pileCards[pile.Name] = pile.PileCards()
+	}
+
+	names := []string{request.BoardPile}
+	for _, player := range request.Players {
+		names = append(names, player.Pile)
+	}
+	cards := []*deck_repo.Card{}
+	for _, name := range names {
+		pile, ok := pileCards[name]
+		if !ok {
+			return nil, nil, errors.NotFoundError(fmt.Sprintf("the deck %s has no pile %q", request.DeckID, name), nil)
+		}
+		cards = append(cards, pile...)
+	}
+
+	// Validate all cards together, so a card in more than one pile of a shoe is rejected.
+	converted, err := poker.FromDeckCards(cards)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	board := converted[:len(pileCards[request.BoardPile])]
+	converted = converted[len(board):]
+
+	hands := make([][]poker.Card, 0, len(request.Players))
+	for _, player := range request.Players {
+		hands = append(hands, converted[:len(pileCards[player.Pile])])
+		converted = converted[len(pileCards[player.Pile]):]
+	}
+
+	return hands, board, nil
+}
+
+// Equity calculates the probabilities of every player to win or tie.
+// Accepts an EquityRequest JSON body with the hole cards of the players, the known part of the board and
+// the dead cards. The equity is calculated exactly when feasible, and otherwise estimated by dealing random
+// boards, up to the given number of iterations and within the time budget.
+// If any of the cards is invalid or known more than once, or the request is not valid for the game,
+// returns a 400 Bad Request error response.
+func (p *PokerService) Equity(ctx *gin.Context) {
+	request := &EquityRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+	if request.Iterations < 0 || request.Iterations > MaxEquityIterations {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("the number of iterations must be between 0 and %d", MaxEquityIterations), nil))
+		return
+	}
+
+	timeBudget := time.Duration(request.TimeBudgetMs) * time.Millisecond
+	if timeBudget <= 0 || timeBudget > MaxEquityTimeBudget {
+		timeBudget = MaxEquityTimeBudget
+	}
+	seed := request.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Parse all cards together, so a card known more than once is rejected.
+	codes := append(append([]string{}, request.Board...), request.Dead...)
+	for _, hand := range request.Hands {
+		codes = append(codes, hand...)
+	}
+	cards, err := poker.ParseCards(codes...)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	options := &poker.EquityOptions{
+		Game:       request.Game,
+		Board:      cards[:len(request.Board)],
+		Dead:       cards[len(request.Board) : len(request.Board)+len(request.Dead)],
+		Iterations: request.Iterations,
+		TimeBudget: timeBudget,
+		Seed:       seed,
+	}
+	cards = cards[len(request.Board)+len(request.Dead):]
+	for _, hand := range request.Hands {
+		options.Hands = append(options.Hands, cards[:len(hand)])
+		cards = cards[len(hand):]
+	}
+
+	result, err := poker.CalculateEquity(options)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &EquityResponse{
+		Exact:     result.Exact,
+		Boards:    result.Boards,
+		Truncated: result.Truncated,
+	}
+	if !result.Exact {
+		response.Seed = seed
+	}
+	for i, player := range result.Players {
+		response.Players = append(response.Players, PlayerEquityResponse{
+			Cards:  toCardResponses(options.Hands[i]),
+			Win:    player.Win,
+			Tie:    player.Tie,
+			Equity: player.Equity,
+			Wins:   player.Wins,
+			Ties:   player.Ties,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func toCardResponses(cards []poker.Card) []deck_api.CardResponse {
+	respCards := []deck_api.CardResponse{}
+
+	for _, card := range cards {
+		deckCard := &deck_repo.Card{
+			Value: card.Code(),
+		}
+		respCards = append(respCards, deck_api.CardResponse{
+			Code:  deckCard.Value,
+			Suit:  deckCard.SuitName(),
+			Value: deckCard.RankName(),
+		})
+	}
+
+	return respCards
+}
+
+// NewPokerService creates a new pointer to a PokerService taking the cards from the piles of the given Deck Service.
+func NewPokerService(decks *deck_api.DeckService) *PokerService {
+	return &PokerService{
+		Decks: decks,
+	}
+}
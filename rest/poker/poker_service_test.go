@@ -0,0 +1,342 @@
+package poker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) (*gin.Engine, *deck_api.DeckService) {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	decks := deck_api.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupPokerServiceRouting(router.Group("/v1"), NewPokerService(decks))
+
+	return router, decks
+}
+
+func TestEvaluate(t *testing.T) {
+	router, _ := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/poker/evaluate", strings.NewReader(`{"cards": ["KH", "KS", "2C", "KD", "2H", "9S", "AC"]}`))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead.", w.Code)
+	}
+
+	resp := &EvaluateResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the evaluation, but got error: %s", err.Error())
+	}
+	if resp.Category != "FULL_HOUSE" {
+		t.Errorf("Expected a full house, but got %s.", resp.Category)
+	}
+	if len(resp.Best) != 5 || resp.Best[0].Value != "KING" || resp.Best[4].Value != "2" {
+		t.Errorf("Expected the best five cards to be kings full of twos, but got: %+v", resp.Best)
+	}
+	if resp.Score == 0 {
+		t.Error("Expected the score to be set.")
+	}
+}
+
+func TestEvaluate_InvalidCards(t *testing.T) {
+	router, _ := setupTest(t)
+
+	for _, body := range []string{
+		`{"cards": ["KH", "KS", "2C", "KD"]}`,
+		`{"cards": ["KH", "KS", "2C", "KD", "KH"]}`,
+		`{"cards": ["KH", "KS", "2C", "KD", "1X"]}`,
+		`{"cards": "KH"}`,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/poker/evaluate", strings.NewReader(body))
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected response code 400 (Bad Request) for %s, but got %d instead.", body, w.Code)
+		}
+	}
+}
+
+func TestShowdown(t *testing.T) {
+	router, _ := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/poker/showdown", strings.NewReader(`{
+		"pot": 101,
+		"players": [
+			{"name": "alice", "cards": ["AS", "2D"]},
+			{"name": "bob", "cards": ["AH", "3D"]},
+			{"name": "carol", "cards": ["KS", "KD"]}
+		],
+		"board": ["AC", "QC", "JD", "9H", "8S"]
+	}`))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead: %s", w.Code, w.Body.String())
+	}
+
+	resp := &ShowdownResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the showdown, but got error: %s", err.Error())
+	}
+	if !resp.Split || len(resp.Winners) != 2 || resp.Winners[0] != 0 || resp.Winners[1] != 1 {
+		t.Fatalf("Expected alice and bob to split the pot, but got winners: %v", resp.Winners)
+	}
+	if resp.Players[0].Name != "alice" || resp.Players[0].Share != 51 || resp.Players[1].Share != 50 {
+		t.Errorf("Expected the pot to be split 51/50, but got: %+v", resp.Players)
+	}
+	if resp.Players[2].Category != "ONE_PAIR" || resp.Players[2].Rank != 3 {
+		t.Errorf("Expected carol to be third with a pair of kings, but got %s at rank %d.", resp.Players[2].Category, resp.Players[2].Rank)
+	}
+	if len(resp.Board) != 5 || len(resp.Players[0].Cards) != 2 {
+		t.Error("Expected the response to hold the board and the hole cards.")
+	}
+}
+
+// addPiles puts the cards on the named public piles of the deck.
+func addPiles(t *testing.T, decks *deck_api.DeckService, deckID string, piles map[string]string) {
+	token, err := deck_repo.NewToken()
+	if err != nil {
+		t.Fatalf("Failed to generate the token of the player: %s", err.Error())
+	}
+	player, err := decks.Players.CreatePlayer(&deck_repo.Player{DeckID: deckID, Name: "dealer", TokenHash: deck_repo.HashToken(token)})
+	if err != nil {
+		t.Fatalf("Failed to add a player: %s", err.Error())
+	}
+	for name, cards := range piles {
+		if _, err := decks.Players.MoveToPile(player, name, deck_repo.AsCards(cards)); err != nil {
+			t.Fatalf("Failed to put the cards on the pile %s: %s", name, err.Error())
+		}
+	}
+}
+
+func TestShowdown_FromPiles(t *testing.T) {
+	router, decks := setupTest(t)
+
+	deck, err := decks.Repository.CreateDeck(&deck_repo.Deck{})
+	if err != nil {
+		t.Fatalf("Failed to create deck: %s", err.Error())
+	}
+	addPiles(t, decks, deck.ID, map[string]string{
+		"alice": "AS,AD",
+		"bob":   "KS,KD",
+		"board": "AC,QC,JD,9H,8S",
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/poker/showdown", strings.NewReader(`{
+		"deck_id": "`+deck.ID+`",
+		"board_pile": "board",
+		"players": [{"name": "alice", "pile": "alice"}, {"name": "bob", "pile": "bob"}]
+	}`))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead: %s", w.Code, w.Body.String())
+	}
+
+	resp := &ShowdownResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the showdown, but got error: %s", err.Error())
+	}
+	if resp.Players[0].Cards[0].Code != "AS" || resp.Players[1].Cards[0].Code != "KS" || len(resp.Board) != 5 ||
+		resp.Board[0].Code != "AC" {
+		t.Errorf("Expected the cards of the piles, but got: %+v %+v", resp.Players, resp.Board)
+	}
+	if resp.Players[0].Category != "THREE_OF_A_KIND" || len(resp.Winners) != 1 || resp.Winners[0] != 0 {
+		t.Errorf("Expected alice to win with three aces, but got: %+v", resp)
+	}
+
+	unchanged, err := decks.Repository.GetDeck(deck.ID)
+	if err != nil {
+		t.Fatalf("Failed to get deck: %s", err.Error())
+	}
+	if unchanged.Remaining != 52 {
+		t.Errorf("Expected the deck not to change, but %d cards remain.", unchanged.Remaining)
+	}
+}
+
+func TestShowdown_FromPilesInvalid(t *testing.T) {
+	router, decks := setupTest(t)
+
+	shoe, err := decks.Repository.CreateDeck(&deck_repo.Deck{Packs: 2})
+	if err != nil {
+		t.Fatalf("Failed to create shoe: %s", err.Error())
+	}
+	addPiles(t, decks, shoe.ID, map[string]string{
+		"alice": "AS,AD",
+		"bob":   "AS,KD",
+		"board": "AC,QC,JD,9H,8S",
+	})
+
+	for name, test := range map[string]struct {
+		body string
+		code int
+	}{
+		"duplicated card": {`{"deck_id": "` + shoe.ID + `", "board_pile": "board",
+			"players": [{"pile": "alice"}, {"pile": "bob"}]}`, http.StatusBadRequest},
+		"no such pile": {`{"deck_id": "` + shoe.ID + `", "board_pile": "board",
+			"players": [{"pile": "alice"}, {"pile": "carol"}]}`, http.StatusNotFound},
+		"no board pile": {`{"deck_id": "` + shoe.ID + `",
+			"players": [{"pile": "alice"}, {"pile": "bob"}]}`, http.StatusBadRequest},
+		"cards with a deck": {`{"deck_id": "` + shoe.ID + `", "board_pile": "board",
+			"players": [{"pile": "alice"}, {"cards": ["2C", "3C"]}]}`, http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/poker/showdown", strings.NewReader(test.body))
+		router.ServeHTTP(w, req)
+
+		if w.Code != test.code {
+			t.Errorf("%s: expected response code %d, but got %d instead: %s", name, test.code, w.Code, w.Body.String())
+		}
+	}
+
+	unchanged, err := decks.Repository.GetDeck(shoe.ID)
+	if err != nil {
+		t.Fatalf("Failed to get shoe: %s", err.Error())
+	}
+	if unchanged.Remaining != 104 {
+		t.Errorf("Expected the shoe not to change, but %d cards remain.", unchanged.Remaining)
+	}
+}
+
+func TestShowdown_Invalid(t *testing.T) {
+	router, _ := setupTest(t)
+
+	for _, body := range []string{
+		`{"players": [{"cards": ["AS", "AD"]}, {"cards": ["AS", "KD"]}], "board": ["2C", "9C", "10C"]}`,
+		`{"players": [{"cards": ["AS", "AD"]}, {"cards": ["KS", "KD"]}], "board": ["AS", "9C", "10C"]}`,
+		`{"players": [{"cards": ["AS", "AD"]}], "board": ["2C", "9C", "10C"]}`,
+		`{"players": [{"cards": ["AS", "AD"]}, {"cards": ["KS", "KD"]}], "board": ["2C"]}`,
+		`{"game": "stud", "players": [{"cards": ["AS", "AD"]}, {"cards": ["KS", "KD"]}], "board": ["2C", "9C", "10C"]}`,
+		`{"pot": -1, "players": [{"cards": ["AS", "AD"]}, {"cards": ["KS", "KD"]}], "board": ["2C", "9C", "10C"]}`,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/poker/showdown", strings.NewReader(body))
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected response code 400 (Bad Request) for %s, but got %d instead.", body, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/poker/showdown", strings.NewReader(`{"deck_id": "missing", "board_pile": "board", "players": [{"pile": "a"}, {"pile": "b"}]}`))
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected response code 404 (Not Found) for a missing deck, but got %d instead.", w.Code)
+	}
+}
+
+func TestEquity(t *testing.T) {
+	router, _ := setupTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/v1/poker/equity", strings.NewReader(`{
+		"hands": [["AS", "AH"], ["KS", "KH"]],
+		"board": ["2C", "7D", "9H"],
+		"dead": ["KC"]
+	}`))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code 200 (OK), but got %d instead: %s", w.Code, w.Body.String())
+	}
+
+	resp := &EquityResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the equity, but got error: %s", err.Error())
+	}
+	// 44 remaining cards: C(44, 2) = 946 turn and river combinations.
+	if !resp.Exact || resp.Boards != 946 || resp.Seed != 0 {
+		t.Fatalf("Expected all 946 boards to be enumerated, but got %d (exact: %t).", resp.Boards, resp.Exact)
+	}
+	if len(resp.Players) != 2 || resp.Players[0].Equity < 0.9 || resp.Players[0].Cards[0].Code != "AS" {
+		t.Errorf("Expected aces to be a big favourite, but got: %+v", resp.Players)
+	}
+}
+
+func TestEquity_MonteCarlo(t *testing.T) {
+	router, _ := setupTest(t)
+
+	body := `{"game": "omaha", "hands": [["AS", "AH", "KS", "KH"], ["QC", "JC", "10D", "9D"]], "iterations": 5000, "seed": 7}`
+	var results []*EquityResponse
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/poker/equity", strings.NewReader(body))
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected response code 200 (OK), but got %d instead: %s", w.Code, w.Body.String())
+		}
+		resp := &EquityResponse{}
+		if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatalf("Expected to deserialize the equity, but got error: %s", err.Error())
+		}
+		results = append(results, resp)
+	}
+
+	if results[0].Exact || results[0].Boards != 5000 || results[0].Seed != 7 {
+		t.Fatalf("Expected 5000 random boards with seed 7, but got %d (exact: %t, seed: %d).", results[0].Boards, results[0].Exact, results[0].Seed)
+	}
+	if results[0].Players[0].Wins != results[1].Players[0].Wins {
+		t.Error("Expected the same seed to give the same estimate.")
+	}
+}
+
+func TestEquity_Invalid(t *testing.T) {
+	router, _ := setupTest(t)
+
+	for _, body := range []string{
+		`{"hands": [["AS", "AH"]]}`,
+		`{"hands": [["AS", "AH"], ["AS", "KH"]]}`,
+		`{"hands": [["AS", "AH"], ["KS", "KH"]], "dead": ["AH"]}`,
+		`{"hands": [["AS", "AH"], ["KS", "KH"]], "board": ["2C"]}`,
+		`{"hands": [["AS", "AH"], ["KS", "1X"]]}`,
+		`{"hands": [["AS", "AH"], ["KS", "KH"]], "iterations": 100000000}`,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/v1/poker/equity", strings.NewReader(body))
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected response code 400 (Bad Request) for %s, but got %d instead.", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package poker
+
+import "github.com/gin-gonic/gin"
+
+// SetupPokerServiceRouting sets up the routing for PokerService with gin router.
+func SetupPokerServiceRouting(group *gin.RouterGroup, pokerService *PokerService) {
+	group.POST("/poker/evaluate", pokerService.Evaluate)
+	group.POST("/poker/showdown", pokerService.Showdown)
+	group.POST("/poker/equity", pokerService.Equity)
+}
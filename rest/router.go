@@ -6,13 +6,80 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/natemago/card-games-api/config"
 	"github.com/natemago/card-games-api/errors"
+	baccarat_api "github.com/natemago/card-games-api/rest/baccarat"
+	blackjack_api "github.com/natemago/card-games-api/rest/blackjack"
+	bridge_api "github.com/natemago/card-games-api/rest/bridge"
+	cribbage_api "github.com/natemago/card-games-api/rest/cribbage"
 	deck_api "github.com/natemago/card-games-api/rest/deck"
+	games_api "github.com/natemago/card-games-api/rest/games"
+	graphql_api "github.com/natemago/card-games-api/rest/graphql"
+	health_api "github.com/natemago/card-games-api/rest/health"
+	holdem_api "github.com/natemago/card-games-api/rest/holdem"
+	klondike_api "github.com/natemago/card-games-api/rest/klondike"
+	poker_api "github.com/natemago/card-games-api/rest/poker"
+	rummy_api "github.com/natemago/card-games-api/rest/rummy"
+	tricks_api "github.com/natemago/card-games-api/rest/tricks"
 )
 
+// Services holds all of the REST API services that are routed by the API.
+type Services struct {
+	// DeckService is the service for the deck resource.
+	DeckService *deck_api.DeckService
+
+	// HealthService is the service that reports the health of the API.
+	HealthService *health_api.HealthService
+
+	// PokerService is the service for evaluating poker hands.
+	PokerService *poker_api.PokerService
+
+	// TableService is the service for the tables of Texas Hold'em.
+	TableService *holdem_api.TableService
+
+	// BlackjackService is the service for the blackjack tables.
+	BlackjackService *blackjack_api.TableService
+
+	// KlondikeService is the service for the games of Klondike solitaire.
+	KlondikeService *klondike_api.GameService
+
+	// TricksService is the service for the trick-taking games, like Hearts and Spades.
+	TricksService *tricks_api.GameService
+
+	// BridgeService is the service for dealing bridge boards.
+	BridgeService *bridge_api.BridgeService
+
+	// CribbageService is the service for scoring cribbage hands and pegging.
+	CribbageService *cribbage_api.CribbageService
+
+	// RummyService is the service for arranging rummy hands into melds and scoring knocks.
+	RummyService *rummy_api.RummyService
+
+	// BaccaratService is the service for the baccarat shoes and rounds.
+	BaccaratService *baccarat_api.ShoeService
+
+	// GamesService is the service for the sessions of the turn-based games with registered rule engines.
+	GamesService *games_api.SessionService
+
+	// GraphQLService is the service for the GraphQL endpoint of the decks.
+	GraphQLService *graphql_api.GraphQLService
+}
+
 // SetupRouting sets up the routing for the whole API.
-func SetupRouting(router *gin.Engine, deckService deck_api.DeckService) {
+func SetupRouting(router *gin.Engine, services *Services) {
+	health_api.SetupHealthServiceRouting(router, services.HealthService)
+
 	v1group := router.Group("/v1")
-	deck_api.SetupDeckServiceRouting(v1group, &deckService)
+	deck_api.SetupDeckServiceRouting(v1group, services.DeckService)
+	poker_api.SetupPokerServiceRouting(v1group, services.PokerService)
+	holdem_api.SetupTableServiceRouting(v1group, services.TableService)
+	blackjack_api.SetupTableServiceRouting(v1group, services.BlackjackService)
+	klondike_api.SetupGameServiceRouting(v1group, services.KlondikeService)
+	tricks_api.SetupGameServiceRouting(v1group, services.TricksService)
+	bridge_api.SetupBridgeServiceRouting(v1group, services.BridgeService)
+	cribbage_api.SetupCribbageServiceRouting(v1group, services.CribbageService)
+	rummy_api.SetupRummyServiceRouting(v1group, services.RummyService)
+	baccarat_api.SetupShoeServiceRouting(v1group, services.BaccaratService)
+	games_api.SetupSessionServiceRouting(v1group, services.GamesService)
+	graphql_api.SetupGraphQLServiceRouting(v1group, services.GraphQLService)
 }
 
 // SetupAPI sets up the gin router for the whole API based on the provided API Configuration.
@@ -27,10 +94,10 @@ func SetupAPI(conf *config.APIConfig) *gin.Engine {
 // RunAPI sets up the API, then sets up routing with gin and finally binds and runs the gin router binding to host and port.
 // This will basically set up the whole API, then run the HTTP server to accept connections.
 // Blocks until the server is shut down.
-func RunAPI(conf *config.APIConfig, deckService deck_api.DeckService) error {
+func RunAPI(conf *config.APIConfig, services *Services) error {
 	router := SetupAPI(conf)
 
-	SetupRouting(router, deckService)
+	SetupRouting(router, services)
 
 	bindAddress := fmt.Sprintf("%s:%d", conf.Host, conf.Port)
 
@@ -0,0 +1,97 @@
+package rummy
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// MeldsRequest represents the request of a Melds call.
+type MeldsRequest struct {
+	// Cards is a comma-separated list of the cards of the hand, like "AS,2S,3S,7H,7D,7C". A joker is given as
+	// "JOKER".
+	Cards string `json:"cards" binding:"required"`
+}
+
+// KnockRequest represents the request of a Knock call.
+type KnockRequest struct {
+	// Knocker is a comma-separated list of the ten cards of the knocker, after the discard.
+	Knocker string `json:"knocker" binding:"required"`
+
+	// Defender is a comma-separated list of the ten cards of the defender.
+	Defender string `json:"defender" binding:"required"`
+
+	// KnockLimit is the maximal deadwood value to knock with. Default is 10.
+	KnockLimit *int `json:"knock_limit"`
+}
+
+// MeldResponse represents a meld.
+type MeldResponse struct {
+	// Kind is the kind of the meld: SET or RUN.
+	Kind string `json:"kind"`
+
+	// Cards are the cards of the meld. The cards of a run are ordered by rank.
+	Cards []deck_api.CardResponse `json:"cards"`
+}
+
+// ArrangementResponse represents an arrangement of a hand into melds and deadwood.
+type ArrangementResponse struct {
+	// Melds holds the melds of the arrangement.
+	Melds []MeldResponse `json:"melds"`
+
+	// Deadwood holds the cards left out of the melds.
+	Deadwood []deck_api.CardResponse `json:"deadwood"`
+
+	// DeadwoodPoints is the deadwood value of the arrangement.
+	DeadwoodPoints int `json:"deadwood_points"`
+}
+
+// MeldsResponse represents the response of a Melds call.
+type MeldsResponse struct {
+	// Arrangements holds all arrangements with the least deadwood.
+	Arrangements []ArrangementResponse `json:"arrangements"`
+
+	// DeadwoodPoints is the least deadwood value of the hand.
+	DeadwoodPoints int `json:"deadwood_points"`
+
+	// CanKnock is a flag whether the deadwood is low enough to knock with.
+	CanKnock bool `json:"can_knock"`
+
+	// Gin is a flag whether all of the cards are melded.
+	Gin bool `json:"gin"`
+}
+
+// LayoffResponse represents a card laid off onto a meld of the knocker.
+type LayoffResponse struct {
+	// Card is the card laid off.
+	Card deck_api.CardResponse `json:"card"`
+
+	// Meld is the index of the meld of the knocker the card is laid off onto.
+	Meld int `json:"meld"`
+}
+
+// KnockResponse represents the response of a Knock call.
+type KnockResponse struct {
+	// Knocker is the arrangement declared by the knocker.
+	Knocker ArrangementResponse `json:"knocker"`
+
+	// Defender is the arrangement of the defender, before the layoffs.
+	Defender ArrangementResponse `json:"defender"`
+
+	// Layoffs holds the cards of the defender laid off onto the melds of the knocker.
+	Layoffs []LayoffResponse `json:"layoffs"`
+
+	// DefenderDeadwood holds the deadwood of the defender left after the layoffs.
+	DefenderDeadwood []deck_api.CardResponse `json:"defender_deadwood"`
+
+	// DefenderDeadwoodPoints is the deadwood value of the defender after the layoffs.
+	DefenderDeadwoodPoints int `json:"defender_deadwood_points"`
+
+	// Gin is a flag whether the knocker went gin.
+	Gin bool `json:"gin"`
+
+	// Undercut is a flag whether the defender undercut the knocker.
+	Undercut bool `json:"undercut"`
+
+	// Winner is the winner of the hand: KNOCKER or DEFENDER.
+	Winner string `json:"winner"`
+
+	// Points is the number of points scored by the winner.
+	Points int `json:"points"`
+}
@@ -0,0 +1,9 @@
+package rummy
+
+import "github.com/gin-gonic/gin"
+
+// SetupRummyServiceRouting sets up the routing for RummyService with gin router.
+func SetupRummyServiceRouting(group *gin.RouterGroup, rummyService *RummyService) {
+	group.POST("/rummy/melds", rummyService.Melds)
+	group.POST("/rummy/knocks", rummyService.Knock)
+}
@@ -0,0 +1,120 @@
+package rummy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+	"github.com/natemago/card-games-api/rummy"
+)
+
+// RummyService represents the REST API service for arranging rummy hands into melds and scoring knocks.
+type RummyService struct{}
+
+// Melds finds all optimal arrangements of a hand into sets and runs, the ones with the least deadwood.
+// Accepts a MeldsRequest JSON body with the cards of the hand, where jokers are wild.
+// Returns the arrangements, the deadwood value and whether the hand can knock or goes gin.
+// If the cards are not valid, returns a 400 Bad Request error response.
+func (s *RummyService) Melds(ctx *gin.Context) {
+	request := &MeldsRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	arrangements, err := rummy.Arrange(deck_repo.AsCards(request.Cards))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &MeldsResponse{
+		Arrangements:   []ArrangementResponse{},
+		DeadwoodPoints: arrangements[0].Points,
+		CanKnock:       arrangements[0].Points <= rummy.KnockLimit,
+		Gin:            arrangements[0].Points == 0,
+	}
+	for _, arrangement := range arrangements {
+		response.Arrangements = append(response.Arrangements, toArrangementResponse(arrangement))
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Knock validates a knock in gin rummy and scores the hand, with the layoffs of the defender.
+// Accepts a KnockRequest JSON body with the cards of the knocker and the defender, and an optional knock limit.
+// Returns the arrangements of both players, the layoffs, the deadwood left to the defender, the winner and the points.
+// If the cards are not valid, or the knocker has too much deadwood to knock, returns a 400 Bad Request error
+// response.
+func (s *RummyService) Knock(ctx *gin.Context) {
+	request := &KnockRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	knockLimit := rummy.KnockLimit
+	if request.KnockLimit != nil {
+		knockLimit = *request.KnockLimit
+	}
+
+	result, err := rummy.Knock(deck_repo.AsCards(request.Knocker), deck_repo.AsCards(request.Defender), knockLimit)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := &KnockResponse{
+		Knocker:                toArrangementResponse(result.Knocker),
+		Defender:               toArrangementResponse(result.Defender),
+		Layoffs:                []LayoffResponse{},
+		DefenderDeadwood:       toCardResponses(result.Deadwood),
+		DefenderDeadwoodPoints: result.Points,
+		Gin:                    result.Gin,
+		Undercut:               result.Undercut,
+		Winner:                 result.Winner,
+		Points:                 result.Score,
+	}
+	for _, layoff := range result.Layoffs {
+		response.Layoffs = append(response.Layoffs, LayoffResponse{
+			Card: toCardResponses([]*deck_repo.Card{layoff.Card})[0],
+			Meld: layoff.Meld,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func toArrangementResponse(arrangement *rummy.Arrangement) ArrangementResponse {
+	response := ArrangementResponse{
+		Melds:          []MeldResponse{},
+		Deadwood:       toCardResponses(arrangement.Deadwood),
+		DeadwoodPoints: arrangement.Points,
+	}
+	for _, meld := range arrangement.Melds {
+		response.Melds = append(response.Melds, MeldResponse{
+			Kind:  meld.Kind,
+			Cards: toCardResponses(meld.Cards),
+		})
+	}
+	return response
+}
+
+// toCardResponses converts the cards like deck_api.ToCardResponses, with the value of a joker being JOKER.
+func toCardResponses(cards []*deck_repo.Card) []deck_api.CardResponse {
+	respCards := deck_api.ToCardResponses(cards)
+	for i, card := range cards {
+		if rummy.IsJoker(card) {
+			respCards[i].Value = rummy.JokerCode
+		}
+	}
+	return respCards
+}
+
+// NewRummyService creates a new pointer to a RummyService.
+func NewRummyService() *RummyService {
+	return &RummyService{}
+}
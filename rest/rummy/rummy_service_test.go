@@ -0,0 +1,99 @@
+package rummy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+)
+
+func setupTest() *gin.Engine {
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupRummyServiceRouting(router.Group("/v1"), NewRummyService())
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, path, body string, expectedCode int) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s, but got %d instead: %s", expectedCode, path, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func TestMelds(t *testing.T) {
+	router := setupTest()
+
+	w := call(t, router, "/v1/rummy/melds", `{"cards": "10H,10D,10S,JS,QS,2C,3C,JOKER,8D,KH"}`, http.StatusOK)
+
+	resp := &MeldsResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the melds, but got error: %s", err.Error())
+	}
+	if resp.DeadwoodPoints != 23 || !strings.Contains(w.Body.String(), `"gin":false`) || resp.CanKnock {
+		t.Errorf("Expected 23 deadwood, but got: %s", w.Body.String())
+	}
+	if len(resp.Arrangements) != 2 {
+		t.Fatalf("Expected the joker in the set of tens or in the run of spades, but got: %+v", resp.Arrangements)
+	}
+	for _, arrangement := range resp.Arrangements {
+		if len(arrangement.Melds) != 2 || arrangement.DeadwoodPoints != 23 {
+			t.Errorf("Expected 2 melds and 23 deadwood, but got: %+v", arrangement)
+		}
+	}
+
+	w = call(t, router, "/v1/rummy/melds", `{"cards": "AS,2S,3S,4S,5H,5D,5C,9D,10D,JD"}`, http.StatusOK)
+	resp = &MeldsResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the melds, but got error: %s", err.Error())
+	}
+	if !resp.Gin || !resp.CanKnock || len(resp.Arrangements[0].Deadwood) != 0 {
+		t.Errorf("Expected gin, but got: %s", w.Body.String())
+	}
+}
+
+func TestKnock(t *testing.T) {
+	router := setupTest()
+
+	w := call(t, router, "/v1/rummy/knocks", `{
+		"knocker": "AS,2S,3S,7H,7D,7C,9D,10D,JD,2C",
+		"defender": "KH,KD,KC,QH,QS,QC,7S,8D,3H,AC"
+	}`, http.StatusOK)
+
+	resp := &KnockResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the knock, but got error: %s", err.Error())
+	}
+	if resp.Knocker.DeadwoodPoints != 2 || len(resp.Layoffs) != 2 || resp.DefenderDeadwoodPoints != 4 {
+		t.Errorf("Expected a knock with 2 deadwood and 2 layoffs, but got: %s", w.Body.String())
+	}
+	if resp.Winner != "KNOCKER" || resp.Points != 2 || resp.Gin || resp.Undercut {
+		t.Errorf("Expected the knocker to score 2, but got: %s", w.Body.String())
+	}
+
+	call(t, router, "/v1/rummy/knocks", `{
+		"knocker": "AS,2S,3S,7H,7D,7C,9D,10D,JD,2C",
+		"defender": "KH,KD,KC,QH,QS,QC,7S,8D,3H,AC",
+		"knock_limit": 0
+	}`, http.StatusBadRequest)
+}
+
+func TestRummy_Invalid(t *testing.T) {
+	router := setupTest()
+
+	call(t, router, "/v1/rummy/melds", `{}`, http.StatusBadRequest)
+	call(t, router, "/v1/rummy/melds", `{"cards": "AS,AS,2S"}`, http.StatusBadRequest)
+	call(t, router, "/v1/rummy/melds", `{"cards": "JOKER,JOKER,JOKER"}`, http.StatusBadRequest)
+	call(t, router, "/v1/rummy/knocks", `{"knocker": "AS,2S,3S", "defender": "KH,KD,KC"}`, http.StatusBadRequest)
+}
@@ -0,0 +1,228 @@
+package tricks
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+	deck_api "github.com/natemago/card-games-api/rest/deck"
+	"github.com/natemago/card-games-api/tricks"
+)
+
+// GameService represents the REST API service for trick-taking games, like Hearts and Spades.
+// Uses the GameRepository to store the games, and the DeckRepository to deal the cards.
+type GameService struct {
+	Games tricks_repo.GameRepository
+	Decks deck_repo.DeckRepository
+}
+
+// CreateGame creates a new game and deals the first hand from a new shuffled deck.
+// Accepts a CreateGameRequest JSON body with the variant and the names of the players. Every player gets a secret
+// token, shown only once, to view the game from the seat of the player, to bid and to play. The game is returned as
+// viewed by no player.
+// If the variant is not supported or the number of players does not match it, returns a 400 Bad Request
+// error response.
+func (s *GameService) CreateGame(ctx *gin.Context) {
+	request := &CreateGameRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	game, err := tricks.NewGame(strings.ToLower(request.Variant), request.Players, s.Decks)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tokens := []string{}
+	for _, player := range game.Players {
+		token, err := deck_repo.NewToken()
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		player.TokenHash = deck_repo.HashToken(token)
+		tokens = append(tokens, token)
+	}
+
+	game, err = s.Games.CreateGame(game)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	response := toGameResponse(game, deck_api.NoSeat)
+	for seat, token := range tokens {
+		response.Players[seat].Token = token
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetGame looks up a game by its ID and returns its state, viewed from the seat of the token of the request: only
+// the hand of that seat is shown. Without a token, all hands are hidden.
+// If the game does not exist, returns a 404 Not Found error response. If the token is not the token of a player of
+// the game, returns a 401 Unauthorized error response.
+func (s *GameService) GetGame(ctx *gin.Context) {
+	game, seat, err := s.getGame(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toGameResponse(game, seat))
+}
+
+// StartHand deals the next hand once the current hand is over. Any player of the game may deal, and the game is
+// returned as viewed from the seat of the token of the request.
+// If there is no token, or it is not the token of a player of the game, returns a 401 Unauthorized error response.
+// If the hand is not over, returns a 400 Bad Request error response.
+func (s *GameService) StartHand(ctx *gin.Context) {
+	s.updateGame(ctx, func(game *tricks_repo.Game, seat int) error {
+		return tricks.StartHand(game, s.Decks)
+	})
+}
+
+// Bid makes the bid given as a BidRequest JSON body, for the seat of the token of the request. The game is returned
+// as viewed from the bidding player.
+// If there is no token, or it is not the token of a player of the game, returns a 401 Unauthorized error response.
+// If it is not the turn of the player or the bid is not valid, returns a 400 Bad Request error response.
+func (s *GameService) Bid(ctx *gin.Context) {
+	request := &BidRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateGame(ctx, func(game *tricks_repo.Game, seat int) error {
+		return tricks.Bid(game, seat, request.Bid)
+	})
+}
+
+// Play plays the card given as a PlayRequest JSON body, for the seat of the token of the request. The game is
+// returned as viewed from the playing player.
+// If there is no token, or it is not the token of a player of the game, returns a 401 Unauthorized error response.
+// If it is not the turn of the player or the card may not be played, returns a 400 Bad Request error response.
+func (s *GameService) Play(ctx *gin.Context) {
+	request := &PlayRequest{}
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		ctx.Error(errors.BadRequestError(fmt.Sprintf("invalid request: %s", err.Error()), err))
+		return
+	}
+
+	s.updateGame(ctx, func(game *tricks_repo.Game, seat int) error {
+		return tricks.Play(game, seat, strings.ToUpper(request.Card))
+	})
+}
+
+// getGame looks up the game from the path, and the seat of the token of the request (see deck_api.RequestSeat).
+func (s *GameService) getGame(ctx *gin.Context) (*tricks_repo.Game, int, error) {
+	game, err := s.Games.GetGame(ctx.Param("gameId"))
+	if err != nil {
+		return nil, deck_api.NoSeat, err
+	}
+
+	seat, err := deck_api.RequestSeat(ctx, game.TokenHashes())
+	if err != nil {
+		return nil, deck_api.NoSeat, err
+	}
+	return game, seat, nil
+}
+
+// updateGame looks up the game from the path, applies the change as the seat of the token of the request and stores
+// the game. The change must be made by a player of the game, otherwise an UnauthorizedError is returned.
+func (s *GameService) updateGame(ctx *gin.Context, change func(game *tricks_repo.Game, seat int) error) {
+	game, seat, err := s.getGame(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if seat == deck_api.NoSeat {
+		ctx.Error(errors.UnauthorizedError("the token of a player is required", nil))
+		return
+	}
+
+	if err := change(game, seat); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	game, err = s.Games.UpdateGame(game)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toGameResponse(game, seat))
+}
+
+func toGameResponse(game *tricks_repo.Game, seat int) *GameResponse {
+	rules, _ := tricks.GetRuleSet(game.Variant)
+
+	response := &GameResponse{
+		GameID:     game.ID,
+		Variant:    game.Variant,
+		Stage:      game.Stage,
+		HandNumber: game.HandNumber,
+		Dealer:     game.Dealer,
+		Turn:       game.Turn,
+		Broken:     game.Broken,
+		Trick:      toPlayResponses(game.TrickCards(), game.Leader, len(game.Players)),
+		LastTrick:  toPlayResponses(game.LastTrickCards(), game.LastTrickLeader, len(game.Players)),
+		Players:    []PlayerResponse{},
+		LegalPlays: deck_api.ToCardResponses(tricks.LegalPlays(game, seat)),
+		Winners:    tricks.Winners(game),
+	}
+	if rules != nil {
+		response.Trump = rules.Trump()
+	}
+	if game.LastTrick != "" {
+		winner := game.LastTrickWinner
+		response.LastTrickWinner = &winner
+	}
+
+	for _, player := range game.Players {
+		playerResponse := PlayerResponse{
+			Seat:        player.Seat,
+			Name:        player.Name,
+			CardsInHand: len(player.HandCards()),
+			Tricks:      player.Tricks,
+			HandScore:   player.HandScore,
+			Score:       player.Score,
+			Bags:        player.Bags,
+		}
+		if player.Seat == seat {
+			playerResponse.Cards = deck_api.ToCardResponses(player.HandCards())
+		}
+		if player.Bid != tricks_repo.NoBid {
+			bid := player.Bid
+			playerResponse.Bid = &bid
+		}
+		response.Players = append(response.Players, playerResponse)
+	}
+
+	return response
+}
+
+func toPlayResponses(cards []*deck_repo.Card, leader, players int) []PlayResponse {
+	plays := []PlayResponse{}
+	for i, card := range deck_api.ToCardResponses(cards) {
+		plays = append(plays, PlayResponse{
+			Seat: (leader + i) % players,
+			Card: card,
+		})
+	}
+	return plays
+}
+
+// NewGameService creates a new pointer to a GameService using the given repositories.
+func NewGameService(gameRepository tricks_repo.GameRepository, deckRepository deck_repo.DeckRepository) *GameService {
+	return &GameService{
+		Games: gameRepository,
+		Decks: deckRepository,
+	}
+}
@@ -0,0 +1,154 @@
+package tricks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+func setupTest(t *testing.T) *gin.Engine {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	router := gin.Default()
+	router.Use(errors.ErrorHandler())
+
+	SetupGameServiceRouting(router.Group("/v1"), NewGameService(tricks_repo.NewDBGameRepository(db), deck_repo.NewDBDeckRepository(db)))
+
+	return router
+}
+
+func call(t *testing.T, router *gin.Engine, method, path, token, body string, expectedCode int) *GameResponse {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedCode {
+		t.Fatalf("Expected response code %d for %s %s, but got %d instead: %s", expectedCode, method, path, w.Code, w.Body.String())
+	}
+	if expectedCode >= 400 {
+		return nil
+	}
+
+	resp := &GameResponse{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("Expected to deserialize the game, but got error: %s", err.Error())
+	}
+	return resp
+}
+
+func TestHeartsFlow(t *testing.T) {
+	router := setupTest(t)
+
+	game := call(t, router, "POST", "/v1/tricks/games", "", `{
+		"variant": "hearts",
+		"players": ["north", "east", "south", "west"]
+	}`, http.StatusCreated)
+	if game.Stage != "PLAYING" || game.Trump != "" || len(game.Players) != 4 || game.Players[2].Name != "south" {
+		t.Fatalf("Expected a new game of Hearts, but got: %+v", game)
+	}
+	tokens := []string{}
+	for _, player := range game.Players {
+		if player.CardsInHand != 13 || player.Cards != nil || player.Token == "" {
+			t.Errorf("Expected the hands to be hidden and a token for every player, but got: %+v", player)
+		}
+		tokens = append(tokens, player.Token)
+	}
+	path := "/v1/tricks/games/" + game.GameID
+	leader := game.Turn
+
+	game = call(t, router, "GET", path, tokens[leader], "", http.StatusOK)
+	if len(game.Players[leader].Cards) != 13 || len(game.LegalPlays) != 1 || game.LegalPlays[0].Code != "2C" {
+		t.Fatalf("Expected the leader to see the hand and lead 2C, but got: %+v", game)
+	}
+	for _, player := range game.Players {
+		if player.Seat != leader && player.Cards != nil {
+			t.Errorf("Expected the other hands to be hidden, but got: %+v", player)
+		}
+	}
+
+	// the next player may not play for the leader by naming the seat of the leader in the body
+	call(t, router, "POST", path+"/plays", tokens[(leader+1)%4], fmt.Sprintf(`{"seat": %d, "card": "2C"}`, leader),
+		http.StatusBadRequest)
+	call(t, router, "POST", path+"/plays", "", `{"card": "2C"}`, http.StatusUnauthorized)
+	call(t, router, "POST", path+"/plays", "invalid", `{"card": "2C"}`, http.StatusUnauthorized)
+	call(t, router, "POST", path+"/bids", tokens[leader], `{"bid": 3}`, http.StatusBadRequest)
+	call(t, router, "POST", path+"/hands", "", "", http.StatusUnauthorized)
+	call(t, router, "POST", path+"/hands", tokens[leader], "", http.StatusBadRequest)
+
+	game = call(t, router, "POST", path+"/plays", tokens[leader], `{"card": "2c"}`, http.StatusOK)
+	if len(game.Trick) != 1 || game.Trick[0].Seat != leader || game.Trick[0].Card.Code != "2C" || game.Turn != (leader+1)%4 {
+		t.Errorf("Expected 2C in the trick, but got: %+v", game)
+	}
+	if game.Players[leader].CardsInHand != 12 || len(game.LegalPlays) != 0 {
+		t.Errorf("Expected the card to leave the hand, but got: %+v", game.Players[leader])
+	}
+}
+
+func TestSpadesFlow(t *testing.T) {
+	router := setupTest(t)
+
+	game := call(t, router, "POST", "/v1/tricks/games", "", `{
+		"variant": "Spades",
+		"players": ["north", "east", "south", "west"]
+	}`, http.StatusCreated)
+	if game.Stage != "BIDDING" || game.Trump != "S" || game.Turn != 1 {
+		t.Fatalf("Expected the bidding to start left of the dealer, but got: %+v", game)
+	}
+	path := "/v1/tricks/games/" + game.GameID
+	tokens := []string{}
+	for _, player := range game.Players {
+		tokens = append(tokens, player.Token)
+	}
+
+	call(t, router, "POST", path+"/bids", tokens[1], `{"bid": 14}`, http.StatusBadRequest)
+	for _, seat := range []int{1, 2, 3, 0} {
+		game = call(t, router, "POST", path+"/bids", tokens[seat], fmt.Sprintf(`{"bid": %d}`, seat), http.StatusOK)
+	}
+
+	if game.Stage != "PLAYING" || game.Turn != 1 || game.Players[0].Bid == nil || *game.Players[0].Bid != 0 {
+		t.Fatalf("Expected the play to start after the bidding, but got: %+v", game)
+	}
+	if len(game.Players[0].Cards) != 13 || len(game.LegalPlays) != 0 {
+		t.Errorf("Expected the hand of the last bidder, but got: %+v", game)
+	}
+}
+
+func TestCreateGame_Invalid(t *testing.T) {
+	router := setupTest(t)
+
+	call(t, router, "POST", "/v1/tricks/games", "", `{"variant": "canasta", "players": ["a", "b", "c", "d"]}`, http.StatusBadRequest)
+	call(t, router, "POST", "/v1/tricks/games", "", `{"variant": "hearts", "players": ["a", "b"]}`, http.StatusBadRequest)
+	call(t, router, "GET", "/v1/tricks/games/missing", "", "", http.StatusNotFound)
+
+	game := call(t, router, "POST", "/v1/tricks/games", "", `{"variant": "hearts", "players": ["a", "b", "c", "d"]}`,
+		http.StatusCreated)
+	other := call(t, router, "POST", "/v1/tricks/games", "", `{"variant": "hearts", "players": ["e", "f", "g", "h"]}`,
+		http.StatusCreated)
+	call(t, router, "GET", "/v1/tricks/games/"+game.GameID, "invalid", "", http.StatusUnauthorized)
+	call(t, router, "GET", "/v1/tricks/games/"+game.GameID, other.Players[0].Token, "", http.StatusUnauthorized)
+}
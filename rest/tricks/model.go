@@ -0,0 +1,112 @@
+package tricks
+
+import deck_api "github.com/natemago/card-games-api/rest/deck"
+
+// CreateGameRequest represents the request of a CreateGame call.
+type CreateGameRequest struct {
+	// Variant is the game: "hearts" or "spades".
+	Variant string `json:"variant"`
+
+	// Players holds the names of the players, in the order of their seats.
+	Players []string `json:"players"`
+}
+
+// BidRequest represents the request of a Bid call. The bid is made by the seat of the token of the request.
+type BidRequest struct {
+	// Bid is the number of tricks the player bids, 0 for nil.
+	Bid int `json:"bid"`
+}
+
+// PlayRequest represents the request of a Play call. The card is played by the seat of the token of the request.
+type PlayRequest struct {
+	// Card is the code of the played card, like "QS".
+	Card string `json:"card"`
+}
+
+// PlayResponse represents a card played to a trick.
+type PlayResponse struct {
+	// Seat is the seat of the player that played the card.
+	Seat int `json:"seat"`
+
+	// Card is the played card.
+	Card deck_api.CardResponse `json:"card"`
+}
+
+// PlayerResponse represents a player in a GameResponse.
+type PlayerResponse struct {
+	// Seat is the position of the player.
+	Seat int `json:"seat"`
+
+	// Name is the name of the player.
+	Name string `json:"name"`
+
+	// CardsInHand is the number of cards in the hand of the player.
+	CardsInHand int `json:"cards_in_hand"`
+
+	// Cards holds the cards in the hand of the player. Only the hand of the seat the game is viewed from is shown.
+	Cards []deck_api.CardResponse `json:"cards,omitempty"`
+
+	// Bid is the bid of the player in the current hand, if the player has bid.
+	Bid *int `json:"bid,omitempty"`
+
+	// Tricks is the number of tricks the player won in the current hand.
+	Tricks int `json:"tricks"`
+
+	// HandScore is the score of the player in the last scored hand.
+	HandScore int `json:"hand_score"`
+
+	// Score is the total score of the player.
+	Score int `json:"score"`
+
+	// Bags is the number of accumulated overtricks, in games that count them.
+	Bags int `json:"bags"`
+
+	// Token is the secret token of the player, to view the game from the seat of the player, to bid and to play. It
+	// is shown only once, when the game is created.
+	Token string `json:"token,omitempty"`
+}
+
+// GameResponse represents the state of a trick-taking game, viewed from a seat.
+type GameResponse struct {
+	// GameID is the ID of the game.
+	GameID string `json:"game_id"`
+
+	// Variant is the game, like "hearts".
+	Variant string `json:"variant"`
+
+	// Stage is the stage of the current hand: BIDDING, PLAYING, HAND_OVER or GAME_OVER.
+	Stage string `json:"stage"`
+
+	// HandNumber is the number of hands dealt.
+	HandNumber int `json:"hand_number"`
+
+	// Dealer is the seat of the dealer of the current hand.
+	Dealer int `json:"dealer"`
+
+	// Turn is the seat of the player to bid or to play.
+	Turn int `json:"turn"`
+
+	// Trump is the code of the trump suit, or empty if there is no trump.
+	Trump string `json:"trump,omitempty"`
+
+	// Broken is true once the suit that may not be led at first was played.
+	Broken bool `json:"broken"`
+
+	// Trick holds the cards played to the current trick.
+	Trick []PlayResponse `json:"trick"`
+
+	// LastTrick holds the cards of the last complete trick.
+	LastTrick []PlayResponse `json:"last_trick"`
+
+	// LastTrickWinner is the seat of the player that won the last complete trick.
+	LastTrickWinner *int `json:"last_trick_winner,omitempty"`
+
+	// Players holds the players, ordered by seat.
+	Players []PlayerResponse `json:"players"`
+
+	// LegalPlays holds the cards the viewing player may play, if it is the turn of the player.
+	LegalPlays []deck_api.CardResponse `json:"legal_plays"`
+
+	// Winners holds the seats of the winners once the game is over.
+	Winners []int `json:"winners,omitempty"`
+}
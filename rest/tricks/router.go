@@ -0,0 +1,12 @@
+package tricks
+
+import "github.com/gin-gonic/gin"
+
+// SetupGameServiceRouting sets up the routing for GameService with gin router.
+func SetupGameServiceRouting(group *gin.RouterGroup, gameService *GameService) {
+	group.POST("/tricks/games", gameService.CreateGame)
+	group.GET("/tricks/games/:gameId", gameService.GetGame)
+	group.POST("/tricks/games/:gameId/hands", gameService.StartHand)
+	group.POST("/tricks/games/:gameId/bids", gameService.Bid)
+	group.POST("/tricks/games/:gameId/plays", gameService.Play)
+}
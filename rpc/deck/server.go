@@ -0,0 +1,173 @@
+package deck
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/natemago/card-games-api/events"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_svcs "github.com/natemago/card-games-api/rest/deck"
+	rpc_errors "github.com/natemago/card-games-api/rpc/errors"
+)
+
+// Server implements the gRPC DeckService on top of the Deck Service of the REST API, so both APIs share the same
+// permissions, the event log and the hub of the deck events.
+type Server struct {
+	UnimplementedDeckServiceServer
+
+	// Decks is the Deck Service of the REST API.
+	Decks *deck_svcs.DeckService
+}
+
+// CreateDeck creates a new deck of cards. Without cards, a full deck is created.
+// Returns an InvalidArgument error if the cards contain invalid or duplicated values.
+func (s *Server) CreateDeck(ctx context.Context, request *CreateDeckRequest) (*CreateDeckResponse, error) {
+	var cards []*deck_repo.Card
+	if len(request.Cards) > 0 {
+		cards = deck_repo.AsCards(strings.Join(request.Cards, ","))
+	}
+	packs := int(request.Packs)
+	if packs == 0 {
+		packs = 1
+	}
+
+	deck, dealerToken, err := s.Decks.NewDeck(&deck_repo.Deck{
+		Shuffled: request.Shuffled,
+		Cards:    cards,
+		Packs:    packs,
+	})
+	if err != nil {
+		return nil, rpc_errors.GRPCStatus(err)
+	}
+
+	return &CreateDeckResponse{
+		DeckId:      deck.ID,
+		Shuffled:    deck.Shuffled,
+		Remaining:   int32(deck.Remaining),
+		DealerToken: dealerToken,
+	}, nil
+}
+
+// OpenDeck returns the deck with its remaining cards. Once the deck has players, only the dealer sees the cards.
+// Returns a NotFound error if the deck does not exist, and an Unauthenticated error for an invalid token.
+func (s *Server) OpenDeck(ctx context.Context, request *OpenDeckRequest) (*OpenDeckResponse, error) {
+	deck, err := s.Decks.Repository.GetDeck(request.DeckId)
+	if err != nil {
+		return nil, rpc_errors.GRPCStatus(err)
+	}
+	seesCards, err := s.Decks.SeesCards(token(ctx), request.DeckId)
+	if err != nil {
+		return nil, rpc_errors.GRPCStatus(err)
+	}
+
+	response := &OpenDeckResponse{
+		DeckId:      deck.ID,
+		Shuffled:    deck.Shuffled,
+		Remaining:   int32(deck.Remaining),
+		CardsHidden: !seesCards,
+	}
+	if seesCards {
+		response.Cards = toCards(deck.Cards)
+	}
+	return response, nil
+}
+
+// DrawCards draws cards from the deck. A count less than one draws one card.
+// Returns a NotFound error if the deck does not exist, an InvalidArgument error if there are not enough cards in the
+// deck, and a PermissionDenied error if the deck has players and the caller is not the dealer.
+func (s *Server) DrawCards(ctx context.Context, request *DrawCardsRequest) (*DrawCardsResponse, error) {
+	drawn, err := s.Decks.Draw(token(ctx), request.DeckId, int(request.Count))
+	if err != nil {
+		return nil, rpc_errors.GRPCStatus(err)
+	}
+
+	return &DrawCardsResponse{
+		Cards: toCards(drawn),
+	}, nil
+}
+
+// Watch streams the changes of the deck, as soon as they are committed, until the client cancels the call.
+// Returns a NotFound error if the deck does not exist, an Unauthenticated error for an invalid token, and a
+// ResourceExhausted error if the client does not keep up with the events.
+func (s *Server) Watch(request *WatchRequest, stream DeckService_WatchServer) error {
+	deckID := request.DeckId
+	if _, err := s.Decks.Repository.GetDeck(deckID); err != nil {
+		return rpc_errors.GRPCStatus(err)
+	}
+	if _, err := s.Decks.SeesCards(token(stream.Context()), deckID); err != nil {
+		return rpc_errors.GRPCStatus(err)
+	}
+
+	subscription := s.Decks.Hub.Subscribe(deckID)
+	defer subscription.Close()
+
+	// Tell the client the subscription is in place, so no change made after the call returns is missed.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "too slow to keep up with the events of the deck %s", deckID)
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// token returns the token given in the authorization metadata as "Bearer <token>". Empty if there is no token.
+func token(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+}
+
+func toCards(cards []*deck_repo.Card) []*Card {
+	result := []*Card{}
+	for _, card := range cards {
+		result = append(result, &Card{
+			Value: card.RankName(),
+			Suit:  card.SuitName(),
+			Code:  card.Value,
+		})
+	}
+	return result
+}
+
+func toEvent(event *events.Event) *Event {
+	return &Event{
+		Id:        event.ID,
+		Type:      event.Type,
+		DeckId:    event.DeckID,
+		Time:      timestamppb.New(event.Time),
+		Remaining: int32(event.Remaining),
+		Count:     int32(event.Count),
+		Cards:     event.Cards,
+		PlayerId:  event.PlayerID,
+		Pile:      event.Pile,
+	}
+}
+
+// NewServer creates a new gRPC DeckService on top of the given Deck Service of the REST API.
+func NewServer(decks *deck_svcs.DeckService) *Server {
+	return &Server{
+		Decks: decks,
+	}
+}
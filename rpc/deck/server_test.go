@@ -0,0 +1,235 @@
+package deck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/events"
+	"github.com/natemago/card-games-api/repositories"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	deck_svcs "github.com/natemago/card-games-api/rest/deck"
+)
+
+var testDBConfig = &config.DBConfig{
+	Dialect: "sqlite",
+	URL:     "file::memory:?cache=shared",
+}
+
+const testAdminToken = "admin-secret"
+
+func setupTest(t *testing.T) (DeckServiceClient, *deck_svcs.DeckService) {
+	db, err := repositories.OpenDatabase(testDBConfig)
+	if err != nil {
+		t.Fatalf("Failed to open DB connection: %s", err.Error())
+	}
+	if err = repositories.AutoMigrateModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	decks := deck_svcs.NewDeckService(deck_repo.NewDBDeckRepository(db), deck_repo.NewDBPlayerRepository(db))
+	decks.EventLog = deck_repo.NewDBEventRepository(db)
+	decks.AdminToken = testAdminToken
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterDeckServiceServer(server, NewServer(decks))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect to the gRPC server: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewDeckServiceClient(conn), decks
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func addPlayer(t *testing.T, decks *deck_svcs.DeckService, deckID string) string {
+	token, err := deck_repo.NewToken()
+	if err != nil {
+		t.Fatalf("Failed to generate the token of the player: %s", err.Error())
+	}
+	if _, err := decks.Players.CreatePlayer(&deck_repo.Player{
+		DeckID:    deckID,
+		Name:      "Alex",
+		TokenHash: deck_repo.HashToken(token),
+	}); err != nil {
+		t.Fatalf("Failed to add a player: %s", err.Error())
+	}
+	return token
+}
+
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("Expected %s, got: %v", code, err)
+	}
+}
+
+func TestServer_CreateOpenAndDraw(t *testing.T) {
+	client, _ := setupTest(t)
+	ctx := context.Background()
+
+	created, err := client.CreateDeck(ctx, &CreateDeckRequest{Cards: []string{"AS", "KD", "AC"}})
+	if err != nil {
+		t.Fatalf("Failed to create the deck: %s", err.Error())
+	}
+	if created.DeckId == "" || created.DealerToken == "" || created.Remaining != 3 || created.Shuffled {
+		t.Fatalf("Unexpected created deck: %v", created)
+	}
+
+	opened, err := client.OpenDeck(ctx, &OpenDeckRequest{DeckId: created.DeckId})
+	if err != nil {
+		t.Fatalf("Failed to open the deck: %s", err.Error())
+	}
+	if opened.CardsHidden || len(opened.Cards) != 3 {
+		t.Fatalf("Expected the 3 cards to be shown, got: %v", opened)
+	}
+	if card := opened.Cards[0]; card.Code != "AS" || card.Value != "ACE" || card.Suit != "SPADES" {
+		t.Errorf("Unexpected first card: %v", card)
+	}
+
+	drawn, err := client.DrawCards(ctx, &DrawCardsRequest{DeckId: created.DeckId, Count: 2})
+	if err != nil {
+		t.Fatalf("Failed to draw the cards: %s", err.Error())
+	}
+	if len(drawn.Cards) != 2 || drawn.Cards[0].Code != "AS" || drawn.Cards[1].Code != "KD" {
+		t.Errorf("Unexpected drawn cards: %v", drawn.Cards)
+	}
+
+	_, err = client.DrawCards(ctx, &DrawCardsRequest{DeckId: created.DeckId, Count: 2})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestServer_Errors(t *testing.T) {
+	client, _ := setupTest(t)
+	ctx := context.Background()
+
+	_, err := client.CreateDeck(ctx, &CreateDeckRequest{Cards: []string{"AS", "AS"}})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = client.OpenDeck(ctx, &OpenDeckRequest{DeckId: "no-such-deck"})
+	assertCode(t, err, codes.NotFound)
+
+	created, err := client.CreateDeck(ctx, &CreateDeckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to create the deck: %s", err.Error())
+	}
+	_, err = client.OpenDeck(withToken("invalid"), &OpenDeckRequest{DeckId: created.DeckId})
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+func TestServer_HiddenCards(t *testing.T) {
+	client, decks := setupTest(t)
+	ctx := context.Background()
+
+	created, err := client.CreateDeck(ctx, &CreateDeckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to create the deck: %s", err.Error())
+	}
+	playerToken := addPlayer(t, decks, created.DeckId)
+
+	opened, err := client.OpenDeck(withToken(playerToken), &OpenDeckRequest{DeckId: created.DeckId})
+	if err != nil {
+		t.Fatalf("Failed to open the deck: %s", err.Error())
+	}
+	if !opened.CardsHidden || len(opened.Cards) != 0 || opened.Remaining != 52 {
+		t.Errorf("Expected the cards to be hidden from the player, got: %v", opened)
+	}
+
+	_, err = client.DrawCards(withToken(playerToken), &DrawCardsRequest{DeckId: created.DeckId})
+	assertCode(t, err, codes.PermissionDenied)
+
+	for _, token := range []string{created.DealerToken, testAdminToken} {
+		opened, err = client.OpenDeck(withToken(token), &OpenDeckRequest{DeckId: created.DeckId})
+		if err != nil {
+			t.Fatalf("Failed to open the deck: %s", err.Error())
+		}
+		if opened.CardsHidden || len(opened.Cards) != 52 {
+			t.Errorf("Expected the cards to be shown to the dealer, got hidden=%t and %d cards", opened.CardsHidden, len(opened.Cards))
+		}
+	}
+
+	drawn, err := client.DrawCards(withToken(created.DealerToken), &DrawCardsRequest{DeckId: created.DeckId})
+	if err != nil {
+		t.Fatalf("Failed to draw as the dealer: %s", err.Error())
+	}
+	if len(drawn.Cards) != 1 {
+		t.Errorf("Expected one drawn card, got: %v", drawn.Cards)
+	}
+}
+
+func TestServer_Watch(t *testing.T) {
+	client, decks := setupTest(t)
+
+	created, err := client.CreateDeck(context.Background(), &CreateDeckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to create the deck: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Watch(ctx, &WatchRequest{DeckId: created.DeckId})
+	if err != nil {
+		t.Fatalf("Failed to watch the deck: %s", err.Error())
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("Failed to wait for the subscription: %s", err.Error())
+	}
+
+	// a change made over the REST API is streamed as well
+	if _, err := decks.Draw("", created.DeckId, 2); err != nil {
+		t.Fatalf("Failed to draw the cards: %s", err.Error())
+	}
+	if _, err := client.DrawCards(context.Background(), &DrawCardsRequest{DeckId: created.DeckId}); err != nil {
+		t.Fatalf("Failed to draw the card: %s", err.Error())
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Failed to receive the event: %s", err.Error())
+	}
+	if event.Type != events.Drawn || event.DeckId != created.DeckId || event.Count != 2 || event.Remaining != 50 ||
+		len(event.Cards) != 2 || event.Id == 0 || event.Time.AsTime().IsZero() {
+		t.Errorf("Unexpected first event: %v", event)
+	}
+
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Failed to receive the event: %s", err.Error())
+	}
+	if event.Type != events.Drawn || event.Count != 1 || event.Remaining != 49 {
+		t.Errorf("Unexpected second event: %v", event)
+	}
+
+	stream, err = client.Watch(withToken("invalid"), &WatchRequest{DeckId: created.DeckId})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	assertCode(t, err, codes.Unauthenticated)
+
+	stream, err = client.Watch(context.Background(), &WatchRequest{DeckId: "no-such-deck"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	assertCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,40 @@
+// Package errors maps the errors of the API (see the top-level errors package) to gRPC status errors.
+package errors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+// GRPCCode returns the gRPC status code for the given error, the counterpart of errors.StatusCode: InvalidArgument for
+// BadRequestError and ValidationError, Unauthenticated for UnauthorizedError, PermissionDenied for ForbiddenError,
+// NotFound for NotFoundError, Unavailable for UnavailableError and Internal for any other error.
+func GRPCCode(err error) codes.Code {
+	if api_errors.IsBadRequestError(err) || api_errors.IsValidationError(err) {
+		return codes.InvalidArgument
+	}
+	if api_errors.IsUnauthorizedError(err) {
+		return codes.Unauthenticated
+	}
+	if api_errors.IsForbiddenError(err) {
+		return codes.PermissionDenied
+	}
+	if api_errors.IsNotFoundError(err) {
+		return codes.NotFound
+	}
+	if api_errors.IsUnavailableError(err) {
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+// GRPCStatus converts the given error to a gRPC status error with the code returned by GRPCCode and the message of the
+// error. Returns nil if there is no error.
+func GRPCStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	return status.Error(GRPCCode(err), err.Error())
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api_errors "github.com/natemago/card-games-api/errors"
+)
+
+func TestGRPCCode(t *testing.T) {
+	if GRPCCode(api_errors.BadRequestError("bad parameter", nil)) != codes.InvalidArgument {
+		t.Error("Expected InvalidArgument for a 'bad-request-error'.")
+	}
+	if GRPCCode(api_errors.ValidationError("invalid value", nil)) != codes.InvalidArgument {
+		t.Error("Expected InvalidArgument for a 'validation-error'.")
+	}
+	if GRPCCode(api_errors.UnauthorizedError("invalid token", nil)) != codes.Unauthenticated {
+		t.Error("Expected Unauthenticated for an 'unauthorized-error'.")
+	}
+	if GRPCCode(api_errors.ForbiddenError("not allowed", nil)) != codes.PermissionDenied {
+		t.Error("Expected PermissionDenied for a 'forbidden-error'.")
+	}
+	if GRPCCode(api_errors.NotFoundError("record not found", nil)) != codes.NotFound {
+		t.Error("Expected NotFound for a 'not-found-error'.")
+	}
+	if GRPCCode(api_errors.UnavailableError("try again later", nil)) != codes.Unavailable {
+		t.Error("Expected Unavailable for an 'unavailable-error'.")
+	}
+	if GRPCCode(fmt.Errorf("generic-error")) != codes.Internal {
+		t.Error("Expected Internal for a generic error.")
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	if GRPCStatus(nil) != nil {
+		t.Error("Expected no status without an error.")
+	}
+
+	st, ok := status.FromError(GRPCStatus(api_errors.NotFoundError("no such deck", nil)))
+	if !ok {
+		t.Fatal("Expected a gRPC status error.")
+	}
+	if st.Code() != codes.NotFound || st.Message() != "no such deck" {
+		t.Errorf("Expected NotFound with the message of the error, got %s: %s", st.Code(), st.Message())
+	}
+}
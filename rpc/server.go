@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/natemago/card-games-api/config"
+	deck_rpc "github.com/natemago/card-games-api/rpc/deck"
+)
+
+// Services holds all of the gRPC services served by the gRPC API.
+type Services struct {
+	// DeckServer is the server of the DeckService.
+	DeckServer *deck_rpc.Server
+}
+
+// SetupServer creates the gRPC server and registers all of the services on it.
+func SetupServer(services *Services) *grpc.Server {
+	server := grpc.NewServer()
+
+	deck_rpc.RegisterDeckServiceServer(server, services.DeckServer)
+
+	return server
+}
+
+// ServeGRPC sets up the gRPC server and serves the gRPC API on the host and the gRPC port of the configuration, in the
+// background. Returns nil if the gRPC API is disabled (the gRPC port is zero), or an error if the port cannot be bound.
+// The returned server should be stopped once the application stops.
+func ServeGRPC(conf *config.APIConfig, services *Services) (*grpc.Server, error) {
+	if conf.GRPCPort == 0 {
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", conf.Host, conf.GRPCPort))
+	if err != nil {
+		return nil, err
+	}
+
+	server := SetupServer(services)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("The gRPC API stopped: %s", err.Error())
+		}
+	}()
+
+	return server, nil
+}
@@ -0,0 +1,88 @@
+package rummy
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// JokerCode is the code of a joker. Jokers are wild and stand for any card in a meld.
+const JokerCode = "JOKER"
+
+// MaxJokers is the maximal number of jokers, as found in a standard deck.
+const MaxJokers = 2
+
+// JokerPoints is the deadwood value of a joker left out of the melds.
+const JokerPoints = 15
+
+// rankOrders maps the rank codes to their order, from the ace (1) to the king (13). The ace is always low.
+var rankOrders = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		rankOrders[rank] = i + 1
+	}
+}
+
+// IsJoker checks if the card is a joker.
+func IsJoker(card *deck_repo.Card) bool {
+	return card.Value == JokerCode
+}
+
+// Rank returns the order of the card rank, from the ace (1) to the king (13), or 0 for a joker.
+func Rank(card *deck_repo.Card) int {
+	if IsJoker(card) {
+		return 0
+	}
+	return rankOrders[card.Value[:len(card.Value)-1]]
+}
+
+// Suit returns the suit code of the card, or an empty string for a joker.
+func Suit(card *deck_repo.Card) string {
+	if IsJoker(card) {
+		return ""
+	}
+	return card.Value[len(card.Value)-1:]
+}
+
+// Points returns the deadwood value of the card: the ace counts 1, the face cards count 10, the rest count their
+// number and the joker counts JokerPoints.
+func Points(card *deck_repo.Card) int {
+	if IsJoker(card) {
+		return JokerPoints
+	}
+	if rank := Rank(card); rank < 10 {
+		return rank
+	}
+	return 10
+}
+
+// PointsOf returns the total deadwood value of the cards.
+func PointsOf(cards []*deck_repo.Card) int {
+	total := 0
+	for _, card := range cards {
+		total += Points(card)
+	}
+	return total
+}
+
+// splitJokers splits the cards into the natural cards and the jokers.
+// Returns a ValidationError if there are more than MaxJokers jokers, or the natural cards are not valid or repeat.
+func splitJokers(cards []*deck_repo.Card) ([]*deck_repo.Card, []*deck_repo.Card, error) {
+	var naturals, jokers []*deck_repo.Card
+	for _, card := range cards {
+		if IsJoker(card) {
+			jokers = append(jokers, card)
+		} else {
+			naturals = append(naturals, card)
+		}
+	}
+	if len(jokers) > MaxJokers {
+		return nil, nil, errors.ValidationError(fmt.Sprintf("there can be at most %d jokers", MaxJokers), nil)
+	}
+	if err := deck_repo.ValidateDeckCards(naturals); err != nil {
+		return nil, nil, err
+	}
+	return naturals, jokers, nil
+}
@@ -0,0 +1,293 @@
+package rummy
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// GinHandSize is the number of cards in a hand of gin rummy, after the discard.
+const GinHandSize = 10
+
+// KnockLimit is the maximal (and default) deadwood value a player may knock with.
+const KnockLimit = 10
+
+// Bonuses scored on top of the difference in the deadwood.
+const (
+	// GinBonus is scored by the knocker for going gin, with no deadwood.
+	GinBonus = 25
+
+	// UndercutBonus is scored by the defender for undercutting the knocker, with no more deadwood than the knocker.
+	UndercutBonus = 25
+)
+
+// Winners of a hand.
+const (
+	KNOCKER  = "KNOCKER"
+	DEFENDER = "DEFENDER"
+)
+
+// Layoff is a card of the defender laid off onto a meld of the knocker.
+type Layoff struct {
+	// Card is the card laid off.
+	Card *deck_repo.Card
+
+	// Meld is the index of the meld of the knocker the card is laid off onto.
+	Meld int
+}
+
+// KnockResult is the outcome of a knock.
+type KnockResult struct {
+	// Knocker is the arrangement of the knocker.
+	Knocker *Arrangement
+
+	// Defender is the arrangement of the defender, before the layoffs.
+	Defender *Arrangement
+
+	// Layoffs holds the cards of the defender laid off onto the melds of the knocker. There are no layoffs on gin.
+	Layoffs []Layoff
+
+	// Deadwood holds the deadwood of the defender left after the layoffs.
+	Deadwood []*deck_repo.Card
+
+	// Points is the deadwood value of the defender after the layoffs.
+	Points int
+
+	// Gin is a flag whether the knocker went gin.
+	Gin bool
+
+	// Undercut is a flag whether the defender undercut the knocker.
+	Undercut bool
+
+	// Winner is the winner of the hand: KNOCKER or DEFENDER.
+	Winner string
+
+	// Score is the number of points scored by the winner.
+	Score int
+}
+
+// Knock validates a knock and scores the hand. The knocker declares the melds scoring the most for the knocker, and
+// the defender arranges the hand and lays off cards onto the melds of the knocker to leave the least deadwood.
+// Returns a ValidationError if a hand does not have GinHandSize cards, the cards are not valid or repeat across the
+// hands, the knock limit is not between 0 and KnockLimit, or the knocker has more deadwood than the knock limit.
+func Knock(knocker, defender []*deck_repo.Card, knockLimit int) (*KnockResult, error) {
+	if len(knocker) != GinHandSize || len(defender) != GinHandSize {
+		return nil, errors.ValidationError(fmt.Sprintf("each hand must have exactly %d cards", GinHandSize), nil)
+	}
+	if knockLimit < 0 || knockLimit > KnockLimit {
+		return nil, errors.ValidationError(fmt.Sprintf("the knock limit must be between 0 and %d", KnockLimit), nil)
+	}
+	if _, _, err := splitJokers(append(append([]*deck_repo.Card{}, knocker...), defender...)); err != nil {
+		return nil, err
+	}
+	knockerNaturals, knockerJokers, _ := splitJokers(knocker)
+	defenderNaturals, defenderJokers, _ := splitJokers(defender)
+
+	least := -1
+	var knocks []*Arrangement
+	partitions(knockerNaturals, knockerJokers, func(arrangement *Arrangement) {
+		if least < 0 || arrangement.Points < least {
+			least = arrangement.Points
+		}
+		if arrangement.Points <= knockLimit {
+			knocks = append(knocks, arrangement)
+		}
+	})
+	if len(knocks) == 0 {
+		return nil, errors.ValidationError(fmt.Sprintf("cannot knock with %d deadwood, the knock limit is %d", least, knockLimit), nil)
+	}
+
+	var defences []*Arrangement
+	partitions(defenderNaturals, defenderJokers, func(arrangement *Arrangement) {
+		defences = append(defences, arrangement)
+	})
+
+	var best *KnockResult
+	for _, knock := range knocks {
+		result := defend(knock, defences)
+		if best == nil || knockerScore(result) > knockerScore(best) {
+			best = result
+		}
+	}
+
+	return best, nil
+}
+
+// defend finds the defence against the knock leaving the least deadwood, and scores the hand.
+func defend(knock *Arrangement, defences []*Arrangement) *KnockResult {
+	result := &KnockResult{
+		Knocker: knock,
+		Gin:     knock.Points == 0,
+		Layoffs: []Layoff{},
+	}
+	for _, defence := range defences {
+		bound := -1
+		if result.Defender != nil {
+			bound = result.Points
+		}
+		layoffs, deadwood, ok := []Layoff{}, defence.Deadwood, bound < 0 || defence.Points < bound
+		if !result.Gin {
+			layoffs, deadwood, ok = layOff(defence.Deadwood, knock.Melds, bound)
+		}
+		if ok {
+			result.Defender = defence
+			result.Layoffs = layoffs
+			result.Deadwood = deadwood
+			result.Points = PointsOf(deadwood)
+		}
+	}
+
+	switch {
+	case result.Gin:
+		result.Winner = KNOCKER
+		result.Score = GinBonus + result.Points
+	case result.Points <= knock.Points:
+		result.Undercut = true
+		result.Winner = DEFENDER
+		result.Score = UndercutBonus + knock.Points - result.Points
+	default:
+		result.Winner = KNOCKER
+		result.Score = result.Points - knock.Points
+	}
+	return result
+}
+
+func knockerScore(result *KnockResult) int {
+	if result.Winner == KNOCKER {
+		return result.Score
+	}
+	return -result.Score
+}
+
+// layOff finds the layoffs of the deadwood onto the melds leaving the least deadwood value, if it is less than the
+// bound. A negative bound means no bound.
+// Returns the layoffs, the deadwood left, and whether the deadwood left is less than the bound.
+func layOff(deadwood []*deck_repo.Card, melds []*Meld, bound int) ([]Layoff, []*deck_repo.Card, bool) {
+	targets := make([]int, len(deadwood))
+	bestTargets := make([]int, len(deadwood))
+	bestPoints := bound
+	found := false
+
+	var next func(i, points int)
+	next = func(i, points int) {
+		if bestPoints >= 0 && points >= bestPoints {
+			return
+		}
+		if i == len(deadwood) {
+			laid := make([][]*deck_repo.Card, len(melds))
+			for j, card := range deadwood {
+				if targets[j] >= 0 {
+					laid[targets[j]] = append(laid[targets[j]], card)
+				}
+			}
+			for m, meld := range melds {
+				if len(laid[m]) > 0 && !canExtend(meld, laid[m]) {
+					return
+				}
+			}
+			bestPoints = points
+			copy(bestTargets, targets)
+			found = true
+			return
+		}
+
+		for m, meld := range melds {
+			if mayExtend(meld, deadwood[i]) {
+				targets[i] = m
+				next(i+1, points)
+			}
+		}
+		targets[i] = -1
+		next(i+1, points+Points(deadwood[i]))
+	}
+	next(0, 0)
+	if !found {
+		return nil, nil, false
+	}
+
+	layoffs := []Layoff{}
+	var left []*deck_repo.Card
+	for j, card := range deadwood {
+		if bestTargets[j] < 0 {
+			left = append(left, card)
+		} else {
+			layoffs = append(layoffs, Layoff{
+				Card: card,
+				Meld: bestTargets[j],
+			})
+		}
+	}
+	return layoffs, left, true
+}
+
+// mayExtend checks if the card on its own fits the meld: a card of the rank of a set in a suit missing from it, a card
+// of the suit of a run outside of its ranks, or a joker.
+func mayExtend(meld *Meld, card *deck_repo.Card) bool {
+	if IsJoker(card) {
+		return true
+	}
+	low, high := bounds(meld)
+	for _, c := range meld.Cards {
+		if IsJoker(c) {
+			continue
+		}
+		if meld.Kind == SetMeld && (Rank(c) != Rank(card) || Suit(c) == Suit(card)) {
+			return false
+		}
+		if meld.Kind == RunMeld && Suit(c) != Suit(card) {
+			return false
+		}
+	}
+	return meld.Kind == SetMeld || Rank(card) < low || Rank(card) > high
+}
+
+// canExtend checks if all of the cards laid off together extend the meld into a valid meld.
+func canExtend(meld *Meld, cards []*deck_repo.Card) bool {
+	if meld.Kind == SetMeld {
+		suits := map[string]bool{}
+		for _, card := range append(append([]*deck_repo.Card{}, meld.Cards...), cards...) {
+			if !IsJoker(card) {
+				if suits[Suit(card)] {
+					return false
+				}
+				suits[Suit(card)] = true
+			}
+		}
+		return len(meld.Cards)+len(cards) <= len(deck_repo.Suits)
+	}
+
+	low, high := bounds(meld)
+	newLow, newHigh := low, high
+	ranks := map[int]bool{}
+	jokers := 0
+	for _, card := range cards {
+		if IsJoker(card) {
+			jokers++
+			continue
+		}
+		if ranks[Rank(card)] {
+			return false
+		}
+		ranks[Rank(card)] = true
+		if Rank(card) < newLow {
+			newLow = Rank(card)
+		}
+		if Rank(card) > newHigh {
+			newHigh = Rank(card)
+		}
+	}
+	gaps := newHigh - newLow + 1 - len(meld.Cards) - len(ranks)
+	return gaps <= jokers && len(meld.Cards)+len(cards) <= len(deck_repo.Ranks)
+}
+
+// bounds returns the lowest and the highest rank of a run, including the jokers.
+func bounds(meld *Meld) (int, int) {
+	for i, card := range meld.Cards {
+		if !IsJoker(card) {
+			low := Rank(card) - i
+			return low, low + len(meld.Cards) - 1
+		}
+	}
+	return 0, 0
+}
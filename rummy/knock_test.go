@@ -0,0 +1,107 @@
+package rummy
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func knock(t *testing.T, knocker, defender string, knockLimit int) *KnockResult {
+	result, err := Knock(deck_repo.AsCards(knocker), deck_repo.AsCards(defender), knockLimit)
+	if err != nil {
+		t.Fatalf("Expected to knock with %s, but got error: %s", knocker, err.Error())
+	}
+	return result
+}
+
+func TestKnock(t *testing.T) {
+	result := knock(t, "AS,2S,3S,7H,7D,7C,9D,10D,JD,2C", "KH,KD,KC,QH,QS,QC,7S,8D,3H,AC", KnockLimit)
+
+	if result.Gin || result.Undercut || result.Knocker.Points != 2 {
+		t.Errorf("Expected a knock with 2 deadwood, but got: %+v", result)
+	}
+	if len(result.Layoffs) != 2 {
+		t.Fatalf("Expected 2 layoffs, but got %d.", len(result.Layoffs))
+	}
+	for _, layoff := range result.Layoffs {
+		if !canExtend(result.Knocker.Melds[layoff.Meld], []*deck_repo.Card{layoff.Card}) {
+			t.Errorf("Expected %s to extend the meld %s.", layoff.Card.Value, codes(result.Knocker.Melds[layoff.Meld].Cards))
+		}
+	}
+	if codes(result.Deadwood) != "3H,AC" || result.Points != 4 {
+		t.Errorf("Expected 3H and AC left for 4, but got %s for %d.", codes(result.Deadwood), result.Points)
+	}
+	if result.Winner != KNOCKER || result.Score != 2 {
+		t.Errorf("Expected the knocker to score 2, but got %s %d.", result.Winner, result.Score)
+	}
+}
+
+func TestKnock_BlockLayoffs(t *testing.T) {
+	// leaving the run of spades out of the melds blocks the layoffs of the 4 and the 5 of spades
+	result := knock(t, "AS,2S,3S,7H,7D,7C,9D,10D,JD,2C", "4S,5S,7S,KH,KD,KC,QH,3H,8D,AC", KnockLimit)
+
+	if result.Knocker.Points != 8 || len(result.Knocker.Melds) != 2 {
+		t.Errorf("Expected a knock with 8 deadwood and 2 melds, but got %d and %d.", result.Knocker.Points, len(result.Knocker.Melds))
+	}
+	if codes(result.Deadwood) != "4S,5S,QH,3H,AC" || result.Points != 23 {
+		t.Errorf("Expected 4S, 5S, QH, 3H and AC left for 23, but got %s for %d.", codes(result.Deadwood), result.Points)
+	}
+	if result.Winner != KNOCKER || result.Score != 15 {
+		t.Errorf("Expected the knocker to score 15, but got %s %d.", result.Winner, result.Score)
+	}
+}
+
+func TestKnock_Gin(t *testing.T) {
+	result := knock(t, "AS,2S,3S,7H,7D,7C,9D,10D,JD,QD", "4S,5S,7S,KH,KD,KC,QH,3H,8D,AC", KnockLimit)
+
+	if !result.Gin || len(result.Layoffs) != 0 {
+		t.Errorf("Expected gin with no layoffs, but got: %+v", result)
+	}
+	if result.Winner != KNOCKER || result.Score != GinBonus+38 {
+		t.Errorf("Expected the knocker to score %d, but got %s %d.", GinBonus+38, result.Winner, result.Score)
+	}
+}
+
+func TestKnock_Undercut(t *testing.T) {
+	result := knock(t, "AS,2S,3S,7H,7D,7C,9D,10D,JD,8C", "KH,KD,KC,QS,QH,QC,4H,5H,6H,AC", KnockLimit)
+
+	if !result.Undercut || result.Points != 1 {
+		t.Errorf("Expected an undercut with 1 deadwood, but got: %+v", result)
+	}
+	if result.Winner != DEFENDER || result.Score != UndercutBonus+7 {
+		t.Errorf("Expected the defender to score %d, but got %s %d.", UndercutBonus+7, result.Winner, result.Score)
+	}
+}
+
+func TestKnock_Jokers(t *testing.T) {
+	// the joker of the defender fits none of the melds of the defender, but extends a meld of the knocker
+	result := knock(t, "2S,3S,4S,7H,7D,7C,9D,10D,JD,2C", "JOKER,KS,KH,KD,QH,QC,QS,5H,6C,9C", KnockLimit)
+
+	if len(result.Layoffs) != 1 || !IsJoker(result.Layoffs[0].Card) {
+		t.Fatalf("Expected the joker laid off, but got: %+v", result.Layoffs)
+	}
+	if result.Points != 20 || result.Score != 18 {
+		t.Errorf("Expected 20 deadwood left and 18 for the knocker, but got %d and %d.", result.Points, result.Score)
+	}
+}
+
+func TestKnock_Invalid(t *testing.T) {
+	defender := "4S,5S,7S,KH,KD,KC,QH,3H,8D,AC"
+	tests := []struct {
+		knocker    string
+		defender   string
+		knockLimit int
+	}{
+		{"AS,2S,3S,7H,7D,7C,9C,10D,JD,KS", defender, KnockLimit},
+		{"AS,2S,3S,7H,7D,7C,9D,10D,JD,2C", defender, 1},
+		{"AS,2S,3S,7H,7D,7C,9D,10D,JD,2C", defender, 11},
+		{"AS,2S,3S,7H,7D,7C,9D,10D,JD", defender, KnockLimit},
+		{"AS,2S,3S,7H,7D,7C,9D,10D,JD,AC", defender, KnockLimit},
+	}
+	for _, test := range tests {
+		if _, err := Knock(deck_repo.AsCards(test.knocker), deck_repo.AsCards(test.defender), test.knockLimit); !errors.IsValidationError(err) {
+			t.Errorf("Expected a ValidationError for %s, but got: %v", test.knocker, err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package rummy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Kinds of melds.
+const (
+	// SetMeld is a meld of three or four cards of the same rank.
+	SetMeld = "SET"
+
+	// RunMeld is a meld of three or more cards of consecutive ranks in the same suit. The ace is low.
+	RunMeld = "RUN"
+)
+
+// MinMeldSize is the minimal number of cards in a meld.
+const MinMeldSize = 3
+
+// MaxHandSize is the maximal number of cards arranged at once.
+const MaxHandSize = 13
+
+// Meld is a set or a run of cards.
+type Meld struct {
+	// Kind is the kind of the meld: SetMeld or RunMeld.
+	Kind string
+
+	// Cards are the cards of the meld. The cards of a run are ordered by rank, with the jokers in the place of the
+	// cards they stand for.
+	Cards []*deck_repo.Card
+}
+
+// Arrangement is an arrangement of a hand into melds and deadwood.
+type Arrangement struct {
+	// Melds holds the melds of the arrangement.
+	Melds []*Meld
+
+	// Deadwood holds the cards left out of the melds.
+	Deadwood []*deck_repo.Card
+
+	// Points is the deadwood value of the arrangement.
+	Points int
+}
+
+// candidate is a possible meld of a hand. The slots hold the indexes of the natural cards in the meld, with -1 in
+// the place of a joker.
+type candidate struct {
+	kind     string
+	slots    []int
+	naturals uint32
+	jokers   int
+}
+
+func newCandidate(kind string, slots []int) *candidate {
+	c := &candidate{
+		kind:  kind,
+		slots: slots,
+	}
+	for _, slot := range slots {
+		if slot < 0 {
+			c.jokers++
+		} else {
+			c.naturals |= 1 << slot
+		}
+	}
+	return c
+}
+
+// Arrange finds all optimal arrangements of the cards into melds, the ones with the least deadwood value.
+// The jokers (see JokerCode) are wild. Arrangements differing only in the place of a joker in a run are given once.
+// Returns a ValidationError if there are no cards or more than MaxHandSize cards, or the cards are not valid.
+func Arrange(cards []*deck_repo.Card) ([]*Arrangement, error) {
+	if len(cards) == 0 || len(cards) > MaxHandSize {
+		return nil, errors.ValidationError(fmt.Sprintf("the number of cards must be between 1 and %d", MaxHandSize), nil)
+	}
+	naturals, jokers, err := splitJokers(cards)
+	if err != nil {
+		return nil, err
+	}
+
+	var best []*Arrangement
+	seen := map[string]bool{}
+	partitions(naturals, jokers, func(arrangement *Arrangement) {
+		if len(best) > 0 && arrangement.Points > best[0].Points {
+			return
+		}
+		if len(best) > 0 && arrangement.Points < best[0].Points {
+			best = nil
+			seen = map[string]bool{}
+		}
+		if key := arrangementKey(arrangement); !seen[key] {
+			seen[key] = true
+			best = append(best, arrangement)
+		}
+	})
+
+	return best, nil
+}
+
+// partitions visits every arrangement of the natural cards and the jokers into melds and deadwood.
+func partitions(naturals, jokers []*deck_repo.Card, visit func(*Arrangement)) {
+	byCard := make([][]*candidate, len(naturals))
+	for _, c := range candidates(naturals, len(jokers)) {
+		for i := range naturals {
+			if c.naturals&(1<<i) != 0 {
+				byCard[i] = append(byCard[i], c)
+			}
+		}
+	}
+
+	var melds []*candidate
+	var deadwood []int
+	var next func(i int, used uint32, jokersLeft int)
+	next = func(i int, used uint32, jokersLeft int) {
+		for i < len(naturals) && used&(1<<i) != 0 {
+			i++
+		}
+		if i == len(naturals) {
+			visit(newArrangement(naturals, jokers, melds, deadwood))
+			return
+		}
+
+		deadwood = append(deadwood, i)
+		next(i+1, used|1<<i, jokersLeft)
+		deadwood = deadwood[:len(deadwood)-1]
+
+		for _, c := range byCard[i] {
+			if c.naturals&used != 0 || c.jokers > jokersLeft {
+				continue
+			}
+			melds = append(melds, c)
+			next(i+1, used|c.naturals, jokersLeft-c.jokers)
+			melds = melds[:len(melds)-1]
+		}
+	}
+	next(0, 0, len(jokers))
+}
+
+// candidates finds all possible melds of the natural cards with up to the given number of jokers. Every meld has at
+// least one natural card.
+func candidates(naturals []*deck_repo.Card, jokers int) []*candidate {
+	var result []*candidate
+
+	byRank := map[int][]int{}
+	bySuit := map[string]map[int]int{}
+	for i, card := range naturals {
+		byRank[Rank(card)] = append(byRank[Rank(card)], i)
+		if bySuit[Suit(card)] == nil {
+			bySuit[Suit(card)] = map[int]int{}
+		}
+		bySuit[Suit(card)][Rank(card)] = i
+	}
+
+	for rank := 1; rank <= len(deck_repo.Ranks); rank++ {
+		cards := byRank[rank]
+		for mask := 1; mask < 1<<len(cards); mask++ {
+			var slots []int
+			for i, card := range cards {
+				if mask&(1<<i) != 0 {
+					slots = append(slots, card)
+				}
+			}
+			for j := 0; j <= jokers; j++ {
+				if size := len(slots) + j; size >= MinMeldSize && size <= len(deck_repo.Suits) {
+					result = append(result, newCandidate(SetMeld, append(append([]int{}, slots...), jokerSlots(j)...)))
+				}
+			}
+		}
+	}
+
+	for _, suit := range deck_repo.Suits {
+		at := bySuit[suit]
+		for low := 1; low <= len(deck_repo.Ranks); low++ {
+			for high := low + MinMeldSize - 1; high <= len(deck_repo.Ranks); high++ {
+				var present []int
+				for rank := low; rank <= high; rank++ {
+					if _, ok := at[rank]; ok {
+						present = append(present, rank)
+					}
+				}
+				missing := high - low + 1 - len(present)
+				if missing > jokers {
+					break
+				}
+				// a joker may also stand for a card in the hand, which is then free for another meld
+				for _, replaced := range subsetsUpTo(len(present), jokers-missing) {
+					if len(replaced) == len(present) {
+						continue
+					}
+					skip := map[int]bool{}
+					for _, i := range replaced {
+						skip[present[i]] = true
+					}
+					var slots []int
+					for rank := low; rank <= high; rank++ {
+						if i, ok := at[rank]; ok && !skip[rank] {
+							slots = append(slots, i)
+						} else {
+							slots = append(slots, -1)
+						}
+					}
+					result = append(result, newCandidate(RunMeld, slots))
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func jokerSlots(count int) []int {
+	slots := make([]int, count)
+	for i := range slots {
+		slots[i] = -1
+	}
+	return slots
+}
+
+// subsetsUpTo returns all subsets of the indexes 0 to n-1 with at most k elements.
+func subsetsUpTo(n, k int) [][]int {
+	result := [][]int{nil}
+	for i := 0; i < n; i++ {
+		for _, subset := range result {
+			if len(subset) < k {
+				result = append(result, append(append([]int{}, subset...), i))
+			}
+		}
+	}
+	return result
+}
+
+func newArrangement(naturals, jokers []*deck_repo.Card, melds []*candidate, deadwood []int) *Arrangement {
+	arrangement := &Arrangement{
+		Melds: []*Meld{},
+	}
+	joker := 0
+	for _, c := range melds {
+		meld := &Meld{
+			Kind: c.kind,
+		}
+		for _, slot := range c.slots {
+			if slot < 0 {
+				meld.Cards = append(meld.Cards, jokers[joker])
+				joker++
+			} else {
+				meld.Cards = append(meld.Cards, naturals[slot])
+			}
+		}
+		arrangement.Melds = append(arrangement.Melds, meld)
+	}
+	for _, i := range deadwood {
+		arrangement.Deadwood = append(arrangement.Deadwood, naturals[i])
+	}
+	arrangement.Deadwood = append(arrangement.Deadwood, jokers[joker:]...)
+	arrangement.Points = PointsOf(arrangement.Deadwood)
+	return arrangement
+}
+
+// arrangementKey is the key of the arrangement, leaving out the place of the jokers in the melds.
+func arrangementKey(arrangement *Arrangement) string {
+	var keys []string
+	for _, meld := range arrangement.Melds {
+		var codes []string
+		for _, card := range meld.Cards {
+			codes = append(codes, card.Value)
+		}
+		sort.Strings(codes)
+		keys = append(keys, meld.Kind+":"+strings.Join(codes, ","))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ";")
+}
@@ -0,0 +1,100 @@
+package rummy
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+func arrange(t *testing.T, cards string) []*Arrangement {
+	arrangements, err := Arrange(deck_repo.AsCards(cards))
+	if err != nil {
+		t.Fatalf("Expected to arrange %s, but got error: %s", cards, err.Error())
+	}
+	return arrangements
+}
+
+func codes(cards []*deck_repo.Card) string {
+	result := ""
+	for i, card := range cards {
+		if i > 0 {
+			result += ","
+		}
+		result += card.Value
+	}
+	return result
+}
+
+func TestArrange(t *testing.T) {
+	tests := []struct {
+		cards        string
+		points       int
+		arrangements int
+	}{
+		{"AS,2S,3S,4S,5H,5D,5C,9D,10D,JD", 0, 1},
+		{"7H,8H,9H,9C,9D", 15, 1},
+		{"7H,8H,9H,10H,10C,10D", 0, 1},
+		{"10H,10D,10S,JS,QS", 20, 2},
+		{"5D,5H,5S,5C,6C,7C", 0, 1},
+		{"2C,4D,6H,8S", 20, 1},
+		{"5H,6H,JOKER,9C,9D", 11, 1},
+		{"JOKER,JOKER,KS", 0, 2},
+		{"JOKER,2C,9D", 26, 1},
+	}
+
+	for _, test := range tests {
+		arrangements := arrange(t, test.cards)
+		if len(arrangements) != test.arrangements {
+			t.Errorf("Expected %d arrangements of %s, but got %d.", test.arrangements, test.cards, len(arrangements))
+		}
+		for _, arrangement := range arrangements {
+			if arrangement.Points != test.points || PointsOf(arrangement.Deadwood) != test.points {
+				t.Errorf("Expected %d deadwood for %s, but got %d: %s", test.points, test.cards, arrangement.Points, codes(arrangement.Deadwood))
+			}
+		}
+	}
+}
+
+func TestArrange_Melds(t *testing.T) {
+	arrangements := arrange(t, "9D,JD,10D,5C,5H,5D,AS,3S,2S,4S")
+	melds := arrangements[0].Melds
+	if len(melds) != 3 {
+		t.Fatalf("Expected 3 melds, but got %d.", len(melds))
+	}
+	if melds[0].Kind != RunMeld || codes(melds[0].Cards) != "9D,10D,JD" {
+		t.Errorf("Expected the run ordered by rank, but got %s %s.", melds[0].Kind, codes(melds[0].Cards))
+	}
+	if melds[1].Kind != SetMeld || codes(melds[1].Cards) != "5C,5H,5D" {
+		t.Errorf("Expected the set of fives, but got %s %s.", melds[1].Kind, codes(melds[1].Cards))
+	}
+	if melds[2].Kind != RunMeld || codes(melds[2].Cards) != "AS,2S,3S,4S" {
+		t.Errorf("Expected the ace low run, but got %s %s.", melds[2].Kind, codes(melds[2].Cards))
+	}
+
+	// the joker stands for the seven of hearts, while the seven is in the set
+	arrangements = arrange(t, "5H,6H,7H,8H,7C,7D,JOKER")
+	for _, arrangement := range arrangements {
+		if arrangement.Points != 0 {
+			t.Errorf("Expected no deadwood, but got %s.", codes(arrangement.Deadwood))
+		}
+	}
+	if len(arrangements) != 2 {
+		t.Errorf("Expected the joker in the set or in the run, but got %d arrangements.", len(arrangements))
+	}
+}
+
+func TestArrange_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"AC,2C,3C,4C,5C,6C,7C,8C,9C,10C,JC,QC,KC,AD",
+		"JOKER,JOKER,JOKER,AC",
+		"AC,AC,2C",
+		"AX,2C,3C",
+	}
+	for _, test := range tests {
+		if _, err := Arrange(deck_repo.AsCards(test)); !errors.IsValidationError(err) {
+			t.Errorf("Expected a ValidationError for %s, but got: %v", test, err)
+		}
+	}
+}
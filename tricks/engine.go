@@ -0,0 +1,219 @@
+package tricks
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+// NewGame creates a new game of the variant for the named players, in the order of the seats, and deals the first
+// hand from a new shuffled deck created with the given DeckRepository.
+// Returns a ValidationError if the variant is not supported or the number of players does not match the rules.
+func NewGame(variant string, names []string, decks deck_repo.DeckRepository) (*tricks_repo.Game, error) {
+	rules, err := GetRuleSet(variant)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) != rules.Players() {
+		return nil, errors.ValidationError(fmt.Sprintf("%s is played by %d players", variant, rules.Players()), nil)
+	}
+
+	game := &tricks_repo.Game{
+		Variant: variant,
+		Players: []*tricks_repo.Player{},
+	}
+	for seat, name := range names {
+		game.Players = append(game.Players, &tricks_repo.Player{
+			Seat: seat,
+			Name: name,
+			Bid:  tricks_repo.NoBid,
+		})
+	}
+
+	if err := StartHand(game, decks); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// StartHand deals a new hand from a new shuffled deck, one card at a time starting left of the dealer. The deal
+// passes to the left after every hand.
+// Returns a BadRequestError if the current hand is not over.
+func StartHand(game *tricks_repo.Game, decks deck_repo.DeckRepository) error {
+	rules, err := GetRuleSet(game.Variant)
+	if err != nil {
+		return err
+	}
+	if game.HandNumber > 0 && game.Stage != tricks_repo.HandOverStage {
+		return errors.BadRequestError("the current hand is not over", nil)
+	}
+
+	deck, err := decks.CreateDeck(&deck_repo.Deck{
+		Shuffled: true,
+	})
+	if err != nil {
+		return err
+	}
+	cards, err := decks.DrawCards(deck.ID, deck.Remaining)
+	if err != nil {
+		return err
+	}
+
+	players := len(game.Players)
+	if game.HandNumber > 0 {
+		game.Dealer = (game.Dealer + 1) % players
+	}
+
+	hands := make([][]*deck_repo.Card, players)
+	for i, card := range cards {
+		seat := (game.Dealer + 1 + i) % players
+		hands[seat] = append(hands[seat], card)
+	}
+	for seat, player := range game.Players {
+		sortCards(hands[seat])
+		player.Hand = tricks_repo.JoinCodes("", hands[seat]...)
+		player.Taken = ""
+		player.Bid = tricks_repo.NoBid
+		player.Tricks = 0
+	}
+
+	game.DeckID = deck.ID
+	game.HandNumber++
+	game.Trick = ""
+	game.LastTrick = ""
+	game.TricksPlayed = 0
+	game.Broken = false
+
+	if rules.Bidding() {
+		game.Stage = tricks_repo.BiddingStage
+		game.Turn = (game.Dealer + 1) % players
+		return nil
+	}
+
+	startPlay(game, rules)
+	return nil
+}
+
+// Bid makes the bid of the player. The players bid in turn, starting left of the dealer, and the play starts once
+// all players have bid.
+// Returns a BadRequestError if it is not the turn of the player to bid or the bid is not valid.
+func Bid(game *tricks_repo.Game, seat, bid int) error {
+	rules, err := GetRuleSet(game.Variant)
+	if err != nil {
+		return err
+	}
+	if game.Stage != tricks_repo.BiddingStage {
+		return errors.BadRequestError("there is no bidding in progress", nil)
+	}
+	if seat != game.Turn {
+		return errors.BadRequestError(fmt.Sprintf("it is the turn of seat %d to bid", game.Turn), nil)
+	}
+	if err := rules.ValidateBid(game, seat, bid); err != nil {
+		return err
+	}
+
+	game.Players[seat].Bid = bid
+	game.Turn = (game.Turn + 1) % len(game.Players)
+
+	if game.Players[game.Turn].Bid == tricks_repo.NoBid {
+		return nil
+	}
+	startPlay(game, rules)
+	return nil
+}
+
+// Play plays the card of the player to the current trick. Once every player has played, the trick goes to
+// the winner, who leads the next trick. Once all cards are played, the hand is scored.
+// Returns a BadRequestError if it is not the turn of the player or the card may not be played.
+func Play(game *tricks_repo.Game, seat int, code string) error {
+	rules, err := GetRuleSet(game.Variant)
+	if err != nil {
+		return err
+	}
+	if game.Stage != tricks_repo.PlayingStage {
+		return errors.BadRequestError("there is no play in progress", nil)
+	}
+	if seat != game.Turn {
+		return errors.BadRequestError(fmt.Sprintf("it is the turn of seat %d to play", game.Turn), nil)
+	}
+
+	var card *deck_repo.Card
+	for _, legal := range rules.LegalPlays(game, seat) {
+		if legal.Value == code {
+			card = legal
+		}
+	}
+	if card == nil {
+		return errors.BadRequestError(fmt.Sprintf("the card %s cannot be played, legal plays are %s", code,
+			tricks_repo.JoinCodes("", rules.LegalPlays(game, seat)...)), nil)
+	}
+
+	player := game.Players[seat]
+	remaining := []*deck_repo.Card{}
+	for _, held := range player.HandCards() {
+		if held.Value != card.Value {
+			remaining = append(remaining, held)
+		}
+	}
+	player.Hand = tricks_repo.JoinCodes("", remaining...)
+	game.Trick = tricks_repo.JoinCodes(game.Trick, card)
+	if Suit(card) == rules.BreakingSuit() {
+		game.Broken = true
+	}
+
+	trick := game.TrickCards()
+	if len(trick) < len(game.Players) {
+		game.Turn = (game.Turn + 1) % len(game.Players)
+		return nil
+	}
+
+	winner := TrickWinner(trick, game.Leader, len(game.Players), rules.Trump())
+	game.Players[winner].Tricks++
+	game.Players[winner].Taken = tricks_repo.JoinCodes(game.Players[winner].Taken, trick...)
+	game.LastTrick = game.Trick
+	game.LastTrickLeader = game.Leader
+	game.LastTrickWinner = winner
+	game.Trick = ""
+	game.TricksPlayed++
+	game.Leader = winner
+	game.Turn = winner
+
+	if len(remaining) > 0 {
+		return nil
+	}
+
+	rules.ScoreHand(game)
+	if rules.Winners(game) != nil {
+		game.Stage = tricks_repo.GameOverStage
+	} else {
+		game.Stage = tricks_repo.HandOverStage
+	}
+	return nil
+}
+
+// LegalPlays returns the cards the player may play, or an empty list if it is not the turn of the player to play.
+func LegalPlays(game *tricks_repo.Game, seat int) []*deck_repo.Card {
+	rules, err := GetRuleSet(game.Variant)
+	if err != nil || game.Stage != tricks_repo.PlayingStage || seat != game.Turn {
+		return []*deck_repo.Card{}
+	}
+	return rules.LegalPlays(game, seat)
+}
+
+// Winners returns the seats of the winners once the game is over, or nil.
+func Winners(game *tricks_repo.Game) []int {
+	rules, err := GetRuleSet(game.Variant)
+	if err != nil || game.Stage != tricks_repo.GameOverStage {
+		return nil
+	}
+	return rules.Winners(game)
+}
+
+// startPlay moves the hand to the play of the first trick.
+func startPlay(game *tricks_repo.Game, rules RuleSet) {
+	game.Stage = tricks_repo.PlayingStage
+	game.Leader = rules.FirstLeader(game)
+	game.Turn = game.Leader
+}
@@ -0,0 +1,120 @@
+package tricks
+
+import (
+	"testing"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTest(t *testing.T) deck_repo.DeckRepository {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to migrate deck models: %s", err.Error())
+	}
+
+	return deck_repo.NewDBDeckRepository(db)
+}
+
+func TestNewGame_Hearts(t *testing.T) {
+	decks := setupTest(t)
+
+	game, err := NewGame(Hearts, []string{"north", "east", "south", "west"}, decks)
+	if err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	if game.Stage != tricks_repo.PlayingStage || game.HandNumber != 1 || game.DeckID == "" {
+		t.Fatalf("Expected the first hand to be dealt, but got: %+v", game)
+	}
+	for _, player := range game.Players {
+		if len(player.HandCards()) != 13 {
+			t.Errorf("Expected 13 cards for seat %d, but got %s.", player.Seat, player.Hand)
+		}
+	}
+	if plays := LegalPlays(game, game.Turn); len(plays) != 1 || plays[0].Value != "2C" {
+		t.Errorf("Expected the player to act to lead 2C, but got %v.", plays)
+	}
+	if plays := LegalPlays(game, (game.Turn+1)%4); len(plays) != 0 {
+		t.Errorf("Expected no legal plays out of turn, but got %v.", plays)
+	}
+
+	if err := Play(game, (game.Turn+1)%4, "2C"); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a play out of turn, but got: %v", err)
+	}
+	if err := Play(game, game.Turn, "AS"); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for an illegal play, but got: %v", err)
+	}
+	if err := StartHand(game, decks); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a new hand in the middle of the hand, but got: %v", err)
+	}
+}
+
+func TestNewGame_Invalid(t *testing.T) {
+	decks := setupTest(t)
+
+	if _, err := NewGame("whist", []string{"a", "b", "c", "d"}, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for an unsupported variant, but got: %v", err)
+	}
+	if _, err := NewGame(Spades, []string{"a", "b", "c"}, decks); !errors.IsValidationError(err) {
+		t.Errorf("Expected a ValidationError for three players of Spades, but got: %v", err)
+	}
+}
+
+func TestBid(t *testing.T) {
+	decks := setupTest(t)
+
+	game, err := NewGame(Spades, []string{"north", "east", "south", "west"}, decks)
+	if err != nil {
+		t.Fatalf("Failed to create game: %s", err.Error())
+	}
+	if game.Stage != tricks_repo.BiddingStage || game.Turn != 1 {
+		t.Fatalf("Expected the player left of the dealer to bid first, but got: %+v", game)
+	}
+	if err := Play(game, 1, game.Players[1].HandCards()[0].Value); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a play during the bidding, but got: %v", err)
+	}
+	if err := Bid(game, 2, 3); !errors.IsBadRequestError(err) {
+		t.Errorf("Expected a BadRequestError for a bid out of turn, but got: %v", err)
+	}
+
+	for _, seat := range []int{1, 2, 3, 0} {
+		if err := Bid(game, seat, 3); err != nil {
+			t.Fatalf("Failed to bid for seat %d: %s", seat, err.Error())
+		}
+	}
+	if game.Stage != tricks_repo.PlayingStage || game.Leader != 1 || game.Turn != 1 {
+		t.Errorf("Expected the player left of the dealer to lead, but got: %+v", game)
+	}
+}
+
+func TestStartHand_RotatesDealer(t *testing.T) {
+	decks := setupTest(t)
+	game := newTestGame(Spades, "AS", "KS", "QS", "JS")
+	game.Leader = 0
+	game.Turn = 0
+	for _, player := range game.Players {
+		player.Bid = 1
+	}
+
+	for seat, card := range []string{"AS", "KS", "QS", "JS"} {
+		if err := Play(game, seat, card); err != nil {
+			t.Fatalf("Failed to play %s: %s", card, err.Error())
+		}
+	}
+	if game.Stage != tricks_repo.HandOverStage || game.Players[0].HandScore != -20 {
+		t.Fatalf("Expected the hand to be scored, but got: %+v, %+v", game, game.Players[0])
+	}
+
+	if err := StartHand(game, decks); err != nil {
+		t.Fatalf("Failed to start hand: %s", err.Error())
+	}
+	if game.Dealer != 1 || game.Turn != 2 || game.HandNumber != 2 || game.Players[0].Tricks != 0 || game.Players[0].Score != -20 {
+		t.Errorf("Expected the deal to pass to the left, but got: %+v", game)
+	}
+}
@@ -0,0 +1,150 @@
+package tricks
+
+import (
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+// Hearts is the name of the Hearts variant.
+const Hearts = "hearts"
+
+// HeartsGameEnd is the score that ends a game of Hearts.
+const HeartsGameEnd = 100
+
+// HeartsRules are the rules of Hearts for four players, without passing cards: every heart taken scores a point
+// and the queen of spades 13 points. A player that takes all of them shoots the moon, and every other player
+// scores 26 points instead. The game ends when a player reaches 100 points, and the lowest score wins.
+type HeartsRules struct{}
+
+// Players returns the number of players of Hearts: 4.
+func (r *HeartsRules) Players() int {
+	return 4
+}
+
+// Trump returns "", there is no trump in Hearts.
+func (r *HeartsRules) Trump() string {
+	return ""
+}
+
+// BreakingSuit returns "H": hearts may not be led until a heart was played.
+func (r *HeartsRules) BreakingSuit() string {
+	return "H"
+}
+
+// Bidding returns false, there is no bidding in Hearts.
+func (r *HeartsRules) Bidding() bool {
+	return false
+}
+
+// ValidateBid returns nil, there is no bidding in Hearts.
+func (r *HeartsRules) ValidateBid(game *tricks_repo.Game, seat, bid int) error {
+	return nil
+}
+
+// FirstLeader returns the seat of the player holding the two of clubs.
+func (r *HeartsRules) FirstLeader(game *tricks_repo.Game) int {
+	for _, player := range game.Players {
+		for _, card := range player.HandCards() {
+			if card.Value == "2C" {
+				return player.Seat
+			}
+		}
+	}
+	return (game.Dealer + 1) % r.Players()
+}
+
+// LegalPlays returns the cards the player may play: the two of clubs leads the first trick, hearts may not be led
+// until broken, the players must follow suit, and no points may be played to the first trick unless the player has
+// nothing else.
+func (r *HeartsRules) LegalPlays(game *tricks_repo.Game, seat int) []*deck_repo.Card {
+	hand := game.Players[seat].HandCards()
+	trick := game.TrickCards()
+	firstTrick := game.TricksPlayed == 0
+
+	if len(trick) == 0 {
+		if firstTrick {
+			for _, card := range hand {
+				if card.Value == "2C" {
+					return []*deck_repo.Card{card}
+				}
+			}
+		}
+		if !game.Broken {
+			return without(hand, isHeart)
+		}
+		return hand
+	}
+
+	plays := followSuit(hand, trick)
+	if firstTrick && Suit(plays[0]) != Suit(trick[0]) {
+		return without(plays, func(card *deck_repo.Card) bool {
+			return heartsPoints(card) > 0
+		})
+	}
+	return plays
+}
+
+// ScoreHand scores the hearts and the queen of spades taken by every player, or 26 points for every other player
+// if a player shot the moon.
+func (r *HeartsRules) ScoreHand(game *tricks_repo.Game) {
+	points := make([]int, len(game.Players))
+	moon := -1
+	for i, player := range game.Players {
+		for _, card := range player.TakenCards() {
+			points[i] += heartsPoints(card)
+		}
+		if points[i] == 26 {
+			moon = i
+		}
+	}
+
+	for i, player := range game.Players {
+		if moon >= 0 {
+			points[i] = 26
+			if i == moon {
+				points[i] = 0
+			}
+		}
+		player.HandScore = points[i]
+		player.Score += points[i]
+	}
+}
+
+// Winners returns the seats with the lowest score once a player has reached 100 points.
+func (r *HeartsRules) Winners(game *tricks_repo.Game) []int {
+	highest, lowest := game.Players[0].Score, game.Players[0].Score
+	for _, player := range game.Players {
+		if player.Score > highest {
+			highest = player.Score
+		}
+		if player.Score < lowest {
+			lowest = player.Score
+		}
+	}
+	if highest < HeartsGameEnd {
+		return nil
+	}
+
+	winners := []int{}
+	for _, player := range game.Players {
+		if player.Score == lowest {
+			winners = append(winners, player.Seat)
+		}
+	}
+	return winners
+}
+
+func isHeart(card *deck_repo.Card) bool {
+	return Suit(card) == "H"
+}
+
+func heartsPoints(card *deck_repo.Card) int {
+	switch {
+	case isHeart(card):
+		return 1
+	case card.Value == "QS":
+		return 13
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,119 @@
+package tricks
+
+import (
+	"testing"
+
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+func TestHeartsRules_LegalPlays(t *testing.T) {
+	rules := &HeartsRules{}
+	game := newTestGame(Hearts, "3C,QS,5H", "2C,4C,AH", "KH,QH,JD", "3D,7H,QD")
+
+	// The two of clubs leads the first trick.
+	if first := rules.FirstLeader(game); first != 1 {
+		t.Fatalf("Expected the holder of 2C to lead, but got seat %d.", first)
+	}
+	if plays := codes(rules.LegalPlays(game, 1)); plays != "2C" {
+		t.Errorf("Expected only 2C to be led, but got %s.", plays)
+	}
+
+	// No points on the first trick, unless there is nothing else.
+	game.Trick = "2C"
+	if plays := codes(rules.LegalPlays(game, 2)); plays != "JD" {
+		t.Errorf("Expected no hearts on the first trick, but got %s.", plays)
+	}
+	game.Players[2].Hand = "KH,QH"
+	if plays := codes(rules.LegalPlays(game, 2)); plays != "KH,QH" {
+		t.Errorf("Expected hearts on the first trick with nothing else, but got %s.", plays)
+	}
+	if plays := codes(rules.LegalPlays(game, 0)); plays != "3C" {
+		t.Errorf("Expected to follow suit, but got %s.", plays)
+	}
+
+	// Hearts may not be led until broken.
+	game.Trick = ""
+	game.TricksPlayed = 1
+	if plays := codes(rules.LegalPlays(game, 3)); plays != "3D,QD" {
+		t.Errorf("Expected no hearts to be led, but got %s.", plays)
+	}
+	game.Broken = true
+	if plays := codes(rules.LegalPlays(game, 3)); plays != "3D,7H,QD" {
+		t.Errorf("Expected hearts to be led once broken, but got %s.", plays)
+	}
+}
+
+func TestHeartsRules_ScoreHand(t *testing.T) {
+	rules := &HeartsRules{}
+	game := newTestGame(Hearts, "", "", "", "")
+	game.Players[0].Taken = "2C,3C,4C,5C,QS,2H,3H,4H"
+	game.Players[1].Taken = "AH,KH,QH,JH"
+	game.Players[1].Score = 10
+
+	rules.ScoreHand(game)
+	if game.Players[0].HandScore != 16 || game.Players[1].Score != 14 || game.Players[2].Score != 0 {
+		t.Errorf("Expected the hearts and the queen of spades to score, but got: %+v, %+v",
+			game.Players[0], game.Players[1])
+	}
+
+	// Shooting the moon.
+	game.Players[0].Taken = "QS,2H,3H,4H,5H,6H,7H,8H,9H,10H,JH,QH,KH,AH"
+	game.Players[1].Taken = ""
+	rules.ScoreHand(game)
+	if game.Players[0].HandScore != 0 || game.Players[1].HandScore != 26 || game.Players[1].Score != 40 {
+		t.Errorf("Expected the other players to score 26, but got: %+v, %+v", game.Players[0], game.Players[1])
+	}
+}
+
+func TestHeartsRules_Winners(t *testing.T) {
+	rules := &HeartsRules{}
+	game := newTestGame(Hearts, "", "", "", "")
+	for i, score := range []int{99, 40, 30, 30} {
+		game.Players[i].Score = score
+	}
+
+	if winners := rules.Winners(game); winners != nil {
+		t.Errorf("Expected the game to go on below 100 points, but got winners %v.", winners)
+	}
+
+	game.Players[0].Score = 104
+	winners := rules.Winners(game)
+	if len(winners) != 2 || winners[0] != 2 || winners[1] != 3 {
+		t.Errorf("Expected seats 2 and 3 to share the win, but got %v.", winners)
+	}
+}
+
+func TestHeartsHand(t *testing.T) {
+	game := newTestGame(Hearts, "3C,QS", "2C,AH", "KC,QH", "4C,2D")
+	game.Leader = 1
+	game.Turn = 1
+
+	for _, play := range []struct {
+		seat int
+		card string
+	}{{1, "2C"}, {2, "KC"}, {3, "4C"}, {0, "3C"}} {
+		if err := Play(game, play.seat, play.card); err != nil {
+			t.Fatalf("Expected %s of seat %d to be allowed, but got error: %s", play.card, play.seat, err.Error())
+		}
+	}
+	if game.LastTrickWinner != 2 || game.Turn != 2 || game.Players[2].Tricks != 1 || game.Trick != "" {
+		t.Fatalf("Expected seat 2 to win the first trick, but got: %+v", game)
+	}
+
+	// A heart was not played yet, but seat 2 has only hearts.
+	for _, play := range []struct {
+		seat int
+		card string
+	}{{2, "QH"}, {3, "2D"}, {0, "QS"}, {1, "AH"}} {
+		if err := Play(game, play.seat, play.card); err != nil {
+			t.Fatalf("Expected %s of seat %d to be allowed, but got error: %s", play.card, play.seat, err.Error())
+		}
+	}
+
+	if game.Stage != tricks_repo.HandOverStage || !game.Broken {
+		t.Fatalf("Expected the hand to be over, but got: %+v", game)
+	}
+	if game.Players[1].HandScore != 15 || game.Players[2].HandScore != 0 {
+		t.Errorf("Expected seat 1 to take 15 points, but got: %+v", game.Players[1])
+	}
+}
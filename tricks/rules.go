@@ -0,0 +1,161 @@
+package tricks
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+// RuleSet defines the rules of a trick-taking game. The engine (see Play) deals the hands, takes the bids, and plays
+// the tricks; the rule set decides which cards may be played, how a hand is scored and when the game is over.
+type RuleSet interface {
+
+	// Players returns the number of players of the game.
+	Players() int
+
+	// Trump returns the code of the trump suit, like "S", or "" if there is no trump.
+	Trump() string
+
+	// BreakingSuit returns the code of the suit that may not be led until a card of it was played in the hand
+	// (see tricks_repo.Game.Broken), or "" if any suit may be led.
+	BreakingSuit() string
+
+	// Bidding returns true if the players bid on the number of tricks before the play.
+	Bidding() bool
+
+	// ValidateBid returns a BadRequestError if the player cannot make the bid.
+	ValidateBid(game *tricks_repo.Game, seat, bid int) error
+
+	// FirstLeader returns the seat of the player that leads the first trick of the hand.
+	FirstLeader(game *tricks_repo.Game) int
+
+	// LegalPlays returns the cards in the hand of the player that may be played to the current trick.
+	LegalPlays(game *tricks_repo.Game, seat int) []*deck_repo.Card
+
+	// ScoreHand scores the complete hand and adds the scores to the scores of the players.
+	ScoreHand(game *tricks_repo.Game)
+
+	// Winners returns the seats of the winners if the game is over, or nil if the game goes on.
+	Winners(game *tricks_repo.Game) []int
+}
+
+// ruleSets holds the supported rule sets by the name of the variant.
+var ruleSets = map[string]RuleSet{
+	Hearts: &HeartsRules{},
+	Spades: &SpadesRules{},
+}
+
+// GetRuleSet returns the rule set of the variant.
+// Returns a ValidationError if the variant is not supported.
+func GetRuleSet(variant string) (RuleSet, error) {
+	rules, ok := ruleSets[variant]
+	if !ok {
+		return nil, errors.ValidationError(fmt.Sprintf("unsupported game: %s, supported games are %v", variant, Variants()), nil)
+	}
+	return rules, nil
+}
+
+// Variants returns the names of the supported variants, sorted.
+func Variants() []string {
+	variants := make([]string, 0, len(ruleSets))
+	for variant := range ruleSets {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+// rankIndex maps the rank codes to their order in a trick, from the two (1) to the ace (13).
+var rankIndex = map[string]int{}
+
+func init() {
+	for i, rank := range deck_repo.Ranks {
+		rankIndex[rank] = i
+	}
+	rankIndex[deck_repo.Ranks[0]] = len(deck_repo.Ranks)
+}
+
+// Rank returns the order of the card in a trick, from the two (1) to the ace (13).
+func Rank(card *deck_repo.Card) int {
+	return rankIndex[card.Value[:len(card.Value)-1]]
+}
+
+// Suit returns the code of the suit of the card, like "H".
+func Suit(card *deck_repo.Card) string {
+	return card.Value[len(card.Value)-1:]
+}
+
+// TrickWinner returns the seat of the player that wins the trick: the highest trump, or the highest card of the
+// suit led if no trump was played. The cards are in the order of play, starting with the card of the leader.
+func TrickWinner(trick []*deck_repo.Card, leader, players int, trump string) int {
+	best := 0
+	for i, card := range trick[1:] {
+		if beats(card, trick[best], Suit(trick[0]), trump) {
+			best = i + 1
+		}
+	}
+	return (leader + best) % players
+}
+
+// beats returns true if the card beats the best card of the trick so far.
+func beats(card, best *deck_repo.Card, led, trump string) bool {
+	if Suit(card) == Suit(best) {
+		return Rank(card) > Rank(best)
+	}
+	return Suit(card) == trump || (Suit(best) != trump && Suit(card) == led)
+}
+
+// followSuit returns the cards of the hand of the suit led, or the whole hand if the trick is empty or the player
+// has no card of the suit led.
+func followSuit(hand, trick []*deck_repo.Card) []*deck_repo.Card {
+	if len(trick) == 0 {
+		return hand
+	}
+	if cards := ofSuit(hand, Suit(trick[0])); len(cards) > 0 {
+		return cards
+	}
+	return hand
+}
+
+// ofSuit returns the cards of the given suit.
+func ofSuit(cards []*deck_repo.Card, suit string) []*deck_repo.Card {
+	result := []*deck_repo.Card{}
+	for _, card := range cards {
+		if Suit(card) == suit {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// without returns the cards that do not match, or all the cards if they all match.
+func without(cards []*deck_repo.Card, match func(card *deck_repo.Card) bool) []*deck_repo.Card {
+	result := []*deck_repo.Card{}
+	for _, card := range cards {
+		if !match(card) {
+			result = append(result, card)
+		}
+	}
+	if len(result) == 0 {
+		return cards
+	}
+	return result
+}
+
+// sortCards sorts a hand by suit, then by rank.
+func sortCards(cards []*deck_repo.Card) {
+	suitIndex := map[string]int{}
+	for i, suit := range deck_repo.Suits {
+		suitIndex[suit] = i
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if Suit(cards[i]) != Suit(cards[j]) {
+			return suitIndex[Suit(cards[i])] < suitIndex[Suit(cards[j])]
+		}
+		return Rank(cards[i]) < Rank(cards[j])
+	})
+}
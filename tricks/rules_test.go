@@ -0,0 +1,64 @@
+package tricks
+
+import (
+	"testing"
+
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+// newTestGame creates a game in the play with the given hands, in the order of the seats. Seat 0 deals, and the
+// player left of the dealer leads.
+func newTestGame(variant string, hands ...string) *tricks_repo.Game {
+	game := &tricks_repo.Game{
+		Variant:    variant,
+		Stage:      tricks_repo.PlayingStage,
+		HandNumber: 1,
+		Leader:     1,
+		Turn:       1,
+		Players:    []*tricks_repo.Player{},
+	}
+	for seat, hand := range hands {
+		game.Players = append(game.Players, &tricks_repo.Player{
+			Seat: seat,
+			Hand: hand,
+			Bid:  tricks_repo.NoBid,
+		})
+	}
+	return game
+}
+
+func TestTrickWinner(t *testing.T) {
+	cases := []struct {
+		trick  string
+		trump  string
+		winner int
+	}{
+		{"10H,AH,2H,KH", "", 2},
+		{"10H,AS,2C,9H", "", 1},
+		{"10H,2S,AH,3S", "S", 0},
+		{"10H,AC,2D,KC", "S", 1},
+		{"2S,AH,AD,AC", "S", 1},
+	}
+
+	for _, c := range cases {
+		if winner := TrickWinner(deck_repo.AsCards(c.trick), 1, 4, c.trump); winner != c.winner {
+			t.Errorf("Expected seat %d to win %s (trump %q), but got seat %d.", c.winner, c.trick, c.trump, winner)
+		}
+	}
+}
+
+func TestGetRuleSet(t *testing.T) {
+	for _, variant := range Variants() {
+		if _, err := GetRuleSet(variant); err != nil {
+			t.Errorf("Expected a rule set for %s, but got error: %s", variant, err.Error())
+		}
+	}
+	if _, err := GetRuleSet("bridge"); err == nil {
+		t.Error("Expected an error for an unsupported variant.")
+	}
+}
+
+func codes(cards []*deck_repo.Card) string {
+	return tricks_repo.JoinCodes("", cards...)
+}
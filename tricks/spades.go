@@ -0,0 +1,132 @@
+package tricks
+
+import (
+	"fmt"
+
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	tricks_repo "github.com/natemago/card-games-api/repositories/tricks"
+)
+
+// Spades is the name of the Spades variant.
+const Spades = "spades"
+
+// Game ends of Spades.
+const (
+	// SpadesGameEnd is the score of a partnership that ends the game.
+	SpadesGameEnd = 500
+
+	// SpadesGameLoss is the score of a partnership that loses the game.
+	SpadesGameLoss = -200
+)
+
+// SpadesRules are the rules of partnership Spades for four players, the players in seats 0 and 2 against the players
+// in seats 1 and 3. Spades are trumps. Every player bids a number of tricks, or nil (0) for no tricks at all.
+// A partnership that takes at least the sum of the bids of its partners scores 10 points per trick bid and a point
+// per overtrick (bag), otherwise it loses 10 points per trick bid. Every 10 bags cost 100 points. A nil scores
+// 100 points, or loses 100 points if the player takes a trick; the tricks of a nil bidder do not count for the bid
+// of the partner. The game ends when a partnership reaches 500 points or drops to -200 points.
+type SpadesRules struct{}
+
+// Players returns the number of players of Spades: 4.
+func (r *SpadesRules) Players() int {
+	return 4
+}
+
+// Trump returns "S", spades are trumps.
+func (r *SpadesRules) Trump() string {
+	return "S"
+}
+
+// BreakingSuit returns "S": spades may not be led until a spade was played.
+func (r *SpadesRules) BreakingSuit() string {
+	return "S"
+}
+
+// Bidding returns true, every player bids before the play.
+func (r *SpadesRules) Bidding() bool {
+	return true
+}
+
+// ValidateBid returns a BadRequestError if the bid is not between 0 (nil) and 13.
+func (r *SpadesRules) ValidateBid(game *tricks_repo.Game, seat, bid int) error {
+	if bid < 0 || bid > 13 {
+		return errors.BadRequestError(fmt.Sprintf("the bid must be between 0 (nil) and 13, got %d", bid), nil)
+	}
+	return nil
+}
+
+// FirstLeader returns the seat left of the dealer.
+func (r *SpadesRules) FirstLeader(game *tricks_repo.Game) int {
+	return (game.Dealer + 1) % r.Players()
+}
+
+// LegalPlays returns the cards the player may play: spades may not be led until broken, and the players must
+// follow suit.
+func (r *SpadesRules) LegalPlays(game *tricks_repo.Game, seat int) []*deck_repo.Card {
+	hand := game.Players[seat].HandCards()
+	trick := game.TrickCards()
+
+	if len(trick) == 0 && !game.Broken {
+		return without(hand, func(card *deck_repo.Card) bool {
+			return Suit(card) == "S"
+		})
+	}
+	return followSuit(hand, trick)
+}
+
+// ScoreHand scores the bids of both partnerships. Both partners get the score of the partnership.
+func (r *SpadesRules) ScoreHand(game *tricks_repo.Game) {
+	for team := 0; team < 2; team++ {
+		partners := []*tricks_repo.Player{game.Players[team], game.Players[team+2]}
+
+		score, contract, tricks := 0, 0, 0
+		for _, player := range partners {
+			if player.Bid == 0 {
+				if player.Tricks == 0 {
+					score += 100
+				} else {
+					score -= 100
+				}
+				continue
+			}
+			contract += player.Bid
+			tricks += player.Tricks
+		}
+
+		bags := partners[0].Bags
+		if tricks >= contract {
+			score += 10*contract + tricks - contract
+			bags += tricks - contract
+		} else {
+			score -= 10 * contract
+		}
+		if bags >= 10 {
+			score -= 100
+			bags -= 10
+		}
+
+		for _, player := range partners {
+			player.HandScore = score
+			player.Score += score
+			player.Bags = bags
+		}
+	}
+}
+
+// Winners returns the seats of the partnership with the higher score once a partnership has reached 500 points or
+// dropped to -200 points. If both partnerships have the same score, the game goes on.
+func (r *SpadesRules) Winners(game *tricks_repo.Game) []int {
+	first, second := game.Players[0].Score, game.Players[1].Score
+	if first == second {
+		return nil
+	}
+	if first < SpadesGameEnd && second < SpadesGameEnd && first > SpadesGameLoss && second > SpadesGameLoss {
+		return nil
+	}
+
+	if first > second {
+		return []int{0, 2}
+	}
+	return []int{1, 3}
+}
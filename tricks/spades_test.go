@@ -0,0 +1,94 @@
+package tricks
+
+import (
+	"testing"
+)
+
+func TestSpadesRules_LegalPlays(t *testing.T) {
+	rules := &SpadesRules{}
+	game := newTestGame(Spades, "AS,KH", "2S,3S", "4D,5S", "QH,2H")
+
+	if plays := codes(rules.LegalPlays(game, 0)); plays != "KH" {
+		t.Errorf("Expected no spades to be led, but got %s.", plays)
+	}
+	if plays := codes(rules.LegalPlays(game, 1)); plays != "2S,3S" {
+		t.Errorf("Expected spades to be led with nothing else, but got %s.", plays)
+	}
+
+	game.Trick = "4H"
+	if plays := codes(rules.LegalPlays(game, 3)); plays != "QH,2H" {
+		t.Errorf("Expected to follow suit, but got %s.", plays)
+	}
+	if plays := codes(rules.LegalPlays(game, 2)); plays != "4D,5S" {
+		t.Errorf("Expected any card when void, but got %s.", plays)
+	}
+
+	if err := rules.ValidateBid(game, 0, 14); err == nil {
+		t.Error("Expected a bid of 14 to be rejected.")
+	}
+}
+
+func TestSpadesRules_ScoreHand(t *testing.T) {
+	rules := &SpadesRules{}
+	game := newTestGame(Spades, "", "", "", "")
+
+	// Seats 0 and 2 bid 4 + 3 and take 9 tricks; seat 1 bids nil and takes a trick, seat 3 bids 2 and takes 3.
+	for i, bid := range []int{4, 0, 3, 2} {
+		game.Players[i].Bid = bid
+	}
+	for i, tricks := range []int{5, 1, 4, 3} {
+		game.Players[i].Tricks = tricks
+	}
+	game.Players[0].Bags = 8
+	game.Players[2].Bags = 8
+
+	rules.ScoreHand(game)
+
+	// 70 + 2 bags, and the 10th bag costs 100.
+	if game.Players[0].HandScore != -28 || game.Players[2].Score != -28 || game.Players[0].Bags != 0 {
+		t.Errorf("Expected the bag penalty, but got: %+v", game.Players[0])
+	}
+	// -100 for the nil, 20 + 1 bag for the bid of 2.
+	if game.Players[1].HandScore != -79 || game.Players[3].Score != -79 || game.Players[3].Bags != 1 {
+		t.Errorf("Expected the failed nil, but got: %+v", game.Players[1])
+	}
+
+	// A set partnership loses 10 points per trick bid.
+	for i, tricks := range []int{2, 0, 2, 9} {
+		game.Players[i].Tricks = tricks
+	}
+	rules.ScoreHand(game)
+	if game.Players[0].HandScore != -70 || game.Players[1].HandScore != 127 {
+		t.Errorf("Expected -70 and 127, but got %d and %d.", game.Players[0].HandScore, game.Players[1].HandScore)
+	}
+}
+
+func TestSpadesRules_Winners(t *testing.T) {
+	rules := &SpadesRules{}
+	game := newTestGame(Spades, "", "", "", "")
+
+	cases := []struct {
+		first   int
+		second  int
+		winners []int
+	}{
+		{490, 300, nil},
+		{510, 300, []int{0, 2}},
+		{510, 520, []int{1, 3}},
+		{510, 510, nil},
+		{-210, 100, []int{1, 3}},
+	}
+
+	for _, c := range cases {
+		for i, player := range game.Players {
+			player.Score = c.first
+			if i%2 == 1 {
+				player.Score = c.second
+			}
+		}
+		winners := rules.Winners(game)
+		if len(winners) != len(c.winners) || (len(winners) > 0 && winners[0] != c.winners[0]) {
+			t.Errorf("Expected winners %v for %d to %d, but got %v.", c.winners, c.first, c.second, winners)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/natemago/card-games-api/errors"
+)
+
+// CheckHost checks that the host of a webhook URL, a name or an IP address, resolves only to public addresses, so a
+// webhook cannot reach the server itself or the internal network. Returns a ValidationError if the host cannot be
+// resolved or any of its addresses is a loopback, private, link-local, multicast or unspecified address.
+func CheckHost(host string) error {
+	ips, err := net.DefaultResolver.LookupIP(context.Background(), "ip", host)
+	if err != nil {
+		return errors.ValidationError(fmt.Sprintf("cannot resolve the webhook host %s", host), err)
+	}
+	for _, ip := range ips {
+		if err := checkAddress(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAddress returns a ValidationError if the address is not a public unicast address.
+func checkAddress(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return errors.ValidationError(fmt.Sprintf("the webhook address %s is not a public address", ip), nil)
+	}
+	return nil
+}
+
+// checkDial checks the address the client connects to, after the host is resolved, so a host resolving to a public
+// address when the webhook is created cannot resolve to a private address when the events are delivered.
+func checkDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid webhook address: %s", address)
+	}
+	return checkAddress(ip)
+}
+
+// newClient creates the HTTP client posting to the webhooks, connecting only to public addresses unless
+// allowPrivate is set. The proxy of the environment is not used, as the addresses of the webhooks are checked on
+// connect.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = checkDial
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,182 @@
+package webhooks
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/natemago/card-games-api/config"
+	"github.com/natemago/card-games-api/errors"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+)
+
+// Headers of the requests posted to the webhooks.
+const (
+	// SignatureHeader holds the signature of the payload as "sha256=<signature>" (see deck_repo.SignPayload).
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventHeader holds the type of the event, like deck_repo.WebhookDeckCreated.
+	EventHeader = "X-Webhook-Event"
+
+	// DeliveryHeader holds the id of the delivery, the same for every attempt to deliver the event.
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// batchSize is the maximal number of deliveries attempted at once.
+const batchSize = 100
+
+// Worker delivers the events stored in the outbox of the webhooks (see deck_repo.WebhookDelivery). A failed delivery is
+// retried with an exponential backoff, until it is given up on after too many attempts.
+type Worker struct {
+	repository deck_repo.WebhookRepository
+	client     *http.Client
+	config     config.WebhooksConfig
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// DeliverPending attempts the deliveries that are due once. Returns the number of delivered events.
+func (w *Worker) DeliverPending() (int, error) {
+	deliveries, err := w.repository.PendingDeliveries(time.Now().UTC(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		ok, err := w.deliver(delivery)
+		if err != nil {
+			log.Printf("Failed to deliver the event %d to the webhook %s: %s", delivery.ID, delivery.WebhookID, err.Error())
+			continue
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver makes a single attempt to deliver the event. The delivery is claimed first, so no other worker attempts
+// it at the same time. Returns true if the event was delivered.
+func (w *Worker) deliver(delivery *deck_repo.WebhookDelivery) (bool, error) {
+	webhook, err := w.repository.GetWebhook(delivery.WebhookID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return false, err
+	}
+
+	delivery.Attempts++
+	delivery.NextAttemptAt = time.Now().UTC().Add(w.backoff(delivery.Attempts))
+	if webhook == nil {
+		delivery.Dead = true
+		delivery.LastError = "no such webhook"
+	}
+	if _, err := w.repository.UpdateDelivery(delivery); err != nil {
+		if errors.IsBadRequestError(err) {
+			// claimed by another worker
+			return false, nil
+		}
+		return false, err
+	}
+	if webhook == nil {
+		return false, nil
+	}
+
+	if err := w.post(webhook, delivery); err != nil {
+		delivery.LastError = err.Error()
+		delivery.Dead = delivery.Attempts >= w.config.MaxAttempts
+	} else {
+		now := time.Now().UTC()
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	}
+	if _, err := w.repository.UpdateDelivery(delivery); err != nil {
+		return false, err
+	}
+
+	return delivery.DeliveredAt != nil, nil
+}
+
+// post posts the payload of the delivery to the webhook, signed with the secret of the webhook. Any response other
+// than 2xx is an error.
+func (w *Worker) post(webhook *deck_repo.Webhook, delivery *deck_repo.WebhookDelivery) error {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+deck_repo.SignPayload(webhook.Secret, payload))
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
+
+// backoff returns the time to wait after the given number of attempts: the backoff doubles with every attempt, up to
+// the maximal backoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	backoff := w.config.Backoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if w.config.MaxBackoff > 0 && backoff >= w.config.MaxBackoff {
+			return w.config.MaxBackoff
+		}
+	}
+	return backoff
+}
+
+// Start keeps delivering the events in the background, checking for due deliveries every interval, until Stop is
+// called. If the interval is not positive, the events are not delivered.
+func (w *Worker) Start() {
+	if w.config.Interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := w.DeliverPending(); err != nil {
+					log.Printf("Failed to deliver the webhook events: %s", err.Error())
+				}
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops delivering the events.
+func (w *Worker) Stop() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+}
+
+// NewWorker creates a new Worker delivering the events stored in the given WebhookRepository. Unless the configuration
+// allows the private addresses, the events are delivered only to public addresses (see CheckHost).
+func NewWorker(repository deck_repo.WebhookRepository, conf *config.WebhooksConfig) *Worker {
+	return &Worker{
+		repository: repository,
+		client:     newClient(conf.Timeout, conf.AllowPrivateAddresses),
+		config:     *conf,
+		stop:       make(chan struct{}),
+	}
+}
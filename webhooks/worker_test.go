@@ -0,0 +1,216 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/natemago/card-games-api/config"
+	deck_repo "github.com/natemago/card-games-api/repositories/deck"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// receiver is a webhook that records the requests, responding with the status.
+type receiver struct {
+	mutex    sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, string(body))
+	w.WriteHeader(r.status)
+}
+
+func (r *receiver) received() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.requests)
+}
+
+func setupTest(t *testing.T, status int) (*gorm.DB, *receiver, *httptest.Server) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup database: %s", err.Error())
+	}
+	if err := deck_repo.AutoMigrateDeckModels(db); err != nil {
+		t.Fatalf("Failed to generate db structure: %s", err.Error())
+	}
+
+	hook := &receiver{status: status}
+	return db, hook, httptest.NewServer(hook)
+}
+
+func TestWorker_Deliver(t *testing.T) {
+	db, hook, server := setupTest(t, http.StatusNoContent)
+	defer server.Close()
+	repo := deck_repo.NewDBWebhookRepository(db)
+	worker := NewWorker(repo, &config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 3, Backoff: time.Second, AllowPrivateAddresses: true})
+
+	webhook, _ := repo.CreateWebhook(&deck_repo.Webhook{URL: server.URL, Secret: "secret", Events: deck_repo.WebhookDeckCreated})
+	defer repo.DeleteWebhook(webhook.ID)
+	deck, err := deck_repo.NewDBDeckRepository(db).CreateDeck(&deck_repo.Deck{})
+	if err != nil {
+		t.Fatalf("Failed to create a deck: %s", err.Error())
+	}
+
+	if delivered, err := worker.DeliverPending(); err != nil || delivered != 1 {
+		t.Fatalf("Expected a single delivered event, but got %d (%v).", delivered, err)
+	}
+	if hook.received() != 1 {
+		t.Fatalf("Expected the webhook to receive the event, but got %d requests.", hook.received())
+	}
+
+	req, body := hook.requests[0], hook.bodies[0]
+	if req.Header.Get(SignatureHeader) != "sha256="+deck_repo.SignPayload("secret", []byte(body)) {
+		t.Errorf("Expected the payload to be signed, but got: %s", req.Header.Get(SignatureHeader))
+	}
+	if req.Header.Get(EventHeader) != deck_repo.WebhookDeckCreated || req.Header.Get(DeliveryHeader) == "" {
+		t.Errorf("Expected the event and delivery headers, but got: %v", req.Header)
+	}
+	if !strings.Contains(body, `"deck_id":"`+deck.ID+`"`) {
+		t.Errorf("Expected the payload of the created deck, but got: %s", body)
+	}
+
+	if delivered, _ := worker.DeliverPending(); delivered != 0 || hook.received() != 1 {
+		t.Errorf("Expected the event to be delivered only once, but got %d requests.", hook.received())
+	}
+}
+
+func TestWorker_Retries(t *testing.T) {
+	db, hook, server := setupTest(t, http.StatusInternalServerError)
+	defer server.Close()
+	repo := deck_repo.NewDBWebhookRepository(db)
+	worker := NewWorker(repo, &config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 3, Backoff: 20 * time.Millisecond,
+		MaxBackoff: 30 * time.Millisecond, AllowPrivateAddresses: true})
+
+	deck, err := deck_repo.NewDBDeckRepository(db).CreateDeck(&deck_repo.Deck{})
+	if err != nil {
+		t.Fatalf("Failed to create a deck: %s", err.Error())
+	}
+	webhook, _ := repo.CreateWebhook(&deck_repo.Webhook{DeckID: deck.ID, URL: server.URL, Secret: "secret"})
+	defer repo.DeleteWebhook(webhook.ID)
+	if _, err := deck_repo.NewDBDeckRepository(db).ShuffleDeck(deck.ID); err != nil {
+		t.Fatalf("Failed to shuffle the deck: %s", err.Error())
+	}
+
+	worker.DeliverPending()
+	worker.DeliverPending()
+	if hook.received() != 1 {
+		t.Fatalf("Expected no retry before the backoff, but got %d requests.", hook.received())
+	}
+
+	for i := 0; i < 100 && hook.received() < 3; i++ {
+		time.Sleep(5 * time.Millisecond)
+		worker.DeliverPending()
+	}
+	if hook.received() != 3 {
+		t.Fatalf("Expected 3 attempts, but got %d requests.", hook.received())
+	}
+
+	dead, err := repo.ListDeadDeliveries()
+	if err != nil || len(dead) == 0 {
+		t.Fatalf("Expected a dead delivery, but got: %v %v", dead, err)
+	}
+	delivery := dead[len(dead)-1]
+	if delivery.WebhookID != webhook.ID || delivery.Attempts != 3 || delivery.LastError == "" || delivery.DeliveredAt != nil {
+		t.Errorf("Expected the delivery to be given up on after 3 attempts, but got: %+v", delivery)
+	}
+
+	// once the webhook is fixed, the dead letter may be retried
+	hook.mutex.Lock()
+	hook.status = http.StatusOK
+	hook.mutex.Unlock()
+	if _, err := repo.RetryDelivery(delivery.ID); err != nil {
+		t.Fatalf("Failed to retry the delivery: %s", err.Error())
+	}
+	if delivered, err := worker.DeliverPending(); err != nil || delivered != 1 {
+		t.Errorf("Expected the retried delivery to be delivered, but got %d (%v).", delivered, err)
+	}
+}
+
+func TestWorker_Backoff(t *testing.T) {
+	worker := NewWorker(nil, &config.WebhooksConfig{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, backoff := range expected {
+		if worker.backoff(i+1) != backoff {
+			t.Errorf("Expected a backoff of %s after %d attempts, but got %s.", backoff, i+1, worker.backoff(i+1))
+		}
+	}
+}
+
+func TestWorker_StartStop(t *testing.T) {
+	db, hook, server := setupTest(t, http.StatusOK)
+	defer server.Close()
+	repo := deck_repo.NewDBWebhookRepository(db)
+	worker := NewWorker(repo, &config.WebhooksConfig{Interval: 10 * time.Millisecond, Timeout: time.Second, MaxAttempts: 1, AllowPrivateAddresses: true})
+
+	webhook, _ := repo.CreateWebhook(&deck_repo.Webhook{URL: server.URL, Secret: "secret", Events: deck_repo.WebhookDeckEmptied})
+	defer repo.DeleteWebhook(webhook.ID)
+	deckRepo := deck_repo.NewDBDeckRepository(db)
+	deck, _ := deckRepo.CreateDeck(&deck_repo.Deck{Cards: deck_repo.AsCards("AS")})
+
+	worker.Start()
+	defer worker.Stop()
+	if _, err := deckRepo.DrawCards(deck.ID, 1); err != nil {
+		t.Fatalf("Failed to draw a card: %s", err.Error())
+	}
+	for i := 0; i < 100 && hook.received() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if hook.received() != 1 {
+		t.Errorf("Expected the emptied event to be delivered in the background, but got %d requests.", hook.received())
+	}
+	worker.Stop()
+}
+
+func TestCheckHost(t *testing.T) {
+	for _, host := range []string{"127.0.0.1", "localhost", "10.0.0.1", "192.168.1.1", "169.254.169.254", "::1", "fe80::1", "0.0.0.0"} {
+		if err := CheckHost(host); err == nil {
+			t.Errorf("Expected the host %s to be rejected.", host)
+		}
+	}
+	if err := CheckHost("93.184.216.34"); err != nil {
+		t.Errorf("Expected a public address to be accepted, but got: %s", err.Error())
+	}
+}
+
+func TestWorker_PrivateAddress(t *testing.T) {
+	db, hook, server := setupTest(t, http.StatusOK)
+	defer server.Close()
+	repo := deck_repo.NewDBWebhookRepository(db)
+	worker := NewWorker(repo, &config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 1, Backoff: time.Second})
+
+	deck, err := deck_repo.NewDBDeckRepository(db).CreateDeck(&deck_repo.Deck{})
+	if err != nil {
+		t.Fatalf("Failed to create a deck: %s", err.Error())
+	}
+	// the webhook was created with a public address, but now resolves to the loopback address
+	webhook, _ := repo.CreateWebhook(&deck_repo.Webhook{DeckID: deck.ID, URL: server.URL, Secret: "secret"})
+	defer repo.DeleteWebhook(webhook.ID)
+	if _, err := deck_repo.NewDBDeckRepository(db).ShuffleDeck(deck.ID); err != nil {
+		t.Fatalf("Failed to shuffle the deck: %s", err.Error())
+	}
+
+	if delivered, _ := worker.DeliverPending(); delivered != 0 || hook.received() != 0 {
+		t.Fatalf("Expected no delivery to the loopback address, but got %d requests.", hook.received())
+	}
+	dead, err := repo.ListDeadDeliveries()
+	if err != nil || len(dead) == 0 {
+		t.Fatalf("Expected a dead delivery, but got: %v %v", dead, err)
+	}
+	if delivery := dead[len(dead)-1]; !strings.Contains(delivery.LastError, "not a public address") {
+		t.Errorf("Expected the delivery to fail on the private address, but got: %+v", delivery)
+	}
+}